@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"strconv"
 
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/api/presenter/protos"
@@ -19,13 +20,33 @@ func (s *ServerGrpc) ValidateRequest(ctx context.Context,
 	if err != nil {
 		return &protos.ValidationResponse{Error: err.Error(), IsValid: false}, err
 	}
+	apiToken, err := s.GetApiTokenScope(token)
+	if err != nil {
+		return &protos.ValidationResponse{Error: err.Error(), IsValid: false}, err
+	}
+	if apiToken != nil && !validations.IsIPAllowed(apiToken.AllowedIPs, inputRequest.ClientIP) {
+		err = errors.New("auth gRPC - API token is not allowed from " + inputRequest.ClientIP)
+		return &protos.ValidationResponse{Error: err.Error(), IsValid: false}, err
+	}
 	claims := token.Claims.(jwt.MapClaims)
 	uid := claims["uid"].(string)
-	err = validations.RbacValidator(uid, inputRequest.ProjectId,
-		inputRequest.RequiredRoles, inputRequest.Invitation, s.ApplicationService)
+	// the requests which aren't scoped to a project are only allowed to the admins
+	if inputRequest.ProjectId == "" {
+		err = validations.AdminValidator(uid, inputRequest.RequiredRoles, inputRequest.Operation,
+			apiToken, s.ApplicationService)
+	} else {
+		err = validations.RbacValidator(uid, inputRequest.ProjectId,
+			inputRequest.RequiredRoles, inputRequest.Invitation, inputRequest.Operation,
+			apiToken, s.ApplicationService)
+	}
 	if err != nil {
 		return &protos.ValidationResponse{Error: err.Error(), IsValid: false}, err
 	}
+	if apiToken != nil {
+		if err := s.UpdateApiTokenLastUsed(inputRequest.Jwt, inputRequest.ClientIP); err != nil {
+			log.Warn(err)
+		}
+	}
 	return &protos.ValidationResponse{Error: "", IsValid: true}, nil
 }
 
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/golang-jwt/jwt"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/api/handlers/grpc"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/api/mocks"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/api/presenter/protos"
@@ -166,3 +167,262 @@ func TestGetUserById(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRequest(t *testing.T) {
+	viewer := entities.RoleViewer
+	testcases := []struct {
+		name          string
+		apiToken      *entities.ApiToken
+		request       *protos.ValidationRequest
+		expectedValid bool
+	}{
+		{
+			name: "PositiveTestSessionToken",
+			request: &protos.ValidationRequest{
+				ProjectId:     "project-id",
+				RequiredRoles: []string{"Owner", "Editor"},
+				Operation:     "ReRunChaosExperiment",
+			},
+			expectedValid: true,
+		},
+		{
+			name: "PositiveTestApiTokenInScope",
+			apiToken: &entities.ApiToken{
+				ProjectIDs:  []string{"project-id"},
+				Permissions: []string{"GetWorkflowRun", "ReRunChaosExperiment"},
+				AllowedIPs:  []string{"10.0.0.0/8"},
+			},
+			request: &protos.ValidationRequest{
+				ProjectId:     "project-id",
+				RequiredRoles: []string{"Owner", "Editor"},
+				Operation:     "ReRunChaosExperiment",
+				ClientIP:      "10.1.2.3",
+			},
+			expectedValid: true,
+		},
+		{
+			name:     "NegativeTestApiTokenOtherProject",
+			apiToken: &entities.ApiToken{ProjectIDs: []string{"other-project-id"}},
+			request: &protos.ValidationRequest{
+				ProjectId:     "project-id",
+				RequiredRoles: []string{"Owner", "Editor", "Viewer"},
+				Operation:     "GetWorkflowRun",
+			},
+			expectedValid: false,
+		},
+		{
+			name:     "NegativeTestViewerApiTokenMutation",
+			apiToken: &entities.ApiToken{Role: &viewer},
+			request: &protos.ValidationRequest{
+				ProjectId:     "project-id",
+				RequiredRoles: []string{"Owner", "Editor"},
+				Operation:     "ReRunChaosExperiment",
+			},
+			expectedValid: false,
+		},
+		{
+			name:     "NegativeTestApiTokenPermissionNotGranted",
+			apiToken: &entities.ApiToken{Permissions: []string{"GetWorkflowRun"}},
+			request: &protos.ValidationRequest{
+				ProjectId:     "project-id",
+				RequiredRoles: []string{"Owner", "Editor"},
+				Operation:     "DeleteChaosExperiment",
+			},
+			expectedValid: false,
+		},
+		{
+			name:     "NegativeTestApiTokenDisallowedIP",
+			apiToken: &entities.ApiToken{AllowedIPs: []string{"192.168.1.10"}},
+			request: &protos.ValidationRequest{
+				ProjectId:     "project-id",
+				RequiredRoles: []string{"Owner", "Editor"},
+				Operation:     "ReRunChaosExperiment",
+				ClientIP:      "192.168.1.11",
+			},
+			expectedValid: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &mocks.MockedApplicationService{}
+			s := &grpc.ServerGrpc{ApplicationService: mockService}
+
+			token := &jwt.Token{Claims: jwt.MapClaims{"uid": "user-id"}, Valid: true}
+			tc.request.Jwt = "token"
+			mockService.On("ValidateToken", "token").Return(token, nil)
+			mockService.On("GetApiTokenScope", token).Return(tc.apiToken, nil)
+			mockService.On("GetUser", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+			mockService.On("GetProjects", mock.Anything).Return([]*entities.Project{{ID: "project-id"}}, nil)
+			mockService.On("UpdateApiTokenLastUsed", "token", tc.request.ClientIP).Return(nil)
+
+			resp, err := s.ValidateRequest(context.Background(), tc.request)
+
+			assert.Equal(t, tc.expectedValid, resp.IsValid)
+			if tc.expectedValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateRequestCustomRole(t *testing.T) {
+	onCall := &entities.CustomRole{
+		ID:          "on-call-role-id",
+		ProjectID:   "project-id",
+		Permissions: []entities.Permission{entities.PermissionExperimentView, entities.PermissionExperimentRun},
+	}
+	testcases := []struct {
+		name          string
+		operation     string
+		expectedValid bool
+	}{
+		{
+			name:          "PositiveTestPermissionGranted",
+			operation:     "StopChaosExperiment",
+			expectedValid: true,
+		},
+		{
+			name:          "PositiveTestMemberOperation",
+			operation:     "leaveProject",
+			expectedValid: true,
+		},
+		{
+			name:          "NegativeTestPermissionNotGranted",
+			operation:     "CreateChaosExperiment",
+			expectedValid: false,
+		},
+		{
+			name:          "NegativeTestOperationNotInCatalogue",
+			operation:     "UnknownOperation",
+			expectedValid: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &mocks.MockedApplicationService{}
+			s := &grpc.ServerGrpc{ApplicationService: mockService}
+
+			token := &jwt.Token{Claims: jwt.MapClaims{"uid": "user-id"}, Valid: true}
+			mockService.On("ValidateToken", "token").Return(token, nil)
+			mockService.On("GetApiTokenScope", token).Return(nil, nil)
+			mockService.On("GetUser", "user-id").Return(&entities.User{ID: "user-id"}, nil)
+			// the member has no built-in role, so only the lookup of the membership finds the project
+			mockService.On("GetProjects", mock.Anything).Return([]*entities.Project{}, nil).Once()
+			mockService.On("GetProjects", mock.Anything).Return([]*entities.Project{{
+				ID: "project-id",
+				Members: []*entities.Member{
+					{UserID: "user-id", Role: entities.MemberRole(onCall.ID), Invitation: entities.AcceptedInvitation},
+				},
+			}}, nil).Once()
+			mockService.On("GetCustomRole", "project-id", onCall.ID).Return(onCall, nil)
+
+			resp, err := s.ValidateRequest(context.Background(), &protos.ValidationRequest{
+				Jwt:           "token",
+				ProjectId:     "project-id",
+				RequiredRoles: []string{"Owner", "Editor"},
+				Invitation:    string(entities.AcceptedInvitation),
+				Operation:     tc.operation,
+			})
+
+			assert.Equal(t, tc.expectedValid, resp.IsValid)
+			if tc.expectedValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateRequestAdmin(t *testing.T) {
+	owner := entities.RoleOwner
+	viewer := entities.RoleViewer
+	testcases := []struct {
+		name          string
+		role          entities.Role
+		apiToken      *entities.ApiToken
+		clientIP      string
+		expectedValid bool
+	}{
+		{
+			name:          "PositiveTestAdminSessionToken",
+			role:          entities.RoleAdmin,
+			expectedValid: true,
+		},
+		{
+			name:          "NegativeTestUserSessionToken",
+			role:          entities.RoleUser,
+			expectedValid: false,
+		},
+		{
+			name: "PositiveTestAdminApiTokenInScope",
+			role: entities.RoleAdmin,
+			apiToken: &entities.ApiToken{
+				Role:        &owner,
+				Permissions: []string{"ListAuditLogs"},
+				AllowedIPs:  []string{"10.0.0.0/8"},
+			},
+			clientIP:      "10.1.2.3",
+			expectedValid: true,
+		},
+		{
+			name:          "NegativeTestAdminApiTokenRestrictedToProjects",
+			role:          entities.RoleAdmin,
+			apiToken:      &entities.ApiToken{ProjectIDs: []string{"project-id"}},
+			expectedValid: false,
+		},
+		{
+			name:          "NegativeTestAdminViewerApiToken",
+			role:          entities.RoleAdmin,
+			apiToken:      &entities.ApiToken{Role: &viewer},
+			expectedValid: false,
+		},
+		{
+			name:          "NegativeTestAdminApiTokenPermissionNotGranted",
+			role:          entities.RoleAdmin,
+			apiToken:      &entities.ApiToken{Permissions: []string{"GetWorkflowRun"}},
+			expectedValid: false,
+		},
+		{
+			name:          "NegativeTestAdminApiTokenDisallowedIP",
+			role:          entities.RoleAdmin,
+			apiToken:      &entities.ApiToken{AllowedIPs: []string{"192.168.1.10"}},
+			clientIP:      "192.168.1.11",
+			expectedValid: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &mocks.MockedApplicationService{}
+			s := &grpc.ServerGrpc{ApplicationService: mockService}
+
+			token := &jwt.Token{Claims: jwt.MapClaims{"uid": "user-id"}, Valid: true}
+			mockService.On("ValidateToken", "token").Return(token, nil)
+			mockService.On("GetApiTokenScope", token).Return(tc.apiToken, nil)
+			mockService.On("GetUser", "user-id").Return(&entities.User{ID: "user-id", Role: tc.role}, nil)
+			mockService.On("UpdateApiTokenLastUsed", "token", tc.clientIP).Return(nil)
+
+			// the requests without a project don't look up any membership
+			resp, err := s.ValidateRequest(context.Background(), &protos.ValidationRequest{
+				Jwt:           "token",
+				RequiredRoles: []string{"Owner"},
+				Invitation:    string(entities.AcceptedInvitation),
+				Operation:     "ListAuditLogs",
+				ClientIP:      tc.clientIP,
+			})
+
+			assert.Equal(t, tc.expectedValid, resp.IsValid)
+			if tc.expectedValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+			mockService.AssertNotCalled(t, "GetProjects", mock.Anything)
+		})
+	}
+}
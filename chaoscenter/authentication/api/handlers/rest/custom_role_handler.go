@@ -0,0 +1,331 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/api/presenter"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/entities"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/services"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/utils"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/validations"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetPermissions 		godoc
+//
+//	@Summary		Get permission catalogue.
+//	@Description	Return the permissions custom roles are made of.
+//	@Tags			ProjectRouter
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	response.Response{}
+//	@Router			/permissions [get]
+//
+// GetPermissions returns the catalogue of permissions
+func GetPermissions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"data": validations.PermissionCatalogue})
+	}
+}
+
+// ListCustomRoles 		godoc
+//
+//	@Summary		List custom roles.
+//	@Description	Return the custom roles of a project.
+//	@Tags			ProjectRouter
+//	@Param			project_id	path	string	true	"Project ID"
+//	@Accept			json
+//	@Produce		json
+//	@Failure		401	{object}	response.ErrUnauthorized
+//	@Failure		500	{object}	response.ErrServerError
+//	@Success		200	{object}	response.Response{}
+//	@Router			/list_custom_roles/:project_id [get]
+//
+// ListCustomRoles returns the custom roles of a project
+func ListCustomRoles(service services.ApplicationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		projectID := c.Param("project_id")
+
+		err := validations.RbacValidator(c.MustGet("uid").(string), projectID,
+			validations.MutationRbacRules["listCustomRoles"], string(entities.AcceptedInvitation),
+			"listCustomRoles", apiTokenFromContext(c), service)
+		if err != nil {
+			log.Warn(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrUnauthorized],
+				presenter.CreateErrorResponse(utils.ErrUnauthorized))
+			return
+		}
+
+		roles, err := service.GetCustomRoles(projectID)
+		if err != nil {
+			log.Error(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrServerError], presenter.CreateErrorResponse(utils.ErrServerError))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": roles})
+	}
+}
+
+// CreateCustomRole 		godoc
+//
+//	@Summary		Create custom role.
+//	@Description	Create a custom role in a project from the permission catalogue.
+//	@Tags			ProjectRouter
+//	@Accept			json
+//	@Produce		json
+//	@Failure		400	{object}	response.ErrInvalidRequest
+//	@Failure		401	{object}	response.ErrUnauthorized
+//	@Failure		500	{object}	response.ErrServerError
+//	@Success		200	{object}	response.Response{}
+//	@Router			/create_custom_role [post]
+//
+// CreateCustomRole creates a custom role in a project
+func CreateCustomRole(service services.ApplicationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var roleRequest entities.CustomRoleInput
+		err := c.BindJSON(&roleRequest)
+		if err != nil {
+			log.Warn(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrInvalidRequest], presenter.CreateErrorResponse(utils.ErrInvalidRequest))
+			return
+		}
+
+		err = validations.RbacValidator(c.MustGet("uid").(string), roleRequest.ProjectID,
+			validations.MutationRbacRules["createCustomRole"], string(entities.AcceptedInvitation),
+			"createCustomRole", apiTokenFromContext(c), service)
+		if err != nil {
+			log.Warn(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrUnauthorized],
+				presenter.CreateErrorResponse(utils.ErrUnauthorized))
+			return
+		}
+
+		if err := validations.ValidateCustomRoleInput(roleRequest); err != nil {
+			log.Warn(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrInvalidRequest], gin.H{"message": err.Error()})
+			return
+		}
+
+		currentTime := time.Now().UnixMilli()
+		role := &entities.CustomRole{
+			ID:          uuid.Must(uuid.NewRandom()).String(),
+			ProjectID:   roleRequest.ProjectID,
+			Name:        roleRequest.Name,
+			Description: roleRequest.Description,
+			Permissions: roleRequest.Permissions,
+			CreatedAt:   currentTime,
+			UpdatedAt:   currentTime,
+		}
+		err = service.CreateCustomRole(role)
+		if err != nil {
+			log.Error(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrServerError], presenter.CreateErrorResponse(utils.ErrServerError))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": role})
+	}
+}
+
+// UpdateCustomRole 		godoc
+//
+//	@Summary		Update custom role.
+//	@Description	Update the name, description and permissions of a custom role.
+//	@Tags			ProjectRouter
+//	@Accept			json
+//	@Produce		json
+//	@Failure		400	{object}	response.ErrInvalidRequest
+//	@Failure		401	{object}	response.ErrUnauthorized
+//	@Failure		500	{object}	response.ErrServerError
+//	@Success		200	{object}	response.Response{}
+//	@Router			/update_custom_role [post]
+//
+// UpdateCustomRole updates a custom role of a project, the change applies to all the members having the role
+func UpdateCustomRole(service services.ApplicationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var roleRequest entities.CustomRoleInput
+		err := c.BindJSON(&roleRequest)
+		if err != nil {
+			log.Warn(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrInvalidRequest], presenter.CreateErrorResponse(utils.ErrInvalidRequest))
+			return
+		}
+
+		err = validations.RbacValidator(c.MustGet("uid").(string), roleRequest.ProjectID,
+			validations.MutationRbacRules["updateCustomRole"], string(entities.AcceptedInvitation),
+			"updateCustomRole", apiTokenFromContext(c), service)
+		if err != nil {
+			log.Warn(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrUnauthorized],
+				presenter.CreateErrorResponse(utils.ErrUnauthorized))
+			return
+		}
+
+		if err := validations.ValidateCustomRoleInput(roleRequest); err != nil {
+			log.Warn(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrInvalidRequest], gin.H{"message": err.Error()})
+			return
+		}
+
+		role, err := service.GetCustomRole(roleRequest.ProjectID, roleRequest.RoleID)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(utils.ErrorStatusCodes[utils.ErrCustomRoleNotFound], presenter.CreateErrorResponse(utils.ErrCustomRoleNotFound))
+			return
+		} else if err != nil {
+			log.Error(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrServerError], presenter.CreateErrorResponse(utils.ErrServerError))
+			return
+		}
+
+		role.Name = roleRequest.Name
+		role.Description = roleRequest.Description
+		role.Permissions = roleRequest.Permissions
+		role.UpdatedAt = time.Now().UnixMilli()
+		err = service.UpdateCustomRole(role)
+		if err != nil {
+			log.Error(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrServerError], presenter.CreateErrorResponse(utils.ErrServerError))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"data": role})
+	}
+}
+
+// DeleteCustomRole 		godoc
+//
+//	@Summary		Delete custom role.
+//	@Description	Delete a custom role which isn't assigned to any member.
+//	@Tags			ProjectRouter
+//	@Accept			json
+//	@Produce		json
+//	@Failure		400	{object}	response.ErrCustomRoleInUse
+//	@Failure		401	{object}	response.ErrUnauthorized
+//	@Failure		500	{object}	response.ErrServerError
+//	@Success		200	{object}	response.Response{}
+//	@Router			/delete_custom_role [post]
+//
+// DeleteCustomRole deletes a custom role of a project, the roles assigned to members can't be deleted
+func DeleteCustomRole(service services.ApplicationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var roleRequest entities.DeleteCustomRoleInput
+		err := c.BindJSON(&roleRequest)
+		if err != nil {
+			log.Warn(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrInvalidRequest], presenter.CreateErrorResponse(utils.ErrInvalidRequest))
+			return
+		}
+
+		err = validations.RbacValidator(c.MustGet("uid").(string), roleRequest.ProjectID,
+			validations.MutationRbacRules["deleteCustomRole"], string(entities.AcceptedInvitation),
+			"deleteCustomRole", apiTokenFromContext(c), service)
+		if err != nil {
+			log.Warn(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrUnauthorized],
+				presenter.CreateErrorResponse(utils.ErrUnauthorized))
+			return
+		}
+
+		projects, err := service.GetProjects(bson.D{
+			{"_id", roleRequest.ProjectID},
+			{"members.role", roleRequest.RoleID},
+		})
+		if err != nil {
+			log.Error(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrServerError], presenter.CreateErrorResponse(utils.ErrServerError))
+			return
+		}
+		if len(projects) > 0 {
+			c.JSON(utils.ErrorStatusCodes[utils.ErrCustomRoleInUse], presenter.CreateErrorResponse(utils.ErrCustomRoleInUse))
+			return
+		}
+
+		err = service.DeleteCustomRole(roleRequest.ProjectID, roleRequest.RoleID)
+		if err != nil {
+			log.Error(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrCustomRoleNotFound], presenter.CreateErrorResponse(utils.ErrCustomRoleNotFound))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Successful"})
+	}
+}
+
+// UpdateMemberRole 		godoc
+//
+//	@Summary		Update member role.
+//	@Description	Change the role of a member to a built-in or custom role.
+//	@Tags			ProjectRouter
+//	@Accept			json
+//	@Produce		json
+//	@Failure		400	{object}	response.ErrInvalidRole
+//	@Failure		401	{object}	response.ErrUnauthorized
+//	@Failure		500	{object}	response.ErrServerError
+//	@Success		200	{object}	response.Response{}
+//	@Router			/update_member_role [post]
+//
+// UpdateMemberRole changes the role of an accepted member to Editor, Viewer or a custom role of the project,
+// the role of the project owner can't be changed
+func UpdateMemberRole(service services.ApplicationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var memberRequest entities.MemberRoleInput
+		err := c.BindJSON(&memberRequest)
+		if err != nil {
+			log.Warn(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrInvalidRequest], presenter.CreateErrorResponse(utils.ErrInvalidRequest))
+			return
+		}
+
+		err = validations.RbacValidator(c.MustGet("uid").(string), memberRequest.ProjectID,
+			validations.MutationRbacRules["updateMemberRole"], string(entities.AcceptedInvitation),
+			"updateMemberRole", apiTokenFromContext(c), service)
+		if err != nil {
+			log.Warn(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrUnauthorized],
+				presenter.CreateErrorResponse(utils.ErrUnauthorized))
+			return
+		}
+
+		if memberRequest.Role == entities.RoleOwner {
+			c.JSON(utils.ErrorStatusCodes[utils.ErrInvalidRole], presenter.CreateErrorResponse(utils.ErrInvalidRole))
+			return
+		}
+		if !memberRequest.Role.IsBuiltIn() {
+			_, err := service.GetCustomRole(memberRequest.ProjectID, string(memberRequest.Role))
+			if err == mongo.ErrNoDocuments {
+				c.JSON(utils.ErrorStatusCodes[utils.ErrInvalidRole], presenter.CreateErrorResponse(utils.ErrInvalidRole))
+				return
+			} else if err != nil {
+				log.Error(err)
+				c.JSON(utils.ErrorStatusCodes[utils.ErrServerError], presenter.CreateErrorResponse(utils.ErrServerError))
+				return
+			}
+		}
+
+		currentRole, err := service.GetProjectRole(memberRequest.ProjectID, memberRequest.UserID)
+		if err != nil || currentRole == nil {
+			c.JSON(utils.ErrorStatusCodes[utils.ErrUserNotFound], presenter.CreateErrorResponse(utils.ErrUserNotFound))
+			return
+		}
+		if *currentRole == entities.RoleOwner {
+			c.JSON(utils.ErrorStatusCodes[utils.ErrInvalidRequest], gin.H{"message": "the role of the project owner can't be changed"})
+			return
+		}
+
+		err = service.UpdateMemberRole(memberRequest.ProjectID, memberRequest.UserID, memberRequest.Role)
+		if err != nil {
+			log.Error(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrServerError], presenter.CreateErrorResponse(utils.ErrServerError))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Successful"})
+	}
+}
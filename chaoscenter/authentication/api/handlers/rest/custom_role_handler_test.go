@@ -0,0 +1,162 @@
+package rest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/api/handlers/rest"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/api/mocks"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// givenProjectOwner mocks the RbacValidator lookups of an owner of the project
+func givenProjectOwner(service *mocks.MockedApplicationService) {
+	service.On("GetUser", "testUserID").Return(&entities.User{ID: "testUserID"}, nil)
+	service.On("GetProjects", mock.Anything).Return([]*entities.Project{{ID: "testProjectID"}}, nil)
+}
+
+func TestCreateCustomRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		inputBody    *entities.CustomRoleInput
+		given        func(service *mocks.MockedApplicationService)
+		expectedCode int
+	}{
+		{
+			name: "Valid Request",
+			inputBody: &entities.CustomRoleInput{
+				ProjectID:   "testProjectID",
+				Name:        "on-call",
+				Permissions: []entities.Permission{entities.PermissionExperimentView, entities.PermissionExperimentRun},
+			},
+			given: func(service *mocks.MockedApplicationService) {
+				givenProjectOwner(service)
+				service.On("CreateCustomRole", mock.MatchedBy(func(role *entities.CustomRole) bool {
+					return role.ProjectID == "testProjectID" && role.Name == "on-call" && role.ID != ""
+				})).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Unknown Permission",
+			inputBody: &entities.CustomRoleInput{
+				ProjectID:   "testProjectID",
+				Name:        "on-call",
+				Permissions: []entities.Permission{"experiment.everything"},
+			},
+			given:        givenProjectOwner,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Built-in Role Name",
+			inputBody: &entities.CustomRoleInput{
+				ProjectID:   "testProjectID",
+				Name:        "Owner",
+				Permissions: []entities.Permission{entities.PermissionExperimentView},
+			},
+			given:        givenProjectOwner,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(mocks.MockedApplicationService)
+			w := httptest.NewRecorder()
+			c := GetTestGinContext(w)
+			bodyBytes, _ := json.Marshal(tt.inputBody)
+			c.Request = httptest.NewRequest(http.MethodPost, "/create_custom_role", bytes.NewReader(bodyBytes))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("uid", "testUserID")
+
+			tt.given(service)
+
+			rest.CreateCustomRole(service)(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestUpdateMemberRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	owner := entities.RoleOwner
+	viewer := entities.RoleViewer
+
+	tests := []struct {
+		name         string
+		inputBody    *entities.MemberRoleInput
+		given        func(service *mocks.MockedApplicationService)
+		expectedCode int
+	}{
+		{
+			name: "Assign Custom Role",
+			inputBody: &entities.MemberRoleInput{
+				ProjectID: "testProjectID",
+				UserID:    "memberUserID",
+				Role:      "customRoleID",
+			},
+			given: func(service *mocks.MockedApplicationService) {
+				givenProjectOwner(service)
+				service.On("GetCustomRole", "testProjectID", "customRoleID").Return(&entities.CustomRole{ID: "customRoleID"}, nil)
+				service.On("GetProjectRole", "testProjectID", "memberUserID").Return(&viewer, nil)
+				service.On("UpdateMemberRole", "testProjectID", "memberUserID", entities.MemberRole("customRoleID")).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Unknown Custom Role",
+			inputBody: &entities.MemberRoleInput{
+				ProjectID: "testProjectID",
+				UserID:    "memberUserID",
+				Role:      "customRoleID",
+			},
+			given: func(service *mocks.MockedApplicationService) {
+				givenProjectOwner(service)
+				service.On("GetCustomRole", "testProjectID", "customRoleID").Return(nil, mongo.ErrNoDocuments)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Change Owner Role",
+			inputBody: &entities.MemberRoleInput{
+				ProjectID: "testProjectID",
+				UserID:    "testUserID",
+				Role:      entities.RoleViewer,
+			},
+			given: func(service *mocks.MockedApplicationService) {
+				givenProjectOwner(service)
+				service.On("GetProjectRole", "testProjectID", "testUserID").Return(&owner, nil)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(mocks.MockedApplicationService)
+			w := httptest.NewRecorder()
+			c := GetTestGinContext(w)
+			bodyBytes, _ := json.Marshal(tt.inputBody)
+			c.Request = httptest.NewRequest(http.MethodPost, "/update_member_role", bytes.NewReader(bodyBytes))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("uid", "testUserID")
+
+			tt.given(service)
+
+			rest.UpdateMemberRole(service)(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
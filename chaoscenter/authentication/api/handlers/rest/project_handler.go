@@ -86,7 +86,7 @@ func GetProject(service services.ApplicationService) gin.HandlerFunc {
 		projectID := c.Param("project_id")
 
 		err := validations.RbacValidator(c.MustGet("uid").(string), projectID,
-			validations.MutationRbacRules["getProject"], string(entities.AcceptedInvitation), service)
+			validations.MutationRbacRules["getProject"], string(entities.AcceptedInvitation), "getProject", apiTokenFromContext(c), service)
 		if err != nil {
 			log.Warn(err)
 			c.JSON(utils.ErrorStatusCodes[utils.ErrUnauthorized],
@@ -121,6 +121,16 @@ func GetProjectsByUserID(service services.ApplicationService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		uID := c.MustGet("uid").(string)
 		projects, err := service.GetProjectsByUserID(uID, false)
+		if apiToken := apiTokenFromContext(c); apiToken != nil {
+			// the projects the API token isn't valid for are left out
+			var scoped []*entities.Project
+			for _, project := range projects {
+				if validations.IsProjectInApiTokenScope(apiToken, project.ID) {
+					scoped = append(scoped, project)
+				}
+			}
+			projects = scoped
+		}
 		if projects == nil {
 			c.JSON(http.StatusOK, gin.H{
 				"message": "No projects found",
@@ -186,6 +196,9 @@ func GetActiveProjectMembers(service services.ApplicationService) gin.HandlerFun
 	return func(c *gin.Context) {
 		projectID := c.Param("project_id")
 		state := c.Param("state")
+		if rejectApiTokenProject(c, projectID) {
+			return
+		}
 		members, err := service.GetProjectMembers(projectID, state)
 		if err != nil {
 			c.JSON(utils.ErrorStatusCodes[utils.ErrServerError], presenter.CreateErrorResponse(utils.ErrServerError))
@@ -264,6 +277,10 @@ func ListInvitations(service services.ApplicationService) gin.HandlerFunc {
 //	@Router			/create_project [post]
 func CreateProject(service services.ApplicationService) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if rejectApiToken(c, "create projects") {
+			return
+		}
+
 		var userRequest entities.CreateProjectInput
 		err := c.BindJSON(&userRequest)
 		if err != nil {
@@ -372,7 +389,7 @@ func SendInvitation(service services.ApplicationService) gin.HandlerFunc {
 		}
 		err = validations.RbacValidator(c.MustGet("uid").(string), member.ProjectID,
 			validations.MutationRbacRules["sendInvitation"], string(entities.AcceptedInvitation),
-			service)
+			"sendInvitation", apiTokenFromContext(c), service)
 		if err != nil {
 			log.Warn(err)
 			c.JSON(utils.ErrorStatusCodes[utils.ErrUnauthorized],
@@ -476,7 +493,7 @@ func AcceptInvitation(service services.ApplicationService) gin.HandlerFunc {
 		err = validations.RbacValidator(c.MustGet("uid").(string), member.ProjectID,
 			validations.MutationRbacRules["acceptInvitation"],
 			string(entities.PendingInvitation),
-			service)
+			"acceptInvitation", apiTokenFromContext(c), service)
 		if err != nil {
 			log.Warn(err)
 			c.JSON(utils.ErrorStatusCodes[utils.ErrUnauthorized],
@@ -524,7 +541,7 @@ func DeclineInvitation(service services.ApplicationService) gin.HandlerFunc {
 		err = validations.RbacValidator(c.MustGet("uid").(string), member.ProjectID,
 			validations.MutationRbacRules["declineInvitation"],
 			string(entities.PendingInvitation),
-			service)
+			"declineInvitation", apiTokenFromContext(c), service)
 		if err != nil {
 			log.Warn(err)
 			c.JSON(utils.ErrorStatusCodes[utils.ErrUnauthorized],
@@ -572,7 +589,7 @@ func LeaveProject(service services.ApplicationService) gin.HandlerFunc {
 		err = validations.RbacValidator(c.MustGet("uid").(string), member.ProjectID,
 			validations.MutationRbacRules["leaveProject"],
 			string(entities.AcceptedInvitation),
-			service)
+			"leaveProject", apiTokenFromContext(c), service)
 		if err != nil {
 			log.Warn(err)
 			c.JSON(utils.ErrorStatusCodes[utils.ErrUnauthorized],
@@ -624,7 +641,7 @@ func RemoveInvitation(service services.ApplicationService) gin.HandlerFunc {
 		err = validations.RbacValidator(c.MustGet("uid").(string), member.ProjectID,
 			validations.MutationRbacRules["removeInvitation"],
 			string(entities.AcceptedInvitation),
-			service)
+			"removeInvitation", apiTokenFromContext(c), service)
 		if err != nil {
 			log.Warn(err)
 			c.JSON(utils.ErrorStatusCodes[utils.ErrUnauthorized],
@@ -691,7 +708,7 @@ func UpdateProjectName(service services.ApplicationService) gin.HandlerFunc {
 			userRequest.ProjectID,
 			validations.MutationRbacRules["updateProjectName"],
 			string(entities.AcceptedInvitation),
-			service)
+			"updateProjectName", apiTokenFromContext(c), service)
 		if err != nil {
 			log.Warn(err)
 			c.JSON(utils.ErrorStatusCodes[utils.ErrUnauthorized],
@@ -775,6 +792,9 @@ func GetProjectRole(service services.ApplicationService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		uid := c.MustGet("uid").(string)
 		projectID := c.Param("project_id")
+		if rejectApiTokenProject(c, projectID) {
+			return
+		}
 		role := "N/A"
 		res, err := service.GetProjectRole(projectID, uid)
 		if err != nil {
@@ -10,8 +10,10 @@ import (
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/api/handlers/rest"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/api/mocks"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/entities"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/services"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -135,6 +137,69 @@ func TestGetProjectsByUserID(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
+	t.Run("API token lists only the projects of its scope", func(t *testing.T) {
+
+		w := httptest.NewRecorder()
+		ctx := GetTestGinContext(w)
+		ctx.Set("uid", "testUserID")
+		ctx.Set("apiToken", &entities.ApiToken{UserID: "testUserID", ProjectIDs: []string{"testProjectID"}})
+		projects := []*entities.Project{
+			{
+				ID:   "testProjectID",
+				Name: "Test Project",
+			},
+			{
+				ID:   "otherProjectID",
+				Name: "Other Project",
+			},
+		}
+		service := new(mocks.MockedApplicationService)
+		service.On("GetProjectsByUserID", "testUserID", false).Return(projects, nil)
+		rest.GetProjectsByUserID(service)(ctx)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "testProjectID")
+		assert.NotContains(t, w.Body.String(), "otherProjectID")
+	})
+
+}
+
+func TestProjectApiTokenScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handlers := map[string]func(services.ApplicationService) gin.HandlerFunc{
+		"GetProjectRole":          rest.GetProjectRole,
+		"GetActiveProjectMembers": rest.GetActiveProjectMembers,
+	}
+	for name, handler := range handlers {
+		t.Run(name+" rejects an API token of another project", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx := GetTestGinContext(w)
+			ctx.Set("uid", "testUserID")
+			ctx.Set("apiToken", &entities.ApiToken{UserID: "testUserID", ProjectIDs: []string{"otherProjectID"}})
+			ctx.Params = gin.Params{{Key: "project_id", Value: "testProjectID"}, {Key: "state", Value: "all"}}
+			service := new(mocks.MockedApplicationService)
+
+			handler(service)(ctx)
+
+			assert.Equal(t, utils.ErrorStatusCodes[utils.ErrUnauthorized], w.Code)
+			service.AssertNotCalled(t, "GetProjectRole", mock.Anything, mock.Anything)
+			service.AssertNotCalled(t, "GetProjectMembers", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("GetActiveProjectMembers allows an API token of the project", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx := GetTestGinContext(w)
+		ctx.Set("uid", "testUserID")
+		ctx.Set("apiToken", &entities.ApiToken{UserID: "testUserID", ProjectIDs: []string{"testProjectID"}})
+		ctx.Params = gin.Params{{Key: "project_id", Value: "testProjectID"}, {Key: "state", Value: "all"}}
+		service := new(mocks.MockedApplicationService)
+		service.On("GetProjectMembers", "testProjectID", "all").Return([]*entities.Member{}, nil)
+
+		rest.GetActiveProjectMembers(service)(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
 }
 
 func TestGetProject(t *testing.T) {
@@ -8,6 +8,7 @@ import (
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/entities"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/services"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/utils"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/validations"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -32,6 +33,10 @@ const BearerSchema = "Bearer "
 //	@Router			/create_user [post]
 func CreateUser(service services.ApplicationService) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if rejectApiToken(c, "create users") {
+			return
+		}
+
 		userRole := c.MustGet("role").(string)
 
 		if entities.Role(userRole) != entities.RoleAdmin {
@@ -110,6 +115,10 @@ func CreateUser(service services.ApplicationService) gin.HandlerFunc {
 //	@Router			/update/details [post]
 func UpdateUser(service services.ApplicationService) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if rejectApiToken(c, "update user details") {
+			return
+		}
+
 		var userRequest entities.UserDetails
 		err := c.BindJSON(&userRequest)
 		if err != nil {
@@ -384,6 +393,10 @@ func LogoutUser(service services.ApplicationService) gin.HandlerFunc {
 //	@Router			/update/password [post]
 func UpdatePassword(service services.ApplicationService) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if rejectApiToken(c, "update passwords") {
+			return
+		}
+
 		var userPasswordRequest entities.UserPassword
 		err := c.BindJSON(&userPasswordRequest)
 		if err != nil {
@@ -430,6 +443,10 @@ func UpdatePassword(service services.ApplicationService) gin.HandlerFunc {
 //	@Router			/reset/password [post]
 func ResetPassword(service services.ApplicationService) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if rejectApiToken(c, "reset passwords") {
+			return
+		}
+
 		userRole := c.MustGet("role").(string)
 
 		if entities.Role(userRole) != entities.RoleAdmin {
@@ -490,6 +507,9 @@ func ResetPassword(service services.ApplicationService) gin.HandlerFunc {
 //	@Router			/update/state [post]
 func UpdateUserState(service services.ApplicationService) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if rejectApiToken(c, "update user states") {
+			return
+		}
 
 		userRole := c.MustGet("role").(string)
 
@@ -559,6 +579,17 @@ func CreateApiToken(service services.ApplicationService) gin.HandlerFunc {
 			return
 		}
 
+		if err := validations.ValidateApiTokenInput(apiTokenRequest); err != nil {
+			log.Warn(err)
+			c.JSON(utils.ErrorStatusCodes[utils.ErrInvalidRequest], presenter.CreateErrorResponse(utils.ErrInvalidRequest))
+			return
+		}
+
+		// API tokens can't be used to issue new tokens, which could escape their scope
+		if rejectApiToken(c, "create api tokens") {
+			return
+		}
+
 		// Checking if user exists
 		user, err := service.GetUser(apiTokenRequest.UserID)
 		if err != nil {
@@ -620,6 +651,10 @@ func GetApiTokens(service services.ApplicationService) gin.HandlerFunc {
 // DeleteApiToken deletes the api token for the user
 func DeleteApiToken(service services.ApplicationService) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if rejectApiToken(c, "delete api tokens") {
+			return
+		}
+
 		var deleteApiTokenRequest entities.DeleteApiTokenInput
 		err := c.BindJSON(&deleteApiTokenRequest)
 		if err != nil {
@@ -645,3 +680,35 @@ func DeleteApiToken(service services.ApplicationService) gin.HandlerFunc {
 		}
 	}
 }
+
+// apiTokenFromContext returns the API token the request was authenticated with, or nil for the session tokens
+func apiTokenFromContext(c *gin.Context) *entities.ApiToken {
+	apiToken, ok := c.Get("apiToken")
+	if !ok {
+		return nil
+	}
+	token, _ := apiToken.(*entities.ApiToken)
+	return token
+}
+
+// rejectApiToken responds unauthorized if the request was authenticated with an API token. The account actions
+// aren't bound to a project, so they can't be limited to the scope of a token
+func rejectApiToken(c *gin.Context, action string) bool {
+	if apiTokenFromContext(c) == nil {
+		return false
+	}
+	log.Error("auth error: api tokens can't " + action)
+	c.JSON(utils.ErrorStatusCodes[utils.ErrUnauthorized], presenter.CreateErrorResponse(utils.ErrUnauthorized))
+	return true
+}
+
+// rejectApiTokenProject responds unauthorized if the request was authenticated with an API token which isn't
+// valid for the project
+func rejectApiTokenProject(c *gin.Context, projectID string) bool {
+	if validations.IsProjectInApiTokenScope(apiTokenFromContext(c), projectID) {
+		return false
+	}
+	log.Error("auth error: api token is not valid for the project " + projectID)
+	c.JSON(utils.ErrorStatusCodes[utils.ErrUnauthorized], presenter.CreateErrorResponse(utils.ErrUnauthorized))
+	return true
+}
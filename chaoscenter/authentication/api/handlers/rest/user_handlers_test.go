@@ -611,6 +611,7 @@ func TestCreateApiToken(t *testing.T) {
 	tests := []struct {
 		name         string
 		inputBody    *entities.ApiTokenInput
+		apiToken     *entities.ApiToken
 		given        func()
 		expectedCode int
 	}{
@@ -628,6 +629,24 @@ func TestCreateApiToken(t *testing.T) {
 			},
 			expectedCode: http.StatusOK,
 		},
+		{
+			name: "Invalid Allowed IP",
+			inputBody: &entities.ApiTokenInput{
+				UserID:     "testUserID",
+				AllowedIPs: []string{"10.0.0.300"},
+			},
+			given:        func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Requested With An API Token",
+			inputBody: &entities.ApiTokenInput{
+				UserID: "testUserID",
+			},
+			apiToken:     &entities.ApiToken{UserID: "testUserID"},
+			given:        func() {},
+			expectedCode: http.StatusUnauthorized,
+		},
 	}
 
 	for _, tt := range tests {
@@ -637,6 +656,9 @@ func TestCreateApiToken(t *testing.T) {
 			bodyBytes, _ := json.Marshal(tt.inputBody)
 			c.Request = httptest.NewRequest(http.MethodPost, "/api/token", bytes.NewReader(bodyBytes))
 			c.Request.Header.Set("Content-Type", "application/json")
+			if tt.apiToken != nil {
+				c.Set("apiToken", tt.apiToken)
+			}
 
 			tt.given()
 
@@ -707,3 +729,35 @@ func TestDeleteApiToken(t *testing.T) {
 	// then
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
+
+func TestAccountActionsRejectApiTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service := new(mocks.MockedApplicationService)
+
+	handlers := map[string]gin.HandlerFunc{
+		"create_user":     rest.CreateUser(service),
+		"update_details":  rest.UpdateUser(service),
+		"update_password": rest.UpdatePassword(service),
+		"reset_password":  rest.ResetPassword(service),
+		"update_state":    rest.UpdateUserState(service),
+		"remove_token":    rest.DeleteApiToken(service),
+		"create_project":  rest.CreateProject(service),
+	}
+	for name, handler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c := GetTestGinContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/"+name, bytes.NewReader([]byte("{}")))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("uid", "testUserID")
+			c.Set("username", "testUser")
+			c.Set("role", string(entities.RoleAdmin))
+			c.Set("apiToken", &entities.ApiToken{UserID: "testUserID", ProjectIDs: []string{"project"}})
+
+			handler(c)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
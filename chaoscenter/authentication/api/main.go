@@ -8,8 +8,10 @@ import (
 	"time"
 
 	grpcHandler "github.com/litmuschaos/litmus/chaoscenter/authentication/api/handlers/grpc"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/api/middleware"
 	grpcPresenter "github.com/litmuschaos/litmus/chaoscenter/authentication/api/presenter/protos"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/api/routes"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/audit"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/entities"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/misc"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/project"
@@ -96,6 +98,11 @@ func main() {
 		log.Errorf("failed to create collection  %s", err)
 	}
 
+	// Creating CustomRole Collection
+	if err = utils.CreateCollection(utils.CustomRoleCollection, db); err != nil {
+		log.Errorf("failed to create collection  %s", err)
+	}
+
 	userCollection := db.Collection(utils.UserCollection)
 	userRepo := user.NewRepo(userCollection)
 
@@ -108,9 +115,16 @@ func main() {
 	apiTokenCollection := db.Collection(utils.ApiTokenCollection)
 	apiTokenRepo := session.NewApiTokenRepo(apiTokenCollection)
 
+	customRoleCollection := db.Collection(utils.CustomRoleCollection)
+	customRoleRepo := project.NewCustomRoleRepo(customRoleCollection)
+
+	// the audit log is shared with the GraphQL server, so that all the mutating actions are queried together
+	auditLogCollection := client.Database(utils.AuditDBName).Collection(utils.AuditLogCollection)
+	auditRepo := audit.NewRepo(auditLogCollection)
+
 	miscRepo := misc.NewRepo(db, client)
 
-	applicationService := services.NewService(userRepo, projectRepo, miscRepo, revokedTokenRepo, apiTokenRepo, db)
+	applicationService := services.NewService(userRepo, projectRepo, miscRepo, revokedTokenRepo, apiTokenRepo, customRoleRepo, auditRepo, db)
 
 	validatedAdminSetup(applicationService)
 
@@ -158,11 +172,17 @@ func runRestServer(applicationService services.ApplicationService) {
 	gin.SetMode(gin.ReleaseMode)
 	gin.EnableJsonDecoderDisallowUnknownFields()
 	app := gin.Default()
+	// the client IPs are read from the forwarded headers only when the requests come from the trusted proxies,
+	// the clients could set them to get past the IP allow-lists of the API tokens otherwise
+	if err := app.SetTrustedProxies(utils.TrustedProxies); err != nil {
+		log.Fatalf("Failure to set the trusted proxies due to %v", err)
+	}
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowHeaders:     []string{"*"},
 		AllowCredentials: true,
 	}))
+	app.Use(middleware.AuditMiddleware(applicationService))
 	// Enable dex routes only if passed via environment variables
 	if utils.DexEnabled {
 		routes.DexRouter(app, applicationService)
@@ -0,0 +1,235 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/entities"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/services"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxAuditResponseSize is the size of the response kept to look up the IDs of the created resources
+const maxAuditResponseSize = 64 * 1024
+
+const (
+	// auditRequestKey is the key of the decoded request body in the context of an audited request
+	auditRequestKey = "auditRequest"
+	// auditBeforeKey is the key of the state of the changed resource before the request, in the context of an
+	// audited request
+	auditBeforeKey = "auditBefore"
+)
+
+// auditResponseWriter keeps a copy of the response, so that the audit log can refer to the created resources
+type auditResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *auditResponseWriter) Write(b []byte) (int, error) {
+	if w.body.Len() < maxAuditResponseSize {
+		w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *auditResponseWriter) WriteString(s string) (int, error) {
+	if w.body.Len() < maxAuditResponseSize {
+		w.body.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// AuditMiddleware is a Gin Middleware that records every mutating request in the audit log, along with its
+// actor, outcome and the states of the changed resource. It has to run before the JwtMiddleware, so that the
+// rejected requests are recorded too
+func AuditMiddleware(service services.ApplicationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !isMutatingRequest(c) {
+			c.Next()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			body, _ = io.ReadAll(c.Request.Body)
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		request := decodeJSON(body)
+		c.Set(auditRequestKey, request)
+		writer := &auditResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		// requests to unknown routes aren't actions
+		if c.FullPath() == "" {
+			return
+		}
+		result := decodeJSON(writer.body.Bytes())
+		auditLog := newAuditLog(c, request, result)
+		// the states are only read for the requests which got past the JwtMiddleware
+		if before, ok := c.Get(auditBeforeKey); ok {
+			auditLog.Before, _ = before.(string)
+			auditLog.After = resourceState(c, service, auditLog.Operation, request, pathParams(c), result)
+		}
+		if err := service.RecordAuditLog(auditLog); err != nil {
+			log.Errorf("failed to record audit log of %s, error: %v", c.FullPath(), err)
+		}
+	}
+}
+
+// AuditStateMiddleware is a Gin Middleware that reads the state of the resource changed by a mutating request
+// before it's handled. It has to run after the JwtMiddleware, the users are looked up with their IDs
+func AuditStateMiddleware(service services.ApplicationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get(auditBeforeKey); ok || !isMutatingRequest(c) {
+			c.Next()
+			return
+		}
+
+		request, _ := c.Get(auditRequestKey)
+		c.Set(auditBeforeKey, resourceState(c, service, operationName(c), request, pathParams(c)))
+		c.Next()
+	}
+}
+
+func isMutatingRequest(c *gin.Context) bool {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+func pathParams(c *gin.Context) map[string]interface{} {
+	params := map[string]interface{}{}
+	for _, param := range c.Params {
+		params[param.Key] = param.Value
+	}
+	return params
+}
+
+// newAuditLog builds the audit log of a handled request from its decoded JSON body and response
+func newAuditLog(c *gin.Context, request interface{}, result interface{}) *entities.AuditLog {
+	var (
+		params   = pathParams(c)
+		auditLog = &entities.AuditLog{
+			AuditID:   uuid.New().String(),
+			Timestamp: time.Now().UnixMilli(),
+			Source:    entities.AuditSourceAuthentication,
+			Operation: operationName(c),
+			Actor: entities.AuditActor{
+				UserID:   c.GetString("uid"),
+				Username: c.GetString("username"),
+				ClientIP: c.ClientIP(),
+			},
+			Outcome: entities.AuditOutcomeSuccess,
+		}
+	)
+	if _, ok := c.Get("apiToken"); ok {
+		auditLog.Actor.ApiToken = true
+	}
+	// the login requests are made before the user is authenticated
+	if auditLog.Actor.Username == "" {
+		auditLog.Actor.Username = findValue(request, isUsernameKey)
+	}
+
+	auditLog.ProjectID = firstValue(isProjectKey, request, params, result)
+	auditLog.TargetID = firstValue(isTargetKey, request, params, result)
+
+	status := c.Writer.Status()
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		auditLog.Outcome = entities.AuditOutcomeDenied
+	case status >= http.StatusBadRequest:
+		auditLog.Outcome = entities.AuditOutcomeFailure
+	}
+	if auditLog.Outcome != entities.AuditOutcomeSuccess {
+		auditLog.Error = firstValue(isErrorKey, result)
+	}
+	return auditLog
+}
+
+// operationName returns the name of the route handler, like createProject, which is the name the
+// operations have in the permission catalogue
+func operationName(c *gin.Context) string {
+	name := c.HandlerName()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	parts := strings.Split(name, ".")
+	if len(parts) < 2 || parts[1] == "" {
+		return c.FullPath()
+	}
+	return strings.ToLower(parts[1][:1]) + parts[1][1:]
+}
+
+func decodeJSON(data []byte) interface{} {
+	var value interface{}
+	if len(data) == 0 || json.Unmarshal(data, &value) != nil {
+		return nil
+	}
+	return value
+}
+
+func isProjectKey(key string) bool {
+	return key == "projectID" || key == "project_id"
+}
+
+func isTargetKey(key string) bool {
+	lowerKey := strings.ToLower(key)
+	return !isProjectKey(key) && (strings.HasSuffix(lowerKey, "id") && !strings.HasSuffix(lowerKey, "valid"))
+}
+
+func isUsernameKey(key string) bool {
+	return key == "username"
+}
+
+func isErrorKey(key string) bool {
+	return key == "error" || key == "message"
+}
+
+// firstValue returns the first value matching the key in the given decoded JSON values
+func firstValue(match func(string) bool, values ...interface{}) string {
+	for _, value := range values {
+		if found := findValue(value, match); found != "" {
+			return found
+		}
+	}
+	return ""
+}
+
+// findValue returns the string value of the first key matching in a decoded JSON object, the top level
+// keys are looked up before the ones of the nested objects, in their sorted order
+func findValue(value interface{}, match func(string) bool) string {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if str, ok := object[key].(string); ok && str != "" && match(key) {
+			return str
+		}
+	}
+	for _, key := range keys {
+		if found := findValue(object[key], match); found != "" {
+			return found
+		}
+	}
+	return ""
+}
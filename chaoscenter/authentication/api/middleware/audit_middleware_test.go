@@ -0,0 +1,158 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/api/middleware"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/api/mocks"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func loginUser(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"accessToken": "jwt"})
+}
+
+func createProject(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"projectID": "newProjectID", "name": "chaos"}})
+}
+
+func updateMemberRole(c *gin.Context) {
+	c.JSON(http.StatusForbidden, gin.H{"error": "permission_denied"})
+}
+
+func updateUser(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"message": "User details updated successfully"})
+}
+
+func getProject(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		apiToken   bool
+		setup      func(service *mocks.MockedApplicationService)
+		assertions func(t *testing.T, auditLog *entities.AuditLog)
+	}{
+		{
+			name: "request without a state",
+			path: "/login",
+			body: `{"username":"admin","password":"litmus"}`,
+			assertions: func(t *testing.T, auditLog *entities.AuditLog) {
+				assert.Equal(t, "loginUser", auditLog.Operation)
+				assert.Equal(t, "admin", auditLog.Actor.Username)
+				assert.Equal(t, entities.AuditOutcomeSuccess, auditLog.Outcome)
+				assert.Empty(t, auditLog.Before)
+				assert.Empty(t, auditLog.After)
+			},
+		},
+		{
+			name: "project of a created resource",
+			path: "/create_project",
+			body: `{"projectName":"chaos"}`,
+			setup: func(service *mocks.MockedApplicationService) {
+				service.On("GetProjectByProjectID", "newProjectID").Return(&entities.Project{ID: "newProjectID", Name: "chaos"}, nil).Once()
+			},
+			assertions: func(t *testing.T, auditLog *entities.AuditLog) {
+				assert.Equal(t, "createProject", auditLog.Operation)
+				assert.Equal(t, "testUserID", auditLog.Actor.UserID)
+				assert.Equal(t, "newProjectID", auditLog.ProjectID)
+				assert.Equal(t, entities.AuditOutcomeSuccess, auditLog.Outcome)
+				assert.Empty(t, auditLog.Before)
+				assert.Contains(t, auditLog.After, `"projectID":"newProjectID"`)
+			},
+		},
+		{
+			name: "user without its password",
+			path: "/update/details",
+			body: `{"name":"Admin","password":"litmus"}`,
+			setup: func(service *mocks.MockedApplicationService) {
+				service.On("GetUser", "testUserID").Return(&entities.User{ID: "testUserID", Name: "admin", Password: "hash"}, nil).Once()
+				service.On("GetUser", "testUserID").Return(&entities.User{ID: "testUserID", Name: "Admin", Password: "hash"}, nil).Once()
+			},
+			assertions: func(t *testing.T, auditLog *entities.AuditLog) {
+				assert.Contains(t, auditLog.Before, `"name":"admin"`)
+				assert.Contains(t, auditLog.After, `"name":"Admin"`)
+				assert.NotContains(t, auditLog.Before+auditLog.After, "hash")
+				assert.NotContains(t, auditLog.Before+auditLog.After, "litmus")
+			},
+		},
+		{
+			name:     "denied request",
+			path:     "/update_member_role",
+			body:     `{"projectID":"testProjectID","userID":"memberID","role":"Owner"}`,
+			apiToken: true,
+			setup: func(service *mocks.MockedApplicationService) {
+				service.On("GetProjectByProjectID", "testProjectID").Return(&entities.Project{ID: "testProjectID"}, nil).Twice()
+			},
+			assertions: func(t *testing.T, auditLog *entities.AuditLog) {
+				assert.Equal(t, "updateMemberRole", auditLog.Operation)
+				assert.True(t, auditLog.Actor.ApiToken)
+				assert.Equal(t, "testProjectID", auditLog.ProjectID)
+				assert.Equal(t, "memberID", auditLog.TargetID)
+				assert.Equal(t, entities.AuditOutcomeDenied, auditLog.Outcome)
+				assert.Equal(t, "permission_denied", auditLog.Error)
+				assert.Equal(t, auditLog.Before, auditLog.After)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(mocks.MockedApplicationService)
+			var recorded *entities.AuditLog
+			service.On("RecordAuditLog", mock.Anything).Run(func(args mock.Arguments) {
+				recorded = args.Get(0).(*entities.AuditLog)
+			}).Return(nil)
+			if tt.setup != nil {
+				tt.setup(service)
+			}
+
+			router := gin.New()
+			router.Use(middleware.AuditMiddleware(service))
+			router.POST("/login", loginUser)
+			router.Use(func(c *gin.Context) {
+				c.Set("uid", "testUserID")
+				if tt.apiToken {
+					c.Set("apiToken", &entities.ApiToken{})
+				}
+			})
+			router.Use(middleware.AuditStateMiddleware(service))
+			router.POST("/create_project", createProject)
+			router.POST("/update_member_role", updateMemberRole)
+			router.POST("/update/details", updateUser)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body)))
+
+			service.AssertNumberOfCalls(t, "RecordAuditLog", 1)
+			service.AssertExpectations(t)
+			tt.assertions(t, recorded)
+			assert.NotEmpty(t, recorded.AuditID)
+		})
+	}
+
+	t.Run("read requests aren't recorded", func(t *testing.T) {
+		service := new(mocks.MockedApplicationService)
+		router := gin.New()
+		router.Use(middleware.AuditMiddleware(service))
+		router.GET("/get_project/:project_id", getProject)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/get_project/testProjectID", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		service.AssertNotCalled(t, "RecordAuditLog", mock.Anything)
+	})
+}
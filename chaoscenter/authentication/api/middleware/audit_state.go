@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/services"
+)
+
+// stateReader returns the state of the resource changed by an operation, looked up with the IDs of the given
+// decoded JSON values. The secrets of the resource, like the password of the users, are left out
+type stateReader func(c *gin.Context, service services.ApplicationService, values ...interface{}) interface{}
+
+// operationStates are the readers of the resources whose states are recorded before and after the operations
+var operationStates = map[string]stateReader{
+	"createUser":        userState,
+	"updateUser":        userState,
+	"updatePassword":    userState,
+	"resetPassword":     userState,
+	"updateUserState":   userState,
+	"createProject":     projectState,
+	"sendInvitation":    projectState,
+	"acceptInvitation":  projectState,
+	"declineInvitation": projectState,
+	"removeInvitation":  projectState,
+	"leaveProject":      projectState,
+	"updateProjectName": projectState,
+	"updateMemberRole":  projectState,
+	"createCustomRole":  customRoleState,
+	"updateCustomRole":  customRoleState,
+	"deleteCustomRole":  customRoleState,
+}
+
+// userState returns the user of the request, or the user who made it
+func userState(c *gin.Context, service services.ApplicationService, values ...interface{}) interface{} {
+	if username := firstValue(isUsernameKey, values...); username != "" {
+		user, err := service.FindUserByUsername(username)
+		if err != nil || user == nil {
+			return nil
+		}
+		user.Password = ""
+		return user
+	}
+	if uid := c.GetString("uid"); uid != "" {
+		user, err := service.GetUser(uid)
+		if err != nil || user == nil {
+			return nil
+		}
+		user.Password = ""
+		return user
+	}
+	return nil
+}
+
+func projectState(_ *gin.Context, service services.ApplicationService, values ...interface{}) interface{} {
+	projectID := firstValue(isProjectKey, values...)
+	if projectID == "" {
+		return nil
+	}
+	project, err := service.GetProjectByProjectID(projectID)
+	if err != nil || project == nil {
+		return nil
+	}
+	return project
+}
+
+func customRoleState(_ *gin.Context, service services.ApplicationService, values ...interface{}) interface{} {
+	projectID, roleID := firstValue(isProjectKey, values...), firstValue(isRoleIDKey, values...)
+	if projectID == "" || roleID == "" {
+		return nil
+	}
+	role, err := service.GetCustomRole(projectID, roleID)
+	if err != nil || role == nil {
+		return nil
+	}
+	return role
+}
+
+func isRoleIDKey(key string) bool {
+	return key == "roleID"
+}
+
+// resourceState returns the JSON of the state of the resource changed by an operation, it's empty if the
+// operation doesn't change a resource or if the resource doesn't exist
+func resourceState(c *gin.Context, service services.ApplicationService, operation string, values ...interface{}) string {
+	read, ok := operationStates[operation]
+	if !ok {
+		return ""
+	}
+	state := read(c, service, values...)
+	if state == nil {
+		return ""
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
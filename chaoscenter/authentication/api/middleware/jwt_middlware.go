@@ -6,6 +6,7 @@ import (
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/api/presenter"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/services"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/utils"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/validations"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -26,6 +27,20 @@ func JwtMiddleware(service services.ApplicationService) gin.HandlerFunc {
 			return
 		}
 		if token.Valid {
+			// API tokens are only valid until they are deleted and from their allowed IPs
+			apiToken, err := service.GetApiTokenScope(token)
+			if err != nil || (apiToken != nil && !validations.IsIPAllowed(apiToken.AllowedIPs, c.ClientIP())) {
+				log.Error("auth error: api token is revoked or used from a disallowed address")
+				c.AbortWithStatusJSON(utils.ErrorStatusCodes[utils.ErrUnauthorized], presenter.CreateErrorResponse(utils.ErrUnauthorized))
+				return
+			}
+			if apiToken != nil {
+				c.Set("apiToken", apiToken)
+				if err := service.UpdateApiTokenLastUsed(tokenString, c.ClientIP()); err != nil {
+					log.Warn(err)
+				}
+			}
+
 			claims := token.Claims.(jwt.MapClaims)
 			c.Set("username", claims["username"])
 			c.Set("uid", claims["uid"])
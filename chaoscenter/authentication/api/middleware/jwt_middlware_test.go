@@ -0,0 +1,84 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/api/middleware"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/api/mocks"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/entities"
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestJwtMiddlewareAllowedIPs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   string
+		expectedCode   int
+	}{
+		{
+			name:         "request from an allowed address",
+			remoteAddr:   "10.0.0.1:4000",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "spoofed forwarded address without trusted proxies",
+			remoteAddr:   "192.0.2.1:4000",
+			forwardedFor: "10.0.0.1",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "spoofed forwarded address from an untrusted proxy",
+			trustedProxies: []string{"192.0.2.2"},
+			remoteAddr:     "192.0.2.1:4000",
+			forwardedFor:   "10.0.0.1",
+			expectedCode:   http.StatusUnauthorized,
+		},
+		{
+			name:           "forwarded address from a trusted proxy",
+			trustedProxies: []string{"192.0.2.1"},
+			remoteAddr:     "192.0.2.1:4000",
+			forwardedFor:   "10.0.0.1",
+			expectedCode:   http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(mocks.MockedApplicationService)
+			token := &jwt.Token{Valid: true, Claims: jwt.MapClaims{"username": "user", "uid": "uid", "role": "user"}}
+			service.On("ValidateToken", "apiToken").Return(token, nil)
+			service.On("GetApiTokenScope", token).Return(&entities.ApiToken{AllowedIPs: []string{"10.0.0.1"}}, nil)
+			service.On("UpdateApiTokenLastUsed", "apiToken", mock.Anything).Return(nil)
+
+			// the router is set up like the REST server, which trusts the proxies configured in TRUSTED_PROXIES
+			utils.TrustedProxies = tt.trustedProxies
+			app := gin.New()
+			assert.NoError(t, app.SetTrustedProxies(utils.TrustedProxies))
+			app.GET("/", middleware.JwtMiddleware(service), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("Authorization", "Bearer apiToken")
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			app.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+	utils.TrustedProxies = nil
+}
@@ -120,6 +120,11 @@ func (m *MockedApplicationService) UpdateProjectName(projectID, projectName stri
 	return args.Error(0)
 }
 
+func (m *MockedApplicationService) UpdateMemberRole(projectID string, userID string, role entities.MemberRole) error {
+	args := m.Called(projectID, userID, role)
+	return args.Error(0)
+}
+
 func (m *MockedApplicationService) GetAggregateProjects(pipeline mongo.Pipeline, opts *options.AggregateOptions) (*mongo.Cursor, error) {
 	args := m.Called(pipeline, opts)
 	return args.Get(0).(*mongo.Cursor), args.Error(1)
@@ -175,6 +180,17 @@ func (m *MockedApplicationService) GetApiTokensByUserID(userID string) ([]entiti
 	return args.Get(0).([]entities.ApiToken), args.Error(1)
 }
 
+func (m *MockedApplicationService) GetApiTokenScope(token *jwt.Token) (*entities.ApiToken, error) {
+	args := m.Called(token)
+	apiToken, _ := args.Get(0).(*entities.ApiToken)
+	return apiToken, args.Error(1)
+}
+
+func (m *MockedApplicationService) UpdateApiTokenLastUsed(token string, ip string) error {
+	args := m.Called(token, ip)
+	return args.Error(0)
+}
+
 func (m *MockedApplicationService) DeleteApiToken(token string) error {
 	args := m.Called(token)
 	return args.Error(0)
@@ -199,3 +215,34 @@ func (m *MockedApplicationService) RbacValidator(userID, resourceID string, rule
 	args := m.Called(userID, resourceID, rules, invitationStatus)
 	return args.Error(0)
 }
+
+func (m *MockedApplicationService) CreateCustomRole(role *entities.CustomRole) error {
+	args := m.Called(role)
+	return args.Error(0)
+}
+
+func (m *MockedApplicationService) GetCustomRole(projectID string, roleID string) (*entities.CustomRole, error) {
+	args := m.Called(projectID, roleID)
+	role, _ := args.Get(0).(*entities.CustomRole)
+	return role, args.Error(1)
+}
+
+func (m *MockedApplicationService) GetCustomRoles(projectID string) ([]*entities.CustomRole, error) {
+	args := m.Called(projectID)
+	return args.Get(0).([]*entities.CustomRole), args.Error(1)
+}
+
+func (m *MockedApplicationService) UpdateCustomRole(role *entities.CustomRole) error {
+	args := m.Called(role)
+	return args.Error(0)
+}
+
+func (m *MockedApplicationService) DeleteCustomRole(projectID string, roleID string) error {
+	args := m.Called(projectID, roleID)
+	return args.Error(0)
+}
+
+func (m *MockedApplicationService) RecordAuditLog(log *entities.AuditLog) error {
+	args := m.Called(log)
+	return args.Error(0)
+}
@@ -11,6 +11,7 @@ import (
 // ProjectRouter creates all the required routes for project related purposes.
 func ProjectRouter(router *gin.Engine, service services.ApplicationService) {
 	router.Use(middleware.JwtMiddleware(service))
+	router.Use(middleware.AuditStateMiddleware(service))
 	router.GET("/get_project/:project_id", rest.GetProject(service))
 	router.GET("/get_project_members/:project_id/:state", rest.GetActiveProjectMembers(service))
 	router.GET("/get_user_with_project/:username", rest.GetUserWithProject(service))
@@ -26,4 +27,10 @@ func ProjectRouter(router *gin.Engine, service services.ApplicationService) {
 	router.POST("/remove_invitation", rest.RemoveInvitation(service))
 	router.POST("/leave_project", rest.LeaveProject(service))
 	router.POST("/update_project_name", rest.UpdateProjectName(service))
+	router.POST("/update_member_role", rest.UpdateMemberRole(service))
+	router.GET("/permissions", rest.GetPermissions())
+	router.GET("/list_custom_roles/:project_id", rest.ListCustomRoles(service))
+	router.POST("/create_custom_role", rest.CreateCustomRole(service))
+	router.POST("/update_custom_role", rest.UpdateCustomRole(service))
+	router.POST("/delete_custom_role", rest.DeleteCustomRole(service))
 }
@@ -13,6 +13,7 @@ func UserRouter(router *gin.Engine, service services.ApplicationService) {
 	router.POST("/login", rest.LoginUser(service))
 	router.POST("/logout", rest.LogoutUser(service))
 	router.Use(middleware.JwtMiddleware(service))
+	router.Use(middleware.AuditStateMiddleware(service))
 	router.GET("/token/:uid", rest.GetApiTokens(service))
 	router.POST("/create_token", rest.CreateApiToken(service))
 	router.POST("/remove_token", rest.DeleteApiToken(service))
@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/entities"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Repository holds the mongo database implementation of the audit log, which is append-only
+type Repository interface {
+	CreateAuditLog(log *entities.AuditLog) error
+}
+
+type repository struct {
+	Collection *mongo.Collection
+}
+
+// CreateAuditLog appends an entry to the audit log
+func (r repository) CreateAuditLog(log *entities.AuditLog) error {
+	_, err := r.Collection.InsertOne(context.Background(), log)
+	return err
+}
+
+// NewRepo creates a new instance of this repository
+func NewRepo(collection *mongo.Collection) Repository {
+	return &repository{
+		Collection: collection,
+	}
+}
@@ -0,0 +1,39 @@
+package entities
+
+// AuditOutcome is the result of an audited request
+type AuditOutcome string
+
+const (
+	AuditOutcomeSuccess AuditOutcome = "Success"
+	AuditOutcomeFailure AuditOutcome = "Failure"
+	AuditOutcomeDenied  AuditOutcome = "Denied"
+)
+
+// AuditSourceAuthentication is the source of the audit logs written by the authentication server, the
+// GraphQL server writes its own entries to the same collection
+const AuditSourceAuthentication = "Authentication"
+
+// AuditActor holds the details of the user who made an audited request
+type AuditActor struct {
+	UserID   string `bson:"user_id,omitempty"`
+	Username string `bson:"username,omitempty"`
+	ClientIP string `bson:"client_ip,omitempty"`
+	// ApiToken is true if the request was authenticated with an API token
+	ApiToken bool `bson:"api_token"`
+}
+
+// AuditLog is an immutable record of a mutating request
+type AuditLog struct {
+	AuditID   string     `bson:"audit_id"`
+	Timestamp int64      `bson:"timestamp"`
+	Source    string     `bson:"source"`
+	Actor     AuditActor `bson:"actor"`
+	ProjectID string     `bson:"project_id,omitempty"`
+	Operation string     `bson:"operation"`
+	TargetID  string     `bson:"target_id,omitempty"`
+	// Before and After are the JSON states of the target before and after the request, without its secrets
+	Before  string       `bson:"before,omitempty"`
+	After   string       `bson:"after,omitempty"`
+	Outcome AuditOutcome `bson:"outcome"`
+	Error   string       `bson:"error,omitempty"`
+}
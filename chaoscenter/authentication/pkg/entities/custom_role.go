@@ -0,0 +1,69 @@
+package entities
+
+// Permission is a fine-grained right in a project, custom roles are made of permissions
+type Permission string
+
+const (
+	PermissionProjectView        Permission = "project.view"
+	PermissionProjectManage      Permission = "project.manage"
+	PermissionMemberManage       Permission = "member.manage"
+	PermissionRoleManage         Permission = "role.manage"
+	PermissionExperimentView     Permission = "experiment.view"
+	PermissionExperimentEdit     Permission = "experiment.edit"
+	PermissionExperimentRun      Permission = "experiment.run"
+	PermissionInfraView          Permission = "infra.view"
+	PermissionInfraRegister      Permission = "infra.register"
+	PermissionInfraDelete        Permission = "infra.delete"
+	PermissionEnvironmentView    Permission = "environment.view"
+	PermissionEnvironmentEdit    Permission = "environment.edit"
+	PermissionHubView            Permission = "hub.view"
+	PermissionHubManage          Permission = "hub.manage"
+	PermissionProbeView          Permission = "probe.view"
+	PermissionProbeEdit          Permission = "probe.edit"
+	PermissionGitOpsManage       Permission = "gitops.manage"
+	PermissionRegistryManage     Permission = "registry.manage"
+	PermissionNotificationView   Permission = "notification.view"
+	PermissionNotificationManage Permission = "notification.manage"
+	PermissionAuditView          Permission = "audit.view"
+)
+
+// PermissionDetails describes a permission of the catalogue and the operations it grants
+type PermissionDetails struct {
+	Name        Permission `json:"name"`
+	Description string     `json:"description"`
+	Operations  []string   `json:"operations"`
+}
+
+// CustomRole is a project role defined by the project owner as a set of permissions
+type CustomRole struct {
+	ID          string       `bson:"_id" json:"roleID"`
+	ProjectID   string       `bson:"project_id" json:"projectID"`
+	Name        string       `bson:"name" json:"name"`
+	Description string       `bson:"description" json:"description"`
+	Permissions []Permission `bson:"permissions" json:"permissions"`
+	CreatedAt   int64        `bson:"created_at" json:"createdAt"`
+	UpdatedAt   int64        `bson:"updated_at" json:"updatedAt"`
+}
+
+// CustomRoleInput contains the fields to create or update a custom role
+type CustomRoleInput struct {
+	ProjectID   string       `json:"projectID"`
+	RoleID      string       `json:"roleID"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Permissions []Permission `json:"permissions"`
+}
+
+// DeleteCustomRoleInput contains the fields to delete a custom role
+type DeleteCustomRoleInput struct {
+	ProjectID string `json:"projectID"`
+	RoleID    string `json:"roleID"`
+}
+
+// MemberRoleInput contains the fields to change the role of a member, the role is either
+// Editor, Viewer or the ID of a custom role of the project
+type MemberRoleInput struct {
+	ProjectID string     `json:"projectID"`
+	UserID    string     `json:"userID"`
+	Role      MemberRole `json:"role"`
+}
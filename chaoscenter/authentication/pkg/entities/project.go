@@ -104,7 +104,8 @@ func (member *Member) GetMemberOutput() *Member {
 	}
 }
 
-// MemberRole defines the project role a member has in the project
+// MemberRole defines the project role a member has in the project, which is either one of
+// the built-in roles or the ID of a custom role of the project
 type MemberRole string
 
 const (
@@ -113,6 +114,11 @@ const (
 	RoleViewer MemberRole = "Viewer"
 )
 
+// IsBuiltIn returns true for the Owner, Editor and Viewer roles
+func (role MemberRole) IsBuiltIn() bool {
+	return role == RoleOwner || role == RoleEditor || role == RoleViewer
+}
+
 // Invitation defines the type of the invitation that is sent by the Owner of the project to other users
 type Invitation string
 
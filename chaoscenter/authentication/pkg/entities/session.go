@@ -12,6 +12,14 @@ type ApiTokenInput struct {
 	UserID              string `json:"user_id"`
 	Name                string `json:"name"`
 	DaysUntilExpiration int    `json:"days_until_expiration"`
+	// ProjectIDs restricts the token to the given projects, the token is valid in every project of the user if empty
+	ProjectIDs []string `json:"project_ids"`
+	// Role caps the rights of the token in a project, a Viewer token is read-only even if the user is an Owner
+	Role *MemberRole `json:"role"`
+	// Permissions restricts the token to the given operations or permissions of the catalogue, for example experiment.run for a run-only token
+	Permissions []string `json:"permissions"`
+	// AllowedIPs restricts the addresses the token can be used from, both IPs and CIDRs are accepted
+	AllowedIPs []string `json:"allowed_ips"`
 }
 
 // DeleteApiTokenInput struct for storing DeleteApiTokenInput
@@ -21,9 +29,15 @@ type DeleteApiTokenInput struct {
 
 // ApiToken struct for storing API tokens
 type ApiToken struct {
-	UserID    string `bson:"user_id" json:"user_id"`
-	Name      string `bson:"name" json:"name"`
-	Token     string `bson:"token" json:"token"`
-	ExpiresAt int64  `bson:"expires_at" json:"expires_at"`
-	CreatedAt int64  `bson:"created_at" json:"created_at"`
+	UserID      string      `bson:"user_id" json:"user_id"`
+	Name        string      `bson:"name" json:"name"`
+	Token       string      `bson:"token" json:"token"`
+	ExpiresAt   int64       `bson:"expires_at" json:"expires_at"`
+	CreatedAt   int64       `bson:"created_at" json:"created_at"`
+	ProjectIDs  []string    `bson:"project_ids,omitempty" json:"project_ids,omitempty"`
+	Role        *MemberRole `bson:"role,omitempty" json:"role,omitempty"`
+	Permissions []string    `bson:"permissions,omitempty" json:"permissions,omitempty"`
+	AllowedIPs  []string    `bson:"allowed_ips,omitempty" json:"allowed_ips,omitempty"`
+	LastUsedAt  int64       `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+	LastUsedIP  string      `bson:"last_used_ip,omitempty" json:"last_used_ip,omitempty"`
 }
@@ -0,0 +1,105 @@
+package project
+
+import (
+	"context"
+	"errors"
+
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/entities"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CustomRoleRepository holds the mongo database implementation of the custom roles
+type CustomRoleRepository interface {
+	CreateCustomRole(role *entities.CustomRole) error
+	GetCustomRole(projectID string, roleID string) (*entities.CustomRole, error)
+	GetCustomRoles(projectID string) ([]*entities.CustomRole, error)
+	UpdateCustomRole(role *entities.CustomRole) error
+	DeleteCustomRole(projectID string, roleID string) error
+}
+
+// CreateCustomRole creates a new custom role in a project
+func (r repository) CreateCustomRole(role *entities.CustomRole) error {
+	_, err := r.Collection.InsertOne(context.TODO(), role)
+	return err
+}
+
+// GetCustomRole returns a custom role of a project
+func (r repository) GetCustomRole(projectID string, roleID string) (*entities.CustomRole, error) {
+	var role entities.CustomRole
+	err := r.Collection.FindOne(context.TODO(), bson.D{
+		{"_id", roleID},
+		{"project_id", projectID},
+	}).Decode(&role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// GetCustomRoles returns all the custom roles of a project
+func (r repository) GetCustomRoles(projectID string) ([]*entities.CustomRole, error) {
+	results, err := r.Collection.Find(context.TODO(), bson.D{{"project_id", projectID}})
+	if err != nil {
+		return nil, err
+	}
+
+	roles := []*entities.CustomRole{}
+	err = results.All(context.TODO(), &roles)
+	if err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// UpdateCustomRole updates the name, description and permissions of a custom role
+func (r repository) UpdateCustomRole(role *entities.CustomRole) error {
+	query := bson.D{
+		{"_id", role.ID},
+		{"project_id", role.ProjectID},
+	}
+	update := bson.D{
+		{"$set", bson.D{
+			{"name", role.Name},
+			{"description", role.Description},
+			{"permissions", role.Permissions},
+			{"updated_at", role.UpdatedAt},
+		}},
+	}
+
+	result, err := r.Collection.UpdateOne(context.TODO(), query, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// DeleteCustomRole deletes a custom role of a project
+func (r repository) DeleteCustomRole(projectID string, roleID string) error {
+	result, err := r.Collection.DeleteOne(context.TODO(), bson.D{
+		{"_id", roleID},
+		{"project_id", projectID},
+	})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("could not find matching custom role in database")
+	}
+
+	return nil
+}
+
+// NewCustomRoleRepo creates a new instance of the custom role repository
+func NewCustomRoleRepo(collection *mongo.Collection) CustomRoleRepository {
+	return &repository{
+		Collection: collection,
+	}
+}
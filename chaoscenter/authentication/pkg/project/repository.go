@@ -25,6 +25,7 @@ type Repository interface {
 	RemoveInvitation(projectID string, userID string, invitation entities.Invitation) error
 	UpdateInvite(projectID string, userID string, invitation entities.Invitation, role *entities.MemberRole) error
 	UpdateProjectName(projectID string, projectName string) error
+	UpdateMemberRole(projectID string, userID string, role entities.MemberRole) error
 	GetAggregateProjects(pipeline mongo.Pipeline, opts *options.AggregateOptions) (*mongo.Cursor, error)
 	UpdateProjectState(ctx context.Context, userID string, deactivateTime int64, isDeactivate bool) error
 	GetOwnerProjects(ctx context.Context, userID string) ([]*entities.Project, error)
@@ -277,6 +278,33 @@ func (r repository) UpdateProjectName(projectID string, projectName string) erro
 	return nil
 }
 
+// UpdateMemberRole changes the role of an accepted member of the project
+func (r repository) UpdateMemberRole(projectID string, userID string, role entities.MemberRole) error {
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{
+			bson.D{
+				{"elem.user_id", userID},
+				{"elem.invitation", entities.AcceptedInvitation},
+			},
+		},
+	})
+	query := bson.D{{"_id", projectID}}
+	update := bson.D{
+		{"$set", bson.D{
+			{"members.$[elem].role", role},
+		}}}
+
+	result, err := r.Collection.UpdateOne(context.TODO(), query, update, opts)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("could not find matching projectID in database")
+	}
+
+	return nil
+}
+
 // GetAggregateProjects takes a mongo pipeline to retrieve the project details from the database
 func (r repository) GetAggregateProjects(pipeline mongo.Pipeline, opts *options.AggregateOptions) (*mongo.Cursor, error) {
 	results, err := r.Collection.Aggregate(context.TODO(), pipeline, opts)
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/audit"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/misc"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/project"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/session"
@@ -14,6 +15,8 @@ type ApplicationService interface {
 	transactionService
 	miscService
 	sessionService
+	customRoleService
+	auditService
 }
 
 type applicationService struct {
@@ -22,16 +25,20 @@ type applicationService struct {
 	miscRepository         misc.Repository
 	revokedTokenRepository session.RevokedTokenRepository
 	apiTokenRepository     session.ApiTokenRepository
+	customRoleRepository   project.CustomRoleRepository
+	auditRepository        audit.Repository
 	db                     *mongo.Database
 }
 
 // NewService creates a new instance of this service
-func NewService(userRepo user.Repository, projectRepo project.Repository, miscRepo misc.Repository, revokedTokenRepo session.RevokedTokenRepository, apiTokenRepo session.ApiTokenRepository, db *mongo.Database) ApplicationService {
+func NewService(userRepo user.Repository, projectRepo project.Repository, miscRepo misc.Repository, revokedTokenRepo session.RevokedTokenRepository, apiTokenRepo session.ApiTokenRepository, customRoleRepo project.CustomRoleRepository, auditRepo audit.Repository, db *mongo.Database) ApplicationService {
 	return &applicationService{
 		userRepository:         userRepo,
 		projectRepository:      projectRepo,
 		revokedTokenRepository: revokedTokenRepo,
 		apiTokenRepository:     apiTokenRepo,
+		customRoleRepository:   customRoleRepo,
+		auditRepository:        auditRepo,
 		db:                     db,
 		miscRepository:         miscRepo,
 	}
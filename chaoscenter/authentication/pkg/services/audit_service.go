@@ -0,0 +1,14 @@
+package services
+
+import (
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/entities"
+)
+
+type auditService interface {
+	RecordAuditLog(log *entities.AuditLog) error
+}
+
+// RecordAuditLog appends an entry to the audit log
+func (a applicationService) RecordAuditLog(log *entities.AuditLog) error {
+	return a.auditRepository.CreateAuditLog(log)
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/entities"
+)
+
+type customRoleService interface {
+	CreateCustomRole(role *entities.CustomRole) error
+	GetCustomRole(projectID string, roleID string) (*entities.CustomRole, error)
+	GetCustomRoles(projectID string) ([]*entities.CustomRole, error)
+	UpdateCustomRole(role *entities.CustomRole) error
+	DeleteCustomRole(projectID string, roleID string) error
+}
+
+// CreateCustomRole creates a new custom role in a project
+func (a applicationService) CreateCustomRole(role *entities.CustomRole) error {
+	return a.customRoleRepository.CreateCustomRole(role)
+}
+
+// GetCustomRole returns a custom role of a project
+func (a applicationService) GetCustomRole(projectID string, roleID string) (*entities.CustomRole, error) {
+	return a.customRoleRepository.GetCustomRole(projectID, roleID)
+}
+
+// GetCustomRoles returns all the custom roles of a project
+func (a applicationService) GetCustomRoles(projectID string) ([]*entities.CustomRole, error) {
+	return a.customRoleRepository.GetCustomRoles(projectID)
+}
+
+// UpdateCustomRole updates a custom role of a project
+func (a applicationService) UpdateCustomRole(role *entities.CustomRole) error {
+	return a.customRoleRepository.UpdateCustomRole(role)
+}
+
+// DeleteCustomRole deletes a custom role of a project
+func (a applicationService) DeleteCustomRole(projectID string, roleID string) error {
+	return a.customRoleRepository.DeleteCustomRole(projectID, roleID)
+}
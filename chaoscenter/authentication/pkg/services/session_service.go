@@ -17,6 +17,8 @@ type sessionService interface {
 	GetSignedJWT(user *entities.User) (string, error)
 	CreateApiToken(user *entities.User, request entities.ApiTokenInput) (string, error)
 	GetApiTokensByUserID(userID string) ([]entities.ApiToken, error)
+	GetApiTokenScope(token *jwt.Token) (*entities.ApiToken, error)
+	UpdateApiTokenLastUsed(token string, ip string) error
 	DeleteApiToken(token string) error
 }
 
@@ -89,6 +91,7 @@ func (a applicationService) CreateApiToken(user *entities.User, request entities
 	claims["role"] = user.Role
 	claims["username"] = user.Username
 	claims["exp"] = expiresAt
+	claims[utils.TokenTypeClaim] = utils.ApiTokenType
 
 	tokenString, err := token.SignedString([]byte(utils.JwtSecret))
 	if err != nil {
@@ -97,11 +100,15 @@ func (a applicationService) CreateApiToken(user *entities.User, request entities
 	}
 
 	apiToken := &entities.ApiToken{
-		UserID:    user.ID,
-		Name:      request.Name,
-		Token:     tokenString,
-		ExpiresAt: expiresAt,
-		CreatedAt: time.Now().UnixMilli(),
+		UserID:      user.ID,
+		Name:        request.Name,
+		Token:       tokenString,
+		ExpiresAt:   expiresAt,
+		CreatedAt:   time.Now().UnixMilli(),
+		ProjectIDs:  request.ProjectIDs,
+		Role:        request.Role,
+		Permissions: request.Permissions,
+		AllowedIPs:  request.AllowedIPs,
 	}
 
 	if err = a.apiTokenRepository.CreateApiToken(apiToken); err != nil {
@@ -116,6 +123,21 @@ func (a applicationService) GetApiTokensByUserID(userID string) ([]entities.ApiT
 	return a.apiTokenRepository.GetApiTokensByUserID(userID)
 }
 
+// GetApiTokenScope returns the API Token a validated JWT Token was issued as, or nil for the session tokens.
+// The API Tokens issued before the scopes were introduced carry no scope and are treated as session tokens
+func (a applicationService) GetApiTokenScope(token *jwt.Token) (*entities.ApiToken, error) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims[utils.TokenTypeClaim] != utils.ApiTokenType {
+		return nil, nil
+	}
+	return a.apiTokenRepository.GetApiToken(token.Raw)
+}
+
+// UpdateApiTokenLastUsed records the use of the given API Token
+func (a applicationService) UpdateApiTokenLastUsed(token string, ip string) error {
+	return a.apiTokenRepository.UpdateApiTokenLastUsed(token, time.Now().UnixMilli(), ip)
+}
+
 // DeleteApiToken deletes the given API Token
 func (a applicationService) DeleteApiToken(token string) error {
 	return a.apiTokenRepository.DeleteApiToken(token)
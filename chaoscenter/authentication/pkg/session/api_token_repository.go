@@ -12,6 +12,8 @@ import (
 type ApiTokenRepository interface {
 	CreateApiToken(apiToken *entities.ApiToken) error
 	GetApiTokensByUserID(userID string) ([]entities.ApiToken, error)
+	GetApiToken(token string) (*entities.ApiToken, error)
+	UpdateApiTokenLastUsed(token string, lastUsedAt int64, lastUsedIP string) error
 	DeleteApiToken(token string) error
 }
 
@@ -37,6 +39,35 @@ func (r repository) GetApiTokensByUserID(userID string) ([]entities.ApiToken, er
 	return apiTokens, nil
 }
 
+// GetApiToken returns the API token with the given token string
+func (r repository) GetApiToken(token string) (*entities.ApiToken, error) {
+	var apiToken entities.ApiToken
+	query := bson.D{
+		{Key: "token", Value: token},
+	}
+	err := r.Collection.FindOne(context.TODO(), query).Decode(&apiToken)
+	if err != nil {
+		return nil, err
+	}
+	return &apiToken, nil
+}
+
+// UpdateApiTokenLastUsed records the time and the address the given API token was last used from
+func (r repository) UpdateApiTokenLastUsed(token string, lastUsedAt int64, lastUsedIP string) error {
+	query := bson.D{
+		{Key: "token", Value: token},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "last_used_at", Value: lastUsedAt},
+			{Key: "last_used_ip", Value: lastUsedIP},
+		}},
+	}
+	_, err := r.Collection.UpdateOne(context.TODO(), query, update)
+
+	return err
+}
+
 // DeleteApiToken deletes the given API token
 func (r repository) DeleteApiToken(token string) error {
 	query := bson.D{
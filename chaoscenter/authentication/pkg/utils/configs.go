@@ -3,6 +3,7 @@ package utils
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 var (
@@ -21,6 +22,7 @@ var (
 	DexClientID                  = os.Getenv("DEX_OAUTH_CLIENT_ID")
 	DexClientSecret              = os.Getenv("DEX_OAUTH_CLIENT_SECRET")
 	DexOIDCIssuer                = os.Getenv("OIDC_ISSUER")
+	TrustedProxies               = getEnvAsList("TRUSTED_PROXIES")
 	DBName                       = "auth"
 	Port                         = ":3000"
 	GrpcPort                     = ":3030"
@@ -28,8 +30,13 @@ var (
 	ProjectCollection            = "project"
 	RevokedTokenCollection       = "revoked-token"
 	ApiTokenCollection           = "api-token"
+	CustomRoleCollection         = "custom-role"
+	AuditDBName                  = "litmus"
+	AuditLogCollection           = "auditLogs"
 	UsernameField                = "username"
 	ExpiresAtField               = "expires_at"
+	TokenTypeClaim               = "token_type"
+	ApiTokenType                 = "api_token"
 	PasswordEncryptionCost       = 15
 	DefaultLitmusGqlGrpcEndpoint = "localhost"
 	DefaultLitmusGqlGrpcPort     = ":8000"
@@ -50,3 +57,14 @@ func getEnvAsBool(name string, defaultVal bool) bool {
 	}
 	return defaultVal
 }
+
+// getEnvAsList returns the comma separated values of an environment variable, or nil if it's empty
+func getEnvAsList(name string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
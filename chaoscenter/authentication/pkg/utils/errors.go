@@ -21,6 +21,8 @@ var (
 	ErrEmptyProjectName              AppError = errors.New("invalid project name")
 	ErrInvalidRole                   AppError = errors.New("invalid role")
 	ErrInvalidEmail                  AppError = errors.New("invalid email")
+	ErrCustomRoleNotFound            AppError = errors.New("custom role does not exist")
+	ErrCustomRoleInUse               AppError = errors.New("custom role is in use")
 )
 
 // ErrorStatusCodes holds the http status codes for every AppError
@@ -39,6 +41,8 @@ var ErrorStatusCodes = map[AppError]int{
 	ErrEmptyProjectName:              400,
 	ErrInvalidRole:                   400,
 	ErrInvalidEmail:                  400,
+	ErrCustomRoleNotFound:            400,
+	ErrCustomRoleInUse:               400,
 }
 
 // ErrorDescriptions holds detailed error description for every AppError
@@ -53,4 +57,6 @@ var ErrorDescriptions = map[AppError]string{
 	ErrInvalidRole:                   "Role is invalid",
 	ErrProjectNotFound:               "This project does not exist",
 	ErrInvalidEmail:                  "Email address is invalid",
+	ErrCustomRoleNotFound:            "This custom role does not exist in the project",
+	ErrCustomRoleInUse:               "This custom role is assigned to members of the project",
 }
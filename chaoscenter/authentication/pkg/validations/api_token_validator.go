@@ -0,0 +1,91 @@
+package validations
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/entities"
+)
+
+// ValidateApiTokenInput checks the scope requested for a new API token
+func ValidateApiTokenInput(input entities.ApiTokenInput) error {
+	if input.Role != nil {
+		switch *input.Role {
+		case entities.RoleOwner, entities.RoleEditor, entities.RoleViewer:
+		default:
+			return fmt.Errorf("invalid role %s", *input.Role)
+		}
+	}
+	for _, allowedIP := range input.AllowedIPs {
+		if net.ParseIP(allowedIP) != nil {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(allowedIP); err != nil {
+			return fmt.Errorf("invalid IP or CIDR %s", allowedIP)
+		}
+	}
+	return nil
+}
+
+// IsIPAllowed checks the address a request originated from against the IP allow-list of an API token,
+// an empty allow-list allows every address
+func IsIPAllowed(allowedIPs []string, ip string) bool {
+	if len(allowedIPs) == 0 {
+		return true
+	}
+
+	// the address may carry a port, like the remote address of a request
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	clientIP := net.ParseIP(strings.TrimSpace(ip))
+	if clientIP == nil {
+		return false
+	}
+
+	for _, allowedIP := range allowedIPs {
+		if _, network, err := net.ParseCIDR(allowedIP); err == nil {
+			if network.Contains(clientIP) {
+				return true
+			}
+		} else if parsedIP := net.ParseIP(allowedIP); parsedIP != nil && parsedIP.Equal(clientIP) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsProjectInApiTokenScope checks that an API token is valid for a project, a token without projects is
+// valid for every project of its user
+func IsProjectInApiTokenScope(apiToken *entities.ApiToken, projectID string) bool {
+	return apiToken == nil || len(apiToken.ProjectIDs) == 0 || contains(apiToken.ProjectIDs, projectID)
+}
+
+// validateApiTokenScope checks that an operation in a project is within the scope of an API token, the
+// permissions of a token are either operation names or permissions of the catalogue
+func validateApiTokenScope(apiToken *entities.ApiToken, projectID string, requiredRoles []string, operation string) error {
+	if !IsProjectInApiTokenScope(apiToken, projectID) {
+		return errors.New("auth gRPC - API token is not valid for the project")
+	}
+	if apiToken.Role != nil && !contains(requiredRoles, string(*apiToken.Role)) {
+		return errors.New("auth gRPC - API token role is not allowed to perform the operation")
+	}
+	if len(apiToken.Permissions) > 0 && !contains(apiToken.Permissions, operation) {
+		permission, ok := OperationPermission(operation)
+		if !ok || !contains(apiToken.Permissions, string(permission)) {
+			return errors.New("auth gRPC - API token is not permitted to perform the operation")
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+package validations
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/entities"
+)
+
+// PermissionCatalogue lists the permissions custom roles are made of, with the operations each of them
+// grants. The operations are the ones of the authentication REST APIs and the RoleQuery values of the
+// GraphQL server, so the new GraphQL operations have to be added here to be usable with custom roles
+var PermissionCatalogue = []entities.PermissionDetails{
+	{
+		Name:        entities.PermissionProjectView,
+		Description: "View the project and its custom roles",
+		Operations:  []string{"getProject", "listCustomRoles"},
+	},
+	{
+		Name:        entities.PermissionProjectManage,
+		Description: "Rename the project",
+		Operations:  []string{"updateProjectName"},
+	},
+	{
+		Name:        entities.PermissionMemberManage,
+		Description: "Invite and remove members and change their roles",
+		Operations:  []string{"sendInvitation", "removeInvitation", "updateMemberRole"},
+	},
+	{
+		Name:        entities.PermissionRoleManage,
+		Description: "Create, update and delete custom roles",
+		Operations:  []string{"createCustomRole", "updateCustomRole", "deleteCustomRole"},
+	},
+	{
+		Name:        entities.PermissionExperimentView,
+		Description: "View experiments and their runs",
+		Operations:  []string{"ListExperiment", "ListWorkflowRuns", "GetWorkflowRun"},
+	},
+	{
+		Name:        entities.PermissionExperimentEdit,
+		Description: "Create, update and delete experiments",
+		Operations:  []string{"CreateChaosExperiment", "UpdateChaosExperiment", "DeleteChaosExperiment"},
+	},
+	{
+		Name:        entities.PermissionExperimentRun,
+		Description: "Run and stop experiments",
+		Operations:  []string{"ReRunChaosExperiment", "StopChaosExperiment"},
+	},
+	{
+		Name:        entities.PermissionInfraView,
+		Description: "View chaos infrastructures",
+		Operations:  []string{"ListInfrastructures", "GetInfrastructure", "ListInfraActions", "ListInfraUpgrades"},
+	},
+	{
+		Name:        entities.PermissionInfraRegister,
+		Description: "Register and upgrade chaos infrastructures and get their manifests",
+		Operations:  []string{"userInfrastructureReg", "GetManifest", "GetInfraDetails", "UpgradeInfrastructure"},
+	},
+	{
+		Name:        entities.PermissionInfraDelete,
+		Description: "Delete chaos infrastructures",
+		Operations:  []string{"DeleteInfrastructures"},
+	},
+	{
+		Name:        entities.PermissionEnvironmentView,
+		Description: "View environments",
+		Operations:  []string{"GetEnvironment", "ListEnvironments"},
+	},
+	{
+		Name:        entities.PermissionEnvironmentEdit,
+		Description: "Create, update and delete environments",
+		Operations:  []string{"CreateEnvironment", "UpdateEnvironment", "DeleteEnvironment"},
+	},
+	{
+		Name:        entities.PermissionHubView,
+		Description: "View ChaosHubs and their charts",
+		Operations:  []string{"ListCharts"},
+	},
+	{
+		Name:        entities.PermissionHubManage,
+		Description: "Add, update and delete ChaosHubs",
+		Operations:  []string{"AddChaosHub", "UpdateChaosHub", "DeleteChaosHub", "SaveChaosHub"},
+	},
+	{
+		Name:        entities.PermissionProbeView,
+		Description: "View resilience probes",
+		Operations:  []string{"GetProbe", "ListProbes"},
+	},
+	{
+		Name:        entities.PermissionProbeEdit,
+		Description: "Add, update and delete resilience probes",
+		Operations:  []string{"AddProbe", "UpdateProbe", "DeleteProbe"},
+	},
+	{
+		Name:        entities.PermissionGitOpsManage,
+		Description: "View and configure GitOps",
+		Operations:  []string{"EnableGitOps", "DisableGitOps", "UpdateGitOps", "GetGitOpsDetails", "ListGitOpsConflicts", "ResolveGitOpsConflict"},
+	},
+	{
+		Name:        entities.PermissionRegistryManage,
+		Description: "View and configure the image registry",
+		Operations:  []string{"CreateImageRegistry", "UpdateImageRegistry", "DeleteImageRegistry", "ListImageRegistry", "GetImageRegistry"},
+	},
+	{
+		Name:        entities.PermissionNotificationView,
+		Description: "View notification channels",
+		Operations:  []string{"GetNotificationChannel", "ListNotificationChannels"},
+	},
+	{
+		Name:        entities.PermissionNotificationManage,
+		Description: "Create, update and delete notification channels",
+		Operations:  []string{"CreateNotificationChannel", "UpdateNotificationChannel", "DeleteNotificationChannel"},
+	},
+	{
+		Name:        entities.PermissionAuditView,
+		Description: "View and export the audit log of the project",
+		Operations:  []string{"ListAuditLogs"},
+	},
+}
+
+// memberOperations are the operations every member can perform on their own membership, whatever their role is
+var memberOperations = map[string]bool{
+	"leaveProject": true,
+}
+
+var operationPermissions = func() map[string]entities.Permission {
+	permissions := make(map[string]entities.Permission)
+	for _, permission := range PermissionCatalogue {
+		for _, operation := range permission.Operations {
+			permissions[operation] = permission.Name
+		}
+	}
+	return permissions
+}()
+
+// OperationPermission returns the permission of the catalogue which grants an operation
+func OperationPermission(operation string) (entities.Permission, bool) {
+	permission, ok := operationPermissions[operation]
+	return permission, ok
+}
+
+// IsValidPermission checks that a permission is part of the catalogue
+func IsValidPermission(permission entities.Permission) bool {
+	for _, p := range PermissionCatalogue {
+		if p.Name == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateCustomRoleInput checks the name and the permissions of a custom role
+func ValidateCustomRoleInput(input entities.CustomRoleInput) error {
+	if strings.TrimSpace(input.Name) == "" {
+		return errors.New("custom role name is required")
+	}
+	if entities.MemberRole(input.Name).IsBuiltIn() {
+		return fmt.Errorf("%s is a built-in role", input.Name)
+	}
+	if len(input.Permissions) == 0 {
+		return errors.New("custom role needs at least one permission")
+	}
+	for _, permission := range input.Permissions {
+		if !IsValidPermission(permission) {
+			return fmt.Errorf("unknown permission %s", permission)
+		}
+	}
+	return nil
+}
+
+// customRoleAllows checks that a custom role grants an operation
+func customRoleAllows(role *entities.CustomRole, operation string) bool {
+	if memberOperations[operation] {
+		return true
+	}
+	permission, ok := OperationPermission(operation)
+	if !ok {
+		return false
+	}
+	for _, p := range role.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
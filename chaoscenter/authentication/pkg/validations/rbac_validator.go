@@ -3,15 +3,19 @@ package validations
 import (
 	"errors"
 
+	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/entities"
 	"github.com/litmuschaos/litmus/chaoscenter/authentication/pkg/services"
 
 	log "github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
+// RbacValidator checks that the user has one of the required roles in the project, or a custom role granting
+// the permission of the operation. Requests made with an API token are also checked against the projects,
+// role and permissions the token is restricted to
 func RbacValidator(uid string, projectID string,
-	requiredRoles []string, invitation string,
-	service services.ApplicationService) error {
+	requiredRoles []string, invitation string, operation string,
+	apiToken *entities.ApiToken, service services.ApplicationService) error {
 
 	user, err := service.GetUser(uid)
 	if err != nil {
@@ -23,6 +27,13 @@ func RbacValidator(uid string, projectID string,
 		return errors.New("auth gRPC - Deactivated User")
 	}
 
+	if apiToken != nil {
+		if err := validateApiTokenScope(apiToken, projectID, requiredRoles, operation); err != nil {
+			log.Errorf("authgRPC Error: %s", err)
+			return err
+		}
+	}
+
 	// Check for project permission validity
 	filter := bson.D{
 		{"_id", projectID},
@@ -43,7 +54,80 @@ func RbacValidator(uid string, projectID string,
 		log.Errorf("authgRPC Error: %s", err)
 		return err
 	}
-	if project == nil {
+	if len(project) == 0 {
+		return customRoleValidator(uid, projectID, invitation, operation, service)
+	}
+
+	return nil
+}
+
+// AdminValidator checks that the user is an admin, for the operations which aren't scoped to a project like
+// the export of the audit logs of every project. Requests made with an API token are also checked against
+// the scope of the token, so a token restricted to some projects is never valid for them
+func AdminValidator(uid string, requiredRoles []string, operation string,
+	apiToken *entities.ApiToken, service services.ApplicationService) error {
+
+	user, err := service.GetUser(uid)
+	if err != nil {
+		log.Errorf("authgRPC Error: querying for user -  %s", err)
+		return err
+	}
+	if user.DeactivatedAt != nil {
+		log.Error("authgRPC Error: Deactivated User")
+		return errors.New("auth gRPC - Deactivated User")
+	}
+	if user.Role != entities.RoleAdmin {
+		return errors.New("auth gRPC - the operation is only permitted to the admins")
+	}
+
+	if apiToken != nil {
+		if err := validateApiTokenScope(apiToken, "", requiredRoles, operation); err != nil {
+			log.Errorf("authgRPC Error: %s", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// customRoleValidator checks that the user is a member of the project with a custom role granting the operation
+func customRoleValidator(uid string, projectID string, invitation string, operation string,
+	service services.ApplicationService) error {
+	filter := bson.D{
+		{"_id", projectID},
+		{"members", bson.D{
+			{"$elemMatch", bson.D{
+				{"user_id", uid},
+				{"invitation", invitation},
+			}},
+		}},
+	}
+	projects, err := service.GetProjects(filter)
+	if err != nil {
+		log.Errorf("authgRPC Error: %s", err)
+		return err
+	}
+	if len(projects) == 0 {
+		return errors.New("auth gRPC - Unauthorized")
+	}
+
+	var role entities.MemberRole
+	for _, member := range projects[0].Members {
+		if member.UserID == uid {
+			role = member.Role
+			break
+		}
+	}
+	// the built-in roles were already checked against the required roles
+	if role == "" || role.IsBuiltIn() {
+		return errors.New("auth gRPC - Unauthorized")
+	}
+
+	customRole, err := service.GetCustomRole(projectID, string(role))
+	if err != nil {
+		log.Errorf("authgRPC Error: querying for custom role - %s", err)
+		return errors.New("auth gRPC - Unauthorized")
+	}
+	if !customRoleAllows(customRole, operation) {
 		return errors.New("auth gRPC - Unauthorized")
 	}
 
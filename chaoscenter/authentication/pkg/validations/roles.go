@@ -11,4 +11,9 @@ var MutationRbacRules = map[string][]string{
 	"leaveProject":      {string(entities.RoleViewer), string(entities.RoleEditor)},
 	"updateProjectName": {string(entities.RoleOwner)},
 	"getProject":        {string(entities.RoleOwner), string(entities.RoleViewer), string(entities.RoleEditor)},
+	"updateMemberRole":  {string(entities.RoleOwner)},
+	"createCustomRole":  {string(entities.RoleOwner)},
+	"updateCustomRole":  {string(entities.RoleOwner)},
+	"deleteCustomRole":  {string(entities.RoleOwner)},
+	"listCustomRoles":   {string(entities.RoleOwner), string(entities.RoleViewer), string(entities.RoleEditor)},
 }
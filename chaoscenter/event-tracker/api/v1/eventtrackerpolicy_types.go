@@ -30,24 +30,77 @@ type EventTrackerPolicySpec struct {
 
 	ConditionType string      `json:"condition_type,omitempty"`
 	Conditions    []Condition `json:"conditions,omitempty"`
+	// Resources selects the resource kinds whose changes are audited by the policy,
+	// if it is empty the policy applies to Deployments, StatefulSets and DaemonSets
+	Resources []ResourceSelector `json:"resources,omitempty"`
+	// Cooldown is the minimum duration between two triggers of the experiment
+	// for the same resource, e.g. 10m
+	// +kubebuilder:validation:Pattern=`^(0|([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+)$`
+	Cooldown string `json:"cooldown,omitempty"`
+	// Debounce is the duration for which a resource must not change anymore
+	// before the experiment is triggered, e.g. 30s
+	// +kubebuilder:validation:Pattern=`^(0|([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+)$`
+	Debounce string `json:"debounce,omitempty"`
+	// MaxTriggersPerHour limits the number of triggers of the experiment per resource
+	// +kubebuilder:validation:Minimum=0
+	MaxTriggersPerHour int `json:"max_triggers_per_hour,omitempty"`
+	// DryRun records the matches in the statuses without triggering the experiment
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// ResourceSelector identifies a resource kind by its group, version and resource
+type ResourceSelector struct {
+	Group string `json:"group,omitempty"`
+	// +kubebuilder:validation:Required
+	Version string `json:"version"`
+	// Resource is the plural name of the resource, e.g. deployments
+	// +kubebuilder:validation:Required
+	Resource string `json:"resource"`
 }
 
 type Condition struct {
-	Key      string  `json:"key,omitempty"`
-	Value    *string `json:"value,omitempty"`
-	Operator string  `json:"operator,omitempty"`
+	Key   string  `json:"key,omitempty"`
+	Value *string `json:"value,omitempty"`
+	// Values is the list of values used by the In and NotIn operators
+	Values []string `json:"values,omitempty"`
+	// +kubebuilder:validation:Enum=EqualTo;NotEqualTo;LessThan;GreaterThan;GreaterThanEqualTo;LessThanEqualTo;Change;Matches;In;NotIn;Exists
+	Operator string `json:"operator,omitempty"`
+	// Type is the type used to compare the values, if it is not set numbers
+	// are compared numerically and everything else as strings
+	// +kubebuilder:validation:Enum=String;Int;Float;Duration;SemVer
+	Type string `json:"type,omitempty"`
 }
 
 // EventTrackerPolicyStatus defines the observed state of EventTrackerPolicy
 type EventTrackerPolicyStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
-	TimeStamp    string `json:"time_stamp,omitempty"`
-	Resource     string `json:"resource,omitempty"`
-	ResourceName string `json:"resource_name,omitempty"`
-	Result       string `json:"result,omitempty"`
-	ExperimentID string `json:"experiment_id,omitempty"`
-	IsTriggered  string `json:"is_triggered,omitempty"`
+	TimeStamp         string `json:"time_stamp,omitempty"`
+	Resource          string `json:"resource,omitempty"`
+	ResourceName      string `json:"resource_name,omitempty"`
+	ResourceNamespace string `json:"resource_namespace,omitempty"`
+	Result            string `json:"result,omitempty"`
+	ExperimentID      string `json:"experiment_id,omitempty"`
+	IsTriggered       string `json:"is_triggered,omitempty"`
+	DryRun            bool   `json:"dry_run,omitempty"`
+}
+
+// PendingTrigger is a match of the conditions waiting for the debounce period of the
+// policy, it's recorded in the statuses at DueAt unless the resource changes again
+type PendingTrigger struct {
+	// DueAt is when the match is recorded, in RFC3339
+	DueAt                    string `json:"due_at,omitempty"`
+	EventTrackerPolicyStatus `json:",inline"`
+}
+
+// ResourceWatchError reports a resource kind selected by the policy which can't be watched
+type ResourceWatchError struct {
+	// Resource is the group, version and resource of the kind, e.g. apps/v1, Resource=deployments
+	Resource string `json:"resource,omitempty"`
+	// Reason is NotServed if the cluster doesn't serve the kind and Forbidden if
+	// the event tracker isn't allowed to list and watch it
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -60,6 +113,12 @@ type EventTrackerPolicy struct {
 
 	Spec     EventTrackerPolicySpec     `json:"spec,omitempty"`
 	Statuses []EventTrackerPolicyStatus `json:"statuses,omitempty"`
+	// PendingTriggers are the matches waiting for the debounce period of the policy,
+	// they are kept in the policy so that they are recorded after a restart
+	PendingTriggers []PendingTrigger `json:"pending_triggers,omitempty"`
+	// WatchErrors are the resource kinds selected by the policy which aren't watched,
+	// the changes of these kinds don't trigger the experiment
+	WatchErrors []ResourceWatchError `json:"watch_errors,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -16,6 +16,11 @@ func (in *Condition) DeepCopyInto(out *Condition) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
@@ -39,6 +44,16 @@ func (in *EventTrackerPolicy) DeepCopyInto(out *EventTrackerPolicy) {
 		*out = make([]EventTrackerPolicyStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.PendingTriggers != nil {
+		in, out := &in.PendingTriggers, &out.PendingTriggers
+		*out = make([]PendingTrigger, len(*in))
+		copy(*out, *in)
+	}
+	if in.WatchErrors != nil {
+		in, out := &in.WatchErrors, &out.WatchErrors
+		*out = make([]ResourceWatchError, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventTrackerPolicy.
@@ -101,6 +116,11 @@ func (in *EventTrackerPolicySpec) DeepCopyInto(out *EventTrackerPolicySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceSelector, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventTrackerPolicySpec.
@@ -127,3 +147,49 @@ func (in *EventTrackerPolicyStatus) DeepCopy() *EventTrackerPolicyStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingTrigger) DeepCopyInto(out *PendingTrigger) {
+	*out = *in
+	out.EventTrackerPolicyStatus = in.EventTrackerPolicyStatus
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingTrigger.
+func (in *PendingTrigger) DeepCopy() *PendingTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSelector) DeepCopyInto(out *ResourceSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSelector.
+func (in *ResourceSelector) DeepCopy() *ResourceSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceWatchError) DeepCopyInto(out *ResourceWatchError) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceWatchError.
+func (in *ResourceWatchError) DeepCopy() *ResourceWatchError {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceWatchError)
+	in.DeepCopyInto(out)
+	return out
+}
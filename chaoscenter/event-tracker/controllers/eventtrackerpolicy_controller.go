@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/litmuschaos/litmus/chaoscenter/event-tracker/pkg/utils"
 	"github.com/sirupsen/logrus"
@@ -38,8 +39,14 @@ import (
 type EventTrackerPolicyReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Watcher watches the resource kinds selected by the policies
+	Watcher *utils.ResourceWatcher
 }
 
+// watchRetryInterval is the interval at which the resource kinds of a policy which
+// can't be watched are retried
+const watchRetryInterval = time.Minute
+
 type apiResponse struct {
 	Data struct {
 		GitopsNotifer string `json:"gitopsNotifer"`
@@ -65,6 +72,15 @@ func (r *EventTrackerPolicyReconciler) Reconcile(ctx context.Context, req ctrl.R
 	var mutex = &sync.Mutex{}
 	mutex.Lock()
 
+	if r.Watcher != nil {
+		// the policies are listed in the infra namespace, like the ones the changes are audited against
+		var policies eventtrackerv1.EventTrackerPolicyList
+		if err := r.Client.List(context.Background(), &policies, client.InNamespace(utils.Config.InfraNamespace)); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.Watcher.Sync(policies.Items)
+	}
+
 	var etp eventtrackerv1.EventTrackerPolicy
 	err := r.Client.Get(context.Background(), req.NamespacedName, &etp)
 	if errors.IsNotFound(err) {
@@ -75,7 +91,8 @@ func (r *EventTrackerPolicyReconciler) Reconcile(ctx context.Context, req ctrl.R
 	}
 
 	for index, status := range etp.Statuses {
-		if status.Result == utils.ConditionPassed && strings.ToLower(status.IsTriggered) == "false" {
+		// matches recorded in dry-run mode never trigger the experiment
+		if status.Result == utils.ConditionPassed && strings.ToLower(status.IsTriggered) == "false" && !status.DryRun {
 			logrus.Print("ResourceName: " + status.ResourceName + ", ExperimentID: " + status.ExperimentID)
 			response, err := utils.SendRequest(status.ExperimentID)
 			if err != nil {
@@ -98,6 +115,15 @@ func (r *EventTrackerPolicyReconciler) Reconcile(ctx context.Context, req ctrl.R
 		}
 	}
 
+	var result ctrl.Result
+	if r.Watcher != nil {
+		// the kinds which can't be watched are retried until the RBAC or the CRD is fixed
+		etp.WatchErrors = r.Watcher.WatchErrors(etp)
+		if len(etp.WatchErrors) > 0 {
+			result.RequeueAfter = watchRetryInterval
+		}
+	}
+
 	err = r.Client.Update(context.Background(), &etp)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -105,7 +131,7 @@ func (r *EventTrackerPolicyReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	defer mutex.Unlock()
 
-	return ctrl.Result{}, nil
+	return result, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
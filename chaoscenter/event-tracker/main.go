@@ -24,13 +24,12 @@ import (
 	"net/http"
 	"os"
 	rt "runtime"
-	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/litmuschaos/litmus/chaoscenter/event-tracker/pkg/k8s"
 	"github.com/litmuschaos/litmus/chaoscenter/event-tracker/pkg/utils"
 	"github.com/sirupsen/logrus"
-	"k8s.io/client-go/informers"
+	"k8s.io/client-go/dynamic"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -38,6 +37,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -50,8 +50,9 @@ import (
 )
 
 var (
-	scheme   = runtime.NewScheme()
-	setupLog = ctrl.Log.WithName("setup")
+	scheme          = runtime.NewScheme()
+	setupLog        = ctrl.Log.WithName("setup")
+	resourceWatcher *utils.ResourceWatcher
 )
 
 func init() {
@@ -68,22 +69,35 @@ func init() {
 	utilruntime.Must(eventtrackerv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 
-	clientset, err := k8s.K8sClient()
+	restConfig, err := k8s.GetKubeConfig()
 	if err != nil {
-		logrus.Error(err)
+		logrus.Fatal(err)
 	}
 
-	var factory informers.SharedInformerFactory
-	if utils.Config.InfraScope == "cluster" {
-		factory = informers.NewSharedInformerFactory(clientset, 30*time.Second)
-	} else if utils.Config.InfraScope == "namespace" {
-		factory = informers.NewSharedInformerFactoryWithOptions(clientset, 30*time.Second, informers.WithNamespace(utils.Config.InfraNamespace))
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		logrus.Fatal(err)
 	}
 
-	go utils.RunDeploymentInformer(factory)
-	go utils.RunDSInformer(factory)
-	go utils.RunStsInformer(factory)
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		logrus.Fatal(err)
+	}
 
+	var namespace string
+	if utils.Config.InfraScope == "namespace" {
+		namespace = utils.Config.InfraNamespace
+	}
+
+	// start watching the default resources, the ones selected by the
+	// policies are added as the policies are reconciled
+	resourceWatcher = utils.NewResourceWatcher(dynamicClient, clientset, namespace)
+	resourceWatcher.Sync(nil)
+
+	// the matches waiting for their debounce period when the event tracker stopped are recorded once it's over
+	if err := utils.ResumePendingTriggers(dynamicClient); err != nil {
+		logrus.Errorf("failed to resume the pending triggers: %v", err)
+	}
 }
 
 func main() {
@@ -145,8 +159,9 @@ func main() {
 	}
 
 	if err = (&controllers.EventTrackerPolicyReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Watcher: resourceWatcher,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "EventTrackerPolicy")
 		os.Exit(1)
@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	litmuschaosv1 "github.com/litmuschaos/litmus/chaoscenter/event-tracker/api/v1"
+	"golang.org/x/mod/semver"
+)
+
+// Condition operators supported by the EventTrackerPolicy
+const (
+	OperatorEqualTo            = "EqualTo"
+	OperatorNotEqualTo         = "NotEqualTo"
+	OperatorLessThan           = "LessThan"
+	OperatorGreaterThan        = "GreaterThan"
+	OperatorGreaterThanEqualTo = "GreaterThanEqualTo"
+	OperatorLessThanEqualTo    = "LessThanEqualTo"
+	OperatorChange             = "Change"
+	OperatorMatches            = "Matches"
+	OperatorIn                 = "In"
+	OperatorNotIn              = "NotIn"
+	OperatorExists             = "Exists"
+)
+
+// Value types used to compare the condition values
+const (
+	TypeString   = "String"
+	TypeInt      = "Int"
+	TypeFloat    = "Float"
+	TypeDuration = "Duration"
+	TypeSemVer   = "SemVer"
+)
+
+// evaluateCondition checks if the value found at the condition key satisfies the condition
+func evaluateCondition(condition litmuschaosv1.Condition, result interface{}) (bool, error) {
+	switch condition.Operator {
+	case OperatorChange:
+		return true, nil
+	case OperatorExists:
+		return result != nil, nil
+	}
+
+	// a missing key never satisfies a value based condition
+	if result == nil {
+		return false, nil
+	}
+	actual := fmt.Sprintf("%v", result)
+
+	switch condition.Operator {
+	case OperatorIn, OperatorNotIn:
+		found := false
+		for _, value := range condition.Values {
+			cmp, err := compare(actual, value, condition.Type)
+			if err != nil {
+				return false, err
+			}
+			if cmp == 0 {
+				found = true
+				break
+			}
+		}
+		return found == (condition.Operator == OperatorIn), nil
+	}
+
+	if condition.Value == nil {
+		return false, fmt.Errorf("value is required for the %s operator", condition.Operator)
+	}
+	expected := *condition.Value
+
+	if condition.Operator == OperatorMatches {
+		re, err := regexp.Compile(expected)
+		if err != nil {
+			return false, fmt.Errorf("invalid regular expression %q: %v", expected, err)
+		}
+		return re.MatchString(actual), nil
+	}
+
+	cmp, err := compare(actual, expected, condition.Type)
+	if err != nil {
+		return false, err
+	}
+
+	switch condition.Operator {
+	case OperatorEqualTo:
+		return cmp == 0, nil
+	case OperatorNotEqualTo:
+		return cmp != 0, nil
+	case OperatorLessThan:
+		return cmp < 0, nil
+	case OperatorGreaterThan:
+		return cmp > 0, nil
+	case OperatorGreaterThanEqualTo:
+		return cmp >= 0, nil
+	case OperatorLessThanEqualTo:
+		return cmp <= 0, nil
+	}
+
+	return false, fmt.Errorf("unknown operator %q", condition.Operator)
+}
+
+// compare returns -1, 0 or 1 depending on whether a is less than, equal to or greater than b
+func compare(a, b, valueType string) (int, error) {
+	switch valueType {
+	case TypeInt:
+		x, err := parseInt(a)
+		if err != nil {
+			return 0, err
+		}
+		y, err := parseInt(b)
+		if err != nil {
+			return 0, err
+		}
+		return compareOrdered(x, y), nil
+	case TypeFloat:
+		x, err := strconv.ParseFloat(a, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid float %q: %v", a, err)
+		}
+		y, err := strconv.ParseFloat(b, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid float %q: %v", b, err)
+		}
+		return compareOrdered(x, y), nil
+	case TypeDuration:
+		x, err := time.ParseDuration(a)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %v", a, err)
+		}
+		y, err := time.ParseDuration(b)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %v", b, err)
+		}
+		return compareOrdered(x, y), nil
+	case TypeSemVer:
+		x, y := canonicalSemVer(a), canonicalSemVer(b)
+		if !semver.IsValid(x) {
+			return 0, fmt.Errorf("invalid semantic version %q", a)
+		}
+		if !semver.IsValid(y) {
+			return 0, fmt.Errorf("invalid semantic version %q", b)
+		}
+		return semver.Compare(x, y), nil
+	case TypeString:
+		return strings.Compare(a, b), nil
+	case "":
+		// compare numerically when both the values are numbers
+		x, errA := strconv.ParseFloat(a, 64)
+		y, errB := strconv.ParseFloat(b, 64)
+		if errA == nil && errB == nil {
+			return compareOrdered(x, y), nil
+		}
+		return strings.Compare(a, b), nil
+	}
+
+	return 0, fmt.Errorf("unknown value type %q", valueType)
+}
+
+// parseInt parses an integer, also accepting the float representation used
+// by the JSON decoder for large numbers (e.g. 1e+06)
+func parseInt(s string) (int64, error) {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != math.Trunc(f) {
+		return 0, fmt.Errorf("invalid integer %q", s)
+	}
+	return int64(f), nil
+}
+
+func compareOrdered[T int64 | float64 | time.Duration](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// canonicalSemVer adds the "v" prefix expected by the semver package, so that
+// image tags like 1.18.2 can be compared
+func canonicalSemVer(version string) string {
+	if strings.HasPrefix(version, "v") {
+		return version
+	}
+	return "v" + version
+}
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"testing"
+
+	litmuschaosv1 "github.com/litmuschaos/litmus/chaoscenter/event-tracker/api/v1"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	value := func(v string) *string { return &v }
+	tests := []struct {
+		name      string
+		condition litmuschaosv1.Condition
+		result    interface{}
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "numbers are compared numerically by default",
+			condition: litmuschaosv1.Condition{Operator: OperatorLessThan, Value: value("10")},
+			result:    float64(9),
+			want:      true,
+		},
+		{
+			name:      "strings are compared lexicographically",
+			condition: litmuschaosv1.Condition{Operator: OperatorLessThan, Value: value("10"), Type: TypeString},
+			result:    "9",
+			want:      false,
+		},
+		{
+			name:      "int comparison",
+			condition: litmuschaosv1.Condition{Operator: OperatorGreaterThanEqualTo, Value: value("3"), Type: TypeInt},
+			result:    float64(3),
+			want:      true,
+		},
+		{
+			name:      "invalid int",
+			condition: litmuschaosv1.Condition{Operator: OperatorEqualTo, Value: value("3"), Type: TypeInt},
+			result:    "three",
+			wantErr:   true,
+		},
+		{
+			name:      "duration comparison",
+			condition: litmuschaosv1.Condition{Operator: OperatorGreaterThan, Value: value("90s"), Type: TypeDuration},
+			result:    "2m",
+			want:      true,
+		},
+		{
+			name:      "semantic version comparison",
+			condition: litmuschaosv1.Condition{Operator: OperatorLessThan, Value: value("1.10.0"), Type: TypeSemVer},
+			result:    "1.9.2",
+			want:      true,
+		},
+		{
+			name:      "regular expression match",
+			condition: litmuschaosv1.Condition{Operator: OperatorMatches, Value: value(`^nginx:1\.2\d$`)},
+			result:    "nginx:1.25",
+			want:      true,
+		},
+		{
+			name:      "invalid regular expression",
+			condition: litmuschaosv1.Condition{Operator: OperatorMatches, Value: value(`(`)},
+			result:    "nginx",
+			wantErr:   true,
+		},
+		{
+			name:      "value in list",
+			condition: litmuschaosv1.Condition{Operator: OperatorIn, Values: []string{"3", "5"}},
+			result:    float64(5),
+			want:      true,
+		},
+		{
+			name:      "value not in list",
+			condition: litmuschaosv1.Condition{Operator: OperatorNotIn, Values: []string{"staging", "qa"}},
+			result:    "prod",
+			want:      true,
+		},
+		{
+			name:      "key exists",
+			condition: litmuschaosv1.Condition{Operator: OperatorExists},
+			result:    "value",
+			want:      true,
+		},
+		{
+			name:      "key does not exist",
+			condition: litmuschaosv1.Condition{Operator: OperatorExists},
+			result:    nil,
+			want:      false,
+		},
+		{
+			name:      "unknown operator",
+			condition: litmuschaosv1.Condition{Operator: "Like", Value: value("a")},
+			result:    "a",
+			wantErr:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := evaluateCondition(tc.condition, tc.result)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("evaluateCondition() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("evaluateCondition() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
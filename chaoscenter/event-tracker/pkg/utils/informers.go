@@ -1,131 +1,300 @@
 package utils
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
-	v1 "k8s.io/api/apps/v1"
+	litmuschaosv1 "github.com/litmuschaos/litmus/chaoscenter/event-tracker/api/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/runtime"
-	"k8s.io/client-go/informers"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 )
 
 var annotationKey = "litmuschaos.io/experimentId"
 
-// RunDeploymentInformer K8s informer watching for all the deployment changes
-func RunDeploymentInformer(factory informers.SharedInformerFactory) {
-	deploymentInformer := factory.Apps().V1().Deployments().Informer()
+// informerResyncPeriod is the resync period of the resource informers
+const informerResyncPeriod = 30 * time.Second
 
-	stopper := make(chan struct{})
-	defer close(stopper)
-
-	defer runtime.HandleCrash()
+// DefaultResources are the resource kinds watched for the policies which don't select any resource
+var DefaultResources = []schema.GroupVersionResource{
+	{Group: "apps", Version: "v1", Resource: "deployments"},
+	{Group: "apps", Version: "v1", Resource: "statefulsets"},
+	{Group: "apps", Version: "v1", Resource: "daemonsets"},
+}
 
-	deploymentInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		// When a resource gets updated
-		UpdateFunc: func(oldObj interface{}, newObj interface{}) {
-			depNewObj := newObj.(*v1.Deployment)
-			depOldObj := oldObj.(*v1.Deployment)
+// Reasons of the ResourceWatchErrors
+const (
+	WatchErrorNotServed = "NotServed"
+	WatchErrorForbidden = "Forbidden"
+)
 
-			var experimentId = depNewObj.GetAnnotations()[annotationKey]
+// watchedVerbs are the verbs needed by an informer
+var watchedVerbs = []string{"list", "watch"}
 
-			if depNewObj.GetResourceVersion() != depOldObj.GetResourceVersion() &&
-				!reflect.DeepEqual(depNewObj, depOldObj) &&
-				depNewObj.GetAnnotations()["litmuschaos.io/gitops"] == "true" &&
-				experimentId != "" {
-				logrus.Infof("Event Detected for experimentId: %s, ResourceType: %s, ResourceName: %s, ResourceNamespace: %s", experimentId, "Deployment", depNewObj.Name, depNewObj.Namespace)
-				err := PolicyAuditor("Deployment", depNewObj, depOldObj, experimentId)
-				if err != nil {
-					logrus.Error(err)
-					return
-				}
-			}
-		},
-	})
+// ResourceWatcher runs a dynamic informer for every resource kind selected by
+// the EventTrackerPolicies, so that changes of any kind can trigger an experiment
+type ResourceWatcher struct {
+	mu        sync.Mutex
+	client    dynamic.Interface
+	clientset kubernetes.Interface
+	namespace string
+	informers map[schema.GroupVersionResource]chan struct{}
+	// errors are the selected resource kinds which can't be watched, they are
+	// retried on every Sync
+	errors map[schema.GroupVersionResource]litmuschaosv1.ResourceWatchError
+}
 
-	deploymentInformer.Run(stopper)
-	if !cache.WaitForCacheSync(stopper, deploymentInformer.HasSynced) {
-		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
-		return
+// NewResourceWatcher returns a ResourceWatcher watching the resources of the given
+// namespace, or of the whole cluster if the namespace is empty. The clientset is
+// used to discover the resource kinds and to check the access to them
+func NewResourceWatcher(client dynamic.Interface, clientset kubernetes.Interface, namespace string) *ResourceWatcher {
+	return &ResourceWatcher{
+		client:    client,
+		clientset: clientset,
+		namespace: namespace,
+		informers: make(map[schema.GroupVersionResource]chan struct{}),
+		errors:    make(map[schema.GroupVersionResource]litmuschaosv1.ResourceWatchError),
 	}
 }
 
-// RunStsInformer K8s informer watching for all the Statefullset changes
-func RunStsInformer(factory informers.SharedInformerFactory) {
-	stsInformer := factory.Apps().V1().StatefulSets().Informer()
+// Sync starts the informers for the resource kinds selected by the policies and
+// stops the ones which are no longer selected by any policy
+func (w *ResourceWatcher) Sync(policies []litmuschaosv1.EventTrackerPolicy) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 
-	stopper := make(chan struct{})
-	defer close(stopper)
-
-	defer runtime.HandleCrash()
+	wanted := make(map[schema.GroupVersionResource]bool)
+	for _, gvr := range DefaultResources {
+		wanted[gvr] = true
+	}
+	for _, policy := range policies {
+		for _, gvr := range PolicyResources(policy) {
+			wanted[gvr] = true
+		}
+	}
 
-	stsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		// When a resource gets updated
-		UpdateFunc: func(oldObj interface{}, newObj interface{}) {
-			stsNewObj := newObj.(*v1.StatefulSet)
-			stsOldObj := oldObj.(*v1.StatefulSet)
+	for gvr, stopCh := range w.informers {
+		if !wanted[gvr] {
+			logrus.Infof("Stopping informer for %s", gvr.String())
+			close(stopCh)
+			delete(w.informers, gvr)
+		}
+	}
+	for gvr := range w.errors {
+		if !wanted[gvr] {
+			delete(w.errors, gvr)
+		}
+	}
 
-			var experimentId = stsNewObj.GetAnnotations()[annotationKey]
+	for gvr := range wanted {
+		if _, ok := w.informers[gvr]; ok {
+			continue
+		}
 
-			if stsNewObj.GetResourceVersion() != stsOldObj.GetResourceVersion() &&
-				!reflect.DeepEqual(stsNewObj, stsOldObj) &&
-				stsNewObj.GetAnnotations()["litmuschaos.io/gitops"] == "true" &&
-				experimentId != "" {
-				logrus.Infof("Event Detected for ExperimentId: %s, ResourceType: %s, ResourceName: %s, ResourceNamespace: %s", experimentId, "Deployment", stsNewObj.Name, stsNewObj.Namespace)
-				err := PolicyAuditor("Deployment", stsNewObj, stsOldObj, experimentId)
-				if err != nil {
-					logrus.Error(err)
-					return
-				}
+		namespace, watchErr := w.watchNamespace(gvr)
+		if watchErr != nil {
+			if _, ok := w.errors[gvr]; !ok {
+				logrus.Warnf("Resource %s can't be watched, skipping: %s", gvr.String(), watchErr.Message)
 			}
+			w.errors[gvr] = *watchErr
+			continue
+		}
+		delete(w.errors, gvr)
 
-		},
-	})
+		stopCh := make(chan struct{})
+		w.informers[gvr] = stopCh
+		go w.run(gvr, namespace, stopCh)
+	}
+}
+
+// WatchErrors returns the errors of the resource kinds selected by the policy which aren't watched
+func (w *ResourceWatcher) WatchErrors(policy litmuschaosv1.EventTrackerPolicy) []litmuschaosv1.ResourceWatchError {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 
-	stsInformer.Run(stopper)
-	if !cache.WaitForCacheSync(stopper, stsInformer.HasSynced) {
-		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
-		return
+	var watchErrors []litmuschaosv1.ResourceWatchError
+	for _, gvr := range PolicyResources(policy) {
+		if watchErr, ok := w.errors[gvr]; ok {
+			watchErrors = append(watchErrors, watchErr)
+		}
 	}
+	return watchErrors
 }
 
-// RunDSInformer K8s informer watching for all the daemonset changes
-func RunDSInformer(factory informers.SharedInformerFactory) {
-	dsInformer := factory.Apps().V1().DaemonSets().Informer()
+// watchNamespace returns the namespace to watch the resource kind in, the resources
+// of the cluster scoped kinds are watched cluster wide. It returns an error if the
+// api server doesn't serve the kind, e.g. a CRD which hasn't been installed, or if
+// the event tracker isn't allowed to list and watch it
+func (w *ResourceWatcher) watchNamespace(gvr schema.GroupVersionResource) (string, *litmuschaosv1.ResourceWatchError) {
+	resource, err := w.servedResource(gvr)
+	if err != nil {
+		return "", &litmuschaosv1.ResourceWatchError{
+			Resource: gvr.String(),
+			Reason:   WatchErrorNotServed,
+			Message:  err.Error(),
+		}
+	}
+
+	namespace := w.namespace
+	if !resource.Namespaced {
+		namespace = ""
+	}
+
+	for _, verb := range watchedVerbs {
+		if err := w.checkAccess(gvr, namespace, verb); err != nil {
+			return "", &litmuschaosv1.ResourceWatchError{
+				Resource: gvr.String(),
+				Reason:   WatchErrorForbidden,
+				Message:  err.Error(),
+			}
+		}
+	}
+	return namespace, nil
+}
+
+// servedResource returns the resource kind as served by the api server
+func (w *ResourceWatcher) servedResource(gvr schema.GroupVersionResource) (*metav1.APIResource, error) {
+	resources, err := w.clientset.Discovery().ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if err != nil {
+		return nil, err
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == gvr.Resource {
+			return &resource, nil
+		}
+	}
+	return nil, fmt.Errorf("the server doesn't serve %s in %s", gvr.Resource, gvr.GroupVersion().String())
+}
 
-	stopper := make(chan struct{})
-	defer close(stopper)
+// checkAccess checks if the event tracker is allowed to use the verb on the resource kind in
+// the namespace, the RBAC of the infra only grants the access to some kinds
+func (w *ResourceWatcher) checkAccess(gvr schema.GroupVersionResource, namespace string, verb string) error {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Group:     gvr.Group,
+				Version:   gvr.Version,
+				Resource:  gvr.Resource,
+			},
+		},
+	}
+	result, err := w.clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(), review, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	if !result.Status.Allowed {
+		scope := "the cluster"
+		if namespace != "" {
+			scope = "the namespace " + namespace
+		}
+		message := fmt.Sprintf("the event tracker isn't allowed to %s %s in %s, grant it in the RBAC of the infra", verb, gvr.Resource, scope)
+		if result.Status.Reason != "" {
+			message += ": " + result.Status.Reason
+		}
+		return errors.New(message)
+	}
+	return nil
+}
 
+// run K8s informer watching for all the changes of a resource kind
+func (w *ResourceWatcher) run(gvr schema.GroupVersionResource, namespace string, stopCh chan struct{}) {
 	defer runtime.HandleCrash()
 
-	dsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+	informer := dynamicinformer.NewFilteredDynamicInformer(w.client, gvr, namespace, informerResyncPeriod, cache.Indexers{}, nil).Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		// When a resource gets updated
 		UpdateFunc: func(oldObj interface{}, newObj interface{}) {
-			dsNewObj := newObj.(*v1.DaemonSet)
-			dsOldObj := newObj.(*v1.DaemonSet)
+			resNewObj, ok := newObj.(*unstructured.Unstructured)
+			if !ok {
+				return
+			}
+			resOldObj, ok := oldObj.(*unstructured.Unstructured)
+			if !ok {
+				return
+			}
 
-			var experimentId = dsNewObj.GetAnnotations()[annotationKey]
+			var experimentId = resNewObj.GetAnnotations()[annotationKey]
 
-			if dsNewObj.GetResourceVersion() != dsOldObj.GetResourceVersion() &&
-				!reflect.DeepEqual(dsNewObj, dsOldObj) &&
-				dsNewObj.GetAnnotations()["litmuschaos.io/gitops"] == "true" &&
+			if resNewObj.GetResourceVersion() != resOldObj.GetResourceVersion() &&
+				!reflect.DeepEqual(resNewObj, resOldObj) &&
+				resNewObj.GetAnnotations()["litmuschaos.io/gitops"] == "true" &&
 				experimentId != "" {
-				logrus.Infof("Event Detected for ExperimentId: %s, ResourceType: %s, ResourceName: %s, ResourceNamespace: %s", experimentId, "Deployment", dsNewObj.Name, dsNewObj.Namespace)
-				err := PolicyAuditor("Deployment", dsNewObj, dsOldObj, experimentId)
+				logrus.Infof("Event Detected for ExperimentId: %s, ResourceType: %s, ResourceName: %s, ResourceNamespace: %s", experimentId, resNewObj.GetKind(), resNewObj.GetName(), resNewObj.GetNamespace())
+				err := PolicyAuditor(gvr, resNewObj, resOldObj, experimentId)
 				if err != nil {
 					logrus.Error(err)
 					return
 				}
 			}
-
 		},
 	})
 
-	dsInformer.Run(stopper)
-	if !cache.WaitForCacheSync(stopper, dsInformer.HasSynced) {
-		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
-		return
+	logrus.Infof("Starting informer for %s", gvr.String())
+	informer.Run(stopCh)
+}
+
+// PolicyResources returns the resource kinds selected by the policy
+func PolicyResources(policy litmuschaosv1.EventTrackerPolicy) []schema.GroupVersionResource {
+	if len(policy.Spec.Resources) == 0 {
+		return DefaultResources
+	}
+
+	var resources []schema.GroupVersionResource
+	for _, selector := range policy.Spec.Resources {
+		resources = append(resources, schema.GroupVersionResource{
+			Group:    selector.Group,
+			Version:  selector.Version,
+			Resource: selector.Resource,
+		})
+	}
+	return resources
+}
+
+// policySelectsResource checks if the policy audits the changes of the resource kind
+func policySelectsResource(policy litmuschaosv1.EventTrackerPolicy, gvr schema.GroupVersionResource) bool {
+	for _, resource := range PolicyResources(policy) {
+		if resource == gvr {
+			return true
+		}
+	}
+	return false
+}
+
+// ListPolicies lists the EventTrackerPolicies of the infra namespace
+func ListPolicies(clientSet dynamic.Interface) ([]litmuschaosv1.EventTrackerPolicy, error) {
+	list, err := clientSet.Resource(EventTrackerPolicyResource).Namespace(Config.InfraNamespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []litmuschaosv1.EventTrackerPolicy
+	for _, item := range list.Items {
+		var etp litmuschaosv1.EventTrackerPolicy
+		data, err := json.Marshal(item.Object)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(data, &etp)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, etp)
 	}
+	return policies, nil
 }
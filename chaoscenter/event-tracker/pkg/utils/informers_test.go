@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	litmuschaosv1 "github.com/litmuschaos/litmus/chaoscenter/event-tracker/api/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var (
+	configMaps = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	secrets    = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	nodes      = schema.GroupVersionResource{Version: "v1", Resource: "nodes"}
+	widgets    = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+)
+
+// fakeWatcher returns a ResourceWatcher of the litmus namespace on a cluster serving the
+// default resources, configmaps, secrets and nodes, where the event tracker is allowed to
+// watch the resources of the allowed map
+func fakeWatcher(t *testing.T, allowed map[string]bool) (*ResourceWatcher, *dynamicfake.FakeDynamicClient) {
+	clientset := fake.NewSimpleClientset()
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployments", Namespaced: true},
+				{Name: "statefulsets", Namespaced: true},
+				{Name: "daemonsets", Namespaced: true},
+			},
+		},
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "configmaps", Namespaced: true},
+				{Name: "secrets", Namespaced: true},
+				{Name: "nodes", Namespaced: false},
+			},
+		},
+	}
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = allowed[review.Spec.ResourceAttributes.Resource]
+		return true, review, nil
+	})
+
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		DefaultResources[0]: "DeploymentList",
+		DefaultResources[1]: "StatefulSetList",
+		DefaultResources[2]: "DaemonSetList",
+		configMaps:          "ConfigMapList",
+		secrets:             "SecretList",
+		nodes:               "NodeList",
+	})
+
+	w := NewResourceWatcher(client, clientset, "litmus")
+	t.Cleanup(func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		for _, stopCh := range w.informers {
+			close(stopCh)
+		}
+		w.informers = nil
+	})
+	return w, client
+}
+
+// listNamespace waits for the informer of the resource kind to list the resources and
+// returns the namespace they are listed in
+func listNamespace(t *testing.T, client *dynamicfake.FakeDynamicClient, gvr schema.GroupVersionResource) string {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, action := range client.Actions() {
+			if action.GetVerb() == "list" && action.GetResource() == gvr {
+				return action.GetNamespace()
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("no informer listed %s", gvr.String())
+	return ""
+}
+
+// watched returns the resource kinds having an informer
+func (w *ResourceWatcher) watched() map[schema.GroupVersionResource]bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	watched := make(map[schema.GroupVersionResource]bool)
+	for gvr := range w.informers {
+		watched[gvr] = true
+	}
+	return watched
+}
+
+func policyOf(resources ...schema.GroupVersionResource) litmuschaosv1.EventTrackerPolicy {
+	var policy litmuschaosv1.EventTrackerPolicy
+	for _, gvr := range resources {
+		policy.Spec.Resources = append(policy.Spec.Resources, litmuschaosv1.ResourceSelector{
+			Group:    gvr.Group,
+			Version:  gvr.Version,
+			Resource: gvr.Resource,
+		})
+	}
+	return policy
+}
+
+func TestResourceWatcherSync(t *testing.T) {
+	allowed := map[string]bool{"deployments": true, "statefulsets": true, "daemonsets": true, "configmaps": true, "nodes": true}
+	w, client := fakeWatcher(t, allowed)
+
+	policy := policyOf(configMaps, secrets, nodes, widgets)
+	w.Sync([]litmuschaosv1.EventTrackerPolicy{policy})
+
+	want := map[schema.GroupVersionResource]bool{
+		DefaultResources[0]: true,
+		DefaultResources[1]: true,
+		DefaultResources[2]: true,
+		configMaps:          true,
+		nodes:               true,
+	}
+	if got := w.watched(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Sync() watches %v, want %v", got, want)
+	}
+
+	// the namespaced kinds are watched in the infra namespace and the cluster scoped ones cluster wide
+	if namespace := listNamespace(t, client, configMaps); namespace != "litmus" {
+		t.Errorf("configmaps are listed in the namespace %q, want litmus", namespace)
+	}
+	if namespace := listNamespace(t, client, nodes); namespace != "" {
+		t.Errorf("nodes are listed in the namespace %q, want the whole cluster", namespace)
+	}
+
+	// the kinds which can't be watched are reported on the policy
+	watchErrors := w.WatchErrors(policy)
+	if len(watchErrors) != 2 {
+		t.Fatalf("WatchErrors() = %v, want the secrets and the widgets", watchErrors)
+	}
+	if watchErrors[0].Resource != secrets.String() || watchErrors[0].Reason != WatchErrorForbidden || watchErrors[0].Message == "" {
+		t.Errorf("WatchErrors() reported %v for the secrets, want Forbidden", watchErrors[0])
+	}
+	if watchErrors[1].Resource != widgets.String() || watchErrors[1].Reason != WatchErrorNotServed || watchErrors[1].Message == "" {
+		t.Errorf("WatchErrors() reported %v for the widgets, want NotServed", watchErrors[1])
+	}
+	if watchErrors := w.WatchErrors(policyOf(configMaps)); len(watchErrors) != 0 {
+		t.Errorf("WatchErrors() = %v for a policy on the watched kinds", watchErrors)
+	}
+
+	// the forbidden kinds are retried once they are granted
+	allowed["secrets"] = true
+	w.Sync([]litmuschaosv1.EventTrackerPolicy{policy})
+	if !w.watched()[secrets] {
+		t.Error("Sync() didn't watch the secrets once they are granted")
+	}
+	if watchErrors := w.WatchErrors(policy); len(watchErrors) != 1 || watchErrors[0].Resource != widgets.String() {
+		t.Errorf("WatchErrors() = %v, want only the widgets", watchErrors)
+	}
+
+	// the kinds which are no longer selected are stopped
+	w.Sync(nil)
+	want = map[schema.GroupVersionResource]bool{
+		DefaultResources[0]: true,
+		DefaultResources[1]: true,
+		DefaultResources[2]: true,
+	}
+	if got := w.watched(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sync() watches %v after the policy is deleted, want %v", got, want)
+	}
+	if len(w.errors) != 0 {
+		t.Errorf("Sync() kept the errors %v of the kinds which are no longer selected", w.errors)
+	}
+}
+
+func TestResourceWatcherDefaultResources(t *testing.T) {
+	w, _ := fakeWatcher(t, map[string]bool{"deployments": true, "statefulsets": true})
+
+	// a policy which doesn't select any kind applies to the default resources
+	policy := policyOf()
+	w.Sync([]litmuschaosv1.EventTrackerPolicy{policy})
+
+	watchErrors := w.WatchErrors(policy)
+	if len(watchErrors) != 1 || watchErrors[0].Resource != DefaultResources[2].String() || watchErrors[0].Reason != WatchErrorForbidden {
+		t.Errorf("WatchErrors() = %v, want the forbidden daemonsets", watchErrors)
+	}
+}
@@ -0,0 +1,294 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	litmuschaosv1 "github.com/litmuschaos/litmus/chaoscenter/event-tracker/api/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// ConditionThrottled is recorded when the conditions are matched but the
+	// experiment is not triggered because of the cooldown or the rate limit
+	ConditionThrottled = "ConditionThrottled"
+)
+
+// legacyStatusTimeFormat is the format of the timestamps of the statuses recorded
+// by the previous versions, it only has a second resolution and a two-digit year
+const legacyStatusTimeFormat = time.RFC850
+
+// formatStatusTime returns the timestamp of a policy status, in RFC3339 with a
+// sub-second resolution so that the short cooldowns are accurate
+func formatStatusTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// parseStatusTime parses the timestamp of a policy status, including the ones
+// recorded by the previous versions
+func parseStatusTime(timeStamp string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, timeStamp)
+	if err != nil {
+		return time.Parse(legacyStatusTimeFormat, timeStamp)
+	}
+	return t, nil
+}
+
+// triggerKey identifies the changes of a resource audited by a policy
+type triggerKey struct {
+	policy    string
+	resource  string
+	namespace string
+	name      string
+}
+
+func keyOf(policyName string, status litmuschaosv1.EventTrackerPolicyStatus) triggerKey {
+	return triggerKey{
+		policy:    policyName,
+		resource:  status.Resource,
+		namespace: status.ResourceNamespace,
+		name:      status.ResourceName,
+	}
+}
+
+// debounceTimers holds the pending triggers of the policies with a debounce period
+var (
+	debounceMu     sync.Mutex
+	debounceTimers = make(map[triggerKey]*time.Timer)
+)
+
+// scheduleTrigger records the match of the policy conditions, once the debounce
+// period of the policy has elapsed without any new match for the same resource.
+// The pending match is kept in the policy until then, so that it isn't lost if
+// the event tracker restarts
+func scheduleTrigger(clientSet dynamic.Interface, etp litmuschaosv1.EventTrackerPolicy, status litmuschaosv1.EventTrackerPolicyStatus) error {
+	debounce, err := parsePolicyDuration(etp.Spec.Debounce)
+	if err != nil {
+		return fmt.Errorf("invalid debounce of policy %s: %v", etp.GetName(), err)
+	}
+	if debounce == 0 {
+		return recordTrigger(clientSet, etp.GetName(), status, "")
+	}
+
+	key := keyOf(etp.GetName(), status)
+	pending := litmuschaosv1.PendingTrigger{
+		DueAt:                    formatStatusTime(time.Now().Add(debounce)),
+		EventTrackerPolicyStatus: status,
+	}
+	// the pending match replaces the one of the previous change of the resource
+	err = updatePolicy(clientSet, key.policy, func(etp *litmuschaosv1.EventTrackerPolicy) (bool, error) {
+		etp.PendingTriggers, _ = removePendingTrigger(etp.PendingTriggers, key, "")
+		etp.PendingTriggers = append(etp.PendingTriggers, pending)
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	armTrigger(clientSet, key, pending, debounce)
+
+	logrus.WithField("policyName", key.policy).Infof("Trigger for %s %s debounced for %s", status.Resource, status.ResourceName, debounce)
+	return nil
+}
+
+// ResumePendingTriggers arms the timers of the matches which were waiting for the
+// debounce period of their policies when the event tracker stopped
+func ResumePendingTriggers(clientSet dynamic.Interface) error {
+	policies, err := ListPolicies(clientSet)
+	if err != nil {
+		return err
+	}
+
+	for _, etp := range policies {
+		for _, pending := range etp.PendingTriggers {
+			dueAt, err := parseStatusTime(pending.DueAt)
+			if err != nil {
+				logrus.WithField("policyName", etp.GetName()).Errorf("invalid due time of a pending trigger: %v", err)
+				continue
+			}
+			delay := time.Until(dueAt)
+			if delay < 0 {
+				delay = 0
+			}
+			armTrigger(clientSet, keyOf(etp.GetName(), pending.EventTrackerPolicyStatus), pending, delay)
+		}
+	}
+	return nil
+}
+
+// armTrigger records the pending match once the delay has elapsed, the timer of
+// the previous match of the resource is stopped
+func armTrigger(clientSet dynamic.Interface, key triggerKey, pending litmuschaosv1.PendingTrigger, delay time.Duration) {
+	debounceMu.Lock()
+	defer debounceMu.Unlock()
+
+	if timer, ok := debounceTimers[key]; ok {
+		timer.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		debounceMu.Lock()
+		if debounceTimers[key] == timer {
+			delete(debounceTimers, key)
+		}
+		debounceMu.Unlock()
+
+		status := pending.EventTrackerPolicyStatus
+		status.TimeStamp = formatStatusTime(time.Now())
+		if err := recordTrigger(clientSet, key.policy, status, pending.DueAt); err != nil {
+			logrus.WithField("policyName", key.policy).Error(err)
+		}
+	})
+	debounceTimers[key] = timer
+}
+
+// removePendingTrigger removes the pending match of the resource, only if it's due
+// at dueAt when it's set. It returns whether a match was removed
+func removePendingTrigger(pendingTriggers []litmuschaosv1.PendingTrigger, key triggerKey, dueAt string) ([]litmuschaosv1.PendingTrigger, bool) {
+	var (
+		remaining []litmuschaosv1.PendingTrigger
+		removed   bool
+	)
+	for _, pending := range pendingTriggers {
+		if keyOf(key.policy, pending.EventTrackerPolicyStatus) == key && (dueAt == "" || pending.DueAt == dueAt) {
+			removed = true
+			continue
+		}
+		remaining = append(remaining, pending)
+	}
+	return remaining, removed
+}
+
+// recordTrigger appends the status to the policy, marking it as throttled if
+// the resource has been triggered too recently or too often. The status of a
+// pending match, due at dueAt, is only recorded if the match is still pending
+func recordTrigger(clientSet dynamic.Interface, policyName string, status litmuschaosv1.EventTrackerPolicyStatus, dueAt string) error {
+	return updatePolicy(clientSet, policyName, func(etp *litmuschaosv1.EventTrackerPolicy) (bool, error) {
+		if dueAt != "" {
+			var pending bool
+			etp.PendingTriggers, pending = removePendingTrigger(etp.PendingTriggers, keyOf(policyName, status), dueAt)
+			if !pending {
+				// a newer change of the resource is pending or the match was already recorded
+				return false, nil
+			}
+		}
+
+		throttled, err := isThrottled(*etp, status, time.Now())
+		if err != nil {
+			return false, err
+		}
+
+		newStatus := status
+		newStatus.DryRun = etp.Spec.DryRun
+		if throttled {
+			newStatus.Result = ConditionThrottled
+			logrus.WithField("policyName", policyName).Infof("Trigger for %s %s throttled", status.Resource, status.ResourceName)
+		}
+		etp.Statuses = append(etp.Statuses, newStatus)
+		return true, nil
+	})
+}
+
+// updatePolicy gets the policy, applies the update to it and saves it if the update
+// returns true, it's retried on conflicts. The deleted policies are ignored
+func updatePolicy(clientSet dynamic.Interface, policyName string, update func(etp *litmuschaosv1.EventTrackerPolicy) (bool, error)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		eventTrackerPolicy, err := clientSet.Resource(EventTrackerPolicyResource).Namespace(Config.InfraNamespace).Get(context.TODO(), policyName, metav1.GetOptions{})
+		if k8sErrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		var etp litmuschaosv1.EventTrackerPolicy
+		data, err := json.Marshal(eventTrackerPolicy.Object)
+		if err != nil {
+			return err
+		}
+
+		err = json.Unmarshal(data, &etp)
+		if err != nil {
+			return err
+		}
+
+		changed, err := update(&etp)
+		if err != nil || !changed {
+			return err
+		}
+
+		// Updating EventTrackerPolicy
+		var us unstructured.Unstructured
+		data, err = json.Marshal(etp)
+		if err != nil {
+			return err
+		}
+
+		err = json.Unmarshal(data, &us)
+		if err != nil {
+			return err
+		}
+
+		_, err = clientSet.Resource(EventTrackerPolicyResource).Namespace(Config.InfraNamespace).Update(context.TODO(), &us, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// isThrottled checks the previous triggers of the resource recorded in the
+// policy statuses against the cooldown and the rate limit of the policy
+func isThrottled(etp litmuschaosv1.EventTrackerPolicy, status litmuschaosv1.EventTrackerPolicyStatus, now time.Time) (bool, error) {
+	cooldown, err := parsePolicyDuration(etp.Spec.Cooldown)
+	if err != nil {
+		return false, fmt.Errorf("invalid cooldown of policy %s: %v", etp.GetName(), err)
+	}
+	if cooldown == 0 && etp.Spec.MaxTriggersPerHour == 0 {
+		return false, nil
+	}
+
+	triggersInLastHour := 0
+	for _, previous := range etp.Statuses {
+		// the matches recorded in dry-run mode didn't trigger the experiment
+		if previous.Result != ConditionPassed || previous.DryRun ||
+			previous.Resource != status.Resource ||
+			previous.ResourceName != status.ResourceName ||
+			previous.ResourceNamespace != status.ResourceNamespace {
+			continue
+		}
+
+		triggeredAt, err := parseStatusTime(previous.TimeStamp)
+		if err != nil {
+			continue
+		}
+		elapsed := now.Sub(triggeredAt)
+
+		if elapsed < cooldown {
+			return true, nil
+		}
+		if elapsed < time.Hour {
+			triggersInLastHour++
+		}
+	}
+
+	return etp.Spec.MaxTriggersPerHour > 0 && triggersInLastHour >= etp.Spec.MaxTriggersPerHour, nil
+}
+
+func parsePolicyDuration(duration string) (time.Duration, error) {
+	if duration == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("negative duration %s", duration)
+	}
+	return d, nil
+}
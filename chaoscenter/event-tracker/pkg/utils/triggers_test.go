@@ -0,0 +1,255 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	litmuschaosv1 "github.com/litmuschaos/litmus/chaoscenter/event-tracker/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestIsThrottled(t *testing.T) {
+	now := time.Now()
+	status := func(ago time.Duration, result string) litmuschaosv1.EventTrackerPolicyStatus {
+		return litmuschaosv1.EventTrackerPolicyStatus{
+			TimeStamp:         formatStatusTime(now.Add(-ago)),
+			Resource:          "Deployment",
+			ResourceName:      "nginx",
+			ResourceNamespace: "default",
+			Result:            result,
+		}
+	}
+	current := status(0, ConditionPassed)
+
+	tests := []struct {
+		name    string
+		spec    litmuschaosv1.EventTrackerPolicySpec
+		history []litmuschaosv1.EventTrackerPolicyStatus
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "no throttling configured",
+			history: []litmuschaosv1.EventTrackerPolicyStatus{status(time.Second, ConditionPassed)},
+			want:    false,
+		},
+		{
+			name:    "within cooldown",
+			spec:    litmuschaosv1.EventTrackerPolicySpec{Cooldown: "10m"},
+			history: []litmuschaosv1.EventTrackerPolicyStatus{status(5*time.Minute, ConditionPassed)},
+			want:    true,
+		},
+		{
+			name:    "cooldown elapsed",
+			spec:    litmuschaosv1.EventTrackerPolicySpec{Cooldown: "10m"},
+			history: []litmuschaosv1.EventTrackerPolicyStatus{status(15*time.Minute, ConditionPassed)},
+			want:    false,
+		},
+		{
+			name:    "within a sub-second cooldown",
+			spec:    litmuschaosv1.EventTrackerPolicySpec{Cooldown: "800ms"},
+			history: []litmuschaosv1.EventTrackerPolicyStatus{status(500*time.Millisecond, ConditionPassed)},
+			want:    true,
+		},
+		{
+			name: "within cooldown of a status recorded by a previous version",
+			spec: litmuschaosv1.EventTrackerPolicySpec{Cooldown: "10m"},
+			history: []litmuschaosv1.EventTrackerPolicyStatus{{
+				TimeStamp:         now.Add(-5 * time.Minute).Format(time.RFC850),
+				Resource:          "Deployment",
+				ResourceName:      "nginx",
+				ResourceNamespace: "default",
+				Result:            ConditionPassed,
+			}},
+			want: true,
+		},
+		{
+			name:    "throttled matches are not counted",
+			spec:    litmuschaosv1.EventTrackerPolicySpec{Cooldown: "10m"},
+			history: []litmuschaosv1.EventTrackerPolicyStatus{status(time.Minute, ConditionThrottled)},
+			want:    false,
+		},
+		{
+			name: "dry-run matches are not counted",
+			spec: litmuschaosv1.EventTrackerPolicySpec{Cooldown: "10m", MaxTriggersPerHour: 1},
+			history: []litmuschaosv1.EventTrackerPolicyStatus{func() litmuschaosv1.EventTrackerPolicyStatus {
+				dryRun := status(time.Minute, ConditionPassed)
+				dryRun.DryRun = true
+				return dryRun
+			}()},
+			want: false,
+		},
+		{
+			name:    "negative cooldown",
+			spec:    litmuschaosv1.EventTrackerPolicySpec{Cooldown: "-10m"},
+			wantErr: true,
+		},
+		{
+			name: "rate limit reached",
+			spec: litmuschaosv1.EventTrackerPolicySpec{MaxTriggersPerHour: 2},
+			history: []litmuschaosv1.EventTrackerPolicyStatus{
+				status(10*time.Minute, ConditionPassed),
+				status(30*time.Minute, ConditionPassed),
+				status(2*time.Hour, ConditionPassed),
+			},
+			want: true,
+		},
+		{
+			name: "rate limit not reached",
+			spec: litmuschaosv1.EventTrackerPolicySpec{MaxTriggersPerHour: 2},
+			history: []litmuschaosv1.EventTrackerPolicyStatus{
+				status(10*time.Minute, ConditionPassed),
+				status(2*time.Hour, ConditionPassed),
+			},
+			want: false,
+		},
+		{
+			name:    "invalid cooldown",
+			spec:    litmuschaosv1.EventTrackerPolicySpec{Cooldown: "ten minutes"},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			etp := litmuschaosv1.EventTrackerPolicy{Spec: tc.spec, Statuses: tc.history}
+			got, err := isThrottled(etp, current, now)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("isThrottled() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("isThrottled() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseStatusTime(t *testing.T) {
+	now := time.Date(2024, time.March, 5, 10, 30, 15, 250*int(time.Millisecond), time.UTC)
+
+	got, err := parseStatusTime(formatStatusTime(now))
+	if err != nil || !got.Equal(now) {
+		t.Errorf("parseStatusTime(formatStatusTime()) = %v, %v, want %v", got, err, now)
+	}
+
+	// the statuses recorded by the previous versions have no sub-second part
+	got, err = parseStatusTime(now.Format(time.RFC850))
+	if err != nil || !got.Equal(now.Truncate(time.Second)) {
+		t.Errorf("parseStatusTime() of an RFC850 timestamp = %v, %v, want %v", got, err, now.Truncate(time.Second))
+	}
+
+	if _, err := parseStatusTime("yesterday"); err == nil {
+		t.Error("parseStatusTime() of an invalid timestamp didn't fail")
+	}
+}
+
+// fakePolicyClient returns a dynamic client serving the policy in the litmus namespace
+func fakePolicyClient(t *testing.T, etp litmuschaosv1.EventTrackerPolicy) *dynamicfake.FakeDynamicClient {
+	Config.InfraNamespace = "litmus"
+	etp.APIVersion = "eventtracker.litmuschaos.io/v1"
+	etp.Kind = "EventTrackerPolicy"
+	etp.Namespace = "litmus"
+
+	data, err := json.Marshal(etp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var object unstructured.Unstructured
+	if err := json.Unmarshal(data, &object.Object); err != nil {
+		t.Fatal(err)
+	}
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		EventTrackerPolicyResource: "EventTrackerPolicyList",
+	}, &object)
+}
+
+// waitForPolicy waits for the policy to satisfy the condition and returns it
+func waitForPolicy(t *testing.T, client *dynamicfake.FakeDynamicClient, name string, condition func(litmuschaosv1.EventTrackerPolicy) bool) litmuschaosv1.EventTrackerPolicy {
+	var etp litmuschaosv1.EventTrackerPolicy
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		object, err := client.Resource(EventTrackerPolicyResource).Namespace("litmus").Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := json.Marshal(object.Object)
+		etp = litmuschaosv1.EventTrackerPolicy{}
+		if err := json.Unmarshal(data, &etp); err != nil {
+			t.Fatal(err)
+		}
+		if condition(etp) {
+			return etp
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("policy %s = %+v, the condition wasn't met", name, etp)
+	return etp
+}
+
+func TestScheduleTriggerDebounce(t *testing.T) {
+	etp := litmuschaosv1.EventTrackerPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "debounced"},
+		Spec:       litmuschaosv1.EventTrackerPolicySpec{Debounce: "100ms"},
+	}
+	client := fakePolicyClient(t, etp)
+	match := litmuschaosv1.EventTrackerPolicyStatus{
+		Resource:          "Deployment",
+		ResourceName:      "nginx",
+		ResourceNamespace: "default",
+		Result:            ConditionPassed,
+	}
+
+	// the successive matches of the resource are kept as a single pending trigger
+	for i := 0; i < 3; i++ {
+		if err := scheduleTrigger(client, etp, match); err != nil {
+			t.Fatalf("scheduleTrigger() error = %v", err)
+		}
+	}
+	pending := waitForPolicy(t, client, "debounced", func(etp litmuschaosv1.EventTrackerPolicy) bool { return true })
+	if len(pending.PendingTriggers) != 1 || len(pending.Statuses) != 0 {
+		t.Fatalf("scheduleTrigger() kept %d pending triggers and %d statuses, want 1 and 0", len(pending.PendingTriggers), len(pending.Statuses))
+	}
+
+	// the pending trigger is recorded once the debounce period elapsed
+	recorded := waitForPolicy(t, client, "debounced", func(etp litmuschaosv1.EventTrackerPolicy) bool {
+		return len(etp.Statuses) > 0
+	})
+	if len(recorded.Statuses) != 1 || len(recorded.PendingTriggers) != 0 {
+		t.Errorf("scheduleTrigger() recorded %d statuses and kept %d pending triggers, want 1 and 0", len(recorded.Statuses), len(recorded.PendingTriggers))
+	}
+}
+
+func TestResumePendingTriggers(t *testing.T) {
+	pending := litmuschaosv1.PendingTrigger{
+		DueAt: formatStatusTime(time.Now().Add(-time.Minute)),
+		EventTrackerPolicyStatus: litmuschaosv1.EventTrackerPolicyStatus{
+			Resource:          "Deployment",
+			ResourceName:      "nginx",
+			ResourceNamespace: "default",
+			Result:            ConditionPassed,
+		},
+	}
+	client := fakePolicyClient(t, litmuschaosv1.EventTrackerPolicy{
+		ObjectMeta:      metav1.ObjectMeta{Name: "restarted"},
+		Spec:            litmuschaosv1.EventTrackerPolicySpec{Debounce: "10m"},
+		PendingTriggers: []litmuschaosv1.PendingTrigger{pending},
+	})
+
+	if err := ResumePendingTriggers(client); err != nil {
+		t.Fatalf("ResumePendingTriggers() error = %v", err)
+	}
+
+	// the trigger which was due while the event tracker was stopped is recorded right away
+	recorded := waitForPolicy(t, client, "restarted", func(etp litmuschaosv1.EventTrackerPolicy) bool {
+		return len(etp.Statuses) > 0
+	})
+	if len(recorded.PendingTriggers) != 0 || recorded.Statuses[0].ResourceName != "nginx" {
+		t.Errorf("ResumePendingTriggers() recorded %+v and kept %d pending triggers", recorded.Statuses, len(recorded.PendingTriggers))
+	}
+}
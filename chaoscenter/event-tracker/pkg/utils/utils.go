@@ -3,17 +3,15 @@ package utils
 import (
 	"bytes"
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
+	"reflect"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/jmespath/go-jmespath"
 	litmuschaosv1 "github.com/litmuschaos/litmus/chaoscenter/event-tracker/api/v1"
 	"github.com/litmuschaos/litmus/chaoscenter/event-tracker/pkg/k8s"
-	v1 "k8s.io/api/apps/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -21,7 +19,6 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"net/http"
-	"strings"
 	"time"
 
 	"k8s.io/client-go/dynamic"
@@ -35,31 +32,6 @@ const (
 	//ConditionFailed       = "ConditionFailed"
 )
 
-const (
-	StateFulSet = "statefulset"
-	Deployment  = "deployment"
-	DaemonSet   = "daemonset"
-)
-
-func cases(key string, value string, operator string) bool {
-	switch operator {
-	case "EqualTo":
-		return key == value
-	case "NotEqualTo":
-		return key != value
-	case "LessThan":
-		return key < value
-	case "GreaterThan":
-		return key > value
-	case "GreaterThanEqualTo":
-		return key >= value
-	case "LessThanEqualTo":
-		return key <= value
-	}
-
-	return false
-}
-
 func conditionChecker(etp litmuschaosv1.EventTrackerPolicy, newData interface{}, oldData interface{}) bool {
 	finalResult := false
 	if etp.Spec.ConditionType == "and" {
@@ -76,18 +48,16 @@ func conditionChecker(etp litmuschaosv1.EventTrackerPolicy, newData interface{},
 				return false
 			}
 
-			if newDataResult != oldDataResult {
-				if condition.Operator == "Change" {
-					finalResult = true
-				} else {
-					str := fmt.Sprintf("%v", newDataResult)
-					if val := cases(str, *condition.Value, condition.Operator); !val {
-						finalResult = val
-						break
-					} else if val {
-						finalResult = true
-					}
+			if !reflect.DeepEqual(newDataResult, oldDataResult) {
+				val, err := evaluateCondition(condition, newDataResult)
+				if err != nil {
+					logrus.WithField("key", condition.Key).Error(err)
+					return false
+				}
+				if !val {
+					return false
 				}
+				finalResult = true
 			}
 		}
 	} else if etp.Spec.ConditionType == "or" {
@@ -103,14 +73,14 @@ func conditionChecker(etp litmuschaosv1.EventTrackerPolicy, newData interface{},
 				return false
 			}
 
-			if newDataResult != oldDataResult {
-				if condition.Operator == "Change" {
-					finalResult = true
-				} else {
-					str := fmt.Sprintf("%v", newDataResult)
-					if val := cases(str, *condition.Value, condition.Operator); val {
-						finalResult = val
-					}
+			if !reflect.DeepEqual(newDataResult, oldDataResult) {
+				val, err := evaluateCondition(condition, newDataResult)
+				if err != nil {
+					logrus.WithField("key", condition.Key).Error(err)
+					continue
+				}
+				if val {
+					finalResult = val
 				}
 			}
 		}
@@ -119,7 +89,12 @@ func conditionChecker(etp litmuschaosv1.EventTrackerPolicy, newData interface{},
 	return finalResult
 }
 
-func PolicyAuditor(resourceType string, newObj interface{}, oldObj interface{}, experimentId string) error {
+// EventTrackerPolicyResource is the GroupVersionResource of the EventTrackerPolicies
+var EventTrackerPolicyResource = schema.GroupVersionResource{Group: "eventtracker.litmuschaos.io", Version: "v1", Resource: "eventtrackerpolicies"}
+
+// PolicyAuditor checks the change of a resource against the conditions of the
+// policies selecting its kind, and records the matches in the policy statuses
+func PolicyAuditor(gvr schema.GroupVersionResource, newObj *unstructured.Unstructured, oldObj *unstructured.Unstructured, experimentId string) error {
 	restConfig, err := k8s.GetKubeConfig()
 	if err != nil {
 		return err
@@ -130,119 +105,26 @@ func PolicyAuditor(resourceType string, newObj interface{}, oldObj interface{},
 		return err
 	}
 
-	deploymentRes := schema.GroupVersionResource{Group: "eventtracker.litmuschaos.io", Version: "v1", Resource: "eventtrackerpolicies"}
-	deploymentConfigList, err := clientSet.Resource(deploymentRes).Namespace(Config.InfraNamespace).List(context.TODO(), metav1.ListOptions{})
+	policies, err := ListPolicies(clientSet)
 	if err != nil {
 		return err
 	}
 
-	if len(deploymentConfigList.Items) == 0 {
+	if len(policies) == 0 {
 		logrus.Infof("No event-tracker policy(s) found in %s namespace", Config.InfraNamespace)
 		return nil
 	}
 
-	for _, ep := range deploymentConfigList.Items {
-
-		eventTrackerPolicy, err := clientSet.Resource(deploymentRes).Namespace(Config.InfraNamespace).Get(context.TODO(), ep.GetName(), metav1.GetOptions{})
-		if err != nil {
-			return err
-		}
-
-		var etp litmuschaosv1.EventTrackerPolicy
-		data, err := json.Marshal(eventTrackerPolicy.Object)
-		if err != nil {
-			return err
-		}
-
-		err = json.Unmarshal(data, &etp)
-		if err != nil {
-			return err
-		}
-
-		var (
-			newDataInterface interface{}
-			resourceName     string
-			oldDataInterface interface{}
-		)
-
-		expr := strings.ToLower(resourceType)
-		switch expr {
-		case Deployment:
-			newDeploy := newObj.(*v1.Deployment)
-			resourceName = newDeploy.GetName()
-
-			newMar, err := json.Marshal(newDeploy)
-			if err != nil {
-				return err
-			}
-
-			err = json.Unmarshal(newMar, &newDataInterface)
-			if err != nil {
-				return err
-			}
-
-			oldDeploy := oldObj.(*v1.Deployment)
-			oldMar, err := json.Marshal(oldDeploy)
-			if err != nil {
-				return err
-			}
-
-			err = json.Unmarshal(oldMar, &oldDataInterface)
-			if err != nil {
-				return err
-			}
-
-		case StateFulSet:
-			newSts := newObj.(*v1.StatefulSet)
-			resourceName = newSts.GetName()
-
-			newMar, err := json.Marshal(newSts)
-			if err != nil {
-				return err
-			}
-
-			err = json.Unmarshal(newMar, &newDataInterface)
-			if err != nil {
-				return err
-			}
-
-			oldSts := oldObj.(*v1.StatefulSet)
-			oldMar, err := json.Marshal(oldSts)
-			if err != nil {
-				return err
-			}
-
-			err = json.Unmarshal(oldMar, &oldDataInterface)
-			if err != nil {
-				return err
-			}
-
-		case DaemonSet:
-			newDs := newObj.(*v1.DaemonSet)
-			resourceName = newDs.GetName()
-			newMar, err := json.Marshal(newDs)
-			if err != nil {
-				return err
-			}
-
-			err = json.Unmarshal(newMar, &newDataInterface)
-			if err != nil {
-				return err
-			}
-
-			oldDs := oldObj.(*v1.DaemonSet)
-			oldMar, err := json.Marshal(oldDs)
-			if err != nil {
-				return err
-			}
-
-			err = json.Unmarshal(oldMar, &oldDataInterface)
-			if err != nil {
-				return err
-			}
+	var (
+		resourceType     = newObj.GetKind()
+		resourceName     = newObj.GetName()
+		newDataInterface = newObj.Object
+		oldDataInterface = oldObj.Object
+	)
 
-		default:
-			return errors.New("resource not supported")
+	for _, etp := range policies {
+		if !policySelectsResource(etp, gvr) {
+			continue
 		}
 
 		logFields := logrus.Fields{
@@ -256,28 +138,15 @@ func PolicyAuditor(resourceType string, newObj interface{}, oldObj interface{},
 		check := conditionChecker(etp, newDataInterface, oldDataInterface)
 
 		if check {
-			etp.Statuses = append(etp.Statuses, litmuschaosv1.EventTrackerPolicyStatus{
-				TimeStamp:    time.Now().Format(time.RFC850),
-				Resource:     resourceType,
-				ResourceName: resourceName,
-				Result:       ConditionPassed,
-				ExperimentID: experimentId,
-				IsTriggered:  "false",
+			err = scheduleTrigger(clientSet, etp, litmuschaosv1.EventTrackerPolicyStatus{
+				TimeStamp:         formatStatusTime(time.Now()),
+				Resource:          resourceType,
+				ResourceName:      resourceName,
+				ResourceNamespace: newObj.GetNamespace(),
+				Result:            ConditionPassed,
+				ExperimentID:      experimentId,
+				IsTriggered:       "false",
 			})
-
-			// Updating EventTrackerPolicy
-			var us unstructured.Unstructured
-			data, err = json.Marshal(etp)
-			if err != nil {
-				return err
-			}
-
-			err = json.Unmarshal(data, &us)
-			if err != nil {
-				return err
-			}
-
-			_, err = clientSet.Resource(deploymentRes).Namespace(Config.InfraNamespace).Update(context.TODO(), &us, metav1.UpdateOptions{})
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,28 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.42
+
+import (
+	"context"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/authorization"
+	"github.com/sirupsen/logrus"
+)
+
+// ListAuditLogs is the resolver for the listAuditLogs field.
+func (r *queryResolver) ListAuditLogs(ctx context.Context, projectID string, request *model.ListAuditLogsRequest) (*model.ListAuditLogsResponse, error) {
+	logFields := logrus.Fields{
+		"projectId": projectID,
+	}
+	logrus.WithFields(logFields).Info("request received to list audit logs")
+	err := authorization.ValidateRole(ctx, projectID,
+		authorization.ListAuditLogs,
+		model.InvitationAccepted.String())
+	if err != nil {
+		return nil, err
+	}
+	return r.auditService.ListAuditLogs(ctx, projectID, request)
+}
@@ -29,7 +29,7 @@ func (r *mutationResolver) RunChaosExperiment(ctx context.Context, experimentID
 
 	logrus.WithFields(logFields).Info("request received to run chaos experiment")
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.CreateChaosExperiment],
+		authorization.CreateChaosExperiment,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
@@ -67,7 +67,7 @@ func (r *mutationResolver) StopExperimentRuns(ctx context.Context, projectID str
 
 	logrus.WithFields(logFields).Info("request received to stop chaos experiment")
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.StopChaosExperiment],
+		authorization.StopChaosExperiment,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return false, err
@@ -89,7 +89,7 @@ func (r *queryResolver) GetExperimentRun(ctx context.Context, projectID string,
 	}
 	logrus.WithFields(logFields).Info("request received to fetch chaos experiment run")
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.GetWorkflowRun],
+		authorization.GetWorkflowRun,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
@@ -113,7 +113,7 @@ func (r *queryResolver) ListExperimentRun(ctx context.Context, projectID string,
 	logrus.WithFields(logFields).Info("request received to list chaos experiment run")
 
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.ListWorkflowRuns],
+		authorization.ListWorkflowRuns,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
@@ -133,7 +133,7 @@ func (r *queryResolver) GetExperimentRunStats(ctx context.Context, projectID str
 	}
 	logrus.WithFields(logFields).Info("request received to get chaos experiment run stats")
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.ListWorkflowRuns],
+		authorization.ListWorkflowRuns,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
@@ -6,7 +6,6 @@ package graph
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -29,7 +28,7 @@ func (r *mutationResolver) RegisterInfra(ctx context.Context, projectID string,
 	logrus.WithFields(logFields).Info("request received for new a chaos infrastructure")
 
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.UserInfrastructureReg],
+		authorization.UserInfrastructureReg,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
@@ -58,7 +57,7 @@ func (r *mutationResolver) DeleteInfra(ctx context.Context, projectID string, in
 	logrus.WithFields(logFields).Info("request received to delete chaos infrastructure")
 
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.DeleteInfrastructures],
+		authorization.DeleteInfrastructures,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return "", err
@@ -99,6 +98,43 @@ func (r *mutationResolver) KubeObj(ctx context.Context, request model.KubeObject
 	return r.chaosInfrastructureService.KubeObj(request, *data_store.Store)
 }
 
+// AckInfraAction is the resolver for the ackInfraAction field.
+func (r *mutationResolver) AckInfraAction(ctx context.Context, request model.InfraActionAck) (string, error) {
+	return r.chaosInfrastructureService.AckInfraAction(request, *data_store.Store)
+}
+
+// InfraHeartbeat is the resolver for the infraHeartbeat field.
+func (r *mutationResolver) InfraHeartbeat(ctx context.Context, request model.InfraHeartbeatRequest) (string, error) {
+	return r.chaosInfrastructureService.InfraHeartbeat(request, *data_store.Store)
+}
+
+// UpgradeInfra is the resolver for the upgradeInfra field.
+func (r *mutationResolver) UpgradeInfra(ctx context.Context, projectID string, infraID string) (*model.InfraUpgrade, error) {
+	logFields := logrus.Fields{
+		"projectId":    projectID,
+		"chaosInfraId": infraID,
+	}
+	logrus.WithFields(logFields).Info("request received to upgrade chaos infrastructure")
+	err := authorization.ValidateRole(ctx, projectID,
+		authorization.UpgradeInfrastructure,
+		model.InvitationAccepted.String())
+	if err != nil {
+		return nil, err
+	}
+
+	upgrade, err := r.chaosInfrastructureService.UpgradeInfra(ctx, projectID, infraID, *data_store.Store)
+	if err != nil {
+		logrus.WithFields(logFields).Error(err)
+		return nil, err
+	}
+	return upgrade, nil
+}
+
+// UpdateInfraUpgradeStatus is the resolver for the updateInfraUpgradeStatus field.
+func (r *mutationResolver) UpdateInfraUpgradeStatus(ctx context.Context, request model.InfraUpgradeStatusRequest) (string, error) {
+	return r.chaosInfrastructureService.UpdateInfraUpgradeStatus(request, *data_store.Store)
+}
+
 // GetInfra is the resolver for the getInfra field.
 func (r *queryResolver) GetInfra(ctx context.Context, projectID string, infraID string) (*model.Infra, error) {
 	logFields := logrus.Fields{
@@ -107,7 +143,7 @@ func (r *queryResolver) GetInfra(ctx context.Context, projectID string, infraID
 	}
 	logrus.WithFields(logFields).Info("request received to get chaos infrastructure")
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.GetInfrastructure],
+		authorization.GetInfrastructure,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
@@ -127,7 +163,7 @@ func (r *queryResolver) ListInfras(ctx context.Context, projectID string, reques
 	}
 	logrus.WithFields(logFields).Info("request received to list chaos infrastructures")
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.ListInfrastructures],
+		authorization.ListInfrastructures,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
@@ -149,7 +185,7 @@ func (r *queryResolver) GetInfraDetails(ctx context.Context, infraID string, pro
 	}
 	logrus.WithFields(logFields).Info("request received to get chaos infrastructure details")
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.GetInfraDetails],
+		authorization.GetInfraDetails,
 		model.InvitationAccepted.String())
 
 	gcaResponse, err := r.chaosInfrastructureService.GetInfraDetails(ctx, infraID, projectID)
@@ -167,7 +203,7 @@ func (r *queryResolver) GetInfraManifest(ctx context.Context, infraID string, up
 	}
 	logrus.WithFields(logFields).Info("request received to get chaos infrastructure manifest")
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.GetManifest],
+		authorization.GetManifest,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return "", err
@@ -193,7 +229,7 @@ func (r *queryResolver) GetInfraStats(ctx context.Context, projectID string) (*m
 	}
 	logrus.WithFields(logFields).Info("request received to get chaos infrastructure stats")
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.GetInfraDetails],
+		authorization.GetInfraDetails,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
@@ -201,6 +237,37 @@ func (r *queryResolver) GetInfraStats(ctx context.Context, projectID string) (*m
 	return r.chaosInfrastructureService.GetInfraStats(ctx, projectID)
 }
 
+// ListInfraActions is the resolver for the listInfraActions field.
+func (r *queryResolver) ListInfraActions(ctx context.Context, projectID string, request *model.ListInfraActionsRequest) ([]*model.InfraActionStatus, error) {
+	logFields := logrus.Fields{
+		"projectId": projectID,
+	}
+	logrus.WithFields(logFields).Info("request received to list chaos infrastructure actions")
+	err := authorization.ValidateRole(ctx, projectID,
+		authorization.ListInfraActions,
+		model.InvitationAccepted.String())
+	if err != nil {
+		return nil, err
+	}
+	return r.chaosInfrastructureService.ListInfraActions(projectID, request, *data_store.Store)
+}
+
+// ListInfraUpgrades is the resolver for the listInfraUpgrades field.
+func (r *queryResolver) ListInfraUpgrades(ctx context.Context, projectID string, infraID string) ([]*model.InfraUpgrade, error) {
+	logFields := logrus.Fields{
+		"projectId":    projectID,
+		"chaosInfraId": infraID,
+	}
+	logrus.WithFields(logFields).Info("request received to list chaos infrastructure upgrades")
+	err := authorization.ValidateRole(ctx, projectID,
+		authorization.ListInfraUpgrades,
+		model.InvitationAccepted.String())
+	if err != nil {
+		return nil, err
+	}
+	return r.chaosInfrastructureService.ListInfraUpgrades(ctx, projectID, infraID)
+}
+
 // GetVersionDetails is the resolver for the getVersionDetails field.
 func (r *queryResolver) GetVersionDetails(ctx context.Context, projectID string) (*model.InfraVersionDetails, error) {
 	return r.chaosInfrastructureService.GetVersionDetails()
@@ -216,12 +283,11 @@ func (r *subscriptionResolver) GetInfraEvents(ctx context.Context, projectID str
 	logrus.Print("NEW EVENT ", projectID)
 	infraEvent := make(chan *model.InfraEventResponse, 1)
 
-	data_store.Store.Mutex.Lock()
-	data_store.Store.InfraEventPublish[projectID] = append(data_store.Store.InfraEventPublish[projectID], infraEvent)
-	data_store.Store.Mutex.Unlock()
+	unsubscribe := data_store.Store.SubscribeInfraEvents(projectID, infraEvent)
 
 	go func() {
 		<-ctx.Done()
+		unsubscribe()
 	}()
 
 	return infraEvent, nil
@@ -231,20 +297,34 @@ func (r *subscriptionResolver) GetInfraEvents(ctx context.Context, projectID str
 func (r *subscriptionResolver) InfraConnect(ctx context.Context, request model.InfraIdentity) (<-chan *model.InfraActionResponse, error) {
 	logrus.Print("NEW CLUSTER CONNECT: ", request.InfraID)
 	infraAction := make(chan *model.InfraActionResponse, 1)
-	verifiedInfra, err := r.chaosInfrastructureService.VerifyInfra(request)
+	// the infras of the compatible versions can connect, the ones of a previous release to report their version
+	verifiedInfra, err := r.chaosInfrastructureService.VerifyUpgradingInfra(request)
 	if err != nil {
 		logrus.Print("VALIDATION FAILED: ", request.InfraID)
 		return infraAction, err
 	}
-	data_store.Store.Mutex.Lock()
-	if _, ok := data_store.Store.ConnectedInfra[request.InfraID]; ok {
-		data_store.Store.Mutex.Unlock()
-		return infraAction, errors.New("CLUSTER ALREADY CONNECTED")
+	legacy := chaos_infrastructure.IsLegacyInfraVersion(request.Version)
+	if err := data_store.Store.ConnectInfra(ctx, request.InfraID, infraAction, legacy); err != nil {
+		return infraAction, err
+	}
+	// the infras of a previous release can't run the operations of this release nor upgrade themselves, they
+	// aren't active until their upgrade manifest is applied
+	if legacy {
+		logrus.Printf("LEGACY CLUSTER CONNECTED: %s, apply its upgrade manifest to upgrade it from version %s", request.InfraID, request.Version)
+		go func() {
+			<-ctx.Done()
+			data_store.Store.DisconnectInfra(request.InfraID, infraAction)
+		}()
+		query := bson.D{{"infra_id", request.InfraID}}
+		update := bson.D{{"$set", bson.D{{"is_active", false}, {"updated_at", time.Now().UnixMilli()}, {"version", request.Version}}}}
+		return infraAction, r.chaosInfrastructureService.UpdateInfra(query, update)
 	}
-	data_store.Store.ConnectedInfra[request.InfraID] = infraAction
-	data_store.Store.Mutex.Unlock()
 	go func() {
 		<-ctx.Done()
+		// the subscription was dropped as stale, the infra may have connected again since
+		if !data_store.Store.DisconnectInfra(request.InfraID, infraAction) {
+			return
+		}
 		verifiedInfra.IsActive = false
 
 		newVerifiedInfra := model.Infra{}
@@ -252,9 +332,6 @@ func (r *subscriptionResolver) InfraConnect(ctx context.Context, request model.I
 
 		r.chaosInfrastructureService.SendInfraEvent("infra-status", "Infra Offline", "Infra Disconnect", newVerifiedInfra, *data_store.Store)
 
-		data_store.Store.Mutex.Lock()
-		delete(data_store.Store.ConnectedInfra, request.InfraID)
-		data_store.Store.Mutex.Unlock()
 		query := bson.D{{"infra_id", request.InfraID}}
 		update := bson.D{{"$set", bson.D{{"is_active", false}, {"updated_at", time.Now().UnixMilli()}}}}
 
@@ -285,30 +362,23 @@ func (r *subscriptionResolver) GetPodLog(ctx context.Context, request model.PodL
 	logrus.Print("NEW LOG REQUEST: ", request.InfraID, request.PodName)
 	workflowLog := make(chan *model.PodLogResponse, 1)
 	reqID := uuid.New()
-	data_store.Store.Mutex.Lock()
-	data_store.Store.ExperimentLog[reqID.String()] = workflowLog
-	data_store.Store.Mutex.Unlock()
 	go func() {
-		<-ctx.Done()
+		r.chaosExperimentHandler.StreamLogs(ctx, reqID.String(), request, workflowLog, *data_store.Store)
 		logrus.Print("CLOSED LOG LISTENER: ", request.InfraID, request.PodName)
-		delete(data_store.Store.ExperimentLog, reqID.String())
 	}()
-	go r.chaosExperimentHandler.GetLogs(reqID.String(), request, *data_store.Store)
 	return workflowLog, nil
 }
 
 // GetKubeObject is the resolver for the getKubeObject field.
 func (r *subscriptionResolver) GetKubeObject(ctx context.Context, request model.KubeObjectRequest) (<-chan *model.KubeObjectResponse, error) {
 	logrus.Print("NEW KUBEOBJECT REQUEST", request.InfraID)
-	kubeObjData := make(chan *model.KubeObjectResponse)
+	kubeObjData := make(chan *model.KubeObjectResponse, 1)
 	reqID := uuid.New()
-	data_store.Store.Mutex.Lock()
-	data_store.Store.KubeObjectData[reqID.String()] = kubeObjData
-	data_store.Store.Mutex.Unlock()
+	unsubscribe := data_store.Store.SubscribeKubeObject(reqID.String(), kubeObjData)
 	go func() {
 		<-ctx.Done()
 		logrus.Println("Closed KubeObj Listener")
-		delete(data_store.Store.KubeObjectData, reqID.String())
+		unsubscribe()
 	}()
 	go r.chaosExperimentHandler.GetKubeObjData(reqID.String(), request, *data_store.Store)
 
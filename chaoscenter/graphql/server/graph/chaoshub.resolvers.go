@@ -10,47 +10,78 @@ import (
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/authorization"
 	chaosHubOps "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/chaoshub/ops"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/gitops"
 )
 
 // AddChaosHub is the resolver for the addChaosHub field.
 func (r *mutationResolver) AddChaosHub(ctx context.Context, projectID string, request model.CreateChaosHubRequest) (*model.ChaosHub, error) {
 	if err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.AddChaosHub],
+		authorization.AddChaosHub,
 		model.InvitationAccepted.String()); err != nil {
 		return nil, err
 	}
 
-	return r.chaosHubService.AddChaosHub(ctx, request, projectID)
+	chaosHub, err := r.chaosHubService.AddChaosHub(ctx, request, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gitops Update
+	err = r.gitopsService.UpsertResourceToGit(ctx, projectID, gitops.ResourceKindChaosHub, chaosHub.Name, chaosHub)
+	if err != nil {
+		return nil, err
+	}
+	return chaosHub, nil
 }
 
 // AddRemoteChaosHub is the resolver for the addRemoteChaosHub field.
 func (r *mutationResolver) AddRemoteChaosHub(ctx context.Context, projectID string, request model.CreateRemoteChaosHub) (*model.ChaosHub, error) {
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.SaveChaosHub],
+		authorization.SaveChaosHub,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
 	}
 
-	return r.chaosHubService.AddRemoteChaosHub(ctx, request, projectID)
+	chaosHub, err := r.chaosHubService.AddRemoteChaosHub(ctx, request, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gitops Update
+	err = r.gitopsService.UpsertResourceToGit(ctx, projectID, gitops.ResourceKindChaosHub, chaosHub.Name, chaosHub)
+	if err != nil {
+		return nil, err
+	}
+	return chaosHub, nil
 }
 
 // SaveChaosHub is the resolver for the saveChaosHub field.
 func (r *mutationResolver) SaveChaosHub(ctx context.Context, projectID string, request model.CreateChaosHubRequest) (*model.ChaosHub, error) {
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.SaveChaosHub],
+		authorization.SaveChaosHub,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
 	}
 
-	return r.chaosHubService.SaveChaosHub(ctx, request, projectID)
+	chaosHub, err := r.chaosHubService.SaveChaosHub(ctx, request, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gitops Update
+	err = r.gitopsService.UpsertResourceToGit(ctx, projectID, gitops.ResourceKindChaosHub, chaosHub.Name, chaosHub)
+	if err != nil {
+		return nil, err
+	}
+	return chaosHub, nil
 }
 
 // SyncChaosHub is the resolver for the syncChaosHub field.
 func (r *mutationResolver) SyncChaosHub(ctx context.Context, id string, projectID string) (string, error) {
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.UpdateChaosExperiment],
+		authorization.UpdateChaosExperiment,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return "", err
@@ -74,29 +105,64 @@ func (r *mutationResolver) GenerateSSHKey(ctx context.Context) (*model.SSHKey, e
 // UpdateChaosHub is the resolver for the updateChaosHub field.
 func (r *mutationResolver) UpdateChaosHub(ctx context.Context, projectID string, request model.UpdateChaosHubRequest) (*model.ChaosHub, error) {
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.UpdateChaosHub],
+		authorization.UpdateChaosHub,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
 	}
-	return r.chaosHubService.UpdateChaosHub(ctx, request, projectID)
+	prevChaosHub, err := r.chaosHubService.GetChaosHub(ctx, request.ID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	chaosHub, err := r.chaosHubService.UpdateChaosHub(ctx, request, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gitops Update
+	chaosHub.HubType = prevChaosHub.HubType
+	err = r.gitopsService.UpsertResourceToGit(ctx, projectID, gitops.ResourceKindChaosHub, chaosHub.Name, chaosHub)
+	if err != nil {
+		return nil, err
+	}
+	if prevChaosHub.Name != chaosHub.Name {
+		err = r.gitopsService.DeleteResourceFromGit(ctx, projectID, gitops.ResourceKindChaosHub, prevChaosHub.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return chaosHub, nil
 }
 
 // DeleteChaosHub is the resolver for the deleteChaosHub field.
 func (r *mutationResolver) DeleteChaosHub(ctx context.Context, projectID string, hubID string) (bool, error) {
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.DeleteChaosHub],
+		authorization.DeleteChaosHub,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return false, err
 	}
-	return r.chaosHubService.DeleteChaosHub(ctx, hubID, projectID)
+	chaosHub, err := r.chaosHubService.GetChaosHub(ctx, hubID, projectID)
+	if err != nil {
+		return false, err
+	}
+	deleted, err := r.chaosHubService.DeleteChaosHub(ctx, hubID, projectID)
+	if err != nil {
+		return false, err
+	}
+
+	// Gitops Update
+	err = r.gitopsService.DeleteResourceFromGit(ctx, projectID, gitops.ResourceKindChaosHub, chaosHub.Name)
+	if err != nil {
+		return false, err
+	}
+	return deleted, nil
 }
 
 // ListChaosFaults is the resolver for the listChaosFaults field.
 func (r *queryResolver) ListChaosFaults(ctx context.Context, hubID string, projectID string) ([]*model.Chart, error) {
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.ListCharts],
+		authorization.ListCharts,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
@@ -9,6 +9,7 @@ import (
 
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/authorization"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/gitops"
 	"github.com/sirupsen/logrus"
 )
 
@@ -19,12 +20,23 @@ func (r *mutationResolver) CreateEnvironment(ctx context.Context, projectID stri
 	}
 	logrus.WithFields(logFields).Info("request received to create new environment")
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.CreateEnvironment],
+		authorization.CreateEnvironment,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
 	}
-	return r.environmentService.CreateEnvironment(ctx, projectID, request)
+	environment, err := r.environmentService.CreateEnvironment(ctx, projectID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gitops Update
+	err = r.gitopsService.UpsertResourceToGit(ctx, projectID, gitops.ResourceKindEnvironment, request.EnvironmentID, environment)
+	if err != nil {
+		logrus.WithFields(logFields).Errorf("failed to push the environment to Git, err: %v", err)
+		return nil, err
+	}
+	return environment, nil
 }
 
 // UpdateEnvironment is the resolver for the updateEnvironment field.
@@ -35,12 +47,27 @@ func (r *mutationResolver) UpdateEnvironment(ctx context.Context, projectID stri
 	}
 	logrus.WithFields(logFields).Info("request received to update environment")
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.UpdateEnvironment],
+		authorization.UpdateEnvironment,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return "", err
 	}
-	return r.environmentService.UpdateEnvironment(ctx, projectID, request)
+	response, err := r.environmentService.UpdateEnvironment(ctx, projectID, request)
+	if err != nil {
+		return response, err
+	}
+
+	// Gitops Update
+	environment, err := r.environmentService.GetEnvironment(projectID, request.EnvironmentID)
+	if err != nil {
+		return "", err
+	}
+	err = r.gitopsService.UpsertResourceToGit(ctx, projectID, gitops.ResourceKindEnvironment, request.EnvironmentID, environment)
+	if err != nil {
+		logrus.WithFields(logFields).Errorf("failed to push the environment to Git, err: %v", err)
+		return "", err
+	}
+	return response, nil
 }
 
 // DeleteEnvironment is the resolver for the deleteEnvironment field.
@@ -51,12 +78,23 @@ func (r *mutationResolver) DeleteEnvironment(ctx context.Context, projectID stri
 	}
 	logrus.WithFields(logFields).Info("request received to delete environment")
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.DeleteEnvironment],
+		authorization.DeleteEnvironment,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return "", err
 	}
-	return r.environmentService.DeleteEnvironment(ctx, projectID, environmentID)
+	response, err := r.environmentService.DeleteEnvironment(ctx, projectID, environmentID)
+	if err != nil {
+		return response, err
+	}
+
+	// Gitops Update
+	err = r.gitopsService.DeleteResourceFromGit(ctx, projectID, gitops.ResourceKindEnvironment, environmentID)
+	if err != nil {
+		logrus.WithFields(logFields).Errorf("failed to delete the environment from Git, err: %v", err)
+		return "", err
+	}
+	return response, nil
 }
 
 // GetEnvironment is the resolver for the getEnvironment field.
@@ -67,7 +105,7 @@ func (r *queryResolver) GetEnvironment(ctx context.Context, projectID string, en
 	}
 	logrus.WithFields(logFields).Info("request received to get environment")
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.GetEnvironment],
+		authorization.GetEnvironment,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
@@ -82,7 +120,7 @@ func (r *queryResolver) ListEnvironments(ctx context.Context, projectID string,
 	}
 	logrus.WithFields(logFields).Info("request received to list environments")
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.ListEnvironments],
+		authorization.ListEnvironments,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
@@ -25,7 +25,7 @@ func (r *mutationResolver) GitopsNotifier(ctx context.Context, clusterInfo model
 // EnableGitOps is the resolver for the enableGitOps field.
 func (r *mutationResolver) EnableGitOps(ctx context.Context, projectID string, configurations model.GitConfig) (bool, error) {
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.EnableGitOps],
+		authorization.EnableGitOps,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return false, err
@@ -36,7 +36,7 @@ func (r *mutationResolver) EnableGitOps(ctx context.Context, projectID string, c
 // DisableGitOps is the resolver for the disableGitOps field.
 func (r *mutationResolver) DisableGitOps(ctx context.Context, projectID string) (bool, error) {
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.DisableGitOps],
+		authorization.DisableGitOps,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return false, err
@@ -47,7 +47,7 @@ func (r *mutationResolver) DisableGitOps(ctx context.Context, projectID string)
 // UpdateGitOps is the resolver for the updateGitOps field.
 func (r *mutationResolver) UpdateGitOps(ctx context.Context, projectID string, configurations model.GitConfig) (bool, error) {
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.UpdateGitOps],
+		authorization.UpdateGitOps,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return false, err
@@ -55,10 +55,21 @@ func (r *mutationResolver) UpdateGitOps(ctx context.Context, projectID string, c
 	return r.gitopsService.UpdateGitOpsDetailsHandler(ctx, projectID, configurations)
 }
 
+// ResolveGitOpsConflict is the resolver for the resolveGitOpsConflict field.
+func (r *mutationResolver) ResolveGitOpsConflict(ctx context.Context, projectID string, conflictID string, resolution model.GitOpsConflictResolution) (bool, error) {
+	err := authorization.ValidateRole(ctx, projectID,
+		authorization.ResolveGitOpsConflict,
+		model.InvitationAccepted.String())
+	if err != nil {
+		return false, err
+	}
+	return r.gitopsService.ResolveGitOpsConflict(ctx, projectID, conflictID, resolution)
+}
+
 // GetGitOpsDetails is the resolver for the getGitOpsDetails field.
 func (r *queryResolver) GetGitOpsDetails(ctx context.Context, projectID string) (*model.GitConfigResponse, error) {
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.GetGitOpsDetails],
+		authorization.GetGitOpsDetails,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
@@ -66,3 +77,15 @@ func (r *queryResolver) GetGitOpsDetails(ctx context.Context, projectID string)
 
 	return r.gitopsService.GetGitOpsDetails(ctx, projectID)
 }
+
+// ListGitOpsConflicts is the resolver for the listGitOpsConflicts field.
+func (r *queryResolver) ListGitOpsConflicts(ctx context.Context, projectID string) ([]*model.GitOpsConflict, error) {
+	err := authorization.ValidateRole(ctx, projectID,
+		authorization.ListGitOpsConflicts,
+		model.InvitationAccepted.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return r.gitopsService.ListGitOpsConflicts(ctx, projectID)
+}
@@ -9,13 +9,14 @@ import (
 
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/authorization"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/gitops"
 	"github.com/sirupsen/logrus"
 )
 
 // CreateImageRegistry is the resolver for the createImageRegistry field.
 func (r *mutationResolver) CreateImageRegistry(ctx context.Context, projectID string, imageRegistryInfo model.ImageRegistryInput) (*model.ImageRegistryResponse, error) {
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.CreateImageRegistry],
+		authorization.CreateImageRegistry,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
@@ -24,14 +25,22 @@ func (r *mutationResolver) CreateImageRegistry(ctx context.Context, projectID st
 	ciResponse, err := r.imageRegistryService.CreateImageRegistry(ctx, projectID, imageRegistryInfo)
 	if err != nil {
 		logrus.Error(err)
+		return ciResponse, err
 	}
-	return ciResponse, err
+
+	// Gitops Update
+	err = r.gitopsService.UpsertResourceToGit(ctx, projectID, gitops.ResourceKindImageRegistry, gitops.ImageRegistryResourceName, imageRegistryInfo)
+	if err != nil {
+		logrus.Errorf("failed to push the image registry to Git, err: %v", err)
+		return nil, err
+	}
+	return ciResponse, nil
 }
 
 // UpdateImageRegistry is the resolver for the updateImageRegistry field.
 func (r *mutationResolver) UpdateImageRegistry(ctx context.Context, imageRegistryID string, projectID string, imageRegistryInfo model.ImageRegistryInput) (*model.ImageRegistryResponse, error) {
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.UpdateImageRegistry],
+		authorization.UpdateImageRegistry,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
@@ -40,15 +49,22 @@ func (r *mutationResolver) UpdateImageRegistry(ctx context.Context, imageRegistr
 	uiRegistry, err := r.imageRegistryService.UpdateImageRegistry(ctx, imageRegistryID, projectID, imageRegistryInfo)
 	if err != nil {
 		logrus.Error(err)
+		return uiRegistry, err
 	}
 
-	return uiRegistry, err
+	// Gitops Update
+	err = r.gitopsService.UpsertResourceToGit(ctx, projectID, gitops.ResourceKindImageRegistry, gitops.ImageRegistryResourceName, imageRegistryInfo)
+	if err != nil {
+		logrus.Errorf("failed to push the image registry to Git, err: %v", err)
+		return nil, err
+	}
+	return uiRegistry, nil
 }
 
 // DeleteImageRegistry is the resolver for the deleteImageRegistry field.
 func (r *mutationResolver) DeleteImageRegistry(ctx context.Context, imageRegistryID string, projectID string) (string, error) {
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.DeleteImageRegistry],
+		authorization.DeleteImageRegistry,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return "", err
@@ -57,15 +73,22 @@ func (r *mutationResolver) DeleteImageRegistry(ctx context.Context, imageRegistr
 	diRegistry, err := r.imageRegistryService.DeleteImageRegistry(ctx, imageRegistryID, projectID)
 	if err != nil {
 		logrus.Error(err)
+		return diRegistry, err
 	}
 
-	return diRegistry, err
+	// Gitops Update
+	err = r.gitopsService.DeleteResourceFromGit(ctx, projectID, gitops.ResourceKindImageRegistry, gitops.ImageRegistryResourceName)
+	if err != nil {
+		logrus.Errorf("failed to delete the image registry from Git, err: %v", err)
+		return "", err
+	}
+	return diRegistry, nil
 }
 
 // ListImageRegistry is the resolver for the listImageRegistry field.
 func (r *queryResolver) ListImageRegistry(ctx context.Context, projectID string) ([]*model.ImageRegistryResponse, error) {
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.ListImageRegistry],
+		authorization.ListImageRegistry,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
@@ -82,7 +105,7 @@ func (r *queryResolver) ListImageRegistry(ctx context.Context, projectID string)
 // GetImageRegistry is the resolver for the getImageRegistry field.
 func (r *queryResolver) GetImageRegistry(ctx context.Context, projectID string) (*model.ImageRegistryResponse, error) {
 	err := authorization.ValidateRole(ctx, projectID,
-		authorization.MutationRbacRules[authorization.GetImageRegistry],
+		authorization.GetImageRegistry,
 		model.InvitationAccepted.String())
 	if err != nil {
 		return nil, err
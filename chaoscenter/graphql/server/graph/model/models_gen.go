@@ -44,6 +44,26 @@ type ResourceDetails interface {
 	GetTags() []string
 }
 
+// Defines the criteria an experiment run has to meet to pass its gate
+type AcceptanceCriteria struct {
+	// Minimum resiliency score of the experiment run
+	MinResiliencyScore *float64 `json:"minResiliencyScore,omitempty"`
+	// Names of the faults which must pass
+	RequiredFaults []string `json:"requiredFaults,omitempty"`
+	// Maximum number of probes which may fail across all the faults
+	MaxFailedProbes *int `json:"maxFailedProbes,omitempty"`
+}
+
+// Defines the criteria an experiment run has to meet to pass its gate
+type AcceptanceCriteriaInput struct {
+	// Minimum resiliency score of the experiment run
+	MinResiliencyScore *float64 `json:"minResiliencyScore,omitempty"`
+	// Names of the faults which must pass
+	RequiredFaults []string `json:"requiredFaults,omitempty"`
+	// Maximum number of probes which may fail across all the faults
+	MaxFailedProbes *int `json:"maxFailedProbes,omitempty"`
+}
+
 type ActionPayload struct {
 	RequestID    string  `json:"requestID"`
 	RequestType  string  `json:"requestType"`
@@ -62,6 +82,50 @@ type Annotation struct {
 	ChartDescription string `json:"chartDescription"`
 }
 
+// Details of the user who performed an audited action
+type AuditActor struct {
+	UserID   *string `json:"userID,omitempty"`
+	Username *string `json:"username,omitempty"`
+	ClientIP *string `json:"clientIP,omitempty"`
+	// Bool value indicating whether the action was authenticated with an API token
+	APIToken bool `json:"apiToken"`
+}
+
+// Immutable record of a mutating action
+type AuditLog struct {
+	AuditID string `json:"auditID"`
+	// Time of the action in milliseconds
+	Timestamp string      `json:"timestamp"`
+	Source    AuditSource `json:"source"`
+	Actor     *AuditActor `json:"actor"`
+	ProjectID *string     `json:"projectID,omitempty"`
+	// Name of the mutation or of the authentication API
+	Operation string `json:"operation"`
+	// ID of the resource the action was performed on
+	TargetID *string `json:"targetID,omitempty"`
+	// JSON of the target before the action, with the secrets redacted
+	Before *string `json:"before,omitempty"`
+	// JSON of the target after the action, with the secrets redacted
+	After   *string      `json:"after,omitempty"`
+	Outcome AuditOutcome `json:"outcome"`
+	Error   *string      `json:"error,omitempty"`
+}
+
+// Defines the filters of the audit logs
+type AuditLogFilterInput struct {
+	// Names of the operations
+	Operations []string `json:"operations,omitempty"`
+	// Username or ID of the actor
+	Actor    *string       `json:"actor,omitempty"`
+	TargetID *string       `json:"targetID,omitempty"`
+	Outcome  *AuditOutcome `json:"outcome,omitempty"`
+	Source   *AuditSource  `json:"source,omitempty"`
+	// Start of the time range in milliseconds
+	StartTime *string `json:"startTime,omitempty"`
+	// End of the time range in milliseconds
+	EndTime *string `json:"endTime,omitempty"`
+}
+
 // Defines the input for CMD probe properties
 type CMDProbeRequest struct {
 	// Timeout of the Probe
@@ -112,6 +176,16 @@ type ChaosExperimentRequest struct {
 	InfraID string `json:"infraID"`
 	// Tags of the infra
 	Tags []string `json:"tags,omitempty"`
+	// Strategy used to calculate the resiliency score, defaults to WeightedProbeSuccess
+	ScoringStrategy *ResiliencyScoringStrategyInput `json:"scoringStrategy,omitempty"`
+	// Criteria the experiment runs have to meet to pass their gate
+	AcceptanceCriteria *AcceptanceCriteriaInput `json:"acceptanceCriteria,omitempty"`
+	// Removes the scoring strategy of the experiment, which falls back to WeightedProbeSuccess. An update without
+	// a scoring strategy keeps the one of the experiment otherwise
+	ClearScoringStrategy *bool `json:"clearScoringStrategy,omitempty"`
+	// Removes the acceptance criteria of the experiment. An update without acceptance criteria keeps the ones of
+	// the experiment otherwise
+	ClearAcceptanceCriteria *bool `json:"clearAcceptanceCriteria,omitempty"`
 }
 
 // Defines the response received for querying the details of chaos experiment
@@ -166,6 +240,8 @@ type ChaosHub struct {
 	Password *string `json:"password,omitempty"`
 	// Private SSH key for authenticating into private chaos hub
 	SSHPrivateKey *string `json:"sshPrivateKey,omitempty"`
+	// Digest of the OCI artifact the hub was last pulled from
+	Digest *string `json:"digest,omitempty"`
 	// Bool value indicating if the chaos hub is removed
 	IsRemoved bool `json:"isRemoved"`
 	// Timestamp when the chaos hub was created
@@ -241,6 +317,8 @@ type ChaosHubStatus struct {
 	SSHPublicKey *string `json:"sshPublicKey,omitempty"`
 	// Timestamp when the chaos hub was last synced
 	LastSyncedAt string `json:"lastSyncedAt"`
+	// Digest of the OCI artifact the hub was last pulled from
+	Digest *string `json:"digest,omitempty"`
 	// Tags of the ChaosHub
 	Tags []string `json:"tags,omitempty"`
 	// User who created the ChaosHub
@@ -368,6 +446,21 @@ type CreateEnvironmentRequest struct {
 	Tags          []string        `json:"tags,omitempty"`
 }
 
+type CreateNotificationChannelRequest struct {
+	Name        string                  `json:"name"`
+	Description *string                 `json:"description,omitempty"`
+	Tags        []string                `json:"tags,omitempty"`
+	Type        NotificationChannelType `json:"type"`
+	Enabled     *bool                   `json:"enabled,omitempty"`
+	// Required if the type is Webhook
+	Webhook *WebhookConfigInput `json:"webhook,omitempty"`
+	// Required if the type is Slack
+	Slack *SlackConfigInput `json:"slack,omitempty"`
+	// Required if the type is SMTP
+	SMTP   *SMTPConfigInput         `json:"smtp,omitempty"`
+	Filter *NotificationFilterInput `json:"filter,omitempty"`
+}
+
 type CreateRemoteChaosHub struct {
 	// Name of the chaos hub
 	Name string `json:"name"`
@@ -375,8 +468,11 @@ type CreateRemoteChaosHub struct {
 	Tags []string `json:"tags,omitempty"`
 	// Description of ChaosHub
 	Description *string `json:"description,omitempty"`
-	// URL of the git repository
+	// URL of the hub zip, or the reference of the OCI artifact of an OCI hub, with a tag or a digest like
+	// registry.example.com/hubs/chaos-charts:3.0 or registry.example.com/hubs/chaos-charts@sha256:<digest>
 	RepoURL string `json:"repoURL"`
+	// Type of the hub, REMOTE by default
+	HubType *HubType `json:"hubType,omitempty"`
 }
 
 // Defines the start date and end date for the filtering the data
@@ -501,6 +597,10 @@ type Experiment struct {
 	RecentExperimentRunDetails []*RecentExperimentRun `json:"recentExperimentRunDetails,omitempty"`
 	// Details of the user who updated the experiment
 	UpdatedBy *UserDetails `json:"updatedBy,omitempty"`
+	// Strategy used to calculate the resiliency score of the experiment runs
+	ScoringStrategy *ResiliencyScoringStrategy `json:"scoringStrategy,omitempty"`
+	// Criteria the experiment runs have to meet to pass their gate
+	AcceptanceCriteria *AcceptanceCriteria `json:"acceptanceCriteria,omitempty"`
 }
 
 func (Experiment) IsResourceDetails()           {}
@@ -608,6 +708,10 @@ type ExperimentRun struct {
 	NotifyID *string `json:"notifyID,omitempty"`
 	// runSequence is the sequence number of experiment run
 	RunSequence int `json:"runSequence"`
+	// Verdict of the acceptance criteria, available once the experiment run is completed
+	GateVerdict *GateVerdict `json:"gateVerdict,omitempty"`
+	// Reasons why the experiment run failed its acceptance criteria
+	GateVerdictReasons []string `json:"gateVerdictReasons,omitempty"`
 }
 
 func (ExperimentRun) IsAudit()                        {}
@@ -658,6 +762,9 @@ type ExperimentRunRequest struct {
 	IsRemoved *bool `json:"isRemoved,omitempty"`
 	// User who has updated the experiment
 	UpdatedBy string `json:"updatedBy"`
+	// Monotonically increasing sequence number of the event, used to discard
+	// duplicate and out of order events sent by the subscriber
+	EventSequence *int64 `json:"eventSequence,omitempty"`
 }
 
 // Defines sorting options for experiment runs
@@ -809,6 +916,15 @@ type GitConfig struct {
 	Password *string `json:"password,omitempty"`
 	// Private SSH key authenticating into git repository
 	SSHPrivateKey *string `json:"sshPrivateKey,omitempty"`
+	// Interval in seconds at which the repository is polled when no push webhook is received, defaults to 120
+	PollInterval *int `json:"pollInterval,omitempty"`
+	// Defines how the changes are written to the repository, defaults to DirectPush
+	WriteMode *GitOpsWriteMode `json:"writeMode,omitempty"`
+	// Git hosting service of the repository, required by the PullRequest write mode which authenticates to its
+	// API with the token or the password
+	Provider *GitOpsProvider `json:"provider,omitempty"`
+	// Base URL of the API of a self-hosted provider, it is derived from the repository URL by default
+	ProviderAPIURL *string `json:"providerAPIURL,omitempty"`
 }
 
 // Response received after configuring GitOps
@@ -831,6 +947,57 @@ type GitConfigResponse struct {
 	Password *string `json:"password,omitempty"`
 	// Private SSH key authenticating into git repository
 	SSHPrivateKey *string `json:"sshPrivateKey,omitempty"`
+	// Interval in seconds at which the repository is polled when no push webhook is received
+	PollInterval *int `json:"pollInterval,omitempty"`
+	// Secret of the push webhook served at /gitops/webhook/{projectID}, used as the HMAC secret by GitHub,
+	// Gitea and Bitbucket and as the secret token by GitLab
+	WebhookSecret *string `json:"webhookSecret,omitempty"`
+	// Time of the last sync of the repository in milliseconds
+	LastSyncTime *string `json:"lastSyncTime,omitempty"`
+	// Result of the last sync of the repository
+	LastSyncStatus *GitOpsSyncStatus `json:"lastSyncStatus,omitempty"`
+	// Error of the last sync of the repository if it failed
+	LastSyncError  *string          `json:"lastSyncError,omitempty"`
+	WriteMode      *GitOpsWriteMode `json:"writeMode,omitempty"`
+	Provider       *GitOpsProvider  `json:"provider,omitempty"`
+	ProviderAPIURL *string          `json:"providerAPIURL,omitempty"`
+	// Changes pending review in the PullRequest write mode, they are cleared once their pull requests are merged
+	// and synced back, or closed
+	PendingChanges []*GitOpsPendingChange `json:"pendingChanges,omitempty"`
+}
+
+// Experiment edited both in ChaosCenter and in the repository between two syncs, whose changes could not be
+// merged, or whose change made in ChaosCenter had its pull request closed without being merged. An empty manifest
+// is an experiment missing on that side
+type GitOpsConflict struct {
+	ConflictID     string `json:"conflictID"`
+	ExperimentID   string `json:"experimentID"`
+	ExperimentName string `json:"experimentName"`
+	// Path of the experiment file in the repository
+	File string `json:"file"`
+	// Paths of the manifest fields changed differently on both sides
+	ConflictingPaths []string `json:"conflictingPaths"`
+	// Manifest of the last sync both sides were edited from
+	BaseManifest        string `json:"baseManifest"`
+	ChaosCenterManifest string `json:"chaosCenterManifest"`
+	GitManifest         string `json:"gitManifest"`
+	// Time of the detection of the conflict in milliseconds
+	CreatedAt string `json:"createdAt"`
+}
+
+// Change made in ChaosCenter waiting for the review of its pull request
+type GitOpsPendingChange struct {
+	// Branch the change was pushed to
+	Branch string `json:"branch"`
+	// Path of the changed file in the repository
+	File string `json:"file"`
+	// Title of the pull request
+	Title             string `json:"title"`
+	PullRequestNumber int    `json:"pullRequestNumber"`
+	PullRequestURL    string `json:"pullRequestURL"`
+	// Time of the change in milliseconds
+	CreatedAt string  `json:"createdAt"`
+	CreatedBy *string `json:"createdBy,omitempty"`
 }
 
 // Defines the input for HTTP probe properties
@@ -978,6 +1145,10 @@ type Infra struct {
 	InfraType *InfrastructureType `json:"infraType,omitempty"`
 	// update status of infra
 	UpdateStatus UpdateStatus `json:"updateStatus"`
+	// Timestamp of the last heartbeat sent by the infra
+	LastHeartbeat *string `json:"lastHeartbeat,omitempty"`
+	// Health of the infra and its components reported in its heartbeats
+	Health *InfraHealth `json:"health,omitempty"`
 }
 
 func (Infra) IsResourceDetails()           {}
@@ -1000,11 +1171,65 @@ func (this Infra) GetCreatedAt() *string      { return &this.CreatedAt }
 func (this Infra) GetUpdatedBy() *UserDetails { return this.UpdatedBy }
 func (this Infra) GetCreatedBy() *UserDetails { return this.CreatedBy }
 
+// Defines the acknowledgement of an action by the infra
+type InfraActionAck struct {
+	// Identity of the infra
+	InfraID *InfraIdentity `json:"infraID"`
+	// ID of the action
+	RequestID string `json:"requestID"`
+	// State of the action, Applied or Failed
+	State InfraActionState `json:"state"`
+	// Error of the action if it failed
+	Error *string `json:"error,omitempty"`
+}
+
 type InfraActionResponse struct {
 	ProjectID string         `json:"projectID"`
 	Action    *ActionPayload `json:"action"`
 }
 
+// Defines the status of an action sent to an infra
+type InfraActionStatus struct {
+	// ID of the action
+	RequestID string `json:"requestID"`
+	// ID of the infra the action is sent to
+	InfraID string `json:"infraID"`
+	// Type of the action, like create, delete or workflow_run_stop
+	RequestType string `json:"requestType"`
+	// Data of the action, like the experiment runs to stop
+	ExternalData *string `json:"externalData,omitempty"`
+	// Delivery state of the action
+	State InfraActionState `json:"state"`
+	// Error reported by the infra if the action failed
+	Error *string `json:"error,omitempty"`
+	// User who requested the action
+	Username *string `json:"username,omitempty"`
+	// Timestamp when the action was requested
+	CreatedAt string `json:"createdAt"`
+	// Timestamp when the state of the action last changed
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// Defines the health of a component of an infra
+type InfraComponentHealth struct {
+	// Name of the component
+	Name string `json:"name"`
+	// Boolean value indicating if the component is running and ready
+	Healthy bool `json:"healthy"`
+	// Reason why the component isn't healthy
+	Message *string `json:"message,omitempty"`
+}
+
+// Defines the health of a component of an infra sent in its heartbeats
+type InfraComponentHealthRequest struct {
+	// Name of the component
+	Name string `json:"name"`
+	// Boolean value indicating if the component is running and ready
+	Healthy bool `json:"healthy"`
+	// Reason why the component isn't healthy
+	Message *string `json:"message,omitempty"`
+}
+
 type InfraEventResponse struct {
 	EventID     string `json:"eventID"`
 	EventType   string `json:"eventType"`
@@ -1031,12 +1256,74 @@ type InfraFilterInput struct {
 	Tags []*string `json:"tags,omitempty"`
 }
 
+// Defines the health of an infra reported in its heartbeats
+type InfraHealth struct {
+	// Boolean value indicating if the infra sends its heartbeats and all its components are healthy
+	IsHealthy bool `json:"isHealthy"`
+	// Number of nodes in the cluster of the infra
+	NodeCount *int `json:"nodeCount,omitempty"`
+	// Kubernetes version of the cluster of the infra
+	K8sVersion *string `json:"k8sVersion,omitempty"`
+	// Health of the components of the infra
+	Components []*InfraComponentHealth `json:"components"`
+}
+
+// Defines the heartbeat sent periodically by an infra
+type InfraHeartbeatRequest struct {
+	// ID of the infra
+	InfraID *InfraIdentity `json:"infraID"`
+	// Health of the components of the infra
+	Components []*InfraComponentHealthRequest `json:"components"`
+	// Number of nodes in the cluster of the infra
+	NodeCount *int `json:"nodeCount,omitempty"`
+	// Kubernetes version of the cluster of the infra
+	K8sVersion *string `json:"k8sVersion,omitempty"`
+}
+
 type InfraIdentity struct {
 	InfraID   string `json:"infraID"`
 	AccessKey string `json:"accessKey"`
 	Version   string `json:"version"`
 }
 
+// Defines an upgrade of an infra to the version of the control plane
+type InfraUpgrade struct {
+	// ID of the upgrade
+	UpgradeID string `json:"upgradeID"`
+	// ID of the infra being upgraded
+	InfraID string `json:"infraID"`
+	// Version of the infra before the upgrade
+	FromVersion string `json:"fromVersion"`
+	// Version the infra is upgraded to
+	ToVersion string `json:"toVersion"`
+	// Status of the upgrade
+	Status InfraUpgradeStatus `json:"status"`
+	// Stage of the rollout the infra is at, like resources, components or subscriber
+	Stage *string `json:"stage,omitempty"`
+	// Error reported by the infra if the upgrade failed
+	Error *string `json:"error,omitempty"`
+	// User who requested the upgrade
+	Username *string `json:"username,omitempty"`
+	// Timestamp when the upgrade was requested
+	CreatedAt string `json:"createdAt"`
+	// Timestamp when the status of the upgrade last changed
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// Defines the progress of an upgrade reported by the infra
+type InfraUpgradeStatusRequest struct {
+	// Identity of the infra
+	InfraID *InfraIdentity `json:"infraID"`
+	// ID of the upgrade
+	UpgradeID string `json:"upgradeID"`
+	// Status of the upgrade, InProgress, Succeeded, Failed or RolledBack
+	Status InfraUpgradeStatus `json:"status"`
+	// Stage of the rollout the infra is at
+	Stage *string `json:"stage,omitempty"`
+	// Error of the upgrade if it failed
+	Error *string `json:"error,omitempty"`
+}
+
 // InfraVersionDetails returns the details of compatible infra versions and the latest infra version supported
 type InfraVersionDetails struct {
 	// Latest infra version supported
@@ -1346,6 +1633,17 @@ type Link struct {
 	URL  string `json:"url"`
 }
 
+type ListAuditLogsRequest struct {
+	Filter *AuditLogFilterInput `json:"filter,omitempty"`
+	// Details for fetching paginated data, the latest 15 audit logs are returned by default
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+type ListAuditLogsResponse struct {
+	TotalNoOfAuditLogs int         `json:"totalNoOfAuditLogs"`
+	AuditLogs          []*AuditLog `json:"auditLogs"`
+}
+
 type ListChaosHubRequest struct {
 	// Array of ChaosHub IDs for which details will be fetched
 	ChaosHubIDs []string `json:"chaosHubIDs,omitempty"`
@@ -1412,6 +1710,16 @@ type ListExperimentRunResponse struct {
 	ExperimentRuns []*ExperimentRun `json:"experimentRuns"`
 }
 
+// Defines the filters of the actions sent to the infras
+type ListInfraActionsRequest struct {
+	// ID of the infra
+	InfraID *string `json:"infraID,omitempty"`
+	// IDs of the actions
+	RequestIDs []string `json:"requestIDs,omitempty"`
+	// States of the actions
+	States []InfraActionState `json:"states,omitempty"`
+}
+
 // Defines the details for a infra
 type ListInfraRequest struct {
 	// Array of infra IDs for which details will be fetched
@@ -1472,6 +1780,65 @@ type NewInfraEventRequest struct {
 	AccessKey   string `json:"accessKey"`
 }
 
+type NotificationChannel struct {
+	ProjectID   string                  `json:"projectID"`
+	ChannelID   string                  `json:"channelID"`
+	Name        string                  `json:"name"`
+	Description *string                 `json:"description,omitempty"`
+	Tags        []string                `json:"tags,omitempty"`
+	Type        NotificationChannelType `json:"type"`
+	// Bool value indicating whether the channel is notified of events
+	Enabled   bool                `json:"enabled"`
+	Webhook   *WebhookConfig      `json:"webhook,omitempty"`
+	Slack     *SlackConfig        `json:"slack,omitempty"`
+	SMTP      *SMTPConfig         `json:"smtp,omitempty"`
+	Filter    *NotificationFilter `json:"filter,omitempty"`
+	CreatedAt string              `json:"createdAt"`
+	CreatedBy *UserDetails        `json:"createdBy,omitempty"`
+	UpdatedBy *UserDetails        `json:"updatedBy,omitempty"`
+	UpdatedAt string              `json:"updatedAt"`
+	IsRemoved *bool               `json:"isRemoved,omitempty"`
+}
+
+func (NotificationChannel) IsResourceDetails()           {}
+func (this NotificationChannel) GetName() string         { return this.Name }
+func (this NotificationChannel) GetDescription() *string { return this.Description }
+func (this NotificationChannel) GetTags() []string {
+	if this.Tags == nil {
+		return nil
+	}
+	interfaceSlice := make([]string, 0, len(this.Tags))
+	for _, concrete := range this.Tags {
+		interfaceSlice = append(interfaceSlice, concrete)
+	}
+	return interfaceSlice
+}
+
+func (NotificationChannel) IsAudit()                        {}
+func (this NotificationChannel) GetUpdatedAt() *string      { return &this.UpdatedAt }
+func (this NotificationChannel) GetCreatedAt() *string      { return &this.CreatedAt }
+func (this NotificationChannel) GetUpdatedBy() *UserDetails { return this.UpdatedBy }
+func (this NotificationChannel) GetCreatedBy() *UserDetails { return this.CreatedBy }
+
+type NotificationFilter struct {
+	EventTypes     []NotificationEventType `json:"eventTypes,omitempty"`
+	ExperimentTags []string                `json:"experimentTags,omitempty"`
+	Phases         []string                `json:"phases,omitempty"`
+	EnvironmentIDs []string                `json:"environmentIDs,omitempty"`
+}
+
+// Defines the filters of the events a channel is notified of, empty filters match every event
+type NotificationFilterInput struct {
+	// Types of the events
+	EventTypes []NotificationEventType `json:"eventTypes,omitempty"`
+	// Tags of the experiments, an experiment matches if it has any of the tags
+	ExperimentTags []string `json:"experimentTags,omitempty"`
+	// Phases of the experiment runs
+	Phases []string `json:"phases,omitempty"`
+	// IDs of the environments of the infras
+	EnvironmentIDs []string `json:"environmentIDs,omitempty"`
+}
+
 type ObjectData struct {
 	// Labels present in the resource
 	Labels []string `json:"labels,omitempty"`
@@ -1616,6 +1983,8 @@ type PodLog struct {
 	PodType string `json:"podType"`
 	// Logs for the pod
 	Log string `json:"log"`
+	// Whether this is the last chunk of the logs, the logs are sent in a single response when it's not set
+	Done *bool `json:"done,omitempty"`
 }
 
 // Defines the details for fetching the pod logs
@@ -1636,6 +2005,14 @@ type PodLogRequest struct {
 	RunnerPod *string `json:"runnerPod,omitempty"`
 	// Namespace where the experiment is executing
 	ChaosNamespace *string `json:"chaosNamespace,omitempty"`
+	// Keeps streaming the logs of the pods until they stop or the subscription ends
+	Follow *bool `json:"follow,omitempty"`
+	// Number of lines from the end of the logs to return
+	TailLines *int `json:"tailLines,omitempty"`
+	// Returns the logs of the last seconds only
+	SinceSeconds *int `json:"sinceSeconds,omitempty"`
+	// Returns the logs of the previous terminated containers of the pods
+	Previous *bool `json:"previous,omitempty"`
 }
 
 // Defines the response received for querying querying the pod logs
@@ -1646,8 +2023,10 @@ type PodLogResponse struct {
 	PodName string `json:"podName"`
 	// Type of the pod: chaosengine
 	PodType string `json:"podType"`
-	// Logs for the pod
+	// Logs for the pod, a chunk of them when the logs are followed
 	Log string `json:"log"`
+	// Whether the logs ended, no more chunks are sent for the request
+	Done bool `json:"done"`
 }
 
 type PredefinedExperimentList struct {
@@ -1850,10 +2229,56 @@ type ResilienceScoreCategory struct {
 	Count int `json:"count"`
 }
 
+// Defines the strategy used to calculate the resiliency score of the experiment runs
+type ResiliencyScoringStrategy struct {
+	// Type of the strategy
+	Type ResiliencyScoringStrategyType `json:"type"`
+	// Names of the faults which must pass for the experiment run to be scored
+	CriticalFaults []string `json:"criticalFaults,omitempty"`
+	// Time in seconds a fault may take before it is penalised
+	RecoveryTimeThreshold *int `json:"recoveryTimeThreshold,omitempty"`
+	// Points deducted from the fault score for every second above the threshold
+	RecoveryTimePenalty *float64 `json:"recoveryTimePenalty,omitempty"`
+}
+
+// Defines the strategy used to calculate the resiliency score of the experiment runs
+type ResiliencyScoringStrategyInput struct {
+	// Type of the strategy
+	Type ResiliencyScoringStrategyType `json:"type"`
+	// Names of the faults which must pass for the experiment run to be scored, used by the CriticalFaultGate strategy
+	CriticalFaults []string `json:"criticalFaults,omitempty"`
+	// Time in seconds a fault may take before it is penalised, used by the RecoveryTimePenalty strategy
+	RecoveryTimeThreshold *int `json:"recoveryTimeThreshold,omitempty"`
+	// Points deducted from the fault score for every second above the threshold, used by the RecoveryTimePenalty strategy
+	RecoveryTimePenalty *float64 `json:"recoveryTimePenalty,omitempty"`
+}
+
 type RunChaosExperimentResponse struct {
 	NotifyID string `json:"notifyID"`
 }
 
+type SMTPConfig struct {
+	Host     string   `json:"host"`
+	Port     int      `json:"port"`
+	Username *string  `json:"username,omitempty"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+}
+
+// Details of the SMTP server the notification emails are sent with
+type SMTPConfigInput struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+	// Username of the SMTP server, kept on updates unless it's set
+	Username *string `json:"username,omitempty"`
+	// Password of the SMTP server, kept on updates unless it's set
+	Password *string `json:"password,omitempty"`
+	// Sender address of the emails
+	From string `json:"from"`
+	// Recipient addresses of the emails
+	To []string `json:"to"`
+}
+
 // Defines the SSHKey details
 type SSHKey struct {
 	// Public SSH key authenticating into git repository
@@ -1878,6 +2303,16 @@ type SaveChaosExperimentRequest struct {
 	InfraID string `json:"infraID"`
 	// Tags of the infrastructure
 	Tags []string `json:"tags,omitempty"`
+	// Strategy used to calculate the resiliency score, defaults to WeightedProbeSuccess
+	ScoringStrategy *ResiliencyScoringStrategyInput `json:"scoringStrategy,omitempty"`
+	// Criteria the experiment runs have to meet to pass their gate
+	AcceptanceCriteria *AcceptanceCriteriaInput `json:"acceptanceCriteria,omitempty"`
+	// Removes the scoring strategy of the experiment, which falls back to WeightedProbeSuccess. An update without
+	// a scoring strategy keeps the one of the experiment otherwise
+	ClearScoringStrategy *bool `json:"clearScoringStrategy,omitempty"`
+	// Removes the acceptance criteria of the experiment. An update without acceptance criteria keeps the ones of
+	// the experiment otherwise
+	ClearAcceptanceCriteria *bool `json:"clearAcceptanceCriteria,omitempty"`
 }
 
 // Response received for fetching GQL server version
@@ -1888,6 +2323,18 @@ type ServerVersionResponse struct {
 	Value string `json:"value"`
 }
 
+type SlackConfig struct {
+	// Host of the incoming webhook URL of the Slack channel, the URL isn't returned since it grants access to
+	// the channel
+	WebhookHost string `json:"webhookHost"`
+}
+
+// Details of a Slack compatible incoming webhook
+type SlackConfigInput struct {
+	// Incoming webhook URL of the Slack channel
+	WebhookURL string `json:"webhookURL"`
+}
+
 type Spec struct {
 	DisplayName         string        `json:"displayName"`
 	CategoryDescription string        `json:"categoryDescription"`
@@ -1969,12 +2416,40 @@ type UpdateEnvironmentRequest struct {
 	Type          *EnvironmentType `json:"type,omitempty"`
 }
 
+type UpdateNotificationChannelRequest struct {
+	ChannelID   string                   `json:"channelID"`
+	Name        *string                  `json:"name,omitempty"`
+	Description *string                  `json:"description,omitempty"`
+	Tags        []string                 `json:"tags,omitempty"`
+	Enabled     *bool                    `json:"enabled,omitempty"`
+	Webhook     *WebhookConfigInput      `json:"webhook,omitempty"`
+	Slack       *SlackConfigInput        `json:"slack,omitempty"`
+	SMTP        *SMTPConfigInput         `json:"smtp,omitempty"`
+	Filter      *NotificationFilterInput `json:"filter,omitempty"`
+}
+
 type UserDetails struct {
 	UserID   string `json:"userID"`
 	Username string `json:"username"`
 	Email    string `json:"email"`
 }
 
+type WebhookConfig struct {
+	// URL the events are posted to
+	URL string `json:"url"`
+	// Bool value indicating whether the payloads are signed
+	IsSigned bool `json:"isSigned"`
+}
+
+// Details of a generic webhook, the payloads are signed with HMAC-SHA256 if a secret is set
+type WebhookConfigInput struct {
+	// URL the events are posted to
+	URL string `json:"url"`
+	// Secret used to sign the payloads, the signature is sent in the X-Litmus-Signature header. It's kept on
+	// updates unless it's set, an empty secret stops signing the payloads
+	Secret *string `json:"secret,omitempty"`
+}
+
 // Defines the details of the weightages of each chaos fault in the experiment
 type Weightages struct {
 	// Name of the fault
@@ -1997,6 +2472,93 @@ type Workload struct {
 	Namespace string `json:"namespace"`
 }
 
+// Defines the result of an audited action
+type AuditOutcome string
+
+const (
+	AuditOutcomeSuccess AuditOutcome = "Success"
+	AuditOutcomeFailure AuditOutcome = "Failure"
+	// The actor wasn't allowed to perform the action
+	AuditOutcomeDenied AuditOutcome = "Denied"
+)
+
+var AllAuditOutcome = []AuditOutcome{
+	AuditOutcomeSuccess,
+	AuditOutcomeFailure,
+	AuditOutcomeDenied,
+}
+
+func (e AuditOutcome) IsValid() bool {
+	switch e {
+	case AuditOutcomeSuccess, AuditOutcomeFailure, AuditOutcomeDenied:
+		return true
+	}
+	return false
+}
+
+func (e AuditOutcome) String() string {
+	return string(e)
+}
+
+func (e *AuditOutcome) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AuditOutcome(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AuditOutcome", str)
+	}
+	return nil
+}
+
+func (e AuditOutcome) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Defines the server an audited action was made on
+type AuditSource string
+
+const (
+	AuditSourceGraphQl        AuditSource = "GraphQL"
+	AuditSourceAuthentication AuditSource = "Authentication"
+)
+
+var AllAuditSource = []AuditSource{
+	AuditSourceGraphQl,
+	AuditSourceAuthentication,
+}
+
+func (e AuditSource) IsValid() bool {
+	switch e {
+	case AuditSourceGraphQl, AuditSourceAuthentication:
+		return true
+	}
+	return false
+}
+
+func (e AuditSource) String() string {
+	return string(e)
+}
+
+func (e *AuditSource) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AuditSource(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AuditSource", str)
+	}
+	return nil
+}
+
+func (e AuditSource) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type AuthType string
 
 const (
@@ -2316,21 +2878,247 @@ func (e FileType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// Verdict of the acceptance criteria of an experiment run
+type GateVerdict string
+
+const (
+	// The experiment run met its acceptance criteria
+	GateVerdictPass GateVerdict = "Pass"
+	// The experiment run didn't meet its acceptance criteria
+	GateVerdictFail GateVerdict = "Fail"
+	// The experiment run hasn't completed yet
+	GateVerdictPending GateVerdict = "Pending"
+)
+
+var AllGateVerdict = []GateVerdict{
+	GateVerdictPass,
+	GateVerdictFail,
+	GateVerdictPending,
+}
+
+func (e GateVerdict) IsValid() bool {
+	switch e {
+	case GateVerdictPass, GateVerdictFail, GateVerdictPending:
+		return true
+	}
+	return false
+}
+
+func (e GateVerdict) String() string {
+	return string(e)
+}
+
+func (e *GateVerdict) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = GateVerdict(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid GateVerdict", str)
+	}
+	return nil
+}
+
+func (e GateVerdict) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Defines the side kept when resolving a GitOps conflict
+type GitOpsConflictResolution string
+
+const (
+	// The manifest of the experiment in ChaosCenter is pushed to the repository
+	GitOpsConflictResolutionChaosCenter GitOpsConflictResolution = "ChaosCenter"
+	// The manifest of the experiment in the repository is applied to ChaosCenter
+	GitOpsConflictResolutionGit GitOpsConflictResolution = "Git"
+)
+
+var AllGitOpsConflictResolution = []GitOpsConflictResolution{
+	GitOpsConflictResolutionChaosCenter,
+	GitOpsConflictResolutionGit,
+}
+
+func (e GitOpsConflictResolution) IsValid() bool {
+	switch e {
+	case GitOpsConflictResolutionChaosCenter, GitOpsConflictResolutionGit:
+		return true
+	}
+	return false
+}
+
+func (e GitOpsConflictResolution) String() string {
+	return string(e)
+}
+
+func (e *GitOpsConflictResolution) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = GitOpsConflictResolution(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid GitOpsConflictResolution", str)
+	}
+	return nil
+}
+
+func (e GitOpsConflictResolution) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Defines the git hosting service the pull requests are opened on
+type GitOpsProvider string
+
+const (
+	GitOpsProviderGitHub GitOpsProvider = "GitHub"
+	GitOpsProviderGitLab GitOpsProvider = "GitLab"
+	GitOpsProviderGitea  GitOpsProvider = "Gitea"
+)
+
+var AllGitOpsProvider = []GitOpsProvider{
+	GitOpsProviderGitHub,
+	GitOpsProviderGitLab,
+	GitOpsProviderGitea,
+}
+
+func (e GitOpsProvider) IsValid() bool {
+	switch e {
+	case GitOpsProviderGitHub, GitOpsProviderGitLab, GitOpsProviderGitea:
+		return true
+	}
+	return false
+}
+
+func (e GitOpsProvider) String() string {
+	return string(e)
+}
+
+func (e *GitOpsProvider) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = GitOpsProvider(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid GitOpsProvider", str)
+	}
+	return nil
+}
+
+func (e GitOpsProvider) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Defines the result of a GitOps sync
+type GitOpsSyncStatus string
+
+const (
+	GitOpsSyncStatusSuccess GitOpsSyncStatus = "Success"
+	GitOpsSyncStatusFailure GitOpsSyncStatus = "Failure"
+)
+
+var AllGitOpsSyncStatus = []GitOpsSyncStatus{
+	GitOpsSyncStatusSuccess,
+	GitOpsSyncStatusFailure,
+}
+
+func (e GitOpsSyncStatus) IsValid() bool {
+	switch e {
+	case GitOpsSyncStatusSuccess, GitOpsSyncStatusFailure:
+		return true
+	}
+	return false
+}
+
+func (e GitOpsSyncStatus) String() string {
+	return string(e)
+}
+
+func (e *GitOpsSyncStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = GitOpsSyncStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid GitOpsSyncStatus", str)
+	}
+	return nil
+}
+
+func (e GitOpsSyncStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Defines how the changes made in ChaosCenter are written to the Git repository
+type GitOpsWriteMode string
+
+const (
+	// The changes are pushed straight to the branch
+	GitOpsWriteModeDirectPush GitOpsWriteMode = "DirectPush"
+	// The changes are pushed to a generated branch and a pull request is opened against the branch
+	GitOpsWriteModePullRequest GitOpsWriteMode = "PullRequest"
+)
+
+var AllGitOpsWriteMode = []GitOpsWriteMode{
+	GitOpsWriteModeDirectPush,
+	GitOpsWriteModePullRequest,
+}
+
+func (e GitOpsWriteMode) IsValid() bool {
+	switch e {
+	case GitOpsWriteModeDirectPush, GitOpsWriteModePullRequest:
+		return true
+	}
+	return false
+}
+
+func (e GitOpsWriteMode) String() string {
+	return string(e)
+}
+
+func (e *GitOpsWriteMode) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = GitOpsWriteMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid GitOpsWriteMode", str)
+	}
+	return nil
+}
+
+func (e GitOpsWriteMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type HubType string
 
 const (
 	HubTypeGit    HubType = "GIT"
 	HubTypeRemote HubType = "REMOTE"
+	// Hub pulled from an OCI artifact whose layer is the gzipped tarball of the hub, with the credentials of the
+	// pull secret of the image registry if it's found in the namespace of ChaosCenter, or anonymously. The pull
+	// secret has to be labelled with litmuschaos.io/project-id set to the ID of the project
+	HubTypeOci HubType = "OCI"
 )
 
 var AllHubType = []HubType{
 	HubTypeGit,
 	HubTypeRemote,
+	HubTypeOci,
 }
 
 func (e HubType) IsValid() bool {
 	switch e {
-	case HubTypeGit, HubTypeRemote:
+	case HubTypeGit, HubTypeRemote, HubTypeOci:
 		return true
 	}
 	return false
@@ -2442,6 +3230,109 @@ func (e ImagePullPolicy) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// Defines the delivery states of the actions sent to the infras
+type InfraActionState string
+
+const (
+	// The action is queued until the infra is connected
+	InfraActionStatePending InfraActionState = "Pending"
+	// The action was sent to the infra, which hasn't acknowledged it yet
+	InfraActionStateDelivered InfraActionState = "Delivered"
+	// The infra applied the action
+	InfraActionStateApplied InfraActionState = "Applied"
+	// The infra failed to apply the action
+	InfraActionStateFailed InfraActionState = "Failed"
+)
+
+var AllInfraActionState = []InfraActionState{
+	InfraActionStatePending,
+	InfraActionStateDelivered,
+	InfraActionStateApplied,
+	InfraActionStateFailed,
+}
+
+func (e InfraActionState) IsValid() bool {
+	switch e {
+	case InfraActionStatePending, InfraActionStateDelivered, InfraActionStateApplied, InfraActionStateFailed:
+		return true
+	}
+	return false
+}
+
+func (e InfraActionState) String() string {
+	return string(e)
+}
+
+func (e *InfraActionState) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = InfraActionState(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid InfraActionState", str)
+	}
+	return nil
+}
+
+func (e InfraActionState) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Defines the status of an infra upgrade
+type InfraUpgradeStatus string
+
+const (
+	// The upgrade is queued until the infra receives it
+	InfraUpgradeStatusPending InfraUpgradeStatus = "Pending"
+	// The infra is rolling out the new version of its components
+	InfraUpgradeStatusInProgress InfraUpgradeStatus = "InProgress"
+	// All the components of the infra run the new version
+	InfraUpgradeStatusSucceeded InfraUpgradeStatus = "Succeeded"
+	// The upgrade failed and the infra couldn't restore the previous version
+	InfraUpgradeStatusFailed InfraUpgradeStatus = "Failed"
+	// The upgrade failed and the infra restored the previous version
+	InfraUpgradeStatusRolledBack InfraUpgradeStatus = "RolledBack"
+)
+
+var AllInfraUpgradeStatus = []InfraUpgradeStatus{
+	InfraUpgradeStatusPending,
+	InfraUpgradeStatusInProgress,
+	InfraUpgradeStatusSucceeded,
+	InfraUpgradeStatusFailed,
+	InfraUpgradeStatusRolledBack,
+}
+
+func (e InfraUpgradeStatus) IsValid() bool {
+	switch e {
+	case InfraUpgradeStatusPending, InfraUpgradeStatusInProgress, InfraUpgradeStatusSucceeded, InfraUpgradeStatusFailed, InfraUpgradeStatusRolledBack:
+		return true
+	}
+	return false
+}
+
+func (e InfraUpgradeStatus) String() string {
+	return string(e)
+}
+
+func (e *InfraUpgradeStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = InfraUpgradeStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid InfraUpgradeStatus", str)
+	}
+	return nil
+}
+
+func (e InfraUpgradeStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type InfrastructureType string
 
 const (
@@ -2613,6 +3504,100 @@ func (e Mode) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// Defines the types of notification channels
+type NotificationChannelType string
+
+const (
+	NotificationChannelTypeWebhook NotificationChannelType = "Webhook"
+	NotificationChannelTypeSlack   NotificationChannelType = "Slack"
+	NotificationChannelTypeSMTP    NotificationChannelType = "SMTP"
+)
+
+var AllNotificationChannelType = []NotificationChannelType{
+	NotificationChannelTypeWebhook,
+	NotificationChannelTypeSlack,
+	NotificationChannelTypeSMTP,
+}
+
+func (e NotificationChannelType) IsValid() bool {
+	switch e {
+	case NotificationChannelTypeWebhook, NotificationChannelTypeSlack, NotificationChannelTypeSMTP:
+		return true
+	}
+	return false
+}
+
+func (e NotificationChannelType) String() string {
+	return string(e)
+}
+
+func (e *NotificationChannelType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationChannelType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationChannelType", str)
+	}
+	return nil
+}
+
+func (e NotificationChannelType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Defines the lifecycle events a notification channel can be notified of
+type NotificationEventType string
+
+const (
+	NotificationEventTypeExperimentRunStarted   NotificationEventType = "ExperimentRunStarted"
+	NotificationEventTypeExperimentRunCompleted NotificationEventType = "ExperimentRunCompleted"
+	NotificationEventTypeProbeFailed            NotificationEventType = "ProbeFailed"
+	NotificationEventTypeInfraConnected         NotificationEventType = "InfraConnected"
+	NotificationEventTypeInfraDisconnected      NotificationEventType = "InfraDisconnected"
+	NotificationEventTypeInfraDeleted           NotificationEventType = "InfraDeleted"
+)
+
+var AllNotificationEventType = []NotificationEventType{
+	NotificationEventTypeExperimentRunStarted,
+	NotificationEventTypeExperimentRunCompleted,
+	NotificationEventTypeProbeFailed,
+	NotificationEventTypeInfraConnected,
+	NotificationEventTypeInfraDisconnected,
+	NotificationEventTypeInfraDeleted,
+}
+
+func (e NotificationEventType) IsValid() bool {
+	switch e {
+	case NotificationEventTypeExperimentRunStarted, NotificationEventTypeExperimentRunCompleted, NotificationEventTypeProbeFailed, NotificationEventTypeInfraConnected, NotificationEventTypeInfraDisconnected, NotificationEventTypeInfraDeleted:
+		return true
+	}
+	return false
+}
+
+func (e NotificationEventType) String() string {
+	return string(e)
+}
+
+func (e *NotificationEventType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationEventType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationEventType", str)
+	}
+	return nil
+}
+
+func (e NotificationEventType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 // Defines the different statuses of Probes
 type ProbeStatus string
 
@@ -2755,6 +3740,56 @@ func (e ProbeVerdict) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// Strategies available to calculate the resiliency score of an experiment run
+type ResiliencyScoringStrategyType string
+
+const (
+	// Weighted average of the probe success percentage of each fault
+	ResiliencyScoringStrategyTypeWeightedProbeSuccess ResiliencyScoringStrategyType = "WeightedProbeSuccess"
+	// Weighted share of the faults with a Pass verdict
+	ResiliencyScoringStrategyTypeVerdictBased ResiliencyScoringStrategyType = "VerdictBased"
+	// Weighted probe success, which drops to zero if any critical fault doesn't pass
+	ResiliencyScoringStrategyTypeCriticalFaultGate ResiliencyScoringStrategyType = "CriticalFaultGate"
+	// Weighted probe success, penalised for the faults which take longer than the recovery time threshold
+	ResiliencyScoringStrategyTypeRecoveryTimePenalty ResiliencyScoringStrategyType = "RecoveryTimePenalty"
+)
+
+var AllResiliencyScoringStrategyType = []ResiliencyScoringStrategyType{
+	ResiliencyScoringStrategyTypeWeightedProbeSuccess,
+	ResiliencyScoringStrategyTypeVerdictBased,
+	ResiliencyScoringStrategyTypeCriticalFaultGate,
+	ResiliencyScoringStrategyTypeRecoveryTimePenalty,
+}
+
+func (e ResiliencyScoringStrategyType) IsValid() bool {
+	switch e {
+	case ResiliencyScoringStrategyTypeWeightedProbeSuccess, ResiliencyScoringStrategyTypeVerdictBased, ResiliencyScoringStrategyTypeCriticalFaultGate, ResiliencyScoringStrategyTypeRecoveryTimePenalty:
+		return true
+	}
+	return false
+}
+
+func (e ResiliencyScoringStrategyType) String() string {
+	return string(e)
+}
+
+func (e *ResiliencyScoringStrategyType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ResiliencyScoringStrategyType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ResiliencyScoringStrategyType", str)
+	}
+	return nil
+}
+
+func (e ResiliencyScoringStrategyType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type ScheduleType string
 
 const (
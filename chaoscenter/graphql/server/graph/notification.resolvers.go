@@ -0,0 +1,91 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.42
+
+import (
+	"context"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/authorization"
+	"github.com/sirupsen/logrus"
+)
+
+// CreateNotificationChannel is the resolver for the createNotificationChannel field.
+func (r *mutationResolver) CreateNotificationChannel(ctx context.Context, projectID string, request model.CreateNotificationChannelRequest) (*model.NotificationChannel, error) {
+	logFields := logrus.Fields{
+		"projectId": projectID,
+	}
+	logrus.WithFields(logFields).Info("request received to create notification channel")
+	err := authorization.ValidateRole(ctx, projectID,
+		authorization.CreateNotificationChannel,
+		model.InvitationAccepted.String())
+	if err != nil {
+		return nil, err
+	}
+	return r.notificationService.CreateNotificationChannel(ctx, projectID, request)
+}
+
+// UpdateNotificationChannel is the resolver for the updateNotificationChannel field.
+func (r *mutationResolver) UpdateNotificationChannel(ctx context.Context, projectID string, request model.UpdateNotificationChannelRequest) (*model.NotificationChannel, error) {
+	logFields := logrus.Fields{
+		"projectId": projectID,
+		"channelId": request.ChannelID,
+	}
+	logrus.WithFields(logFields).Info("request received to update notification channel")
+	err := authorization.ValidateRole(ctx, projectID,
+		authorization.UpdateNotificationChannel,
+		model.InvitationAccepted.String())
+	if err != nil {
+		return nil, err
+	}
+	return r.notificationService.UpdateNotificationChannel(ctx, projectID, request)
+}
+
+// DeleteNotificationChannel is the resolver for the deleteNotificationChannel field.
+func (r *mutationResolver) DeleteNotificationChannel(ctx context.Context, projectID string, channelID string) (string, error) {
+	logFields := logrus.Fields{
+		"projectId": projectID,
+		"channelId": channelID,
+	}
+	logrus.WithFields(logFields).Info("request received to delete notification channel")
+	err := authorization.ValidateRole(ctx, projectID,
+		authorization.DeleteNotificationChannel,
+		model.InvitationAccepted.String())
+	if err != nil {
+		return "", err
+	}
+	return r.notificationService.DeleteNotificationChannel(ctx, projectID, channelID)
+}
+
+// GetNotificationChannel is the resolver for the getNotificationChannel field.
+func (r *queryResolver) GetNotificationChannel(ctx context.Context, projectID string, channelID string) (*model.NotificationChannel, error) {
+	logFields := logrus.Fields{
+		"projectId": projectID,
+		"channelId": channelID,
+	}
+	logrus.WithFields(logFields).Info("request received to get notification channel")
+	err := authorization.ValidateRole(ctx, projectID,
+		authorization.GetNotificationChannel,
+		model.InvitationAccepted.String())
+	if err != nil {
+		return nil, err
+	}
+	return r.notificationService.GetNotificationChannel(ctx, projectID, channelID)
+}
+
+// ListNotificationChannels is the resolver for the listNotificationChannels field.
+func (r *queryResolver) ListNotificationChannels(ctx context.Context, projectID string) ([]*model.NotificationChannel, error) {
+	logFields := logrus.Fields{
+		"projectId": projectID,
+	}
+	logrus.WithFields(logFields).Info("request received to list notification channels")
+	err := authorization.ValidateRole(ctx, projectID,
+		authorization.ListNotificationChannels,
+		model.InvitationAccepted.String())
+	if err != nil {
+		return nil, err
+	}
+	return r.notificationService.ListNotificationChannels(ctx, projectID)
+}
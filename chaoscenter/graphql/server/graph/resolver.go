@@ -7,6 +7,7 @@ import (
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/generated"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/audit"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/authorization"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/chaos_experiment/handler"
 	chaos_experiment_run2 "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/chaos_experiment_run"
@@ -14,6 +15,7 @@ import (
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/chaos_infrastructure"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/chaoshub"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
+	dbAuditLog "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/audit_log"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_experiment"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_experiment_run"
 	dbSchemaChaosHub "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_hub"
@@ -21,9 +23,12 @@ import (
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/environments"
 	gitops2 "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/gitops"
 	image_registry2 "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/image_registry"
+	dbInfraUpgrade "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/infra_upgrade"
+	dbNotificationChannel "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/notification_channel"
 	envHandler "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/environment/handler"
 	gitops3 "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/gitops"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/image_registry"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/notification"
 	probe "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/probe/handler"
 )
 
@@ -42,6 +47,8 @@ type Resolver struct {
 	chaosExperimentRunHandler  runHandler.ChaosExperimentRunHandler
 	environmentService         envHandler.EnvironmentHandler
 	probeService               probe.Service
+	notificationService        notification.Service
+	auditService               audit.Service
 }
 
 func NewConfig(mongodbOperator mongodb.MongoOperator) generated.Config {
@@ -53,20 +60,25 @@ func NewConfig(mongodbOperator mongodb.MongoOperator) generated.Config {
 	gitopsOperator := gitops2.NewGitOpsOperator(mongodbOperator)
 	imageRegistryOperator := image_registry2.NewImageRegistryOperator(mongodbOperator)
 	EnvironmentOperator := environments.NewEnvironmentOperator(mongodbOperator)
+	notificationChannelOperator := dbNotificationChannel.NewNotificationChannelOperator(mongodbOperator)
+	auditLogOperator := dbAuditLog.NewAuditLogOperator(mongodbOperator)
+	infraUpgradeOperator := dbInfraUpgrade.NewInfraUpgradeOperator(mongodbOperator)
 
 	//service
 	probeService := probe.NewProbeService()
-	chaosHubService := chaoshub.NewService(chaosHubOperator)
-	chaosInfrastructureService := chaos_infrastructure.NewChaosInfrastructureService(chaosInfraOperator, EnvironmentOperator)
+	notificationService := notification.NewNotificationService(notificationChannelOperator)
+	auditService := audit.NewAuditService(auditLogOperator)
+	chaosHubService := chaoshub.NewService(chaosHubOperator, imageRegistryOperator)
+	chaosInfrastructureService := chaos_infrastructure.NewChaosInfrastructureService(chaosInfraOperator, EnvironmentOperator, infraUpgradeOperator, notificationService)
 	chaosExperimentService := chaos_experiment2.NewChaosExperimentService(chaosExperimentOperator, chaosInfraOperator, chaosExperimentRunOperator, probeService)
 	chaosExperimentRunService := chaos_experiment_run2.NewChaosExperimentRunService(chaosExperimentOperator, chaosInfraOperator, chaosExperimentRunOperator)
-	gitOpsService := gitops3.NewGitOpsService(gitopsOperator, chaosExperimentService, *chaosExperimentOperator)
 	imageRegistryService := image_registry.NewImageRegistryService(imageRegistryOperator)
 	environmentService := envHandler.NewEnvironmentService(EnvironmentOperator)
+	gitOpsService := gitops3.NewGitOpsService(gitopsOperator, chaosExperimentService, *chaosExperimentOperator, probeService, environmentService, imageRegistryService, chaosHubService)
 
 	//handler
 	chaosExperimentHandler := handler.NewChaosExperimentHandler(chaosExperimentService, chaosExperimentRunService, chaosInfrastructureService, gitOpsService, chaosExperimentOperator, chaosExperimentRunOperator, mongodbOperator)
-	choasExperimentRunHandler := runHandler.NewChaosExperimentRunHandler(chaosExperimentRunService, chaosInfrastructureService, gitOpsService, chaosExperimentOperator, chaosExperimentRunOperator, mongodbOperator)
+	choasExperimentRunHandler := runHandler.NewChaosExperimentRunHandler(chaosExperimentRunService, chaosInfrastructureService, gitOpsService, notificationService, chaosExperimentOperator, chaosExperimentRunOperator, mongodbOperator)
 
 	config := generated.Config{
 		Resolvers: &Resolver{
@@ -80,6 +92,8 @@ func NewConfig(mongodbOperator mongodb.MongoOperator) generated.Config {
 			chaosExperimentHandler:     *chaosExperimentHandler,
 			chaosExperimentRunHandler:  *choasExperimentRunHandler,
 			probeService:               probeService,
+			notificationService:        notificationService,
+			auditService:               auditService,
 		}}
 
 	config.Directives.Authorized = func(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
@@ -0,0 +1,133 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/authorization"
+	dbAuditLog "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/audit_log"
+)
+
+// NewAuditLog returns the audit log of a mutation, made of its arguments, result and error. The actor is
+// read from the user JWT of the request and the resources from the ID arguments, or the ID of the result
+// for the created resources. The states of the resource are recorded by the Recorder
+func NewAuditLog(ctx context.Context, operation string, args map[string]interface{}, result interface{}, err error) dbAuditLog.AuditLog {
+	auditLog := dbAuditLog.AuditLog{
+		AuditID:   uuid.New().String(),
+		Timestamp: time.Now().UnixMilli(),
+		Source:    model.AuditSourceGraphQl,
+		Operation: operation,
+		Outcome:   model.AuditOutcomeSuccess,
+	}
+
+	auditLog.Actor.ClientIP, _ = ctx.Value(authorization.ClientIPKey).(string)
+	if jwt, _ := ctx.Value(authorization.AuthKey).(string); jwt != "" {
+		if claims, err := authorization.UserValidateJWT(jwt); err == nil {
+			auditLog.Actor.UserID, _ = claims["uid"].(string)
+			auditLog.Actor.Username, _ = claims["username"].(string)
+			auditLog.Actor.ApiToken = claims["token_type"] == "api_token"
+		}
+	}
+
+	request := toJSONValue(args)
+	auditLog.ProjectID = findValue(request, isProjectKey)
+	auditLog.TargetID = topLevelValue(request, isTargetKey)
+	if auditLog.TargetID == "" && err == nil {
+		auditLog.TargetID = findValue(toJSONValue(result), isTargetKey)
+	}
+	if auditLog.TargetID == "" {
+		auditLog.TargetID = findValue(request, isTargetKey)
+	}
+
+	if err != nil {
+		auditLog.Outcome = model.AuditOutcomeFailure
+		if msg := err.Error(); msg == "permission_denied" || msg == "invalid Token" {
+			auditLog.Outcome = model.AuditOutcomeDenied
+		}
+		auditLog.Error = err.Error()
+	}
+	return auditLog
+}
+
+// toJSONValue returns the value decoded from its JSON, so that the models are looked up by their GraphQL names
+func toJSONValue(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil
+	}
+	return decoded
+}
+
+func isProjectKey(key string) bool {
+	return key == "projectID"
+}
+
+func isTargetKey(key string) bool {
+	return !isProjectKey(key) && (key == "id" || strings.HasSuffix(key, "ID") || strings.HasSuffix(key, "IDs"))
+}
+
+// topLevelValue returns the value of the first matching key of a decoded JSON object, in their sorted order.
+// The lists of IDs are joined with commas
+func topLevelValue(value interface{}, match func(string) bool) string {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	for _, key := range sortedKeys(object) {
+		if !match(key) {
+			continue
+		}
+		switch v := object[key].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case []interface{}:
+			var ids []string
+			for _, item := range v {
+				if id, ok := item.(string); ok && id != "" {
+					ids = append(ids, id)
+				}
+			}
+			if len(ids) > 0 {
+				return strings.Join(ids, ",")
+			}
+		}
+	}
+	return ""
+}
+
+// findValue returns the value of the first matching key of a decoded JSON object, the top level keys are
+// looked up before the ones of the nested objects
+func findValue(value interface{}, match func(string) bool) string {
+	if found := topLevelValue(value, match); found != "" {
+		return found
+	}
+
+	object, _ := value.(map[string]interface{})
+	for _, key := range sortedKeys(object) {
+		if found := findValue(object[key], match); found != "" {
+			return found
+		}
+	}
+	return ""
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
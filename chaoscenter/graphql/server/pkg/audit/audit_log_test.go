@@ -0,0 +1,154 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/authorization"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestNewAuditLog(t *testing.T) {
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS512, jwt.MapClaims{
+		"uid":        "user-id",
+		"username":   "admin",
+		"token_type": "api_token",
+	}).SignedString([]byte(utils.Config.JwtSecret))
+	ctx := context.WithValue(context.Background(), authorization.AuthKey, token)
+	ctx = context.WithValue(ctx, authorization.ClientIPKey, "10.0.0.1")
+
+	sshKey := "private-key"
+	testcases := []struct {
+		name        string
+		args        map[string]interface{}
+		result      interface{}
+		err         error
+		wantTarget  string
+		wantOutcome model.AuditOutcome
+	}{
+		{
+			name: "target in the arguments",
+			args: map[string]interface{}{
+				"projectID":    "project-id",
+				"experimentID": "experiment-id",
+			},
+			result:      &model.RunChaosExperimentResponse{NotifyID: "notify-id"},
+			wantTarget:  "experiment-id",
+			wantOutcome: model.AuditOutcomeSuccess,
+		},
+		{
+			name: "target of a created resource",
+			args: map[string]interface{}{
+				"projectID": "project-id",
+				"request": model.CreateChaosHubRequest{
+					Name:          "hub",
+					SSHPrivateKey: &sshKey,
+				},
+			},
+			result:      &model.ChaosHub{ID: "hub-id"},
+			wantTarget:  "hub-id",
+			wantOutcome: model.AuditOutcomeSuccess,
+		},
+		{
+			name: "denied request",
+			args: map[string]interface{}{
+				"projectID":     "project-id",
+				"experimentIDs": []string{"first-id", "second-id"},
+			},
+			err:         errors.New("permission_denied"),
+			wantTarget:  "first-id,second-id",
+			wantOutcome: model.AuditOutcomeDenied,
+		},
+		{
+			name: "failed request",
+			args: map[string]interface{}{
+				"projectID": "project-id",
+				"request":   map[string]interface{}{"infraID": "infra-id"},
+			},
+			err:         errors.New("infra not found"),
+			wantTarget:  "infra-id",
+			wantOutcome: model.AuditOutcomeFailure,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			auditLog := NewAuditLog(ctx, "operation", tc.args, tc.result, tc.err)
+
+			if auditLog.ProjectID != "project-id" {
+				t.Errorf("ProjectID = %s, want project-id", auditLog.ProjectID)
+			}
+			if auditLog.TargetID != tc.wantTarget {
+				t.Errorf("TargetID = %s, want %s", auditLog.TargetID, tc.wantTarget)
+			}
+			if auditLog.Outcome != tc.wantOutcome {
+				t.Errorf("Outcome = %s, want %s", auditLog.Outcome, tc.wantOutcome)
+			}
+			if auditLog.Actor.UserID != "user-id" || auditLog.Actor.Username != "admin" || !auditLog.Actor.ApiToken || auditLog.Actor.ClientIP != "10.0.0.1" {
+				t.Errorf("unexpected actor %+v", auditLog.Actor)
+			}
+		})
+	}
+}
+
+func TestNewAuditLogQuery(t *testing.T) {
+	var (
+		actor     = "admin"
+		outcome   = model.AuditOutcomeDenied
+		startTime = "1700000000000"
+		invalid   = "yesterday"
+	)
+	testcases := []struct {
+		name      string
+		projectID string
+		filter    *model.AuditLogFilterInput
+		want      bson.D
+		wantErr   bool
+	}{
+		{
+			name: "every project",
+			want: bson.D{},
+		},
+		{
+			name:      "filters",
+			projectID: "project-id",
+			filter: &model.AuditLogFilterInput{
+				Operations: []string{"deleteInfra"},
+				Actor:      &actor,
+				Outcome:    &outcome,
+				StartTime:  &startTime,
+			},
+			want: bson.D{
+				{"project_id", "project-id"},
+				{"operation", bson.D{{"$in", []string{"deleteInfra"}}}},
+				{"$or", bson.A{
+					bson.D{{"actor.username", "admin"}},
+					bson.D{{"actor.user_id", "admin"}},
+				}},
+				{"outcome", model.AuditOutcomeDenied},
+				{"timestamp", bson.D{{"$gte", int64(1700000000000)}}},
+			},
+		},
+		{
+			name:      "invalid time range",
+			projectID: "project-id",
+			filter:    &model.AuditLogFilterInput{EndTime: &invalid},
+			wantErr:   true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewAuditLogQuery(tc.projectID, tc.filter)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewAuditLogQuery() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("NewAuditLogQuery() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
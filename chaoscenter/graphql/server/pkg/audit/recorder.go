@@ -0,0 +1,73 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
+)
+
+// infraMutations are the mutations made by the chaos infrastructures with their access keys, they report
+// the state of the infrastructures and aren't actions of the users
+var infraMutations = map[string]bool{
+	"chaosExperimentRun":       true,
+	"confirmInfraRegistration": true,
+	"podLog":                   true,
+	"kubeObj":                  true,
+	"gitopsNotifier":           true,
+}
+
+// Recorder is a gqlgen extension which writes an audit log for every mutation made by the users, including
+// the ones which are denied or fail, along with the states of the changed resource before and after the mutation
+type Recorder struct {
+	service         Service
+	mongodbOperator mongodb.MongoOperator
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.FieldInterceptor
+} = Recorder{}
+
+// NewRecorder returns a new instance of Recorder
+func NewRecorder(service Service, mongodbOperator mongodb.MongoOperator) Recorder {
+	return Recorder{service: service, mongodbOperator: mongodbOperator}
+}
+
+func (Recorder) ExtensionName() string {
+	return "AuditRecorder"
+}
+
+func (Recorder) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (r Recorder) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || fc.Object != "Mutation" || infraMutations[fc.Field.Name] {
+		return next(ctx)
+	}
+
+	var (
+		args          = toJSONValue(fc.Args)
+		projectID     = findValue(args, isProjectKey)
+		res, audited  = mutationResources[fc.Field.Name]
+		before, after string
+	)
+	if audited {
+		before = r.resourceState(ctx, res, projectID, args)
+	}
+
+	result, err := next(ctx)
+
+	auditLog := NewAuditLog(ctx, fc.Field.Name, fc.Args, result, err)
+	if audited {
+		// the request may be cancelled once the mutation is done
+		stateCtx, cancel := context.WithTimeout(context.Background(), recordTimeout)
+		after = r.resourceState(stateCtx, res, projectID, args, toJSONValue(result))
+		cancel()
+	}
+	auditLog.Before, auditLog.After = before, after
+	r.service.Record(auditLog)
+	return result, err
+}
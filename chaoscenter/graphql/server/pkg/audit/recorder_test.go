@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
+	dbAuditLog "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/audit_log"
+	dbMocks "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// fakeService keeps the recorded audit logs
+type fakeService struct {
+	Service
+	recorded []dbAuditLog.AuditLog
+}
+
+func (f *fakeService) Record(auditLog dbAuditLog.AuditLog) {
+	f.recorded = append(f.recorded, auditLog)
+}
+
+func mutationContext(name string, args map[string]interface{}) context.Context {
+	return graphql.WithFieldContext(context.Background(), &graphql.FieldContext{
+		Object: "Mutation",
+		Field:  graphql.CollectedField{Field: &ast.Field{Name: name}},
+		Args:   args,
+	})
+}
+
+func TestRecorderStates(t *testing.T) {
+	mongodbMockOperator := new(dbMocks.MongoOperator)
+	service := &fakeService{}
+	recorder := NewRecorder(service, mongodbMockOperator)
+
+	channel := bson.D{
+		{"project_id", "project-id"},
+		{"channel_id", "channel-id"},
+		{"name", "alerts"},
+		{"webhook", bson.D{{"url", "https://hooks.example.com/token"}, {"secret", "signing-secret"}}},
+	}
+	query := bson.D{{"channel_id", "channel-id"}, {"project_id", "project-id"}}
+	mongodbMockOperator.On("Get", mock.Anything, mongodb.NotificationChannelCollection, query).Return(mongo.NewSingleResultFromDocument(channel, nil, nil), nil).Once()
+	mongodbMockOperator.On("Get", mock.Anything, mongodb.NotificationChannelCollection, query).Return(mongo.NewSingleResultFromDocument(bson.D{}, mongo.ErrNoDocuments, nil), nil).Once()
+
+	ctx := mutationContext("deleteNotificationChannel", map[string]interface{}{"projectID": "project-id", "channelID": "channel-id"})
+	if _, err := recorder.InterceptField(ctx, func(context.Context) (interface{}, error) { return "deleted", nil }); err != nil {
+		t.Fatal(err)
+	}
+	mongodbMockOperator.AssertExpectations(t)
+
+	if len(service.recorded) != 1 {
+		t.Fatalf("recorded %d audit logs, want 1", len(service.recorded))
+	}
+	auditLog := service.recorded[0]
+	if !strings.Contains(auditLog.Before, `"name":"alerts"`) {
+		t.Errorf("Before = %s, want the deleted channel", auditLog.Before)
+	}
+	if strings.Contains(auditLog.Before, "token") || strings.Contains(auditLog.Before, "signing-secret") {
+		t.Errorf("secrets have to be redacted from the state %s", auditLog.Before)
+	}
+	if auditLog.After != "" {
+		t.Errorf("After = %s, want the channel to be deleted", auditLog.After)
+	}
+
+	// the created resources are looked up by the ID of the result, the failed mutations are recorded too
+	environment := bson.D{{"project_id", "project-id"}, {"environment_id", "environment-id"}, {"name", "prod"}}
+	mongodbMockOperator.On("Get", mock.Anything, mongodb.EnvironmentCollection, bson.D{{"environment_id", "environment-id"}, {"project_id", "project-id"}}).Return(mongo.NewSingleResultFromDocument(environment, nil, nil), nil).Once()
+	ctx = mutationContext("createEnvironment", map[string]interface{}{"projectID": "project-id", "request": &model.CreateEnvironmentRequest{Name: "prod"}})
+	recorder.InterceptField(ctx, func(context.Context) (interface{}, error) {
+		return &model.Environment{ProjectID: "project-id", EnvironmentID: "environment-id"}, nil
+	})
+	ctx = mutationContext("deleteEnvironment", map[string]interface{}{"projectID": "project-id"})
+	recorder.InterceptField(ctx, func(context.Context) (interface{}, error) { return nil, errors.New("environment not found") })
+	mongodbMockOperator.AssertExpectations(t)
+
+	if len(service.recorded) != 3 {
+		t.Fatalf("recorded %d audit logs, want 3", len(service.recorded))
+	}
+	if created := service.recorded[1]; created.Before != "" || !strings.Contains(created.After, `"name":"prod"`) {
+		t.Errorf("Before = %q, After = %q, want the created environment after the mutation", created.Before, created.After)
+	}
+	if failed := service.recorded[2]; failed.Outcome != model.AuditOutcomeFailure || failed.Before != "" || failed.After != "" {
+		t.Errorf("unexpected audit log of the failed mutation %+v", failed)
+	}
+}
@@ -0,0 +1,50 @@
+package audit
+
+import "strings"
+
+// RedactedValue replaces the values of the sensitive keys in the audit logs
+const RedactedValue = "REDACTED"
+
+// SensitiveKeys are the parts of the keys whose values are redacted from the audit logs. The keys with a dot
+// are matched with the key of their parent object, like the URL of a webhook which carries its credentials
+var SensitiveKeys = []string{"password", "secret", "token", "privatekey", "accesskey", "authorization", "webhookurl", "webhook.url"}
+
+// Redact replaces the values of the sensitive keys, like passwords and tokens, in a decoded JSON value
+func Redact(value interface{}) interface{} {
+	return redact(value, "")
+}
+
+func redact(value interface{}, parent string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if IsSensitiveKey(key) || IsSensitiveKey(parent+"."+key) {
+				v[key] = RedactedValue
+			} else {
+				v[key] = redact(item, key)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redact(item, parent)
+		}
+	}
+	return value
+}
+
+// IsSensitiveKey checks if the key contains one of the SensitiveKeys, ignoring the case and the underscores.
+// The key of a nested object may be prefixed with the key of its parent object and a dot
+func IsSensitiveKey(key string) bool {
+	key = strings.ToLower(strings.ReplaceAll(key, "_", ""))
+	name := key[strings.LastIndex(key, ".")+1:]
+	for _, sensitive := range SensitiveKeys {
+		if strings.Contains(sensitive, ".") {
+			if key == sensitive {
+				return true
+			}
+		} else if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
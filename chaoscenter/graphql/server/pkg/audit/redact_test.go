@@ -0,0 +1,44 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	value := map[string]interface{}{
+		"name":     "litmus",
+		"password": "litmus",
+		"repoData": map[string]interface{}{
+			"branch":          "main",
+			"ssh_private_key": "private-key",
+			"authType":        "TOKEN",
+		},
+		"secrets": []interface{}{"first", "second"},
+		"headers": []interface{}{
+			map[string]interface{}{"key": "Accept", "Authorization": "Bearer token"},
+		},
+		"webhook": map[string]interface{}{"url": "https://hooks.example.com/token"},
+		"slack":   map[string]interface{}{"webhook_url": "https://hooks.slack.com/services/token"},
+		"url":     "https://hub.example.com",
+	}
+	want := map[string]interface{}{
+		"name":     "litmus",
+		"password": RedactedValue,
+		"repoData": map[string]interface{}{
+			"branch":          "main",
+			"ssh_private_key": RedactedValue,
+			"authType":        "TOKEN",
+		},
+		"secrets": RedactedValue,
+		"headers": []interface{}{
+			map[string]interface{}{"key": "Accept", "Authorization": RedactedValue},
+		},
+		"webhook": map[string]interface{}{"url": RedactedValue},
+		"slack":   map[string]interface{}{"webhook_url": RedactedValue},
+		"url":     "https://hub.example.com",
+	}
+	if got := Redact(value); !reflect.DeepEqual(got, want) {
+		t.Errorf("Redact() = %v, want %v", got, want)
+	}
+}
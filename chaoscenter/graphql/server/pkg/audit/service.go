@@ -0,0 +1,193 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	dbAuditLog "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/audit_log"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// recordTimeout bounds the write of an audit log, which doesn't use the context of the request so that
+// the actions of cancelled requests are still recorded
+const recordTimeout = 10 * time.Second
+
+// Service is the interface for the audit log service
+type Service interface {
+	Record(auditLog dbAuditLog.AuditLog)
+	ListAuditLogs(ctx context.Context, projectID string, request *model.ListAuditLogsRequest) (*model.ListAuditLogsResponse, error)
+	ExportAuditLogs(ctx context.Context, projectID string, filter *model.AuditLogFilterInput, w io.Writer) error
+}
+
+// auditService is the implementation of Service interface
+type auditService struct {
+	auditLogOperator *dbAuditLog.Operator
+}
+
+// NewAuditService returns a new instance of auditService
+func NewAuditService(auditLogOperator *dbAuditLog.Operator) Service {
+	return &auditService{
+		auditLogOperator: auditLogOperator,
+	}
+}
+
+// Record appends the audit log to the collection, the failures are logged as they mustn't fail the action
+func (a *auditService) Record(auditLog dbAuditLog.AuditLog) {
+	ctx, cancel := context.WithTimeout(context.Background(), recordTimeout)
+	defer cancel()
+
+	if err := a.auditLogOperator.InsertAuditLog(ctx, auditLog); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"operation": auditLog.Operation,
+			"projectId": auditLog.ProjectID,
+		}).Errorf("failed to record audit log, error: %v", err)
+	}
+}
+
+func (a *auditService) ListAuditLogs(ctx context.Context, projectID string, request *model.ListAuditLogsRequest) (*model.ListAuditLogsResponse, error) {
+	var (
+		filter     *model.AuditLogFilterInput
+		pagination *model.Pagination
+	)
+	if request != nil {
+		filter, pagination = request.Filter, request.Pagination
+	}
+
+	query, err := NewAuditLogQuery(projectID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pagination or adding a default limit of 15 if pagination not provided
+	paginatedAuditLogs := bson.A{
+		bson.D{{"$sort", bson.D{{"timestamp", -1}}}},
+	}
+	if pagination != nil {
+		paginatedAuditLogs = append(paginatedAuditLogs,
+			bson.D{{"$skip", pagination.Page * pagination.Limit}},
+			bson.D{{"$limit", pagination.Limit}},
+		)
+	} else {
+		paginatedAuditLogs = append(paginatedAuditLogs, bson.D{{"$limit", 15}})
+	}
+
+	pipeline := mongo.Pipeline{
+		{{"$match", query}},
+		{{"$facet", bson.D{
+			{"total_filtered_audit_logs", bson.A{
+				bson.D{{"$count", "count"}},
+			}},
+			{"audit_logs", paginatedAuditLogs},
+		}}},
+	}
+
+	cursor, err := a.auditLogOperator.GetAggregateAuditLogs(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var aggregatedAuditLogs []dbAuditLog.AggregatedAuditLogs
+	if err = cursor.All(ctx, &aggregatedAuditLogs); err != nil {
+		return nil, errors.New("error decoding audit logs cursor: " + err.Error())
+	}
+
+	response := &model.ListAuditLogsResponse{
+		AuditLogs: []*model.AuditLog{},
+	}
+	if len(aggregatedAuditLogs) == 0 {
+		return response, nil
+	}
+	for _, auditLog := range aggregatedAuditLogs[0].AuditLogs {
+		response.AuditLogs = append(response.AuditLogs, auditLog.GetOutputAuditLog())
+	}
+	if len(aggregatedAuditLogs[0].TotalFilteredAuditLogs) > 0 {
+		response.TotalNoOfAuditLogs = aggregatedAuditLogs[0].TotalFilteredAuditLogs[0].Count
+	}
+	return response, nil
+}
+
+// ExportAuditLogs writes the audit logs matching the filter as JSON lines, one audit log per line from the
+// oldest to the latest, which is the format SIEMs ingest. The audit logs of every project are exported if
+// the projectID is empty
+func (a *auditService) ExportAuditLogs(ctx context.Context, projectID string, filter *model.AuditLogFilterInput, w io.Writer) error {
+	query, err := NewAuditLogQuery(projectID, filter)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := a.auditLogOperator.GetAuditLogsCursor(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	encoder := json.NewEncoder(w)
+	for cursor.Next(ctx) {
+		var auditLog dbAuditLog.AuditLog
+		if err := cursor.Decode(&auditLog); err != nil {
+			return err
+		}
+		if err := encoder.Encode(auditLog.GetOutputAuditLog()); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// NewAuditLogQuery returns the query of the audit logs of a project matching the filter, the audit logs of
+// every project are matched if the projectID is empty
+func NewAuditLogQuery(projectID string, filter *model.AuditLogFilterInput) (bson.D, error) {
+	query := bson.D{}
+	if projectID != "" {
+		query = append(query, bson.E{"project_id", projectID})
+	}
+	if filter == nil {
+		return query, nil
+	}
+
+	if len(filter.Operations) > 0 {
+		query = append(query, bson.E{"operation", bson.D{{"$in", filter.Operations}}})
+	}
+	if filter.Actor != nil && *filter.Actor != "" {
+		query = append(query, bson.E{"$or", bson.A{
+			bson.D{{"actor.username", *filter.Actor}},
+			bson.D{{"actor.user_id", *filter.Actor}},
+		}})
+	}
+	if filter.TargetID != nil && *filter.TargetID != "" {
+		query = append(query, bson.E{"target_id", *filter.TargetID})
+	}
+	if filter.Outcome != nil {
+		query = append(query, bson.E{"outcome", *filter.Outcome})
+	}
+	if filter.Source != nil {
+		query = append(query, bson.E{"source", *filter.Source})
+	}
+
+	timeRange := bson.D{}
+	if filter.StartTime != nil && *filter.StartTime != "" {
+		startTime, err := strconv.ParseInt(*filter.StartTime, 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid startTime, it has to be in milliseconds")
+		}
+		timeRange = append(timeRange, bson.E{"$gte", startTime})
+	}
+	if filter.EndTime != nil && *filter.EndTime != "" {
+		endTime, err := strconv.ParseInt(*filter.EndTime, 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid endTime, it has to be in milliseconds")
+		}
+		timeRange = append(timeRange, bson.E{"$lte", endTime})
+	}
+	if len(timeRange) > 0 {
+		query = append(query, bson.E{"timestamp", timeRange})
+	}
+	return query, nil
+}
@@ -0,0 +1,111 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// resource is the document changed by an audited mutation. It's looked up by its ID, read from the first of
+// the paths found in the arguments or in the result of the mutation, within the project of the mutation
+type resource struct {
+	collection int
+	idField    string
+	idPaths    []string
+}
+
+// mutationResources are the resources whose states are recorded before and after the mutations
+var mutationResources = map[string]resource{
+	"createChaosExperiment":     {mongodb.ChaosExperimentCollection, "experiment_id", []string{"request.experimentID", "experimentID"}},
+	"saveChaosExperiment":       {mongodb.ChaosExperimentCollection, "experiment_id", []string{"request.id"}},
+	"updateChaosExperiment":     {mongodb.ChaosExperimentCollection, "experiment_id", []string{"request.experimentID", "experimentID"}},
+	"deleteChaosExperiment":     {mongodb.ChaosExperimentCollection, "experiment_id", []string{"experimentID"}},
+	"updateCronExperimentState": {mongodb.ChaosExperimentCollection, "experiment_id", []string{"experimentID"}},
+	"runChaosExperiment":        {mongodb.ChaosExperimentCollection, "experiment_id", []string{"experimentID"}},
+	"stopExperimentRuns":        {mongodb.ChaosExperimentCollection, "experiment_id", []string{"experimentID"}},
+	"registerInfra":             {mongodb.ChaosInfraCollection, "infra_id", []string{"infraID"}},
+	"deleteInfra":               {mongodb.ChaosInfraCollection, "infra_id", []string{"infraID"}},
+	"upgradeInfra":              {mongodb.ChaosInfraCollection, "infra_id", []string{"infraID"}},
+	"addChaosHub":               {mongodb.ChaosHubCollection, "hub_id", []string{"id"}},
+	"addRemoteChaosHub":         {mongodb.ChaosHubCollection, "hub_id", []string{"id"}},
+	"saveChaosHub":              {mongodb.ChaosHubCollection, "hub_id", []string{"id"}},
+	"syncChaosHub":              {mongodb.ChaosHubCollection, "hub_id", []string{"id"}},
+	"updateChaosHub":            {mongodb.ChaosHubCollection, "hub_id", []string{"request.id"}},
+	"deleteChaosHub":            {mongodb.ChaosHubCollection, "hub_id", []string{"hubID"}},
+	"enableGitOps":              {mongodb.GitOpsCollection, "project_id", []string{"projectID"}},
+	"updateGitOps":              {mongodb.GitOpsCollection, "project_id", []string{"projectID"}},
+	"disableGitOps":             {mongodb.GitOpsCollection, "project_id", []string{"projectID"}},
+	"createImageRegistry":       {mongodb.ImageRegistryCollection, "image_registry_id", []string{"imageRegistryID"}},
+	"updateImageRegistry":       {mongodb.ImageRegistryCollection, "image_registry_id", []string{"imageRegistryID"}},
+	"deleteImageRegistry":       {mongodb.ImageRegistryCollection, "image_registry_id", []string{"imageRegistryID"}},
+	"createNotificationChannel": {mongodb.NotificationChannelCollection, "channel_id", []string{"channelID"}},
+	"updateNotificationChannel": {mongodb.NotificationChannelCollection, "channel_id", []string{"request.channelID"}},
+	"deleteNotificationChannel": {mongodb.NotificationChannelCollection, "channel_id", []string{"channelID"}},
+	"addProbe":                  {mongodb.ChaosProbeCollection, "name", []string{"request.name"}},
+	"updateProbe":               {mongodb.ChaosProbeCollection, "name", []string{"request.name"}},
+	"deleteProbe":               {mongodb.ChaosProbeCollection, "name", []string{"probeName"}},
+	"createEnvironment":         {mongodb.EnvironmentCollection, "environment_id", []string{"request.environmentID", "environmentID"}},
+	"updateEnvironment":         {mongodb.EnvironmentCollection, "environment_id", []string{"request.environmentID"}},
+	"deleteEnvironment":         {mongodb.EnvironmentCollection, "environment_id", []string{"environmentID"}},
+}
+
+// resourceState returns the JSON of the resource, with the secrets redacted. It's empty if the ID of the
+// resource isn't in the given decoded JSON values or if the resource doesn't exist
+func (r Recorder) resourceState(ctx context.Context, res resource, projectID string, values ...interface{}) string {
+	var id string
+	for _, path := range res.idPaths {
+		for _, value := range values {
+			if id, _ = lookupPath(value, path).(string); id != "" {
+				break
+			}
+		}
+		if id != "" {
+			break
+		}
+	}
+	if id == "" {
+		return ""
+	}
+
+	query := bson.D{{res.idField, id}}
+	if res.idField != "project_id" {
+		query = append(query, bson.E{Key: "project_id", Value: projectID})
+	}
+	result, err := r.mongodbOperator.Get(ctx, res.collection, query)
+	if err != nil {
+		return ""
+	}
+	document, err := result.DecodeBytes()
+	if err != nil {
+		return ""
+	}
+	data, err := bson.MarshalExtJSON(document, false, false)
+	if err != nil {
+		return ""
+	}
+
+	var state map[string]interface{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return ""
+	}
+	delete(state, "_id")
+	if data, err = json.Marshal(Redact(state)); err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// lookupPath returns the value at the dot separated path of a decoded JSON value
+func lookupPath(value interface{}, path string) interface{} {
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+	return value
+}
@@ -0,0 +1,258 @@
+package authorization
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/utils"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// maxDecisions bounds the number of cached decisions, the expired ones are evicted when it's reached
+	maxDecisions = 10000
+
+	authDBName           = "auth"
+	authProjectColl      = "project"
+	authRevokedTokenColl = "revoked-token"
+	authApiTokenColl     = "api-token"
+	watchRetryInterval   = 5 * time.Second
+)
+
+// decisionKey identifies an authorization decision, the JWT is hashed so the cache doesn't hold credentials
+type decisionKey struct {
+	tokenHash  [sha256.Size]byte
+	projectID  string
+	operation  RoleQuery
+	invitation string
+	clientIP   string
+}
+
+func newDecisionKey(jwt string, projectID string, operation RoleQuery, invitation string, clientIP string) decisionKey {
+	return decisionKey{
+		tokenHash:  sha256.Sum256([]byte(jwt)),
+		projectID:  projectID,
+		operation:  operation,
+		invitation: invitation,
+		clientIP:   clientIP,
+	}
+}
+
+// apiTokenUsageFields are the fields of the API tokens updated on every request made with them, their
+// updates don't change any decision
+var apiTokenUsageFields = map[string]bool{"last_used_at": true, "last_used_ip": true}
+
+// DecisionCache caches the allowed authorization decisions of the Authentication service for a short TTL.
+// Denied decisions aren't cached, so the access granted to a user is effective right away, while the access
+// revoked is effective once the cached decisions of the project are invalidated or expire
+type DecisionCache struct {
+	ttl time.Duration
+
+	mu        sync.RWMutex
+	decisions map[decisionKey]time.Time
+	// generation is incremented by every invalidation, so that a decision made before an invalidation
+	// isn't cached after it
+	generation uint64
+}
+
+// NewDecisionCache returns a new DecisionCache, the cache is disabled if the TTL isn't positive
+func NewDecisionCache(ttl time.Duration) *DecisionCache {
+	return &DecisionCache{
+		ttl:       ttl,
+		decisions: make(map[decisionKey]time.Time),
+	}
+}
+
+// IsAllowed returns true if an allowed decision is cached for the key and hasn't expired
+func (d *DecisionCache) IsAllowed(key decisionKey) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	expiresAt, ok := d.decisions[key]
+	return ok && time.Now().Before(expiresAt)
+}
+
+// Generation returns the generation of the cache, it has to be read before asking the Authentication
+// service for a decision to cache
+func (d *DecisionCache) Generation() uint64 {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.generation
+}
+
+// Allow caches an allowed decision for the key, made at the given generation of the cache. The decision
+// isn't cached if the cache has been invalidated since, as it may have been made on the revoked access
+func (d *DecisionCache) Allow(key decisionKey, generation uint64) {
+	if d.ttl <= 0 {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if generation != d.generation {
+		return
+	}
+
+	if len(d.decisions) >= maxDecisions {
+		d.evictExpired()
+	}
+	if len(d.decisions) >= maxDecisions {
+		d.decisions = make(map[decisionKey]time.Time)
+	}
+	d.decisions[key] = time.Now().Add(d.ttl)
+}
+
+// InvalidateProject removes the cached decisions of a project
+func (d *DecisionCache) InvalidateProject(projectID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.generation++
+	for key := range d.decisions {
+		if key.projectID == projectID {
+			delete(d.decisions, key)
+		}
+	}
+}
+
+// InvalidateToken removes the cached decisions of a JWT
+func (d *DecisionCache) InvalidateToken(jwt string) {
+	tokenHash := sha256.Sum256([]byte(jwt))
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.generation++
+	for key := range d.decisions {
+		if key.tokenHash == tokenHash {
+			delete(d.decisions, key)
+		}
+	}
+}
+
+// InvalidateAll removes all the cached decisions
+func (d *DecisionCache) InvalidateAll() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.generation++
+	d.decisions = make(map[decisionKey]time.Time)
+}
+
+func (d *DecisionCache) evictExpired() {
+	now := time.Now()
+	for key, expiresAt := range d.decisions {
+		if !now.Before(expiresAt) {
+			delete(d.decisions, key)
+		}
+	}
+}
+
+var (
+	decisionCache     *DecisionCache
+	decisionCacheOnce sync.Once
+)
+
+// Decisions returns the DecisionCache shared by the resolvers, configured with the AUTH_DECISION_CACHE_TTL
+func Decisions() *DecisionCache {
+	decisionCacheOnce.Do(func() {
+		decisionCache = NewDecisionCache(utils.Config.AuthDecisionCacheTtl)
+	})
+	return decisionCache
+}
+
+// WatchAuthChanges invalidates the cached decisions when the memberships, custom roles, API tokens or users
+// change or a token is revoked in the database of the Authentication service, until the context is done. The
+// changes to a project only invalidate the decisions of that project and a revoked token only its decisions,
+// the updates of the usage of the API tokens are ignored, while the other changes invalidate all of them
+func WatchAuthChanges(ctx context.Context, mongoClient *mongo.Client) {
+	pipeline := mongo.Pipeline{
+		bson.D{{"$match", bson.D{
+			{"ns.coll", bson.D{{"$in", bson.A{authProjectColl, authRevokedTokenColl, "custom-role", authApiTokenColl, "users"}}}},
+		}}},
+	}
+
+	for {
+		stream, err := mongoClient.Database(authDBName).Watch(ctx, pipeline)
+		if err == nil {
+			watchAuthChanges(ctx, stream)
+			stream.Close(context.Background())
+		} else {
+			logrus.Errorf("failed to watch the authentication database, error: %v", err)
+		}
+
+		// the changes made while the stream was down are unknown
+		Decisions().InvalidateAll()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryInterval):
+		}
+	}
+}
+
+// authChange is a change of the database of the Authentication service
+type authChange struct {
+	OperationType string `bson:"operationType"`
+	Namespace     struct {
+		Collection string `bson:"coll"`
+	} `bson:"ns"`
+	DocumentKey struct {
+		ID string `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument struct {
+		Token string `bson:"token"`
+	} `bson:"fullDocument"`
+	UpdateDescription struct {
+		UpdatedFields bson.M   `bson:"updatedFields"`
+		RemovedFields []string `bson:"removedFields"`
+	} `bson:"updateDescription"`
+}
+
+// isApiTokenUsage checks if the change only records the usage of an API token
+func (c authChange) isApiTokenUsage() bool {
+	if c.Namespace.Collection != authApiTokenColl || c.OperationType != "update" ||
+		len(c.UpdateDescription.UpdatedFields) == 0 || len(c.UpdateDescription.RemovedFields) > 0 {
+		return false
+	}
+	for field := range c.UpdateDescription.UpdatedFields {
+		if !apiTokenUsageFields[field] {
+			return false
+		}
+	}
+	return true
+}
+
+// apply invalidates the decisions affected by the change
+func (d *DecisionCache) apply(change authChange) {
+	switch {
+	case change.isApiTokenUsage():
+	case change.Namespace.Collection == authProjectColl && change.DocumentKey.ID != "":
+		d.InvalidateProject(change.DocumentKey.ID)
+	case change.Namespace.Collection == authRevokedTokenColl && change.FullDocument.Token != "":
+		d.InvalidateToken(change.FullDocument.Token)
+	default:
+		d.InvalidateAll()
+	}
+}
+
+func watchAuthChanges(ctx context.Context, stream *mongo.ChangeStream) {
+	for stream.Next(ctx) {
+		var change authChange
+		if err := stream.Decode(&change); err != nil {
+			logrus.Errorf("failed to decode the authentication database change, error: %v", err)
+			Decisions().InvalidateAll()
+			continue
+		}
+		Decisions().apply(change)
+	}
+	if err := stream.Err(); err != nil && ctx.Err() == nil {
+		logrus.Errorf("authentication database change stream closed, error: %v", err)
+	}
+}
@@ -0,0 +1,234 @@
+package authorization
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestDecisionCache(t *testing.T) {
+	key := newDecisionKey("jwt", "project-id", GetWorkflowRun, "Accepted", "10.0.0.1")
+	otherProjectKey := newDecisionKey("jwt", "other-project-id", GetWorkflowRun, "Accepted", "10.0.0.1")
+
+	testcases := []struct {
+		name  string
+		ttl   time.Duration
+		given func(cache *DecisionCache)
+		key   decisionKey
+		want  bool
+	}{
+		{
+			name: "allowed decision is cached",
+			ttl:  time.Minute,
+			given: func(cache *DecisionCache) {
+				cache.Allow(key, cache.Generation())
+			},
+			key:  key,
+			want: true,
+		},
+		{
+			name: "decision isn't shared across tokens",
+			ttl:  time.Minute,
+			given: func(cache *DecisionCache) {
+				cache.Allow(key, cache.Generation())
+			},
+			key:  newDecisionKey("other-jwt", "project-id", GetWorkflowRun, "Accepted", "10.0.0.1"),
+			want: false,
+		},
+		{
+			name: "decision isn't shared across operations",
+			ttl:  time.Minute,
+			given: func(cache *DecisionCache) {
+				cache.Allow(key, cache.Generation())
+			},
+			key:  newDecisionKey("jwt", "project-id", StopChaosExperiment, "Accepted", "10.0.0.1"),
+			want: false,
+		},
+		{
+			name: "decision expires",
+			ttl:  time.Millisecond,
+			given: func(cache *DecisionCache) {
+				cache.Allow(key, cache.Generation())
+				time.Sleep(2 * time.Millisecond)
+			},
+			key:  key,
+			want: false,
+		},
+		{
+			name: "disabled cache",
+			given: func(cache *DecisionCache) {
+				cache.Allow(key, cache.Generation())
+			},
+			key:  key,
+			want: false,
+		},
+		{
+			name: "project invalidation",
+			ttl:  time.Minute,
+			given: func(cache *DecisionCache) {
+				cache.Allow(key, cache.Generation())
+				cache.Allow(otherProjectKey, cache.Generation())
+				cache.InvalidateProject("project-id")
+			},
+			key:  key,
+			want: false,
+		},
+		{
+			name: "project invalidation keeps the other projects",
+			ttl:  time.Minute,
+			given: func(cache *DecisionCache) {
+				cache.Allow(key, cache.Generation())
+				cache.Allow(otherProjectKey, cache.Generation())
+				cache.InvalidateProject("project-id")
+			},
+			key:  otherProjectKey,
+			want: true,
+		},
+		{
+			name: "token invalidation",
+			ttl:  time.Minute,
+			given: func(cache *DecisionCache) {
+				cache.Allow(key, cache.Generation())
+				cache.Allow(otherProjectKey, cache.Generation())
+				cache.InvalidateToken("jwt")
+			},
+			key:  otherProjectKey,
+			want: false,
+		},
+		{
+			name: "token invalidation keeps the other tokens",
+			ttl:  time.Minute,
+			given: func(cache *DecisionCache) {
+				cache.Allow(key, cache.Generation())
+				cache.InvalidateToken("other-jwt")
+			},
+			key:  key,
+			want: true,
+		},
+		{
+			name: "full invalidation",
+			ttl:  time.Minute,
+			given: func(cache *DecisionCache) {
+				cache.Allow(key, cache.Generation())
+				cache.InvalidateAll()
+			},
+			key:  key,
+			want: false,
+		},
+		{
+			name: "decision made before an invalidation",
+			ttl:  time.Minute,
+			given: func(cache *DecisionCache) {
+				generation := cache.Generation()
+				cache.InvalidateProject("project-id")
+				cache.Allow(key, generation)
+			},
+			key:  key,
+			want: false,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cache := NewDecisionCache(tc.ttl)
+			tc.given(cache)
+			if got := cache.IsAllowed(tc.key); got != tc.want {
+				t.Errorf("IsAllowed() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDecisionCache_Apply(t *testing.T) {
+	key := newDecisionKey("jwt", "project-id", GetWorkflowRun, "Accepted", "10.0.0.1")
+
+	testcases := []struct {
+		name   string
+		change func(change *authChange)
+		want   bool
+	}{
+		{
+			name: "api token usage",
+			change: func(change *authChange) {
+				change.Namespace.Collection = authApiTokenColl
+				change.OperationType = "update"
+				change.UpdateDescription.UpdatedFields = bson.M{"last_used_at": int64(1), "last_used_ip": "10.0.0.1"}
+			},
+			want: true,
+		},
+		{
+			name: "api token update",
+			change: func(change *authChange) {
+				change.Namespace.Collection = authApiTokenColl
+				change.OperationType = "update"
+				change.UpdateDescription.UpdatedFields = bson.M{"last_used_at": int64(1), "expires_at": int64(2)}
+			},
+			want: false,
+		},
+		{
+			name: "api token deletion",
+			change: func(change *authChange) {
+				change.Namespace.Collection = authApiTokenColl
+				change.OperationType = "delete"
+			},
+			want: false,
+		},
+		{
+			name: "other project",
+			change: func(change *authChange) {
+				change.Namespace.Collection = authProjectColl
+				change.DocumentKey.ID = "other-project-id"
+			},
+			want: true,
+		},
+		{
+			name: "role change",
+			change: func(change *authChange) {
+				change.Namespace.Collection = "custom-role"
+				change.OperationType = "update"
+			},
+			want: false,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cache := NewDecisionCache(time.Minute)
+			cache.Allow(key, cache.Generation())
+			var change authChange
+			tc.change(&change)
+			cache.apply(change)
+			if got := cache.IsAllowed(key); got != tc.want {
+				t.Errorf("IsAllowed() = %v after the change, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDecisionCache_Bounded(t *testing.T) {
+	cache := NewDecisionCache(time.Minute)
+	for i := 0; i < maxDecisions+10; i++ {
+		cache.Allow(newDecisionKey(fmt.Sprintf("jwt-%d", i), "project-id", GetWorkflowRun, "Accepted", ""), cache.Generation())
+	}
+	if len(cache.decisions) > maxDecisions {
+		t.Errorf("cache holds %d decisions, want at most %d", len(cache.decisions), maxDecisions)
+	}
+}
+
+// BenchmarkDecisionCache measures a cached decision, which replaces a round trip to the Authentication
+// service, see BenchmarkValidatorGRPCRequest in pkg/grpc for the cost of the round trip
+func BenchmarkDecisionCache(b *testing.B) {
+	cache := NewDecisionCache(time.Minute)
+	jwt := generateFakeJWTToken("admin")
+	cache.Allow(newDecisionKey(jwt, "project-id", GetWorkflowRun, "Accepted", "10.0.0.1"), cache.Generation())
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if !cache.IsAllowed(newDecisionKey(jwt, "project-id", GetWorkflowRun, "Accepted", "10.0.0.1")) {
+				b.Error("decision isn't cached")
+				return
+			}
+		}
+	})
+}
@@ -15,6 +15,7 @@ type contextKey string
 const (
 	AuthKey      = contextKey("authorization")
 	UserClaim    = contextKey("user-claims")
+	ClientIPKey  = contextKey("client-ip")
 	BearerSchema = "Bearer "
 	CookieName   = "token"
 )
@@ -35,6 +36,7 @@ func Middleware(handler http.Handler, mongoClient *mongo.Client) gin.HandlerFunc
 			return
 		}
 		ctx := context.WithValue(c.Request.Context(), AuthKey, jwt)
+		ctx = context.WithValue(ctx, ClientIPKey, c.ClientIP())
 		c.Request = c.Request.WithContext(ctx)
 		handler.ServeHTTP(c.Writer, c.Request)
 	}
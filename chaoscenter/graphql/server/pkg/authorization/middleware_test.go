@@ -0,0 +1,58 @@
+package authorization
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/utils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMiddlewareClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	// the client isn't connected, so no token is found revoked
+	mongoClient, err := mongo.NewClient(options.Client().ApplyURI("mongodb://localhost:27017"))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		expectedIP     string
+	}{
+		{
+			name:       "spoofed forwarded address without trusted proxies",
+			expectedIP: "192.0.2.1",
+		},
+		{
+			name:           "forwarded address from a trusted proxy",
+			trustedProxies: []string{"192.0.2.1"},
+			expectedIP:     "10.0.0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the router is set up like the server, which trusts the proxies configured in TRUSTED_PROXIES
+			utils.Config.TrustedProxies = tt.trustedProxies
+			router := gin.New()
+			assert.NoError(t, router.SetTrustedProxies(utils.Config.TrustedProxies))
+			var clientIP interface{}
+			router.GET("/query", Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				clientIP = r.Context().Value(ClientIPKey)
+			}), mongoClient))
+
+			req := httptest.NewRequest(http.MethodGet, "/query", nil)
+			req.RemoteAddr = "192.0.2.1:4000"
+			req.Header.Set("Authorization", BearerSchema+"token")
+			req.Header.Set("X-Forwarded-For", "10.0.0.1")
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.expectedIP, clientIP)
+		})
+	}
+	utils.Config.TrustedProxies = nil
+}
@@ -4,22 +4,26 @@ import (
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
 )
 
-// RoleQuery states the query for the roles
+// RoleQuery states the query for the roles. The queries are also the operations the permissions of custom
+// project roles grant, which are mapped in the permission catalogue of the authentication service
 type RoleQuery string
 
 const (
 	// Chaos_Infrastructure
 	UserInfrastructureReg RoleQuery = "userInfrastructureReg"
 	ListInfrastructures   RoleQuery = "ListInfrastructures"
-	GetInfrastructure     RoleQuery = "GetInfrastructure "
+	GetInfrastructure     RoleQuery = "GetInfrastructure"
 	DeleteInfrastructures RoleQuery = "DeleteInfrastructures"
 	GetManifest           RoleQuery = "GetManifest"
 	GetInfraDetails       RoleQuery = "GetInfraDetails"
+	ListInfraActions      RoleQuery = "ListInfraActions"
+	UpgradeInfrastructure RoleQuery = "UpgradeInfrastructure"
+	ListInfraUpgrades     RoleQuery = "ListInfraUpgrades"
 
 	// Chaos_Experiment
 	CreateChaosExperiment RoleQuery = "CreateChaosExperiment"
 	ReRunChaosExperiment  RoleQuery = "ReRunChaosExperiment"
-	DeleteChaosExperiment RoleQuery = "DeleteChaosEnvironment"
+	DeleteChaosExperiment RoleQuery = "DeleteChaosExperiment"
 	UpdateChaosExperiment RoleQuery = "UpdateChaosExperiment"
 	ListExperiment        RoleQuery = "ListExperiment"
 	CreateEnvironment     RoleQuery = "CreateEnvironment"
@@ -37,10 +41,12 @@ const (
 	SaveChaosHub   RoleQuery = "SaveChaosHub"
 
 	// GitOps
-	EnableGitOps     RoleQuery = "EnableGitOps"
-	DisableGitOps    RoleQuery = "DisableGitOps"
-	UpdateGitOps     RoleQuery = "UpdateGitOps"
-	GetGitOpsDetails RoleQuery = "GetGitOpsDetails"
+	EnableGitOps          RoleQuery = "EnableGitOps"
+	DisableGitOps         RoleQuery = "DisableGitOps"
+	UpdateGitOps          RoleQuery = "UpdateGitOps"
+	GetGitOpsDetails      RoleQuery = "GetGitOpsDetails"
+	ListGitOpsConflicts   RoleQuery = "ListGitOpsConflicts"
+	ResolveGitOpsConflict RoleQuery = "ResolveGitOpsConflict"
 
 	// Image_Registry
 	CreateImageRegistry RoleQuery = "CreateImageRegistry"
@@ -56,53 +62,75 @@ const (
 	ListEnvironments  RoleQuery = "ListEnvironments"
 
 	// Probe
-	AddProbe               RoleQuery = "AddProbe"
-	DeleteProbe            RoleQuery = "DeleteProbe"
-	UpdateProbe            RoleQuery = "UpdateProbe"
-	GetProbe               RoleQuery = "GetProbe"
-	ListProbes             RoleQuery = "ListProbes"
-	MemberRoleOwnerString            = string(model.MemberRoleOwner)
-	MemberRoleEditorString           = string(model.MemberRoleEditor)
-	MemberRoleViewerString           = string(model.MemberRoleViewer)
+	AddProbe    RoleQuery = "AddProbe"
+	DeleteProbe RoleQuery = "DeleteProbe"
+	UpdateProbe RoleQuery = "UpdateProbe"
+	GetProbe    RoleQuery = "GetProbe"
+	ListProbes  RoleQuery = "ListProbes"
+
+	// Notification_Channel
+	CreateNotificationChannel RoleQuery = "CreateNotificationChannel"
+	UpdateNotificationChannel RoleQuery = "UpdateNotificationChannel"
+	DeleteNotificationChannel RoleQuery = "DeleteNotificationChannel"
+	GetNotificationChannel    RoleQuery = "GetNotificationChannel"
+	ListNotificationChannels  RoleQuery = "ListNotificationChannels"
+
+	// Audit_Log
+	ListAuditLogs RoleQuery = "ListAuditLogs"
+
+	MemberRoleOwnerString  = string(model.MemberRoleOwner)
+	MemberRoleEditorString = string(model.MemberRoleEditor)
+	MemberRoleViewerString = string(model.MemberRoleViewer)
 )
 
 var MutationRbacRules = map[RoleQuery][]string{
-	UserInfrastructureReg: {MemberRoleOwnerString, MemberRoleEditorString},
-	CreateChaosExperiment: {MemberRoleOwnerString, MemberRoleEditorString},
-	ReRunChaosExperiment:  {MemberRoleOwnerString, MemberRoleEditorString},
-	DeleteChaosExperiment: {MemberRoleOwnerString, MemberRoleEditorString},
-	StopChaosExperiment:   {MemberRoleOwnerString, MemberRoleEditorString},
-	AddChaosHub:           {MemberRoleOwnerString, MemberRoleEditorString},
-	UpdateChaosExperiment: {MemberRoleOwnerString, MemberRoleEditorString},
-	DeleteInfrastructures: {MemberRoleOwnerString, MemberRoleEditorString},
-	UpdateChaosHub:        {MemberRoleOwnerString, MemberRoleEditorString},
-	DeleteChaosHub:        {MemberRoleOwnerString, MemberRoleEditorString},
-	EnableGitOps:          {MemberRoleOwnerString},
-	DisableGitOps:         {MemberRoleOwnerString},
-	UpdateGitOps:          {MemberRoleOwnerString},
-	ListWorkflowRuns:      {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
-	GetWorkflowRun:        {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
-	ListInfrastructures:   {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
-	GetInfrastructure:     {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
-	GetManifest:           {MemberRoleOwnerString, MemberRoleEditorString},
-	GetInfraDetails:       {MemberRoleOwnerString, MemberRoleEditorString},
-	ListCharts:            {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
-	ListExperiment:        {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
-	SaveChaosHub:          {MemberRoleOwnerString, MemberRoleEditorString},
-	CreateImageRegistry:   {MemberRoleOwnerString},
-	UpdateImageRegistry:   {MemberRoleOwnerString},
-	DeleteImageRegistry:   {MemberRoleOwnerString},
-	GetGitOpsDetails:      {MemberRoleOwnerString},
-	ListImageRegistry:     {MemberRoleOwnerString},
-	GetImageRegistry:      {MemberRoleOwnerString},
-	CreateEnvironment:     {MemberRoleOwnerString, MemberRoleEditorString},
-	UpdateEnvironment:     {MemberRoleOwnerString, MemberRoleEditorString},
-	DeleteEnvironment:     {MemberRoleOwnerString, MemberRoleEditorString},
-	GetEnvironment:        {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
-	ListEnvironments:      {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
-	AddProbe:              {MemberRoleOwnerString, MemberRoleEditorString},
-	UpdateProbe:           {MemberRoleOwnerString, MemberRoleEditorString},
-	GetProbe:              {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
-	ListProbes:            {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
-	DeleteProbe:           {MemberRoleOwnerString, MemberRoleEditorString},
+	UserInfrastructureReg:     {MemberRoleOwnerString, MemberRoleEditorString},
+	CreateChaosExperiment:     {MemberRoleOwnerString, MemberRoleEditorString},
+	ReRunChaosExperiment:      {MemberRoleOwnerString, MemberRoleEditorString},
+	DeleteChaosExperiment:     {MemberRoleOwnerString, MemberRoleEditorString},
+	StopChaosExperiment:       {MemberRoleOwnerString, MemberRoleEditorString},
+	AddChaosHub:               {MemberRoleOwnerString, MemberRoleEditorString},
+	UpdateChaosExperiment:     {MemberRoleOwnerString, MemberRoleEditorString},
+	DeleteInfrastructures:     {MemberRoleOwnerString, MemberRoleEditorString},
+	UpdateChaosHub:            {MemberRoleOwnerString, MemberRoleEditorString},
+	DeleteChaosHub:            {MemberRoleOwnerString, MemberRoleEditorString},
+	EnableGitOps:              {MemberRoleOwnerString},
+	DisableGitOps:             {MemberRoleOwnerString},
+	UpdateGitOps:              {MemberRoleOwnerString},
+	ListWorkflowRuns:          {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
+	GetWorkflowRun:            {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
+	ListInfrastructures:       {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
+	GetInfrastructure:         {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
+	GetManifest:               {MemberRoleOwnerString, MemberRoleEditorString},
+	GetInfraDetails:           {MemberRoleOwnerString, MemberRoleEditorString},
+	ListInfraActions:          {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
+	UpgradeInfrastructure:     {MemberRoleOwnerString, MemberRoleEditorString},
+	ListInfraUpgrades:         {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
+	ListCharts:                {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
+	ListExperiment:            {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
+	SaveChaosHub:              {MemberRoleOwnerString, MemberRoleEditorString},
+	CreateImageRegistry:       {MemberRoleOwnerString},
+	UpdateImageRegistry:       {MemberRoleOwnerString},
+	DeleteImageRegistry:       {MemberRoleOwnerString},
+	GetGitOpsDetails:          {MemberRoleOwnerString},
+	ListGitOpsConflicts:       {MemberRoleOwnerString},
+	ResolveGitOpsConflict:     {MemberRoleOwnerString},
+	ListImageRegistry:         {MemberRoleOwnerString},
+	GetImageRegistry:          {MemberRoleOwnerString},
+	CreateEnvironment:         {MemberRoleOwnerString, MemberRoleEditorString},
+	UpdateEnvironment:         {MemberRoleOwnerString, MemberRoleEditorString},
+	DeleteEnvironment:         {MemberRoleOwnerString, MemberRoleEditorString},
+	GetEnvironment:            {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
+	ListEnvironments:          {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
+	AddProbe:                  {MemberRoleOwnerString, MemberRoleEditorString},
+	UpdateProbe:               {MemberRoleOwnerString, MemberRoleEditorString},
+	GetProbe:                  {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
+	ListProbes:                {MemberRoleOwnerString, MemberRoleEditorString, MemberRoleViewerString},
+	DeleteProbe:               {MemberRoleOwnerString, MemberRoleEditorString},
+	CreateNotificationChannel: {MemberRoleOwnerString},
+	UpdateNotificationChannel: {MemberRoleOwnerString},
+	DeleteNotificationChannel: {MemberRoleOwnerString},
+	GetNotificationChannel:    {MemberRoleOwnerString, MemberRoleEditorString},
+	ListNotificationChannels:  {MemberRoleOwnerString, MemberRoleEditorString},
+	ListAuditLogs:             {MemberRoleOwnerString},
 }
@@ -5,22 +5,28 @@ import (
 	"errors"
 
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/grpc"
-
-	grpc2 "google.golang.org/grpc"
 )
 
-// ValidateRole Validates the role of a user in a given project
+// ValidateRole Validates the role of a user in a given project for the given operation. Members with custom
+// roles are checked against the permissions of their role, and API tokens against their scope. The
+// operations which aren't scoped to a project, with an empty projectID, are only allowed to the admins
 func ValidateRole(ctx context.Context, projectID string,
-	requiredRoles []string, invitation string) error {
+	operation RoleQuery, invitation string) error {
 	jwt := ctx.Value(AuthKey).(string)
-	var conn *grpc2.ClientConn
-	client, conn := grpc.GetAuthGRPCSvcClient(conn)
-	defer conn.Close()
-	err := grpc.ValidatorGRPCRequest(client, jwt, projectID,
-		requiredRoles,
-		invitation)
+	clientIP, _ := ctx.Value(ClientIPKey).(string)
+	key := newDecisionKey(jwt, projectID, operation, invitation, clientIP)
+	if Decisions().IsAllowed(key) {
+		return nil
+	}
+	// the decision isn't cached if the access changes while it's being made
+	generation := Decisions().Generation()
+
+	err := grpc.ValidatorGRPCRequest(grpc.GetAuthGRPCSvcClient(), jwt, projectID,
+		MutationRbacRules[operation],
+		invitation, string(operation), clientIP)
 	if err != nil {
 		return errors.New("permission_denied")
 	}
+	Decisions().Allow(key, generation)
 	return nil
 }
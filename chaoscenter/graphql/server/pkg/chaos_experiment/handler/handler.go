@@ -41,6 +41,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// podLogChunkBuffer is the number of chunks of the logs kept while the subscription is sending the previous ones
+const podLogChunkBuffer = 16
+
 // ChaosExperimentHandler is the handler for chaos experiment
 type ChaosExperimentHandler struct {
 	chaosExperimentService     ops.Service
@@ -93,13 +96,17 @@ func (c *ChaosExperimentHandler) SaveChaosExperiment(ctx context.Context, reques
 
 	// typecasting request into chaosExperimentRequest
 	chaosWfReq := model.ChaosExperimentRequest{
-		ExperimentID:          &request.ID,
-		ExperimentManifest:    request.Manifest,
-		ExperimentType:        request.Type,
-		ExperimentName:        request.Name,
-		ExperimentDescription: request.Description,
-		InfraID:               request.InfraID,
-		Tags:                  request.Tags,
+		ExperimentID:            &request.ID,
+		ExperimentManifest:      request.Manifest,
+		ExperimentType:          request.Type,
+		ExperimentName:          request.Name,
+		ExperimentDescription:   request.Description,
+		InfraID:                 request.InfraID,
+		Tags:                    request.Tags,
+		ScoringStrategy:         request.ScoringStrategy,
+		AcceptanceCriteria:      request.AcceptanceCriteria,
+		ClearScoringStrategy:    request.ClearScoringStrategy,
+		ClearAcceptanceCriteria: request.ClearAcceptanceCriteria,
 	}
 
 	newRequest, wfType, err := c.chaosExperimentService.ProcessExperiment(ctx, &chaosWfReq, projectID, revID)
@@ -122,6 +129,18 @@ func (c *ChaosExperimentHandler) SaveChaosExperiment(ctx context.Context, reques
 			}
 		}
 
+		// Keep the scoring strategy and the acceptance criteria of the experiment if the request neither changes
+		// nor clears them
+		if len(wfDetails.Revision) > 0 {
+			latestRevision := wfDetails.Revision[len(wfDetails.Revision)-1]
+			if newRequest.ScoringStrategy == nil && (newRequest.ClearScoringStrategy == nil || !*newRequest.ClearScoringStrategy) {
+				newRequest.ScoringStrategy = latestRevision.ScoringStrategy.GetInputScoringStrategy()
+			}
+			if newRequest.AcceptanceCriteria == nil && (newRequest.ClearAcceptanceCriteria == nil || !*newRequest.ClearAcceptanceCriteria) {
+				newRequest.AcceptanceCriteria = latestRevision.AcceptanceCriteria.GetInputAcceptanceCriteria()
+			}
+		}
+
 		// Gitops Update
 		err = c.gitOpsService.UpsertExperimentToGit(ctx, projectID, newRequest)
 		if err != nil {
@@ -496,6 +515,8 @@ func (c *ChaosExperimentHandler) GetExperiment(ctx context.Context, projectID st
 				Username: exp.UpdatedBy.Username,
 			},
 			RecentExperimentRunDetails: recentExpRuns,
+			ScoringStrategy:            exp.Revision[len(exp.Revision)-1].ScoringStrategy.GetOutputScoringStrategy(),
+			AcceptanceCriteria:         exp.Revision[len(exp.Revision)-1].AcceptanceCriteria.GetOutputAcceptanceCriteria(),
 		},
 		AverageResiliencyScore: &avg,
 	}
@@ -853,6 +874,8 @@ func (c *ChaosExperimentHandler) ListExperiment(projectID string, request model.
 				Username: workflow.UpdatedBy.Username,
 			},
 			RecentExperimentRunDetails: recentExpRuns,
+			ScoringStrategy:            workflow.Revision[len(workflow.Revision)-1].ScoringStrategy.GetOutputScoringStrategy(),
+			AcceptanceCriteria:         workflow.Revision[len(workflow.Revision)-1].AcceptanceCriteria.GetOutputAcceptanceCriteria(),
 		}
 		result = append(result, &newChaosExperiments)
 
@@ -1213,17 +1236,63 @@ func (c *ChaosExperimentHandler) GetLogs(reqID string, pod model.PodLogRequest,
 			ExternalData: &externalData,
 		},
 	}
-	if clusterChan, ok := r.ConnectedInfra[pod.InfraID]; ok {
-		clusterChan <- &payload
-	} else if reqChan, ok := r.ExperimentLog[reqID]; ok {
-		resp := model.PodLogResponse{
-			PodName:         pod.PodName,
-			ExperimentRunID: pod.ExperimentRunID,
-			PodType:         pod.PodType,
-			Log:             "INFRA ERROR : INFRA NOT CONNECTED",
+	// the infra may be connected to another replica
+	if r.Bus.IsInfraConnected(pod.InfraID) {
+		if err := r.Bus.SendInfraAction(pod.InfraID, &payload); err != nil {
+			logrus.WithError(err).Error("failed to send the log request to the infra")
+		}
+		return
+	}
+	resp := model.PodLogResponse{
+		PodName:         pod.PodName,
+		ExperimentRunID: pod.ExperimentRunID,
+		PodType:         pod.PodType,
+		Log:             "INFRA ERROR : INFRA NOT CONNECTED",
+		Done:            true,
+	}
+	if err := r.Bus.SendPodLog(reqID, &resp); err != nil {
+		logrus.WithError(err).Error("failed to send the log response")
+	}
+}
+
+// StreamLogs requests the logs of a pod from the cluster and sends their chunks to the subscription until the
+// last chunk or until the context is done, in which case the cluster stops streaming them
+func (c *ChaosExperimentHandler) StreamLogs(ctx context.Context, reqID string, pod model.PodLogRequest, podLog chan<- *model.PodLogResponse, r store.StateData) {
+	defer close(podLog)
+	chunks := make(chan *model.PodLogResponse, podLogChunkBuffer)
+	unsubscribe := r.SubscribePodLog(reqID, chunks)
+	defer unsubscribe()
+
+	c.GetLogs(reqID, pod, r)
+	for {
+		select {
+		case <-ctx.Done():
+			c.cancelLogs(reqID, pod.InfraID, r)
+			return
+		case chunk := <-chunks:
+			select {
+			case podLog <- chunk:
+			case <-ctx.Done():
+				c.cancelLogs(reqID, pod.InfraID, r)
+				return
+			}
+			if chunk.Done {
+				return
+			}
 		}
-		reqChan <- &resp
-		close(reqChan)
+	}
+}
+
+// cancelLogs stops the streaming of the logs of a request on the cluster
+func (c *ChaosExperimentHandler) cancelLogs(reqID string, infraID string, r store.StateData) {
+	payload := model.InfraActionResponse{
+		Action: &model.ActionPayload{
+			RequestID:   reqID,
+			RequestType: "logs_cancel",
+		},
+	}
+	if err := r.Bus.SendInfraAction(infraID, &payload); err != nil {
+		logrus.WithError(err).Error("failed to cancel the log request on the infra")
 	}
 }
 
@@ -1241,15 +1310,18 @@ func (c *ChaosExperimentHandler) GetKubeObjData(reqID string, kubeObject model.K
 			ExternalData: &externalData,
 		},
 	}
-	if clusterChan, ok := r.ConnectedInfra[kubeObject.InfraID]; ok {
-		clusterChan <- &payload
-	} else if reqChan, ok := r.KubeObjectData[reqID]; ok {
-		resp := model.KubeObjectResponse{
-			InfraID: kubeObject.InfraID,
-			KubeObj: []*model.KubeObject{},
+	if r.Bus.IsInfraConnected(kubeObject.InfraID) {
+		if err := r.Bus.SendInfraAction(kubeObject.InfraID, &payload); err != nil {
+			logrus.WithError(err).Error("failed to send the kube object request to the infra")
 		}
-		reqChan <- &resp
-		close(reqChan)
+		return
+	}
+	resp := model.KubeObjectResponse{
+		InfraID: kubeObject.InfraID,
+		KubeObj: []*model.KubeObject{},
+	}
+	if err := r.Bus.SendKubeObject(reqID, &resp); err != nil {
+		logrus.WithError(err).Error("failed to send the kube object response")
 	}
 }
 
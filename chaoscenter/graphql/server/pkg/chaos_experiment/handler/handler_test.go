@@ -736,3 +736,59 @@ func TestChaosExperimentHandler_GetExperimentStats(t *testing.T) {
 		})
 	}
 }
+
+func TestChaosExperimentHandler_StreamLogs(t *testing.T) {
+	pod := model.PodLogRequest{InfraID: uuid.New().String(), PodName: "pod"}
+	connect := func(ctx context.Context, r *store.StateData) chan *model.InfraActionResponse {
+		infraAction := make(chan *model.InfraActionResponse, 1)
+		if err := r.ConnectInfra(ctx, pod.InfraID, infraAction, false); err != nil {
+			t.Fatalf("failed to connect the infra: %v", err)
+		}
+		return infraAction
+	}
+
+	t.Run("success: chunks are sent until the last one", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		r := store.NewStore()
+		infraAction := connect(ctx, r)
+		podLog := make(chan *model.PodLogResponse, 1)
+		mockServices := NewMockServices()
+		go mockServices.ChaosExperimentHandler.StreamLogs(ctx, "request", pod, podLog, *r)
+
+		action := <-infraAction
+		if action.Action.RequestType != "logs" || action.Action.RequestID != "request" {
+			t.Fatalf("unexpected request %s %s", action.Action.RequestType, action.Action.RequestID)
+		}
+		for _, chunk := range []*model.PodLogResponse{{Log: "first"}, {Log: "second", Done: true}} {
+			_ = r.Bus.SendPodLog("request", chunk)
+			if got := <-podLog; !reflect.DeepEqual(got, chunk) {
+				t.Errorf("ChaosExperimentHandler.StreamLogs() chunk = %v, want %v", got, chunk)
+			}
+		}
+		if _, open := <-podLog; open {
+			t.Error("ChaosExperimentHandler.StreamLogs() didn't end after the last chunk")
+		}
+	})
+
+	t.Run("success: the request is cancelled when the subscription ends", func(t *testing.T) {
+		infraCtx, cancelInfra := context.WithCancel(context.Background())
+		defer cancelInfra()
+		r := store.NewStore()
+		infraAction := connect(infraCtx, r)
+		ctx, cancel := context.WithCancel(context.Background())
+		podLog := make(chan *model.PodLogResponse, 1)
+		mockServices := NewMockServices()
+		go mockServices.ChaosExperimentHandler.StreamLogs(ctx, "request", pod, podLog, *r)
+
+		<-infraAction
+		cancel()
+		action := <-infraAction
+		if action.Action.RequestType != "logs_cancel" || action.Action.RequestID != "request" {
+			t.Errorf("unexpected request %s %s", action.Action.RequestType, action.Action.RequestID)
+		}
+		if _, open := <-podLog; open {
+			t.Error("ChaosExperimentHandler.StreamLogs() didn't end with the subscription")
+		}
+	})
+}
@@ -13,6 +13,8 @@ import (
 
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/chaos_infrastructure"
 
+	chaosExperimentRun "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/chaos_experiment_run"
+
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
 	dbChaosExperimentRun "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_experiment_run"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -79,6 +81,19 @@ func (c *chaosExperimentService) ProcessExperiment(ctx context.Context, workflow
 		return nil, nil, errors.New("ProjectID doesn't match with the chaos_infra identifiers")
 	}
 
+	if _, err := chaosExperimentRun.NewScoringStrategy(dbChaosExperiment.NewScoringStrategy(workflow.ScoringStrategy)); err != nil {
+		return nil, nil, errors.New("invalid resiliency scoring strategy: " + err.Error())
+	}
+	if err := chaosExperimentRun.ValidateAcceptanceCriteria(workflow.AcceptanceCriteria); err != nil {
+		return nil, nil, errors.New("invalid acceptance criteria: " + err.Error())
+	}
+	if workflow.ScoringStrategy != nil && isCleared(workflow.ClearScoringStrategy) {
+		return nil, nil, errors.New("the resiliency scoring strategy can't be both set and cleared")
+	}
+	if workflow.AcceptanceCriteria != nil && isCleared(workflow.ClearAcceptanceCriteria) {
+		return nil, nil, errors.New("the acceptance criteria can't be both set and cleared")
+	}
+
 	wfType := dbChaosExperiment.NonCronExperiment
 	var (
 		workflowID = uuid.New().String()
@@ -171,6 +186,8 @@ func (c *chaosExperimentService) ProcessExperimentCreation(ctx context.Context,
 		ExperimentManifest: input.ExperimentManifest,
 		UpdatedAt:          timeNow,
 		Weightages:         weightages,
+		ScoringStrategy:    dbChaosExperiment.NewScoringStrategy(input.ScoringStrategy),
+		AcceptanceCriteria: dbChaosExperiment.NewAcceptanceCriteria(input.AcceptanceCriteria),
 	})
 
 	newChaosExperiment := dbChaosExperiment.ChaosExperimentRequest{
@@ -227,11 +244,34 @@ func (c *chaosExperimentService) ProcessExperimentUpdate(workflow *model.ChaosEx
 		}
 	}
 
+	// the new revision keeps the scoring strategy and the acceptance criteria of the experiment if the request
+	// neither changes nor clears them
+	scoringStrategy := dbChaosExperiment.NewScoringStrategy(workflow.ScoringStrategy)
+	acceptanceCriteria := dbChaosExperiment.NewAcceptanceCriteria(workflow.AcceptanceCriteria)
+	keepScoringStrategy := scoringStrategy == nil && !isCleared(workflow.ClearScoringStrategy)
+	keepAcceptanceCriteria := acceptanceCriteria == nil && !isCleared(workflow.ClearAcceptanceCriteria)
+	if !updateRevision && workflow.ExperimentID != nil && (keepScoringStrategy || keepAcceptanceCriteria) {
+		latestRevision, err := c.getLatestExperimentRevision(*workflow.ExperimentID, projectID)
+		if err != nil {
+			return err
+		}
+		if latestRevision != nil {
+			if keepScoringStrategy {
+				scoringStrategy = latestRevision.ScoringStrategy
+			}
+			if keepAcceptanceCriteria {
+				acceptanceCriteria = latestRevision.AcceptanceCriteria
+			}
+		}
+	}
+
 	workflowRevision := dbChaosExperiment.ExperimentRevision{
 		RevisionID:         revisionID,
 		ExperimentManifest: workflow.ExperimentManifest,
 		UpdatedAt:          time.Now().UnixMilli(),
 		Weightages:         weightages,
+		ScoringStrategy:    scoringStrategy,
+		AcceptanceCriteria: acceptanceCriteria,
 	}
 
 	query := bson.D{
@@ -293,6 +333,26 @@ func (c *chaosExperimentService) ProcessExperimentUpdate(workflow *model.ChaosEx
 	return nil
 }
 
+// isCleared checks the flags of the experiment requests which clear a setting of the experiment
+func isCleared(clear *bool) bool {
+	return clear != nil && *clear
+}
+
+// getLatestExperimentRevision returns the latest revision of an experiment, or nil if it doesn't have any
+func (c *chaosExperimentService) getLatestExperimentRevision(experimentID, projectID string) (*dbChaosExperiment.ExperimentRevision, error) {
+	experiment, err := c.chaosExperimentOperator.GetExperiment(context.Background(), bson.D{
+		{"experiment_id", experimentID},
+		{"project_id", projectID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(experiment.Revision) == 0 {
+		return nil, nil
+	}
+	return &experiment.Revision[len(experiment.Revision)-1], nil
+}
+
 // ProcessExperimentDelete deletes the workflow entry and sends delete resource request to required chaos_infra
 func (c *chaosExperimentService) ProcessExperimentDelete(query bson.D, workflow dbChaosExperiment.ChaosExperimentRequest, username string, r *store.StateData) error {
 	var (
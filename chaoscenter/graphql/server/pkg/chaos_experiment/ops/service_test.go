@@ -500,3 +500,76 @@ func Test_chaosExperimentService_ProcessExperimentUpdate(t *testing.T) {
 		})
 	}
 }
+
+func Test_chaosExperimentService_ProcessExperimentUpdateKeepsRevisionSettings(t *testing.T) {
+	experimentID := uuid.NewString()
+	projectID := uuid.NewString()
+	wfType := dbChaosExperiment.NonCronExperiment
+	minResiliencyScore := 80.0
+
+	experiment := dbChaosExperiment.ChaosExperimentRequest{
+		ExperimentID: experimentID,
+		ProjectID:    projectID,
+		Revision: []dbChaosExperiment.ExperimentRevision{
+			{
+				RevisionID:         uuid.NewString(),
+				ScoringStrategy:    &dbChaosExperiment.ScoringStrategy{Type: string(model.ResiliencyScoringStrategyTypeVerdictBased)},
+				AcceptanceCriteria: &dbChaosExperiment.AcceptanceCriteria{MinResiliencyScore: &minResiliencyScore, RequiredFaults: []string{"pod-delete"}},
+			},
+		},
+	}
+	mongodbMockOperator.On("Get", mock.Anything, mongodb.ChaosExperimentCollection, mock.Anything).Return(mongo.NewSingleResultFromDocument(experiment, nil, nil), nil).Once()
+	var update bson.D
+	mongodbMockOperator.On("Update", mock.Anything, mongodb.ChaosExperimentCollection, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		update = args.Get(3).(bson.D)
+	}).Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+
+	// the request changes the scoring strategy but omits the acceptance criteria
+	workflow := &model.ChaosExperimentRequest{
+		ExperimentID:       &experimentID,
+		ExperimentManifest: "{\"kind\": \"SomeKubernetesKind\", \"apiVersion\": \"v1\", \"metadata\": {\"name\": \"some-name\"}}",
+		ScoringStrategy:    &model.ResiliencyScoringStrategyInput{Type: model.ResiliencyScoringStrategyTypeCriticalFaultGate, CriticalFaults: []string{"pod-delete"}},
+	}
+	if err := chaosExperimentRunTestService.ProcessExperimentUpdate(workflow, "test", &wfType, uuid.NewString(), false, projectID, nil); err != nil {
+		t.Fatalf("chaosExperimentService.ProcessExperimentUpdate() error = %v", err)
+	}
+
+	revision := update[1].Value.(bson.D)[0].Value.(dbChaosExperiment.ExperimentRevision)
+	if revision.ScoringStrategy == nil || revision.ScoringStrategy.Type != string(model.ResiliencyScoringStrategyTypeCriticalFaultGate) {
+		t.Errorf("the new revision has the scoring strategy %v, want the one of the request", revision.ScoringStrategy)
+	}
+	if !reflect.DeepEqual(revision.AcceptanceCriteria, experiment.Revision[0].AcceptanceCriteria) {
+		t.Errorf("the new revision has the acceptance criteria %v, want the ones of the previous revision", revision.AcceptanceCriteria)
+	}
+}
+
+func Test_chaosExperimentService_ProcessExperimentUpdateClearsRevisionSettings(t *testing.T) {
+	experimentID := uuid.NewString()
+	projectID := uuid.NewString()
+	wfType := dbChaosExperiment.NonCronExperiment
+	clear := true
+
+	var update bson.D
+	mongodbMockOperator.On("Update", mock.Anything, mongodb.ChaosExperimentCollection, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		update = args.Get(3).(bson.D)
+	}).Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+
+	// the request clears both the scoring strategy and the acceptance criteria
+	workflow := &model.ChaosExperimentRequest{
+		ExperimentID:            &experimentID,
+		ExperimentManifest:      "{\"kind\": \"SomeKubernetesKind\", \"apiVersion\": \"v1\", \"metadata\": {\"name\": \"some-name\"}}",
+		ClearScoringStrategy:    &clear,
+		ClearAcceptanceCriteria: &clear,
+	}
+	if err := chaosExperimentRunTestService.ProcessExperimentUpdate(workflow, "test", &wfType, uuid.NewString(), false, projectID, nil); err != nil {
+		t.Fatalf("chaosExperimentService.ProcessExperimentUpdate() error = %v", err)
+	}
+
+	revision := update[1].Value.(bson.D)[0].Value.(dbChaosExperiment.ExperimentRevision)
+	if revision.ScoringStrategy != nil {
+		t.Errorf("the new revision has the scoring strategy %v, want it cleared", revision.ScoringStrategy)
+	}
+	if revision.AcceptanceCriteria != nil {
+		t.Errorf("the new revision has the acceptance criteria %v, want them cleared", revision.AcceptanceCriteria)
+	}
+}
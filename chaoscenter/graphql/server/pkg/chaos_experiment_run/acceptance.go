@@ -0,0 +1,85 @@
+package chaos_experiment_run
+
+import (
+	"errors"
+	"fmt"
+
+	chaosTypes "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	dbChaosExperiment "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_experiment"
+)
+
+// ValidateAcceptanceCriteria checks the acceptance criteria of an experiment request
+func ValidateAcceptanceCriteria(criteria *model.AcceptanceCriteriaInput) error {
+	if criteria == nil {
+		return nil
+	}
+	if criteria.MinResiliencyScore != nil && (*criteria.MinResiliencyScore < 0 || *criteria.MinResiliencyScore > 100) {
+		return errors.New("minimum resiliency score has to be between 0 and 100")
+	}
+	if criteria.MaxFailedProbes != nil && *criteria.MaxFailedProbes < 0 {
+		return errors.New("maximum failed probes can't be negative")
+	}
+	return nil
+}
+
+// EvaluateAcceptanceCriteria returns the gate verdict of a completed experiment run, along with the
+// reasons of a failure. Runs which ended abruptly always fail, and the runs of experiments without
+// acceptance criteria pass only if they completed without any error
+func EvaluateAcceptanceCriteria(criteria *dbChaosExperiment.AcceptanceCriteria, phase string, resiliencyScore float64, results []FaultResult, failedProbes int) (model.GateVerdict, []string) {
+	var reasons []string
+
+	switch model.ExperimentRunStatus(phase) {
+	case model.ExperimentRunStatusError, model.ExperimentRunStatusStopped, model.ExperimentRunStatusTimeout, model.ExperimentRunStatusTerminated:
+		return model.GateVerdictFail, []string{fmt.Sprintf("experiment run ended with phase %s", phase)}
+	}
+
+	if criteria == nil {
+		if model.ExperimentRunStatus(phase) != model.ExperimentRunStatusCompleted {
+			return model.GateVerdictFail, []string{fmt.Sprintf("experiment run ended with phase %s", phase)}
+		}
+		return model.GateVerdictPass, nil
+	}
+
+	if criteria.MinResiliencyScore != nil && resiliencyScore < *criteria.MinResiliencyScore {
+		reasons = append(reasons, fmt.Sprintf("resiliency score %v is below the minimum of %v", resiliencyScore, *criteria.MinResiliencyScore))
+	}
+
+	for _, required := range criteria.RequiredFaults {
+		ran, passed := false, true
+		for _, result := range results {
+			if result.FaultName == required {
+				ran = true
+				passed = passed && result.Verdict == "Pass"
+			}
+		}
+		if !ran {
+			reasons = append(reasons, fmt.Sprintf("required fault %s didn't run", required))
+		} else if !passed {
+			reasons = append(reasons, fmt.Sprintf("required fault %s didn't pass", required))
+		}
+	}
+
+	if criteria.MaxFailedProbes != nil && failedProbes > *criteria.MaxFailedProbes {
+		reasons = append(reasons, fmt.Sprintf("%d probes failed, more than the maximum of %d", failedProbes, *criteria.MaxFailedProbes))
+	}
+
+	if len(reasons) > 0 {
+		return model.GateVerdictFail, reasons
+	}
+	return model.GateVerdictPass, nil
+}
+
+// failedProbes returns the number of probes of the chaos engine which failed
+func failedProbes(chaosData *ChaosData) int {
+	if chaosData.ChaosResult == nil {
+		return 0
+	}
+	count := 0
+	for _, probe := range chaosData.ChaosResult.Status.ProbeStatuses {
+		if probe.Status.Verdict == chaosTypes.ProbeVerdictFailed {
+			count++
+		}
+	}
+	return count
+}
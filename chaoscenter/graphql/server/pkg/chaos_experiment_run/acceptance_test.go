@@ -0,0 +1,79 @@
+package chaos_experiment_run
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	dbChaosExperiment "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_experiment"
+)
+
+func TestEvaluateAcceptanceCriteria(t *testing.T) {
+	minScore := 70.0
+	maxFailedProbes := 1
+	results := []FaultResult{
+		{FaultName: "pod-delete", Verdict: "Pass"},
+		{FaultName: "pod-cpu-hog", Verdict: "Fail"},
+	}
+
+	testcases := []struct {
+		name            string
+		criteria        *dbChaosExperiment.AcceptanceCriteria
+		phase           string
+		resiliencyScore float64
+		failedProbes    int
+		want            model.GateVerdict
+		wantReasons     int
+	}{
+		{
+			name:  "no criteria and completed run",
+			phase: string(model.ExperimentRunStatusCompleted),
+			want:  model.GateVerdictPass,
+		},
+		{
+			name:        "no criteria and run completed with errors",
+			phase:       string(model.ExperimentRunStatusCompletedWithError),
+			want:        model.GateVerdictFail,
+			wantReasons: 1,
+		},
+		{
+			name:        "stopped run",
+			criteria:    &dbChaosExperiment.AcceptanceCriteria{MinResiliencyScore: &minScore},
+			phase:       string(model.ExperimentRunStatusStopped),
+			want:        model.GateVerdictFail,
+			wantReasons: 1,
+		},
+		{
+			name: "all criteria met",
+			criteria: &dbChaosExperiment.AcceptanceCriteria{
+				MinResiliencyScore: &minScore,
+				RequiredFaults:     []string{"pod-delete"},
+				MaxFailedProbes:    &maxFailedProbes,
+			},
+			phase:           "Completed_With_Probe_Failure",
+			resiliencyScore: 75,
+			failedProbes:    1,
+			want:            model.GateVerdictPass,
+		},
+		{
+			name: "no criteria met",
+			criteria: &dbChaosExperiment.AcceptanceCriteria{
+				MinResiliencyScore: &minScore,
+				RequiredFaults:     []string{"pod-cpu-hog", "pod-memory-hog"},
+				MaxFailedProbes:    &maxFailedProbes,
+			},
+			phase:           string(model.ExperimentRunStatusCompleted),
+			resiliencyScore: 50,
+			failedProbes:    2,
+			want:            model.GateVerdictFail,
+			wantReasons:     4,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, reasons := EvaluateAcceptanceCriteria(tc.criteria, tc.phase, tc.resiliencyScore, results, tc.failedProbes)
+			if got != tc.want || len(reasons) != tc.wantReasons {
+				t.Errorf("EvaluateAcceptanceCriteria() = %v, %v, want %v with %d reasons", got, reasons, tc.want, tc.wantReasons)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/authorization"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/chaos_infrastructure"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/gitops"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/metrics"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/notification"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
@@ -43,6 +45,7 @@ import (
 	dbChaosInfra "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_infrastructure"
 
 	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // ChaosExperimentRunHandler is the handler for chaos experiment
@@ -50,6 +53,7 @@ type ChaosExperimentRunHandler struct {
 	chaosExperimentRunService  types.Service
 	infrastructureService      chaos_infrastructure.Service
 	gitOpsService              gitops.Service
+	notificationService        notification.Service
 	chaosExperimentOperator    *dbChaosExperiment.Operator
 	chaosExperimentRunOperator *dbChaosExperimentRun.Operator
 	mongodbOperator            mongodb.MongoOperator
@@ -60,6 +64,7 @@ func NewChaosExperimentRunHandler(
 	chaosExperimentRunService types.Service,
 	infrastructureService chaos_infrastructure.Service,
 	gitOpsService gitops.Service,
+	notificationService notification.Service,
 	chaosExperimentOperator *dbChaosExperiment.Operator,
 	chaosExperimentRunOperator *dbChaosExperimentRun.Operator,
 	mongodbOperator mongodb.MongoOperator,
@@ -68,6 +73,7 @@ func NewChaosExperimentRunHandler(
 		chaosExperimentRunService:  chaosExperimentRunService,
 		infrastructureService:      infrastructureService,
 		gitOpsService:              gitOpsService,
+		notificationService:        notificationService,
 		chaosExperimentOperator:    chaosExperimentOperator,
 		chaosExperimentRunOperator: chaosExperimentRunOperator,
 		mongodbOperator:            mongodbOperator,
@@ -254,6 +260,8 @@ func (c *ChaosExperimentRunHandler) GetExperimentRun(ctx context.Context, projec
 			ExecutionData:      wfRun.ExecutionData,
 			IsRemoved:          &wfRun.IsRemoved,
 			RunSequence:        int(wfRun.RunSequence),
+			GateVerdict:        wfRun.GetGateVerdict(),
+			GateVerdictReasons: wfRun.GateVerdictReasons,
 
 			UpdatedBy: &model.UserDetails{
 				Username: wfRun.UpdatedBy.Username,
@@ -625,6 +633,8 @@ func (c *ChaosExperimentRunHandler) ListExperimentRun(projectID string, request
 			TotalFaults:        workflow.TotalFaults,
 			ExecutionData:      workflow.ExecutionData,
 			IsRemoved:          &workflow.IsRemoved,
+			GateVerdict:        workflow.GetGateVerdict(),
+			GateVerdictReasons: workflow.GateVerdictReasons,
 			UpdatedBy: &model.UserDetails{
 				Username: workflow.UpdatedBy.Username,
 			},
@@ -744,6 +754,10 @@ func (c *ChaosExperimentRunHandler) RunChaosWorkFlow(ctx context.Context, projec
 						meta.Labels["step_pod_name"] = "{{pod.name}}"
 						meta.Labels["workflow_run_id"] = "{{workflow.uid}}"
 					}
+					// label the engine with its fault, so that its result is matched exactly to the fault weightage
+					if meta.GenerateName != "" && len(validation.IsValidLabelValue(meta.GenerateName)) == 0 {
+						meta.Labels[types.FaultNameLabel] = meta.GenerateName
+					}
 
 					if len(meta.Spec.Experiments[0].Spec.Probe) != 0 {
 						meta.Spec.Experiments[0].Spec.Probe = utils.TransformProbe(meta.Spec.Experiments[0].Spec.Probe)
@@ -975,6 +989,10 @@ func (c *ChaosExperimentRunHandler) RunCronExperiment(ctx context.Context, proje
 						meta.Labels["step_pod_name"] = "{{pod.name}}"
 						meta.Labels["workflow_run_id"] = "{{workflow.uid}}"
 					}
+					// label the engine with its fault, so that its result is matched exactly to the fault weightage
+					if meta.GenerateName != "" && len(validation.IsValidLabelValue(meta.GenerateName)) == 0 {
+						meta.Labels[types.FaultNameLabel] = meta.GenerateName
+					}
 					if len(meta.Spec.Experiments[0].Spec.Probe) != 0 {
 						meta.Spec.Experiments[0].Spec.Probe = utils.TransformProbe(meta.Spec.Experiments[0].Spec.Probe)
 					}
@@ -1090,6 +1108,17 @@ func (c *ChaosExperimentRunHandler) ChaosExperimentRunEvent(event model.Experime
 
 	logrus.WithFields(logFields).Info("new workflow event received")
 
+	// subscribers resend an event until it is acknowledged, so the same event
+	// may be received more than once
+	isDuplicate, err := c.isDuplicateExperimentRunEvent(event)
+	if err != nil {
+		return "", err
+	}
+	if isDuplicate {
+		logrus.WithFields(logFields).Info("duplicate workflow event discarded")
+		return fmt.Sprintf("duplicate event discarded for ExperimentID: %s, ExperimentRunID: %s", event.ExperimentID, event.ExperimentRunID), nil
+	}
+
 	expType := experiment.ExperimentType
 	probes, err := probeUtils.ParseProbesFromManifestForRuns(&expType, experiment.Revision[len(experiment.Revision)-1].ExperimentManifest)
 	if err != nil {
@@ -1143,9 +1172,14 @@ func (c *ChaosExperimentRunHandler) ChaosExperimentRunEvent(event model.Experime
 	}
 	//
 	var (
-		isRemoved   = false
-		currentTime = time.Now()
+		isRemoved     = false
+		isNewRun      = false
+		currentTime   = time.Now()
+		eventSequence int64
 	)
+	if event.EventSequence != nil {
+		eventSequence = *event.EventSequence
+	}
 
 	err = mongo.WithSession(ctx, session, func(sessionContext mongo.SessionContext) error {
 		if err = session.StartTransaction(txnOpts); err != nil {
@@ -1169,6 +1203,12 @@ func (c *ChaosExperimentRunHandler) ChaosExperimentRunEvent(event model.Experime
 		if err != nil {
 			return err
 		}
+		// the runs started from the control plane are stored as queued until their first event
+		startedQuery := append(bson.D{{"phase", bson.D{{"$ne", string(model.ExperimentRunStatusQueued)}}}}, query...)
+		startedRunCount, err := c.chaosExperimentRunOperator.CountExperimentRuns(sessionContext, startedQuery)
+		if err != nil {
+			return err
+		}
 		updatedBy, err := base64.RawURLEncoding.DecodeString(event.UpdatedBy)
 		if err != nil {
 			logrus.Fatalf("Failed to parse updated by field %v", err)
@@ -1190,6 +1230,7 @@ func (c *ChaosExperimentRunHandler) ChaosExperimentRunEvent(event model.Experime
 				},
 			},
 		}
+		isNewRun = startedRunCount == 0
 		if experimentRunCount == 0 {
 			filter := bson.D{
 				{"experiment_id", event.ExperimentID},
@@ -1255,24 +1296,27 @@ func (c *ChaosExperimentRunHandler) ChaosExperimentRunEvent(event model.Experime
 		}
 
 		count, err := c.chaosExperimentRunOperator.UpdateExperimentRun(sessionContext, dbChaosExperimentRun.ChaosExperimentRun{
-			InfraID:         event.InfraID.InfraID,
-			ProjectID:       experiment.ProjectID,
-			ExperimentRunID: event.ExperimentRunID,
-			ExperimentID:    event.ExperimentID,
-			NotifyID:        event.NotifyID,
-			Phase:           executionData.Phase,
-			ResiliencyScore: &workflowRunMetrics.ResiliencyScore,
-			FaultsPassed:    &workflowRunMetrics.ExperimentsPassed,
-			FaultsFailed:    &workflowRunMetrics.ExperimentsFailed,
-			FaultsAwaited:   &workflowRunMetrics.ExperimentsAwaited,
-			FaultsStopped:   &workflowRunMetrics.ExperimentsStopped,
-			FaultsNA:        &workflowRunMetrics.ExperimentsNA,
-			TotalFaults:     &workflowRunMetrics.TotalExperiments,
-			ExecutionData:   string(exeData),
-			RevisionID:      event.RevisionID,
-			Completed:       event.Completed,
-			Probes:          probes,
-			RunSequence:     experiment.TotalExperimentRuns + 1,
+			InfraID:            event.InfraID.InfraID,
+			ProjectID:          experiment.ProjectID,
+			ExperimentRunID:    event.ExperimentRunID,
+			ExperimentID:       event.ExperimentID,
+			NotifyID:           event.NotifyID,
+			Phase:              executionData.Phase,
+			ResiliencyScore:    &workflowRunMetrics.ResiliencyScore,
+			FaultsPassed:       &workflowRunMetrics.ExperimentsPassed,
+			FaultsFailed:       &workflowRunMetrics.ExperimentsFailed,
+			FaultsAwaited:      &workflowRunMetrics.ExperimentsAwaited,
+			FaultsStopped:      &workflowRunMetrics.ExperimentsStopped,
+			FaultsNA:           &workflowRunMetrics.ExperimentsNA,
+			TotalFaults:        &workflowRunMetrics.TotalExperiments,
+			ExecutionData:      string(exeData),
+			RevisionID:         event.RevisionID,
+			Completed:          event.Completed,
+			Probes:             probes,
+			RunSequence:        experiment.TotalExperimentRuns + 1,
+			EventSequence:      eventSequence,
+			GateVerdict:        workflowRunMetrics.GateVerdict,
+			GateVerdictReasons: workflowRunMetrics.GateVerdictReasons,
 			Audit: mongodb.Audit{
 				IsRemoved: isRemoved,
 				UpdatedAt: currentTime.UnixMilli(),
@@ -1311,5 +1355,76 @@ func (c *ChaosExperimentRunHandler) ChaosExperimentRunEvent(event model.Experime
 
 	session.EndSession(ctx)
 
+	if event.Completed {
+		metrics.ObserveExperimentRun(experiment.ProjectID, executionData.Phase, workflowRunMetrics.ResiliencyScore)
+	}
+	c.notifyExperimentRunEvent(experiment, event, executionData.Phase, isNewRun, workflowRunMetrics)
+
 	return fmt.Sprintf("Experiment run received for for ExperimentID: %s, ExperimentRunID: %s", event.ExperimentID, event.ExperimentRunID), nil
 }
+
+// notifyExperimentRunEvent sends the lifecycle events of an experiment run to the notification channels of the project
+func (c *ChaosExperimentRunHandler) notifyExperimentRunEvent(experiment dbChaosExperiment.ChaosExperimentRequest, event model.ExperimentRunRequest, phase string, isNewRun bool, runMetrics types.ExperimentRunMetrics) {
+	if c.notificationService == nil || (!isNewRun && !event.Completed) {
+		return
+	}
+
+	notificationEvent := notification.Event{
+		ProjectID:       experiment.ProjectID,
+		ExperimentID:    experiment.ExperimentID,
+		ExperimentName:  experiment.Name,
+		ExperimentRunID: event.ExperimentRunID,
+		ExperimentTags:  experiment.Tags,
+		Phase:           phase,
+		InfraID:         experiment.InfraID,
+	}
+	if infra, err := c.infrastructureService.GetDBInfra(experiment.InfraID); err == nil {
+		notificationEvent.InfraName = infra.Name
+		notificationEvent.EnvironmentID = infra.EnvironmentID
+	}
+
+	if isNewRun {
+		notificationEvent.Type = model.NotificationEventTypeExperimentRunStarted
+		c.notificationService.Notify(notificationEvent)
+	}
+	if event.Completed {
+		notificationEvent.Type = model.NotificationEventTypeExperimentRunCompleted
+		notificationEvent.ResiliencyScore = &runMetrics.ResiliencyScore
+		c.notificationService.Notify(notificationEvent)
+
+		if runMetrics.FailedProbes > 0 {
+			notificationEvent.Type = model.NotificationEventTypeProbeFailed
+			notificationEvent.FailedProbes = runMetrics.FailedProbes
+			c.notificationService.Notify(notificationEvent)
+		}
+	}
+}
+
+// isDuplicateExperimentRunEvent checks if the event has already been applied to the experiment run,
+// either because the run has completed or because a newer event has been received
+func (c *ChaosExperimentRunHandler) isDuplicateExperimentRunEvent(event model.ExperimentRunRequest) (bool, error) {
+	query := bson.D{
+		{"experiment_id", event.ExperimentID},
+		{"experiment_run_id", event.ExperimentRunID},
+	}
+	if event.NotifyID != nil {
+		query = bson.D{
+			{"experiment_id", event.ExperimentID},
+			{"notify_id", event.NotifyID},
+		}
+	}
+
+	experimentRun, err := c.chaosExperimentRunOperator.GetExperimentRun(query)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if experimentRun.Completed {
+		return true, nil
+	}
+
+	return event.EventSequence != nil && experimentRun.EventSequence >= *event.EventSequence, nil
+}
@@ -40,7 +40,7 @@ var (
 	chaosExperimentRunOperator = dbChaosExperimentRun.NewChaosExperimentRunOperator(mongodbMockOperator)
 )
 
-var chaosExperimentRunHandler = NewChaosExperimentRunHandler(chaosExperimentRunService, infrastructureService, gitOpsService, chaosExperimentOperator, chaosExperimentRunOperator, mongodbMockOperator)
+var chaosExperimentRunHandler = NewChaosExperimentRunHandler(chaosExperimentRunService, infrastructureService, gitOpsService, nil, chaosExperimentOperator, chaosExperimentRunOperator, mongodbMockOperator)
 
 // TestMain is the entry point for testing
 func TestMain(m *testing.M) {
@@ -85,7 +85,7 @@ func TestNewChaosExperimentRunHandler(t *testing.T) {
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			if got := NewChaosExperimentRunHandler(tc.args.chaosExperimentRunService, tc.args.infrastructureService, tc.args.gitOpsService, tc.args.chaosExperimentOperator, tc.args.chaosExperimentRunOperator, tc.args.mongodbOperator); !reflect.DeepEqual(got, tc.want) {
+			if got := NewChaosExperimentRunHandler(tc.args.chaosExperimentRunService, tc.args.infrastructureService, tc.args.gitOpsService, nil, tc.args.chaosExperimentOperator, tc.args.chaosExperimentRunOperator, tc.args.mongodbOperator); !reflect.DeepEqual(got, tc.want) {
 				t.Errorf("NewChaosExperimentRunHandler() = %v, want %v", got, tc.want)
 			}
 		})
@@ -439,3 +439,106 @@ func TestChaosExperimentRunHandler_GetExperimentRunStats(t *testing.T) {
 		})
 	}
 }
+
+func TestChaosExperimentRunHandler_isDuplicateExperimentRunEvent(t *testing.T) {
+	experimentId := uuid.NewString()
+	experimentRunId := uuid.NewString()
+	// the subscribers derive the sequences from the clock, they don't fit in 32 bits
+	sequence := int64(1700000000000000000)
+	tests := []struct {
+		name    string
+		event   model.ExperimentRunRequest
+		given   func()
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "success: new experiment run",
+			event: model.ExperimentRunRequest{
+				ExperimentID:    experimentId,
+				ExperimentRunID: experimentRunId,
+				EventSequence:   &sequence,
+			},
+			given: func() {
+				singleResult := mongo.NewSingleResultFromDocument(bson.D{}, mongo.ErrNoDocuments, nil)
+				mongodbMockOperator.On("Get", mock.Anything, mongodb.ChaosExperimentRunsCollection, mock.Anything).Return(singleResult, nil).Once()
+			},
+			want: false,
+		},
+		{
+			name: "success: newer event",
+			event: model.ExperimentRunRequest{
+				ExperimentID:    experimentId,
+				ExperimentRunID: experimentRunId,
+				EventSequence:   &sequence,
+			},
+			given: func() {
+				findResult := bson.D{
+					{Key: "experiment_run_id", Value: experimentRunId},
+					{Key: "event_sequence", Value: sequence - 1},
+				}
+				singleResult := mongo.NewSingleResultFromDocument(findResult, nil, nil)
+				mongodbMockOperator.On("Get", mock.Anything, mongodb.ChaosExperimentRunsCollection, mock.Anything).Return(singleResult, nil).Once()
+			},
+			want: false,
+		},
+		{
+			name: "success: event already applied",
+			event: model.ExperimentRunRequest{
+				ExperimentID:    experimentId,
+				ExperimentRunID: experimentRunId,
+				EventSequence:   &sequence,
+			},
+			given: func() {
+				findResult := bson.D{
+					{Key: "experiment_run_id", Value: experimentRunId},
+					{Key: "event_sequence", Value: sequence},
+				}
+				singleResult := mongo.NewSingleResultFromDocument(findResult, nil, nil)
+				mongodbMockOperator.On("Get", mock.Anything, mongodb.ChaosExperimentRunsCollection, mock.Anything).Return(singleResult, nil).Once()
+			},
+			want: true,
+		},
+		{
+			name: "success: experiment run already completed",
+			event: model.ExperimentRunRequest{
+				ExperimentID:    experimentId,
+				ExperimentRunID: experimentRunId,
+			},
+			given: func() {
+				findResult := bson.D{
+					{Key: "experiment_run_id", Value: experimentRunId},
+					{Key: "completed", Value: true},
+				}
+				singleResult := mongo.NewSingleResultFromDocument(findResult, nil, nil)
+				mongodbMockOperator.On("Get", mock.Anything, mongodb.ChaosExperimentRunsCollection, mock.Anything).Return(singleResult, nil).Once()
+			},
+			want: true,
+		},
+		{
+			name: "failure: mongo error",
+			event: model.ExperimentRunRequest{
+				ExperimentID:    experimentId,
+				ExperimentRunID: experimentRunId,
+			},
+			given: func() {
+				singleResult := mongo.NewSingleResultFromDocument(bson.D{}, nil, nil)
+				mongodbMockOperator.On("Get", mock.Anything, mongodb.ChaosExperimentRunsCollection, mock.Anything).Return(singleResult, errors.New("failed to get experiment run")).Once()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.given()
+			got, err := chaosExperimentRunHandler.isDuplicateExperimentRunEvent(tc.event)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ChaosExperimentRunHandler.isDuplicateExperimentRunEvent() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("ChaosExperimentRunHandler.isDuplicateExperimentRunEvent() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,411 @@
+package chaos_experiment_run
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+
+	chaosTypes "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	dbChaosExperimentRun "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_experiment_run"
+)
+
+// Formats of the experiment run reports
+const (
+	ReportFormatJUnit = "junit"
+	ReportFormatJSON  = "json"
+	ReportFormatSARIF = "sarif"
+)
+
+// Outcomes of the faults and probes in the experiment run reports
+const (
+	OutcomePassed  = "passed"
+	OutcomeFailed  = "failed"
+	OutcomeSkipped = "skipped"
+)
+
+// RunReport is the summary of an experiment run, with the outcome of each fault and probe
+type RunReport struct {
+	ExperimentID    string        `json:"experimentID"`
+	ExperimentName  string        `json:"experimentName"`
+	ExperimentRunID string        `json:"experimentRunID"`
+	Phase           string        `json:"phase"`
+	ResiliencyScore *float64      `json:"resiliencyScore,omitempty"`
+	GateVerdict     string        `json:"gateVerdict,omitempty"`
+	StartedAt       string        `json:"startedAt,omitempty"`
+	FinishedAt      string        `json:"finishedAt,omitempty"`
+	Summary         ReportSummary `json:"summary"`
+	Faults          []FaultReport `json:"faults"`
+}
+
+// ReportSummary counts the outcomes of the faults and the probes of an experiment run
+type ReportSummary struct {
+	Faults        int `json:"faults"`
+	FaultsPassed  int `json:"faultsPassed"`
+	FaultsFailed  int `json:"faultsFailed"`
+	FaultsSkipped int `json:"faultsSkipped"`
+	Probes        int `json:"probes"`
+	ProbesPassed  int `json:"probesPassed"`
+	ProbesFailed  int `json:"probesFailed"`
+	ProbesSkipped int `json:"probesSkipped"`
+}
+
+// FaultReport is the outcome of a fault of the experiment run
+type FaultReport struct {
+	Name                   string        `json:"name"`
+	EngineName             string        `json:"engineName"`
+	Outcome                string        `json:"outcome"`
+	Verdict                string        `json:"verdict"`
+	FailStep               string        `json:"failStep,omitempty"`
+	ProbeSuccessPercentage string        `json:"probeSuccessPercentage,omitempty"`
+	Duration               int64         `json:"duration"`
+	Probes                 []ProbeReport `json:"probes,omitempty"`
+}
+
+// ProbeReport is the outcome of a probe of a fault
+type ProbeReport struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Mode        string `json:"mode"`
+	Outcome     string `json:"outcome"`
+	Verdict     string `json:"verdict"`
+	Description string `json:"description,omitempty"`
+}
+
+// NewRunReport builds the report of an experiment run from its execution data
+func NewRunReport(experimentName string, run dbChaosExperimentRun.ChaosExperimentRun) (*RunReport, error) {
+	report := &RunReport{
+		ExperimentID:    run.ExperimentID,
+		ExperimentName:  experimentName,
+		ExperimentRunID: run.ExperimentRunID,
+		Phase:           run.Phase,
+		ResiliencyScore: run.ResiliencyScore,
+		GateVerdict:     run.GateVerdict,
+		Faults:          []FaultReport{},
+	}
+	if run.ExecutionData == "" {
+		return report, nil
+	}
+
+	var execData ExecutionData
+	if err := json.Unmarshal([]byte(run.ExecutionData), &execData); err != nil {
+		return nil, fmt.Errorf("failed to parse execution data of experiment run %s: %w", run.ExperimentRunID, err)
+	}
+	report.StartedAt = execData.StartedAt
+	report.FinishedAt = execData.FinishedAt
+
+	var nodes []Node
+	for _, node := range execData.Nodes {
+		if node.Type == "ChaosEngine" && node.ChaosExp != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	// faults are reported in the order they ran
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].StartedAt != nodes[j].StartedAt {
+			return nodes[i].StartedAt < nodes[j].StartedAt
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	for _, node := range nodes {
+		fault := FaultReport{
+			Name:                   node.Name,
+			EngineName:             node.ChaosExp.EngineName,
+			Outcome:                faultOutcome(node.ChaosExp.ExperimentVerdict),
+			Verdict:                node.ChaosExp.ExperimentVerdict,
+			FailStep:               node.ChaosExp.FailStep,
+			ProbeSuccessPercentage: node.ChaosExp.ProbeSuccessPercentage,
+			Duration:               faultDuration(node),
+		}
+		if fault.Duration < 0 {
+			fault.Duration = 0
+		}
+		if node.ChaosExp.ChaosResult != nil {
+			for _, probe := range node.ChaosExp.ChaosResult.Status.ProbeStatuses {
+				fault.Probes = append(fault.Probes, ProbeReport{
+					Name:        probe.Name,
+					Type:        probe.Type,
+					Mode:        probe.Mode,
+					Outcome:     probeOutcome(probe.Status.Verdict),
+					Verdict:     string(probe.Status.Verdict),
+					Description: probe.Status.Description,
+				})
+			}
+		}
+		report.addFault(fault)
+	}
+
+	return report, nil
+}
+
+func (r *RunReport) addFault(fault FaultReport) {
+	r.Faults = append(r.Faults, fault)
+
+	r.Summary.Faults++
+	switch fault.Outcome {
+	case OutcomePassed:
+		r.Summary.FaultsPassed++
+	case OutcomeFailed:
+		r.Summary.FaultsFailed++
+	default:
+		r.Summary.FaultsSkipped++
+	}
+	for _, probe := range fault.Probes {
+		r.Summary.Probes++
+		switch probe.Outcome {
+		case OutcomePassed:
+			r.Summary.ProbesPassed++
+		case OutcomeFailed:
+			r.Summary.ProbesFailed++
+		default:
+			r.Summary.ProbesSkipped++
+		}
+	}
+}
+
+func faultOutcome(verdict string) string {
+	switch verdict {
+	case "Pass":
+		return OutcomePassed
+	case "Fail", "Stopped":
+		return OutcomeFailed
+	}
+	return OutcomeSkipped
+}
+
+func probeOutcome(verdict chaosTypes.ProbeVerdict) string {
+	switch verdict {
+	case chaosTypes.ProbeVerdictPassed:
+		return OutcomePassed
+	case chaosTypes.ProbeVerdictFailed:
+		return OutcomeFailed
+	}
+	return OutcomeSkipped
+}
+
+// faultFailureMessage returns the reason of the failure of a fault
+func faultFailureMessage(fault FaultReport) string {
+	if fault.FailStep != "" {
+		return fault.FailStep
+	}
+	return fmt.Sprintf("fault verdict is %s", fault.Verdict)
+}
+
+// probeFailureMessage returns the reason of the failure of a probe
+func probeFailureMessage(probe ProbeReport) string {
+	if probe.Description != "" {
+		return probe.Description
+	}
+	return fmt.Sprintf("probe verdict is %s", probe.Verdict)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     int64            `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       int64           `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      int64         `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// JUnit renders the report as JUnit XML, with a testcase for each fault and for each of its probes
+func (r *RunReport) JUnit() ([]byte, error) {
+	suite := junitTestSuite{
+		Name: r.ExperimentName,
+		Properties: []junitProperty{
+			{Name: "experimentID", Value: r.ExperimentID},
+			{Name: "experimentRunID", Value: r.ExperimentRunID},
+			{Name: "phase", Value: r.Phase},
+		},
+	}
+	if r.ResiliencyScore != nil {
+		suite.Properties = append(suite.Properties, junitProperty{Name: "resiliencyScore", Value: fmt.Sprintf("%v", *r.ResiliencyScore)})
+	}
+	if r.GateVerdict != "" {
+		suite.Properties = append(suite.Properties, junitProperty{Name: "gateVerdict", Value: r.GateVerdict})
+	}
+
+	for _, fault := range r.Faults {
+		testCase := junitTestCase{
+			Name:      fault.Name,
+			ClassName: r.ExperimentName,
+			Time:      fault.Duration,
+		}
+		switch fault.Outcome {
+		case OutcomeFailed:
+			message := faultFailureMessage(fault)
+			testCase.Failure = &junitFailure{Message: message, Type: "FaultFailure", Text: message}
+		case OutcomeSkipped:
+			testCase.Skipped = &junitSkipped{Message: fmt.Sprintf("fault verdict is %s", fault.Verdict)}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Time += fault.Duration
+
+		for _, probe := range fault.Probes {
+			testCase := junitTestCase{
+				Name:      probe.Name,
+				ClassName: r.ExperimentName + "." + fault.Name,
+			}
+			switch probe.Outcome {
+			case OutcomeFailed:
+				message := probeFailureMessage(probe)
+				testCase.Failure = &junitFailure{Message: message, Type: "ProbeFailure", Text: message}
+			case OutcomeSkipped:
+				testCase.Skipped = &junitSkipped{Message: fmt.Sprintf("probe verdict is %s", probe.Verdict)}
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+	}
+	suite.Tests = r.Summary.Faults + r.Summary.Probes
+	suite.Failures = r.Summary.FaultsFailed + r.Summary.ProbesFailed
+	suite.Skipped = r.Summary.FaultsSkipped + r.Summary.ProbesSkipped
+
+	out, err := xml.MarshalIndent(junitTestSuites{
+		Name:     r.ExperimentName,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// JSON renders the report as a JSON summary
+func (r *RunReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool              `json:"tool"`
+	Results    []sarifResult          `json:"results"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID  string       `json:"ruleId"`
+	Level   string       `json:"level"`
+	Kind    string       `json:"kind"`
+	Message sarifMessage `json:"message"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIF renders the report as a SARIF log, with a rule for each fault and a result for each fault and probe
+func (r *RunReport) SARIF() ([]byte, error) {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "LitmusChaos",
+			InformationURI: "https://litmuschaos.io",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+		Properties: map[string]interface{}{
+			"experimentID":    r.ExperimentID,
+			"experimentName":  r.ExperimentName,
+			"experimentRunID": r.ExperimentRunID,
+			"phase":           r.Phase,
+		},
+	}
+	if r.ResiliencyScore != nil {
+		run.Properties["resiliencyScore"] = *r.ResiliencyScore
+	}
+
+	for _, fault := range r.Faults {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               fault.Name,
+			ShortDescription: sarifMessage{Text: fmt.Sprintf("Chaos fault %s of experiment %s", fault.Name, r.ExperimentName)},
+		})
+
+		result := sarifResult{RuleID: fault.Name}
+		switch fault.Outcome {
+		case OutcomePassed:
+			result.Kind, result.Level, result.Message.Text = "pass", "none", "fault passed"
+		case OutcomeFailed:
+			result.Kind, result.Level, result.Message.Text = "fail", "error", faultFailureMessage(fault)
+		default:
+			result.Kind, result.Level, result.Message.Text = "notApplicable", "none", fmt.Sprintf("fault verdict is %s", fault.Verdict)
+		}
+		run.Results = append(run.Results, result)
+
+		for _, probe := range fault.Probes {
+			result := sarifResult{RuleID: fault.Name}
+			switch probe.Outcome {
+			case OutcomePassed:
+				result.Kind, result.Level, result.Message.Text = "pass", "none", fmt.Sprintf("probe %s passed", probe.Name)
+			case OutcomeFailed:
+				result.Kind, result.Level, result.Message.Text = "fail", "error", fmt.Sprintf("probe %s failed: %s", probe.Name, probeFailureMessage(probe))
+			default:
+				result.Kind, result.Level, result.Message.Text = "notApplicable", "none", fmt.Sprintf("probe %s verdict is %s", probe.Name, probe.Verdict)
+			}
+			run.Results = append(run.Results, result)
+		}
+	}
+
+	return json.MarshalIndent(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+}
@@ -0,0 +1,126 @@
+package chaos_experiment_run
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	chaosTypes "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	dbChaosExperimentRun "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_experiment_run"
+)
+
+func newTestRunReport(t *testing.T) *RunReport {
+	execData := ExecutionData{
+		Phase:     "Completed",
+		StartedAt: "1700000000",
+		Nodes: map[string]Node{
+			"step-1": {
+				Name:       "pod-delete",
+				Type:       "ChaosEngine",
+				StartedAt:  "1700000000",
+				FinishedAt: "1700000060",
+				ChaosExp: &ChaosData{
+					EngineName:        "pod-deletex7k2p",
+					ExperimentVerdict: "Pass",
+					ChaosResult: &chaosTypes.ChaosResult{Status: chaosTypes.ChaosResultStatus{
+						ProbeStatuses: []chaosTypes.ProbeStatuses{
+							{Name: "http-probe", Type: "httpProbe", Mode: "Continuous", Status: chaosTypes.ProbeStatus{Verdict: chaosTypes.ProbeVerdictPassed}},
+						},
+					}},
+				},
+			},
+			"step-2": {
+				Name:       "pod-cpu-hog",
+				Type:       "ChaosEngine",
+				StartedAt:  "1700000060",
+				FinishedAt: "1700000120",
+				ChaosExp: &ChaosData{
+					EngineName:        "pod-cpu-hogq9w3e",
+					ExperimentVerdict: "Fail",
+					FailStep:          "Probe execution result didn't met the passing criteria",
+					ChaosResult: &chaosTypes.ChaosResult{Status: chaosTypes.ChaosResultStatus{
+						ProbeStatuses: []chaosTypes.ProbeStatuses{
+							{Name: "cmd-probe", Type: "cmdProbe", Mode: "EOT", Status: chaosTypes.ProbeStatus{Verdict: chaosTypes.ProbeVerdictFailed, Description: "exit code 1"}},
+						},
+					}},
+				},
+			},
+			"step-3": {
+				Name: "install-application",
+				Type: "Pod",
+			},
+		},
+	}
+	data, err := json.Marshal(execData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := NewRunReport("podtato-head", dbChaosExperimentRun.ChaosExperimentRun{
+		ExperimentID:    "experiment-id",
+		ExperimentRunID: "experiment-run-id",
+		Phase:           "Completed_With_Probe_Failure",
+		ExecutionData:   string(data),
+	})
+	if err != nil {
+		t.Fatalf("NewRunReport() error = %v", err)
+	}
+	return report
+}
+
+func TestNewRunReport(t *testing.T) {
+	report := newTestRunReport(t)
+
+	want := ReportSummary{Faults: 2, FaultsPassed: 1, FaultsFailed: 1, Probes: 2, ProbesPassed: 1, ProbesFailed: 1}
+	if report.Summary != want {
+		t.Errorf("NewRunReport() summary = %+v, want %+v", report.Summary, want)
+	}
+	if len(report.Faults) != 2 || report.Faults[0].Name != "pod-delete" || report.Faults[1].Duration != 60 {
+		t.Errorf("NewRunReport() faults = %+v", report.Faults)
+	}
+
+	if _, err := NewRunReport("podtato-head", dbChaosExperimentRun.ChaosExperimentRun{ExecutionData: "{"}); err == nil {
+		t.Error("NewRunReport() expected an error for invalid execution data")
+	}
+}
+
+func TestRunReport_JUnit(t *testing.T) {
+	out, err := newTestRunReport(t).JUnit()
+	if err != nil {
+		t.Fatalf("JUnit() error = %v", err)
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(out, &suites); err != nil {
+		t.Fatalf("JUnit() produced invalid XML: %v", err)
+	}
+	if suites.Tests != 4 || suites.Failures != 2 || len(suites.Suites) != 1 {
+		t.Fatalf("JUnit() = %+v", suites)
+	}
+
+	testCases := suites.Suites[0].TestCases
+	if testCases[2].Name != "pod-cpu-hog" || testCases[2].Failure == nil || testCases[2].Failure.Message != "Probe execution result didn't met the passing criteria" {
+		t.Errorf("JUnit() fault testcase = %+v", testCases[2])
+	}
+	if testCases[3].ClassName != "podtato-head.pod-cpu-hog" || testCases[3].Failure == nil || testCases[3].Failure.Message != "exit code 1" {
+		t.Errorf("JUnit() probe testcase = %+v", testCases[3])
+	}
+}
+
+func TestRunReport_SARIF(t *testing.T) {
+	out, err := newTestRunReport(t).SARIF()
+	if err != nil {
+		t.Fatalf("SARIF() error = %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(out, &log); err != nil {
+		t.Fatalf("SARIF() produced invalid JSON: %v", err)
+	}
+	if len(log.Runs) != 1 || len(log.Runs[0].Tool.Driver.Rules) != 2 || len(log.Runs[0].Results) != 4 {
+		t.Fatalf("SARIF() = %+v", log)
+	}
+	if result := log.Runs[0].Results[3]; result.Level != "error" || result.RuleID != "pod-cpu-hog" {
+		t.Errorf("SARIF() probe result = %+v", result)
+	}
+}
@@ -0,0 +1,225 @@
+package chaos_experiment_run
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	dbChaosExperiment "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_experiment"
+)
+
+// FaultNameLabel is the label of the chaos engines holding the name of the fault they run
+const FaultNameLabel = "fault_name"
+
+// generatedNameSuffixLength is the length of the random suffix added by k8s to the generateName of a resource
+const generatedNameSuffixLength = 5
+
+// FaultResult is the outcome of a fault of the experiment run, as reported by the chaos engine
+type FaultResult struct {
+	FaultName string
+	Weight    int
+	// ProbeSuccessPercentage of the fault, between 0 and 100
+	ProbeSuccessPercentage float64
+	Verdict                string
+	// Duration of the fault step in seconds, -1 if it's not known
+	Duration int64
+}
+
+// ScoringInput contains the details of the experiment run used to calculate the resiliency score
+type ScoringInput struct {
+	// Weights of all the faults of the experiment revision, including the ones which didn't run
+	Weights map[string]int
+	// Results of the faults which ran and matched a weightage
+	Results []FaultResult
+}
+
+// totalWeight returns the sum of the weights of all the faults
+func (in ScoringInput) totalWeight() int {
+	sum := 0
+	for _, weight := range in.Weights {
+		sum += weight
+	}
+	return sum
+}
+
+// ScoringStrategy calculates the resiliency score of an experiment run, between 0 and 100
+type ScoringStrategy interface {
+	Score(input ScoringInput) float64
+}
+
+// ScoringStrategyFactory builds a ScoringStrategy from the strategy stored on the experiment revision
+type ScoringStrategyFactory func(config dbChaosExperiment.ScoringStrategy) (ScoringStrategy, error)
+
+var (
+	scoringStrategiesMu sync.RWMutex
+	scoringStrategies   = map[string]ScoringStrategyFactory{}
+)
+
+func init() {
+	RegisterScoringStrategy(string(model.ResiliencyScoringStrategyTypeWeightedProbeSuccess), func(dbChaosExperiment.ScoringStrategy) (ScoringStrategy, error) {
+		return weightedProbeSuccessStrategy{}, nil
+	})
+	RegisterScoringStrategy(string(model.ResiliencyScoringStrategyTypeVerdictBased), func(dbChaosExperiment.ScoringStrategy) (ScoringStrategy, error) {
+		return verdictStrategy{}, nil
+	})
+	RegisterScoringStrategy(string(model.ResiliencyScoringStrategyTypeCriticalFaultGate), func(config dbChaosExperiment.ScoringStrategy) (ScoringStrategy, error) {
+		if len(config.CriticalFaults) == 0 {
+			return nil, fmt.Errorf("no critical faults specified for the %s strategy", config.Type)
+		}
+		return criticalFaultGateStrategy{criticalFaults: config.CriticalFaults}, nil
+	})
+	RegisterScoringStrategy(string(model.ResiliencyScoringStrategyTypeRecoveryTimePenalty), func(config dbChaosExperiment.ScoringStrategy) (ScoringStrategy, error) {
+		if config.RecoveryTimeThreshold < 0 || config.RecoveryTimePenalty < 0 {
+			return nil, fmt.Errorf("recovery time threshold and penalty of the %s strategy can't be negative", config.Type)
+		}
+		return recoveryTimePenaltyStrategy{
+			threshold: int64(config.RecoveryTimeThreshold),
+			penalty:   config.RecoveryTimePenalty,
+		}, nil
+	})
+}
+
+// RegisterScoringStrategy makes a scoring strategy available to the experiments, replacing
+// any strategy already registered with the same type
+func RegisterScoringStrategy(strategyType string, factory ScoringStrategyFactory) {
+	scoringStrategiesMu.Lock()
+	defer scoringStrategiesMu.Unlock()
+
+	scoringStrategies[strategyType] = factory
+}
+
+// NewScoringStrategy returns the scoring strategy of an experiment revision, falling
+// back to the weighted probe success if the revision doesn't specify any
+func NewScoringStrategy(config *dbChaosExperiment.ScoringStrategy) (ScoringStrategy, error) {
+	strategyType := string(model.ResiliencyScoringStrategyTypeWeightedProbeSuccess)
+	if config != nil && config.Type != "" {
+		strategyType = config.Type
+	}
+
+	scoringStrategiesMu.RLock()
+	factory, ok := scoringStrategies[strategyType]
+	scoringStrategiesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown resiliency scoring strategy %q", strategyType)
+	}
+
+	if config == nil {
+		config = &dbChaosExperiment.ScoringStrategy{Type: strategyType}
+	}
+	return factory(*config)
+}
+
+// weightedProbeSuccessStrategy scores the run as Σ(weight × probeSuccessPercentage)/Σweight
+type weightedProbeSuccessStrategy struct{}
+
+func (weightedProbeSuccessStrategy) Score(input ScoringInput) float64 {
+	return weightedScore(input, func(result FaultResult) float64 {
+		return result.ProbeSuccessPercentage
+	})
+}
+
+// verdictStrategy scores each fault 100 if it passed and 0 otherwise
+type verdictStrategy struct{}
+
+func (verdictStrategy) Score(input ScoringInput) float64 {
+	return weightedScore(input, func(result FaultResult) float64 {
+		if result.Verdict == "Pass" {
+			return 100
+		}
+		return 0
+	})
+}
+
+// criticalFaultGateStrategy scores the run with the weighted probe success, unless
+// a critical fault didn't pass, in which case the whole run scores 0
+type criticalFaultGateStrategy struct {
+	criticalFaults []string
+}
+
+func (s criticalFaultGateStrategy) Score(input ScoringInput) float64 {
+	for _, critical := range s.criticalFaults {
+		passed := false
+		for _, result := range input.Results {
+			if result.FaultName == critical {
+				passed = result.Verdict == "Pass"
+				if !passed {
+					break
+				}
+			}
+		}
+		if !passed {
+			return 0
+		}
+	}
+	return weightedProbeSuccessStrategy{}.Score(input)
+}
+
+// recoveryTimePenaltyStrategy scores the run with the weighted probe success, deducting
+// penalty points from a fault for every second it ran above the threshold
+type recoveryTimePenaltyStrategy struct {
+	threshold int64
+	penalty   float64
+}
+
+func (s recoveryTimePenaltyStrategy) Score(input ScoringInput) float64 {
+	return weightedScore(input, func(result FaultResult) float64 {
+		score := result.ProbeSuccessPercentage
+		if result.Duration > s.threshold {
+			score -= float64(result.Duration-s.threshold) * s.penalty
+		}
+		return math.Max(score, 0)
+	})
+}
+
+// weightedScore returns the weighted average of the fault scores, where the faults which didn't run score 0
+func weightedScore(input ScoringInput, faultScore func(result FaultResult) float64) float64 {
+	weightSum := input.totalWeight()
+	if weightSum == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, result := range input.Results {
+		total += float64(result.Weight) * faultScore(result)
+	}
+	return total / float64(weightSum)
+}
+
+// matchFault returns the name of the fault run by the chaos engine. The fault name label set on the
+// engine is used when available, otherwise the engine name has to be the fault name, optionally
+// followed by the suffix generated by k8s, so that faults whose names are substrings of other
+// fault names are never mis-attributed
+func matchFault(chaosData *ChaosData, weights map[string]int) (string, bool) {
+	if chaosData.FaultName != "" {
+		_, ok := weights[chaosData.FaultName]
+		return chaosData.FaultName, ok
+	}
+
+	engineName := chaosData.EngineName
+	if _, ok := weights[engineName]; ok {
+		return engineName, true
+	}
+	if len(engineName) > generatedNameSuffixLength {
+		faultName := engineName[:len(engineName)-generatedNameSuffixLength]
+		if _, ok := weights[faultName]; ok {
+			return faultName, true
+		}
+	}
+	return "", false
+}
+
+// faultDuration returns the duration in seconds of a node, whose timestamps are unix seconds
+func faultDuration(node Node) int64 {
+	startedAt, err := strconv.ParseInt(strings.TrimSpace(node.StartedAt), 10, 64)
+	if err != nil {
+		return -1
+	}
+	finishedAt, err := strconv.ParseInt(strings.TrimSpace(node.FinishedAt), 10, 64)
+	if err != nil || finishedAt < startedAt {
+		return -1
+	}
+	return finishedAt - startedAt
+}
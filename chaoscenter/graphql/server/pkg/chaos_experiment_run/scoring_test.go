@@ -0,0 +1,169 @@
+package chaos_experiment_run
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	dbChaosExperiment "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_experiment"
+)
+
+func TestNewScoringStrategy(t *testing.T) {
+	testcases := []struct {
+		name    string
+		config  *dbChaosExperiment.ScoringStrategy
+		wantErr bool
+	}{
+		{
+			name:   "success: default strategy",
+			config: nil,
+		},
+		{
+			name:   "success: verdict based strategy",
+			config: &dbChaosExperiment.ScoringStrategy{Type: string(model.ResiliencyScoringStrategyTypeVerdictBased)},
+		},
+		{
+			name:    "failure: critical fault gate without critical faults",
+			config:  &dbChaosExperiment.ScoringStrategy{Type: string(model.ResiliencyScoringStrategyTypeCriticalFaultGate)},
+			wantErr: true,
+		},
+		{
+			name:    "failure: negative recovery time penalty",
+			config:  &dbChaosExperiment.ScoringStrategy{Type: string(model.ResiliencyScoringStrategyTypeRecoveryTimePenalty), RecoveryTimePenalty: -1},
+			wantErr: true,
+		},
+		{
+			name:    "failure: unknown strategy",
+			config:  &dbChaosExperiment.ScoringStrategy{Type: "unknown"},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewScoringStrategy(tc.config)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("NewScoringStrategy() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestScoringStrategy_Score(t *testing.T) {
+	input := ScoringInput{
+		Weights: map[string]int{"pod-delete": 10, "pod-cpu-hog": 5, "pod-memory-hog": 5},
+		Results: []FaultResult{
+			{FaultName: "pod-delete", Weight: 10, ProbeSuccessPercentage: 100, Verdict: "Pass", Duration: 60},
+			{FaultName: "pod-cpu-hog", Weight: 5, ProbeSuccessPercentage: 40, Verdict: "Fail", Duration: 120},
+		},
+	}
+
+	testcases := []struct {
+		name   string
+		config *dbChaosExperiment.ScoringStrategy
+		want   float64
+	}{
+		{
+			name:   "weighted probe success",
+			config: nil,
+			want:   60,
+		},
+		{
+			name:   "verdict based",
+			config: &dbChaosExperiment.ScoringStrategy{Type: string(model.ResiliencyScoringStrategyTypeVerdictBased)},
+			want:   50,
+		},
+		{
+			name:   "critical fault passed",
+			config: &dbChaosExperiment.ScoringStrategy{Type: string(model.ResiliencyScoringStrategyTypeCriticalFaultGate), CriticalFaults: []string{"pod-delete"}},
+			want:   60,
+		},
+		{
+			name:   "critical fault failed",
+			config: &dbChaosExperiment.ScoringStrategy{Type: string(model.ResiliencyScoringStrategyTypeCriticalFaultGate), CriticalFaults: []string{"pod-cpu-hog"}},
+			want:   0,
+		},
+		{
+			name:   "critical fault didn't run",
+			config: &dbChaosExperiment.ScoringStrategy{Type: string(model.ResiliencyScoringStrategyTypeCriticalFaultGate), CriticalFaults: []string{"pod-memory-hog"}},
+			want:   0,
+		},
+		{
+			name: "recovery time penalty",
+			config: &dbChaosExperiment.ScoringStrategy{
+				Type:                  string(model.ResiliencyScoringStrategyTypeRecoveryTimePenalty),
+				RecoveryTimeThreshold: 90,
+				RecoveryTimePenalty:   1,
+			},
+			// pod-cpu-hog loses 30 points, down to 10
+			want: 52.5,
+		},
+		{
+			name: "recovery time penalty doesn't go below zero",
+			config: &dbChaosExperiment.ScoringStrategy{
+				Type:                  string(model.ResiliencyScoringStrategyTypeRecoveryTimePenalty),
+				RecoveryTimeThreshold: 30,
+				RecoveryTimePenalty:   10,
+			},
+			want: 0,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			strategy, err := NewScoringStrategy(tc.config)
+			if err != nil {
+				t.Fatalf("NewScoringStrategy() error = %v", err)
+			}
+			if got := strategy.Score(input); got != tc.want {
+				t.Errorf("Score() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_matchFault(t *testing.T) {
+	weights := map[string]int{"pod-delete": 10, "pod-delete-extended": 10}
+
+	testcases := []struct {
+		name      string
+		chaosData *ChaosData
+		want      string
+		wantOk    bool
+	}{
+		{
+			name:      "fault name label",
+			chaosData: &ChaosData{EngineName: "anything", FaultName: "pod-delete-extended"},
+			want:      "pod-delete-extended",
+			wantOk:    true,
+		},
+		{
+			name:      "fault name label of an unknown fault",
+			chaosData: &ChaosData{EngineName: "pod-deleteabcde", FaultName: "pod-cpu-hog"},
+			want:      "pod-cpu-hog",
+			wantOk:    false,
+		},
+		{
+			name:      "engine name with generated suffix",
+			chaosData: &ChaosData{EngineName: "pod-delete-extendedx7k2p"},
+			want:      "pod-delete-extended",
+			wantOk:    true,
+		},
+		{
+			name:      "engine name equal to the fault name",
+			chaosData: &ChaosData{EngineName: "pod-delete"},
+			want:      "pod-delete",
+			wantOk:    true,
+		},
+		{
+			name:      "fault name being a substring of the engine name",
+			chaosData: &ChaosData{EngineName: "pod-delete-extended-run"},
+			wantOk:    false,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := matchFault(tc.chaosData, weights)
+			if ok != tc.wantOk || (ok && got != tc.want) {
+				t.Errorf("matchFault() = %v, %v, want %v, %v", got, ok, tc.want, tc.wantOk)
+			}
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
@@ -95,10 +94,15 @@ func (c *chaosExperimentRunService) ProcessExperimentRunStop(ctx context.Context
 	return nil
 }
 
-// ProcessCompletedExperimentRun calculates the Resiliency Score and returns the updated ExecutionData
+// ProcessCompletedExperimentRun calculates the Resiliency Score, using the scoring strategy of the
+// experiment revision, evaluates the acceptance criteria of the revision and returns the updated ExecutionData
 func (c *chaosExperimentRunService) ProcessCompletedExperimentRun(execData ExecutionData, wfID string, runID string) (ExperimentRunMetrics, error) {
-	weightSum, totalTestResult := 0, 0
-	var result ExperimentRunMetrics
+	var (
+		result             ExperimentRunMetrics
+		scoringStrategy    *dbChaosExperiment.ScoringStrategy
+		acceptanceCriteria *dbChaosExperiment.AcceptanceCriteria
+		totalFailedProbes  int
+	)
 	weightMap := map[string]int{}
 
 	chaosWorkflows, err := c.chaosExperimentOperator.GetExperiment(context.TODO(), bson.D{
@@ -111,30 +115,37 @@ func (c *chaosExperimentRunService) ProcessCompletedExperimentRun(execData Execu
 		if rev.RevisionID == execData.RevisionID {
 			for _, weights := range rev.Weightages {
 				weightMap[weights.FaultName] = weights.Weightage
-				// Total weight calculated for all experiments
-				weightSum = weightSum + weights.Weightage
 			}
+			scoringStrategy = rev.ScoringStrategy
+			acceptanceCriteria = rev.AcceptanceCriteria
 		}
 	}
 
+	strategy, err := NewScoringStrategy(scoringStrategy)
+	if err != nil {
+		return result, fmt.Errorf("failed to get resiliency scoring strategy, error: %w", err)
+	}
+
+	scoringInput := ScoringInput{Weights: weightMap}
 	result.TotalExperiments = len(weightMap)
 	for _, value := range execData.Nodes {
 		if value.Type == "ChaosEngine" {
-			experimentName := ""
 			if value.ChaosExp == nil {
 				continue
 			}
 
-			for expName := range weightMap {
-				if strings.Contains(value.ChaosExp.EngineName, expName) {
-					experimentName = expName
-				}
-			}
-			weight, ok := weightMap[experimentName]
+			totalFailedProbes += failedProbes(value.ChaosExp)
+
 			// probeSuccessPercentage will be included only if chaosData is present
-			if ok {
+			if faultName, ok := matchFault(value.ChaosExp, weightMap); ok {
 				x, _ := strconv.Atoi(value.ChaosExp.ProbeSuccessPercentage)
-				totalTestResult += weight * x
+				scoringInput.Results = append(scoringInput.Results, FaultResult{
+					FaultName:              faultName,
+					Weight:                 weightMap[faultName],
+					ProbeSuccessPercentage: float64(x),
+					Verdict:                value.ChaosExp.ExperimentVerdict,
+					Duration:               faultDuration(value),
+				})
 			}
 			if value.ChaosExp.ExperimentVerdict == "Pass" {
 				result.ExperimentsPassed += 1
@@ -153,9 +164,12 @@ func (c *chaosExperimentRunService) ProcessCompletedExperimentRun(execData Execu
 			}
 		}
 	}
-	if weightSum != 0 {
-		result.ResiliencyScore = utils.Truncate(float64(totalTestResult) / float64(weightSum))
-	}
+	result.ResiliencyScore = utils.Truncate(strategy.Score(scoringInput))
+	result.FailedProbes = totalFailedProbes
+
+	gateVerdict, reasons := EvaluateAcceptanceCriteria(acceptanceCriteria, execData.Phase, result.ResiliencyScore, scoringInput.Results, totalFailedProbes)
+	result.GateVerdict = string(gateVerdict)
+	result.GateVerdictReasons = reasons
 
 	return result, nil
 }
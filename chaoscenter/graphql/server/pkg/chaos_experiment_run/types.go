@@ -12,6 +12,10 @@ type ExperimentRunMetrics struct {
 	ExperimentsStopped int     `json:"experiments_stopped"`
 	ExperimentsNA      int     `json:"experiments_na"`
 	TotalExperiments   int     `json:"total_experiments"`
+	FailedProbes       int     `json:"failed_probes"`
+	// GateVerdict is the verdict of the acceptance criteria of the experiment run
+	GateVerdict        string   `json:"gate_verdict"`
+	GateVerdictReasons []string `json:"gate_verdict_reasons"`
 }
 
 type ExecutionData struct {
@@ -47,6 +51,7 @@ type ChaosData struct {
 	EngineUID              string                  `json:"engineUID"`
 	EngineContext          string                  `json:"engine_context"`
 	EngineName             string                  `json:"engineName"`
+	FaultName              string                  `json:"faultName,omitempty"`
 	Namespace              string                  `json:"namespace"`
 	ExperimentName         string                  `json:"experimentName"`
 	ExperimentStatus       string                  `json:"experimentStatus"`
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/ghodss/yaml"
+	"github.com/google/uuid"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
 	store "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/data-store"
 	dbChaosInfra "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_infrastructure"
@@ -231,6 +232,7 @@ func SendRequestToSubscriber(subscriberRequest SubscriberRequests, r store.State
 	newAction := &model.InfraActionResponse{
 		ProjectID: subscriberRequest.ProjectID,
 		Action: &model.ActionPayload{
+			RequestID:    uuid.New().String(),
 			K8sManifest:  subscriberRequest.K8sManifest,
 			Namespace:    subscriberRequest.Namespace,
 			RequestType:  subscriberRequest.RequestType,
@@ -239,11 +241,10 @@ func SendRequestToSubscriber(subscriberRequest SubscriberRequests, r store.State
 		},
 	}
 
-	r.Mutex.Lock()
-	if observer, ok := r.ConnectedInfra[subscriberRequest.InfraID]; ok {
-		observer <- newAction
+	// the request is queued until the infra acknowledges it, it's sent again if the infra reconnects before
+	if err := r.QueueInfraAction(subscriberRequest.InfraID, newAction); err != nil {
+		log.WithError(err).WithField("infra_id", subscriberRequest.InfraID).Error("failed to queue the request to the infra")
 	}
-	r.Mutex.Unlock()
 }
 
 // SendExperimentToSubscriber sends the workflow to the subscriber to be handled
@@ -29,6 +29,11 @@ func (s *InfraService) VerifyInfra(identity model.InfraIdentity) (*dbChaosInfra.
 	return args.Get(0).(*dbChaosInfra.ChaosInfra), args.Error(1)
 }
 
+func (s *InfraService) VerifyUpgradingInfra(identity model.InfraIdentity) (*dbChaosInfra.ChaosInfra, error) {
+	args := s.Called(identity)
+	return args.Get(0).(*dbChaosInfra.ChaosInfra), args.Error(1)
+}
+
 func (s *InfraService) DeleteInfra(ctx context.Context, projectID string, infraId string, r store.StateData) (string, error) {
 	args := s.Called(ctx, projectID, infraId, r)
 	return args.String(0), args.Error(1)
@@ -88,6 +93,40 @@ func (s *InfraService) KubeObj(request model.KubeObjectData, r store.StateData)
 	return args.String(0), args.Error(1)
 }
 
+func (s *InfraService) AckInfraAction(request model.InfraActionAck, r store.StateData) (string, error) {
+	args := s.Called(request, r)
+	return args.String(0), args.Error(1)
+}
+
+func (s *InfraService) ListInfraActions(projectID string, request *model.ListInfraActionsRequest, r store.StateData) ([]*model.InfraActionStatus, error) {
+	args := s.Called(projectID, request, r)
+	return args.Get(0).([]*model.InfraActionStatus), args.Error(1)
+}
+
+func (s *InfraService) InfraHeartbeat(request model.InfraHeartbeatRequest, r store.StateData) (string, error) {
+	args := s.Called(request, r)
+	return args.String(0), args.Error(1)
+}
+
+func (s *InfraService) RecurringHeartbeatCheck(r *store.StateData) {
+	s.Called(r)
+}
+
+func (s *InfraService) UpgradeInfra(ctx context.Context, projectID string, infraID string, r store.StateData) (*model.InfraUpgrade, error) {
+	args := s.Called(ctx, projectID, infraID, r)
+	return args.Get(0).(*model.InfraUpgrade), args.Error(1)
+}
+
+func (s *InfraService) UpdateInfraUpgradeStatus(request model.InfraUpgradeStatusRequest, r store.StateData) (string, error) {
+	args := s.Called(request, r)
+	return args.String(0), args.Error(1)
+}
+
+func (s *InfraService) ListInfraUpgrades(ctx context.Context, projectID string, infraID string) ([]*model.InfraUpgrade, error) {
+	args := s.Called(ctx, projectID, infraID)
+	return args.Get(0).([]*model.InfraUpgrade), args.Error(1)
+}
+
 func (s *InfraService) UpdateInfra(query bson.D, update bson.D) error {
 	args := s.Called(query, update)
 	return args.Error(0)
@@ -16,6 +16,7 @@ import (
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/config"
 	dbEnvironments "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/environments"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/k8s"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/notification"
 	"github.com/sirupsen/logrus"
 
 	"github.com/google/uuid"
@@ -23,6 +24,7 @@ import (
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
 	dbChaosInfra "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_infrastructure"
+	dbInfraUpgrade "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/infra_upgrade"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/utils"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
@@ -34,12 +36,16 @@ const (
 	CIVersion             = "ci"
 	ClusterScope   string = "cluster"
 	NamespaceScope string = "namespace"
+
+	// listInfraUpgradesLimit is the number of latest upgrades returned for an infra
+	listInfraUpgradesLimit = 50
 )
 
 type Service interface {
 	RegisterInfra(c context.Context, projectID string, input model.RegisterInfraRequest) (*model.RegisterInfraResponse, error)
 	ConfirmInfraRegistration(request model.InfraIdentity, r store.StateData) (*model.ConfirmInfraRegistrationResponse, error)
 	VerifyInfra(identity model.InfraIdentity) (*dbChaosInfra.ChaosInfra, error)
+	VerifyUpgradingInfra(identity model.InfraIdentity) (*dbChaosInfra.ChaosInfra, error)
 	//NewClusterEvent(request model.NewClusterEventRequest, r store.StateData) (string, error)
 	DeleteInfra(ctx context.Context, projectID string, infraId string, r store.StateData) (string, error)
 	ListInfras(projectID string, request *model.ListInfraRequest) (*model.ListInfraResponse, error)
@@ -53,20 +59,37 @@ type Service interface {
 	QueryServerVersion(ctx context.Context) (*model.ServerVersionResponse, error)
 	PodLog(request model.PodLog, r store.StateData) (string, error)
 	KubeObj(request model.KubeObjectData, r store.StateData) (string, error)
+	AckInfraAction(request model.InfraActionAck, r store.StateData) (string, error)
+	ListInfraActions(projectID string, request *model.ListInfraActionsRequest, r store.StateData) ([]*model.InfraActionStatus, error)
+	InfraHeartbeat(request model.InfraHeartbeatRequest, r store.StateData) (string, error)
+	RecurringHeartbeatCheck(r *store.StateData)
+	UpgradeInfra(ctx context.Context, projectID string, infraID string, r store.StateData) (*model.InfraUpgrade, error)
+	UpdateInfraUpgradeStatus(request model.InfraUpgradeStatusRequest, r store.StateData) (string, error)
+	ListInfraUpgrades(ctx context.Context, projectID string, infraID string) ([]*model.InfraUpgrade, error)
 	UpdateInfra(query bson.D, update bson.D) error
 	GetDBInfra(infraID string) (dbChaosInfra.ChaosInfra, error)
 }
 
 type infraService struct {
-	infraOperator *dbChaosInfra.Operator
-	envOperator   *dbEnvironments.Operator
+	infraOperator        *dbChaosInfra.Operator
+	envOperator          *dbEnvironments.Operator
+	infraUpgradeOperator *dbInfraUpgrade.Operator
+	notificationService  notification.Service
+}
+
+// infraNotificationEvents maps the names of the infra events to the notification events they fire
+var infraNotificationEvents = map[string]model.NotificationEventType{
+	"Infra Live":    model.NotificationEventTypeInfraConnected,
+	"Infra Offline": model.NotificationEventTypeInfraDisconnected,
 }
 
 // NewChaosInfrastructureService returns a new instance of Service
-func NewChaosInfrastructureService(infraOperator *dbChaosInfra.Operator, envOperator *dbEnvironments.Operator) Service {
+func NewChaosInfrastructureService(infraOperator *dbChaosInfra.Operator, envOperator *dbEnvironments.Operator, infraUpgradeOperator *dbInfraUpgrade.Operator, notificationService notification.Service) Service {
 	return &infraService{
-		infraOperator: infraOperator,
-		envOperator:   envOperator,
+		infraOperator:        infraOperator,
+		envOperator:          envOperator,
+		infraUpgradeOperator: infraUpgradeOperator,
+		notificationService:  notificationService,
 	}
 }
 
@@ -272,6 +295,8 @@ func (in *infraService) DeleteInfra(ctx context.Context, projectID string, infra
 		}, r)
 	}
 
+	in.notify(model.NotificationEventTypeInfraDeleted, infra.ProjectID, infra.InfraID, infra.Name, infra.EnvironmentID)
+
 	return "infra deleted successfully", nil
 }
 
@@ -378,6 +403,7 @@ func (in *infraService) GetInfra(ctx context.Context, projectID string, infraID
 				Username: username,
 			},
 		}
+		infraResponse.LastHeartbeat, infraResponse.Health = infra.Heartbeat.GetOutputHealth()
 		lastRun := strconv.FormatInt(infra.ExperimentDetails[0].LastRunTimestamp, 10)
 		if len(infra.ExperimentDetails) > 0 {
 			infraResponse.NoOfExperimentRuns = &infra.ExperimentDetails[0].TotalRuns
@@ -696,6 +722,7 @@ func (in *infraService) ListInfras(projectID string, request *model.ListInfraReq
 		newInfra.UpdatedBy = &model.UserDetails{
 			Username: infra.UpdatedBy.Username,
 		}
+		newInfra.LastHeartbeat, newInfra.Health = infra.Heartbeat.GetOutputHealth()
 		newInfras = append(newInfras, &newInfra)
 
 		//var updateStatus model.UpdateStatus
@@ -769,6 +796,7 @@ func (in *infraService) GetInfraDetails(ctx context.Context, infraID string, pro
 	if err != nil {
 		return nil, err
 	}
+	newInfra.LastHeartbeat, newInfra.Health = infra.Heartbeat.GetOutputHealth()
 
 	return &newInfra, nil
 }
@@ -927,25 +955,30 @@ func (in *infraService) QueryServerVersion(ctx context.Context) (*model.ServerVe
 	}, nil
 }
 
-// PodLog receives logs from the workflow-agent and publishes to frontend clients
+// PodLog receives logs from the workflow-agent and publishes to frontend clients, the logs may be streamed in chunks.
+// The logs of a request which isn't in progress are rejected, so that the infra stops streaming them
 func (in *infraService) PodLog(request model.PodLog, r store.StateData) (string, error) {
 	_, err := in.VerifyInfra(*request.InfraID)
 	if err != nil {
 		log.Print("ERROR", err)
 		return "", err
 	}
-	if reqChan, ok := r.ExperimentLog[request.RequestID]; ok {
-		resp := model.PodLogResponse{
-			PodName:         request.PodName,
-			ExperimentRunID: request.ExperimentRunID,
-			PodType:         request.PodType,
-			Log:             request.Log,
-		}
-		reqChan <- &resp
-		close(reqChan)
-		return "LOGS SENT SUCCESSFULLY", nil
+	if !r.Bus.IsPodLogOpen(request.RequestID) {
+		return "", store.ErrNoSuchPodLogRequest
+	}
+	resp := model.PodLogResponse{
+		PodName:         request.PodName,
+		ExperimentRunID: request.ExperimentRunID,
+		PodType:         request.PodType,
+		Log:             request.Log,
+		// the older subscribers send the logs in a single response
+		Done: request.Done == nil || *request.Done,
 	}
-	return "LOG REQUEST CANCELLED", nil
+	// the request may have been made on another replica
+	if err := r.Bus.SendPodLog(request.RequestID, &resp); err != nil {
+		return "", fmt.Errorf("failed to send the logs %w", err)
+	}
+	return "LOGS SENT SUCCESSFULLY", nil
 }
 
 // KubeObj receives Kubernetes Object data from subscriber
@@ -955,22 +988,345 @@ func (in *infraService) KubeObj(request model.KubeObjectData, r store.StateData)
 		log.Print("Error", err)
 		return "", err
 	}
-	if reqChan, ok := r.KubeObjectData[request.RequestID]; ok {
-		var kubeObjData []*model.KubeObject
-		err = json.Unmarshal([]byte(request.KubeObj), &kubeObjData)
+	var kubeObjData []*model.KubeObject
+	err = json.Unmarshal([]byte(request.KubeObj), &kubeObjData)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal kubeObj data %w", err)
+	}
+
+	resp := model.KubeObjectResponse{
+		InfraID: request.InfraID.InfraID,
+		KubeObj: kubeObjData,
+	}
+	if err := r.Bus.SendKubeObject(request.RequestID, &resp); err != nil {
+		return "", fmt.Errorf("failed to send kubeObj data %w", err)
+	}
+	return "KubeData sent successfully", nil
+}
+
+// AckInfraAction records the state of an action applied by the subscriber, the action isn't sent again
+func (in *infraService) AckInfraAction(request model.InfraActionAck, r store.StateData) (string, error) {
+	_, err := in.VerifyUpgradingInfra(*request.InfraID)
+	if err != nil {
+		log.Print("Error", err)
+		return "", err
+	}
+	if err := r.Actions.Acknowledge(request.InfraID.InfraID, request.RequestID, request.State, request.Error); err != nil {
+		return "", fmt.Errorf("failed to acknowledge the action %w", err)
+	}
+	return "action acknowledged successfully", nil
+}
+
+// ListInfraActions returns the latest actions sent to the infras of a project along with their states
+func (in *infraService) ListInfraActions(projectID string, request *model.ListInfraActionsRequest, r store.StateData) ([]*model.InfraActionStatus, error) {
+	return r.Actions.List(projectID, request)
+}
+
+// InfraHeartbeat records the health sent by an infra in its heartbeat, the infra events are sent when its health
+// changes
+func (in *infraService) InfraHeartbeat(request model.InfraHeartbeatRequest, r store.StateData) (string, error) {
+	infra, err := in.VerifyInfra(*request.InfraID)
+	if err != nil {
+		log.Print("Error", err)
+		return "", err
+	}
+
+	heartbeat := dbChaosInfra.Heartbeat{
+		UpdatedAt:  time.Now().UnixMilli(),
+		IsHealthy:  true,
+		NodeCount:  request.NodeCount,
+		K8sVersion: request.K8sVersion,
+		Components: []dbChaosInfra.ComponentHealth{},
+	}
+	for _, component := range request.Components {
+		heartbeat.Components = append(heartbeat.Components, dbChaosInfra.ComponentHealth{
+			Name:    component.Name,
+			Healthy: component.Healthy,
+			Message: component.Message,
+		})
+		if !component.Healthy {
+			heartbeat.IsHealthy = false
+		}
+	}
+
+	// the infra is active again if it was marked inactive after missing its heartbeats
+	isActive := r.Bus.IsInfraConnected(infra.InfraID)
+	query := bson.D{{"infra_id", infra.InfraID}}
+	update := bson.D{{"$set", bson.D{{"heartbeat", heartbeat}, {"is_active", isActive}}}}
+	if err := in.infraOperator.UpdateInfra(context.Background(), query, update); err != nil {
+		return "", fmt.Errorf("failed to record the heartbeat %w", err)
+	}
+
+	wasHealthy := infra.Heartbeat != nil && infra.Heartbeat.IsHealthy
+	if heartbeat.IsHealthy != wasHealthy {
+		infra.IsActive = isActive
+		infra.Heartbeat = &heartbeat
+		newInfra := model.Infra{}
+		copier.Copy(&newInfra, infra)
+		newInfra.LastHeartbeat, newInfra.Health = heartbeat.GetOutputHealth()
+		if heartbeat.IsHealthy {
+			in.SendInfraEvent("infra-health", "Infra Healthy", "All the infra components are healthy", newInfra, r)
+		} else {
+			in.SendInfraEvent("infra-health", "Infra Unhealthy", "Some of the infra components aren't healthy", newInfra, r)
+		}
+	}
+	return "heartbeat received successfully", nil
+}
+
+// RecurringHeartbeatCheck periodically marks the infras which missed their heartbeats as inactive and unhealthy
+func (in *infraService) RecurringHeartbeatCheck(r *store.StateData) {
+	for {
+		if err := in.checkInfraHeartbeats(*r); err != nil {
+			log.Print("failed to check the infra heartbeats: ", err)
+		}
+		time.Sleep(utils.Config.InfraHeartbeatTimeout / 3)
+	}
+}
+
+// checkInfraHeartbeats marks the infras which didn't send a heartbeat within the timeout as inactive and unhealthy
+// and drops their subscriptions, the infras which never sent one aren't checked
+func (in *infraService) checkInfraHeartbeats(r store.StateData) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stale := bson.D{
+		{"is_removed", false},
+		{"heartbeat.updated_at", bson.D{{"$lt", time.Now().Add(-utils.Config.InfraHeartbeatTimeout).UnixMilli()}}},
+		{"$or", bson.A{
+			bson.D{{"is_active", true}},
+			bson.D{{"heartbeat.is_healthy", true}},
+		}},
+	}
+	infras, err := in.infraOperator.GetInfras(ctx, stale)
+	if err != nil {
+		return err
+	}
+
+	for _, infra := range infras {
+		// another replica may have marked the infra already
+		query := append(bson.D{{"infra_id", infra.InfraID}}, stale...)
+		update := bson.D{{"$set", bson.D{{"is_active", false}, {"heartbeat.is_healthy", false}}}}
+		matched, err := in.infraOperator.UpdateOneInfra(ctx, query, update)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			continue
+		}
+		// the subscription of the infra may not be closed, it would keep the infra from connecting again
+		if err := r.DisconnectStaleInfra(infra.InfraID); err != nil {
+			log.Print("failed to disconnect the stale infra: ", err)
+		}
+
+		infra.IsActive = false
+		infra.Heartbeat.IsHealthy = false
+		newInfra := model.Infra{}
+		copier.Copy(&newInfra, &infra)
+		newInfra.LastHeartbeat, newInfra.Health = infra.Heartbeat.GetOutputHealth()
+		in.SendInfraEvent("infra-health", "Infra Unhealthy", "Infra missed its heartbeats", newInfra, r)
+	}
+	return nil
+}
+
+// UpgradeInfra sends the manifest of the control plane version to an infra, which applies it and reports the
+// progress of the upgrade
+func (in *infraService) UpgradeInfra(ctx context.Context, projectID string, infraID string, r store.StateData) (*model.InfraUpgrade, error) {
+	infra, err := in.infraOperator.GetInfra(infraID)
+	if err != nil {
+		return nil, err
+	}
+	if infra.ProjectID != projectID || infra.IsRemoved {
+		return nil, errors.New("no matching infra")
+	}
+	if !infra.IsInfraConfirmed {
+		return nil, errors.New("the infra isn't confirmed yet, apply its manifest first")
+	}
+
+	version := utils.Config.Version
+	if infra.Version == version {
+		return nil, fmt.Errorf("the infra is already on version %v", version)
+	}
+	// only the infras of this release upgrade themselves, the ones of a previous release ignore the upgrade
+	if err := verifyCurrentInfraVersion(infra.Version); err != nil {
+		return nil, fmt.Errorf("the infra can't be upgraded automatically, apply its upgrade manifest instead: %w", err)
+	}
+
+	if err := in.expireInfraUpgrades(ctx, infraID, r); err != nil {
+		return nil, err
+	}
+	inProgress, err := in.infraUpgradeOperator.GetLatestInfraUpgrade(ctx, bson.D{
+		{"infra_id", infraID},
+		{"status", bson.D{{"$in", bson.A{model.InfraUpgradeStatusPending, model.InfraUpgradeStatusInProgress}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if inProgress != nil {
+		return nil, fmt.Errorf("the infra is already being upgraded to version %v", inProgress.ToVersion)
+	}
+
+	manifest, err := GetK8sInfraYaml(infra)
+	if err != nil {
+		return nil, err
+	}
+
+	tkn := ctx.Value(authorization.AuthKey).(string)
+	username, err := authorization.GetUsername(tkn)
+	if err != nil {
+		return nil, err
+	}
+
+	currentTime := time.Now().UnixMilli()
+	upgrade := dbInfraUpgrade.InfraUpgrade{
+		UpgradeID:   uuid.New().String(),
+		ProjectID:   projectID,
+		InfraID:     infraID,
+		FromVersion: infra.Version,
+		ToVersion:   version,
+		Status:      model.InfraUpgradeStatusPending,
+		Username:    &username,
+		CreatedAt:   currentTime,
+		UpdatedAt:   currentTime,
+	}
+	// an upgrade started concurrently is rejected by the unique index of the upgrades in progress
+	if err := in.infraUpgradeOperator.InsertInfraUpgrade(ctx, upgrade); err != nil {
+		return nil, err
+	}
+
+	namespace := "litmus"
+	if infra.InfraNamespace != nil && *infra.InfraNamespace != "" {
+		namespace = *infra.InfraNamespace
+	}
+	externalData, err := json.Marshal(map[string]string{"version": version})
+	if err != nil {
+		return nil, err
+	}
+	externalDataStr := string(externalData)
+
+	// the upgrade is acknowledged by the infra once it's done, the subscriber which runs the new version
+	// receives it again if the previous one is replaced before acknowledging it
+	action := &model.InfraActionResponse{
+		ProjectID: projectID,
+		Action: &model.ActionPayload{
+			RequestID:    upgrade.UpgradeID,
+			RequestType:  "upgrade",
+			K8sManifest:  string(manifest),
+			Namespace:    namespace,
+			ExternalData: &externalDataStr,
+			Username:     &username,
+		},
+	}
+	if err := r.QueueInfraAction(infraID, action); err != nil {
+		actionErr := err.Error()
+		query := bson.D{{"upgrade_id", upgrade.UpgradeID}}
+		update := bson.D{{"$set", bson.D{{"status", model.InfraUpgradeStatusFailed}, {"error", actionErr}, {"updated_at", time.Now().UnixMilli()}}}}
+		if _, err := in.infraUpgradeOperator.UpdateInfraUpgrade(ctx, query, update); err != nil {
+			logrus.WithError(err).WithField("upgrade_id", upgrade.UpgradeID).Error("failed to record the upgrade failure")
+		}
+		return nil, fmt.Errorf("failed to send the upgrade to the infra %w", err)
+	}
+
+	return upgrade.GetOutputInfraUpgrade(), nil
+}
+
+// expireInfraUpgrades marks the upgrade of an infra which wasn't completed within the timeout as failed, the
+// upgrade isn't sent to the infra anymore
+func (in *infraService) expireInfraUpgrades(ctx context.Context, infraID string, r store.StateData) error {
+	upgrade, err := in.infraUpgradeOperator.GetLatestInfraUpgrade(ctx, bson.D{
+		{"infra_id", infraID},
+		{"status", bson.D{{"$in", bson.A{model.InfraUpgradeStatusPending, model.InfraUpgradeStatusInProgress}}}},
+		{"updated_at", bson.D{{"$lt", time.Now().Add(-utils.Config.InfraUpgradeTimeout).UnixMilli()}}},
+	})
+	if err != nil || upgrade == nil {
+		return err
+	}
+
+	reason := "the infra didn't complete the upgrade in time"
+	query := bson.D{{"upgrade_id", upgrade.UpgradeID}, {"status", upgrade.Status}}
+	update := bson.D{{"$set", bson.D{
+		{"status", model.InfraUpgradeStatusFailed},
+		{"error", reason},
+		{"updated_at", time.Now().UnixMilli()},
+	}}}
+	if _, err := in.infraUpgradeOperator.UpdateInfraUpgrade(ctx, query, update); err != nil {
+		return err
+	}
+	return r.Actions.Cancel(infraID, upgrade.UpgradeID, reason)
+}
+
+// UpdateInfraUpgradeStatus records the progress of an upgrade reported by the infra, the status of a completed
+// upgrade can't change
+func (in *infraService) UpdateInfraUpgradeStatus(request model.InfraUpgradeStatusRequest, r store.StateData) (string, error) {
+	infra, err := in.VerifyUpgradingInfra(*request.InfraID)
+	if err != nil {
+		log.Print("Error", err)
+		return "", err
+	}
+	if request.Status == model.InfraUpgradeStatusPending {
+		return "", errors.New("an infra can't report an upgrade as " + string(model.InfraUpgradeStatusPending))
+	}
+
+	ctx := context.Background()
+	query := bson.D{
+		{"upgrade_id", request.UpgradeID},
+		{"infra_id", infra.InfraID},
+		{"status", bson.D{{"$in", bson.A{model.InfraUpgradeStatusPending, model.InfraUpgradeStatusInProgress}}}},
+	}
+	set := bson.D{{"status", request.Status}, {"updated_at", time.Now().UnixMilli()}}
+	if request.Stage != nil {
+		set = append(set, bson.E{"stage", *request.Stage})
+	}
+	if request.Error != nil {
+		set = append(set, bson.E{"error", *request.Error})
+	}
+	matched, err := in.infraUpgradeOperator.UpdateInfraUpgrade(ctx, query, bson.D{{"$set", set}})
+	if err != nil {
+		return "", fmt.Errorf("failed to record the upgrade status %w", err)
+	}
+
+	if matched == 0 {
+		// the subscriber running the new version reports the end of an upgrade again if the previous one was
+		// replaced before acknowledging it
+		upgrade, err := in.infraUpgradeOperator.GetLatestInfraUpgrade(ctx, bson.D{{"upgrade_id", request.UpgradeID}, {"infra_id", infra.InfraID}})
 		if err != nil {
-			return "", fmt.Errorf("failed to unmarshal kubeObj data %w", err)
+			return "", err
 		}
+		if upgrade == nil {
+			return "", errors.New("no such upgrade found for the infra")
+		}
+		if upgrade.Status != request.Status {
+			return "", fmt.Errorf("the upgrade is already %v", upgrade.Status)
+		}
+		return "upgrade status already recorded", nil
+	}
 
-		resp := model.KubeObjectResponse{
-			InfraID: request.InfraID.InfraID,
-			KubeObj: kubeObjData,
+	if request.Status != model.InfraUpgradeStatusInProgress {
+		newInfra := model.Infra{}
+		copier.Copy(&newInfra, infra)
+		switch request.Status {
+		case model.InfraUpgradeStatusSucceeded:
+			in.SendInfraEvent("infra-upgrade", "Infra Upgraded", "Infra components were upgraded", newInfra, r)
+		case model.InfraUpgradeStatusRolledBack:
+			in.SendInfraEvent("infra-upgrade", "Infra Upgrade Rolled Back", "Infra upgrade failed and the previous version was restored", newInfra, r)
+		default:
+			in.SendInfraEvent("infra-upgrade", "Infra Upgrade Failed", "Infra upgrade failed", newInfra, r)
 		}
-		reqChan <- &resp
-		close(reqChan)
-		return "KubeData sent successfully", nil
 	}
-	return "KubeData sent successfully", nil
+	return "upgrade status updated successfully", nil
+}
+
+// ListInfraUpgrades returns the latest upgrades of an infra
+func (in *infraService) ListInfraUpgrades(ctx context.Context, projectID string, infraID string) ([]*model.InfraUpgrade, error) {
+	upgrades, err := in.infraUpgradeOperator.ListInfraUpgrades(ctx, bson.D{{"project_id", projectID}, {"infra_id", infraID}}, listInfraUpgradesLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []*model.InfraUpgrade{}
+	for _, upgrade := range upgrades {
+		response = append(response, upgrade.GetOutputInfraUpgrade())
+	}
+	return response, nil
 }
 
 // SendInfraEvent sends events from the infras to the appropriate users listening for the events
@@ -982,13 +1338,27 @@ func (in *infraService) SendInfraEvent(eventType, eventName, description string,
 		Description: description,
 		Infra:       &infra,
 	}
-	r.Mutex.Lock()
-	if r.InfraEventPublish != nil {
-		for _, observer := range r.InfraEventPublish[infra.ProjectID] {
-			observer <- &newEvent
-		}
+	if err := r.Bus.PublishInfraEvent(infra.ProjectID, &newEvent); err != nil {
+		logrus.WithError(err).WithField("infra_id", infra.InfraID).Error("failed to publish the infra event")
 	}
-	r.Mutex.Unlock()
+
+	if notificationEvent, ok := infraNotificationEvents[eventName]; ok {
+		in.notify(notificationEvent, infra.ProjectID, infra.InfraID, infra.Name, infra.EnvironmentID)
+	}
+}
+
+// notify sends an infra event to the notification channels of the project
+func (in *infraService) notify(eventType model.NotificationEventType, projectID, infraID, infraName, environmentID string) {
+	if in.notificationService == nil {
+		return
+	}
+	in.notificationService.Notify(notification.Event{
+		Type:          eventType,
+		ProjectID:     projectID,
+		InfraID:       infraID,
+		InfraName:     infraName,
+		EnvironmentID: environmentID,
+	})
 }
 
 // ConfirmInfraRegistration takes the cluster_id and access_key from the subscriber and validates it, if validated generates and sends new access_key
@@ -1029,20 +1399,24 @@ func (in *infraService) ConfirmInfraRegistration(request model.InfraIdentity, r
 	return &model.ConfirmInfraRegistrationResponse{IsInfraConfirmed: false}, err
 }
 
-// VerifyInfra function used to verify infra identity
+// VerifyInfra function used to verify infra identity, the infra has to run a patch of the control plane version
 func (in *infraService) VerifyInfra(identity model.InfraIdentity) (*dbChaosInfra.ChaosInfra, error) {
-	currentVersion := utils.Config.Version
-	if strings.Contains(strings.ToLower(currentVersion), CIVersion) {
-		if currentVersion != identity.Version {
-			return nil, fmt.Errorf("ERROR: infra VERSION MISMATCH (need %v got %v)", currentVersion, identity.Version)
-		}
-	} else {
-		splitCPVersion := strings.Split(currentVersion, ".")
-		splitSubVersion := strings.Split(identity.Version, ".")
-		if len(splitSubVersion) != 3 || splitSubVersion[0] != splitCPVersion[0] || splitSubVersion[1] != splitCPVersion[1] {
-			return nil, fmt.Errorf("ERROR: infra VERSION MISMATCH (need %v.%v.x got %v)", splitCPVersion[0], splitCPVersion[1], identity.Version)
-		}
+	if err := verifyCurrentInfraVersion(identity.Version); err != nil {
+		return nil, err
 	}
+	return in.verifyInfraAccessKey(identity)
+}
+
+// VerifyUpgradingInfra verifies the identity of an infra which receives or reports its upgrade, the infras of the
+// compatible versions are accepted so that they can be upgraded
+func (in *infraService) VerifyUpgradingInfra(identity model.InfraIdentity) (*dbChaosInfra.ChaosInfra, error) {
+	if err := verifyInfraVersion(identity.Version); err != nil {
+		return nil, err
+	}
+	return in.verifyInfraAccessKey(identity)
+}
+
+func (in *infraService) verifyInfraAccessKey(identity model.InfraIdentity) (*dbChaosInfra.ChaosInfra, error) {
 	infra, err := in.infraOperator.GetInfra(identity.InfraID)
 	if err != nil {
 		return nil, err
@@ -1053,6 +1427,43 @@ func (in *infraService) VerifyInfra(identity model.InfraIdentity) (*dbChaosInfra
 	return &infra, nil
 }
 
+// verifyInfraVersion returns an error if an infra of the version can't connect to the control plane. The infras
+// of the compatible versions can connect, but they only get their upgrade
+func verifyInfraVersion(version string) error {
+	var compatibleVersions []string
+	_ = json.Unmarshal([]byte(utils.Config.InfraCompatibleVersions), &compatibleVersions)
+	for _, compatibleVersion := range compatibleVersions {
+		if compatibleVersion == version {
+			return nil
+		}
+	}
+	return verifyCurrentInfraVersion(version)
+}
+
+// verifyCurrentInfraVersion returns an error if the version isn't a patch of the version of the control plane
+func verifyCurrentInfraVersion(version string) error {
+	currentVersion := utils.Config.Version
+	if strings.Contains(strings.ToLower(currentVersion), CIVersion) {
+		if currentVersion != version {
+			return fmt.Errorf("ERROR: infra VERSION MISMATCH (need %v got %v)", currentVersion, version)
+		}
+	} else {
+		splitCPVersion := strings.Split(currentVersion, ".")
+		splitSubVersion := strings.Split(version, ".")
+		if len(splitSubVersion) != 3 || splitSubVersion[0] != splitCPVersion[0] || splitSubVersion[1] != splitCPVersion[1] {
+			return fmt.Errorf("ERROR: infra VERSION MISMATCH (need %v.%v.x got %v)", splitCPVersion[0], splitCPVersion[1], version)
+		}
+	}
+	return nil
+}
+
+// IsLegacyInfraVersion returns true if the version is a compatible version of a previous release. Such infras
+// can't upgrade themselves and may predate the acknowledgements of the actions, they have to be upgraded by
+// applying their upgrade manifest
+func IsLegacyInfraVersion(version string) bool {
+	return verifyCurrentInfraVersion(version) != nil
+}
+
 func (in *infraService) GetManifest(token string) ([]byte, int, error) {
 	infraID, err := InfraValidateJWT(token)
 	if err != nil {
@@ -0,0 +1,396 @@
+package chaos_infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	store "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/data-store"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
+	dbChaosInfra "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_infrastructure"
+	dbInfraUpgrade "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/infra_upgrade"
+	dbMocks "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/mocks"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestVerifyInfraVersion(t *testing.T) {
+	config := utils.Config
+	defer func() { utils.Config = config }()
+	utils.Config.Version = "3.2.1"
+	utils.Config.InfraCompatibleVersions = `["3.1.0", "3.2.0"]`
+
+	// the infras of the same minor version can connect
+	assert.NoError(t, verifyInfraVersion("3.2.0"))
+	assert.NoError(t, verifyInfraVersion("3.2.5"))
+	// the infras of the compatible versions can connect to be upgraded
+	assert.NoError(t, verifyInfraVersion("3.1.0"))
+	assert.Error(t, verifyInfraVersion("3.1.1"))
+	assert.Error(t, verifyInfraVersion("2.14.0"))
+	assert.Error(t, verifyInfraVersion("3.2"))
+
+	// the infras of the compatible versions of the previous releases only get their upgrade
+	assert.False(t, IsLegacyInfraVersion("3.2.0"))
+	assert.True(t, IsLegacyInfraVersion("3.1.0"))
+	assert.Error(t, verifyCurrentInfraVersion("3.1.0"))
+
+	utils.Config.Version = "3.2.1-ci"
+	assert.NoError(t, verifyInfraVersion("3.2.1-ci"))
+	assert.Error(t, verifyInfraVersion("3.2.0-ci"))
+}
+
+func TestExpireInfraUpgrades(t *testing.T) {
+	mongodbMockOperator := new(dbMocks.MongoOperator)
+	service := &infraService{infraUpgradeOperator: dbInfraUpgrade.NewInfraUpgradeOperator(mongodbMockOperator)}
+	state := store.NewStore()
+
+	upgrade := dbInfraUpgrade.InfraUpgrade{UpgradeID: "upgrade", ProjectID: "project", InfraID: "infra", Status: model.InfraUpgradeStatusInProgress}
+	cursor, err := mongo.NewCursorFromDocuments([]interface{}{upgrade}, nil, nil)
+	assert.NoError(t, err)
+	mongodbMockOperator.On("List", mock.Anything, mongodb.InfraUpgradeCollection, mock.Anything, mock.Anything).Return(cursor, nil).Once()
+	mongodbMockOperator.On("Update", mock.Anything, mongodb.InfraUpgradeCollection, mock.Anything, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+
+	// the upgrade was delivered to the infra, which didn't complete it
+	assert.NoError(t, state.Actions.Enqueue("infra", &model.InfraActionResponse{
+		ProjectID: "project",
+		Action:    &model.ActionPayload{RequestID: "upgrade", RequestType: "upgrade"},
+	}))
+	_, err = state.Actions.Next("infra", nil)
+	assert.NoError(t, err)
+
+	assert.NoError(t, service.expireInfraUpgrades(context.Background(), "infra", *state))
+	mongodbMockOperator.AssertExpectations(t)
+
+	// the upgrade isn't sent again on the next connection
+	statuses, err := state.Actions.List("project", nil)
+	assert.NoError(t, err)
+	if assert.Len(t, statuses, 1) {
+		assert.Equal(t, model.InfraActionStateFailed, statuses[0].State)
+	}
+	assert.NoError(t, state.Actions.Requeue("infra"))
+	action, err := state.Actions.Next("infra", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, action)
+}
+
+func TestUpdateInfraUpgradeStatus(t *testing.T) {
+	config := utils.Config
+	defer func() { utils.Config = config }()
+	utils.Config.Version = "3.2.1"
+	utils.Config.InfraCompatibleVersions = `["3.1.0"]`
+
+	stage := "subscriber"
+	testcases := []struct {
+		name       string
+		version    string
+		status     model.InfraUpgradeStatus
+		matched    int64
+		recorded   *dbInfraUpgrade.InfraUpgrade
+		wantResult string
+		wantErr    bool
+	}{
+		{
+			name:       "progress of the upgrade",
+			version:    "3.2.1",
+			status:     model.InfraUpgradeStatusInProgress,
+			matched:    1,
+			wantResult: "upgrade status updated successfully",
+		},
+		{
+			name:       "end of the upgrade reported by an infra of a compatible version",
+			version:    "3.1.0",
+			status:     model.InfraUpgradeStatusSucceeded,
+			matched:    1,
+			wantResult: "upgrade status updated successfully",
+		},
+		{
+			name:    "pending status",
+			version: "3.2.1",
+			status:  model.InfraUpgradeStatusPending,
+			wantErr: true,
+		},
+		{
+			name:    "incompatible version",
+			version: "3.0.0",
+			status:  model.InfraUpgradeStatusSucceeded,
+			wantErr: true,
+		},
+		{
+			name:       "end of the upgrade reported again by the new subscriber",
+			version:    "3.2.1",
+			status:     model.InfraUpgradeStatusSucceeded,
+			recorded:   &dbInfraUpgrade.InfraUpgrade{UpgradeID: "upgrade", InfraID: "infra", Status: model.InfraUpgradeStatusSucceeded},
+			wantResult: "upgrade status already recorded",
+		},
+		{
+			name:     "completed upgrade",
+			version:  "3.2.1",
+			status:   model.InfraUpgradeStatusFailed,
+			recorded: &dbInfraUpgrade.InfraUpgrade{UpgradeID: "upgrade", InfraID: "infra", Status: model.InfraUpgradeStatusSucceeded},
+			wantErr:  true,
+		},
+		{
+			name:    "unknown upgrade",
+			version: "3.2.1",
+			status:  model.InfraUpgradeStatusSucceeded,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mongodbMockOperator := new(dbMocks.MongoOperator)
+			service := &infraService{
+				infraOperator:        dbChaosInfra.NewInfrastructureOperator(mongodbMockOperator),
+				infraUpgradeOperator: dbInfraUpgrade.NewInfraUpgradeOperator(mongodbMockOperator),
+			}
+			infra := dbChaosInfra.ChaosInfra{InfraID: "infra", AccessKey: "access-key", IsRegistered: true}
+			mongodbMockOperator.On("Get", mock.Anything, mongodb.ChaosInfraCollection, mock.Anything).Return(mongo.NewSingleResultFromDocument(infra, nil, nil), nil)
+			mongodbMockOperator.On("Update", mock.Anything, mongodb.InfraUpgradeCollection, mock.Anything, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{MatchedCount: tc.matched}, nil)
+			recorded := []interface{}{}
+			if tc.recorded != nil {
+				recorded = append(recorded, *tc.recorded)
+			}
+			cursor, err := mongo.NewCursorFromDocuments(recorded, nil, nil)
+			assert.NoError(t, err)
+			mongodbMockOperator.On("List", mock.Anything, mongodb.InfraUpgradeCollection, mock.Anything, mock.Anything).Return(cursor, nil)
+
+			result, err := service.UpdateInfraUpgradeStatus(model.InfraUpgradeStatusRequest{
+				InfraID:   &model.InfraIdentity{InfraID: "infra", AccessKey: "access-key", Version: tc.version},
+				UpgradeID: "upgrade",
+				Status:    tc.status,
+				Stage:     &stage,
+			}, *store.NewStore())
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantResult, result)
+		})
+	}
+}
+
+func TestInfraHeartbeat(t *testing.T) {
+	config := utils.Config
+	defer func() { utils.Config = config }()
+	utils.Config.Version = "3.2.1"
+
+	message := "pods not found"
+	testcases := []struct {
+		name       string
+		accessKey  string
+		components []*model.InfraComponentHealthRequest
+		previous   *dbChaosInfra.Heartbeat
+		connected  bool
+		wantEvent  string
+		wantErr    bool
+	}{
+		{
+			name:       "first heartbeat of a healthy infra",
+			accessKey:  "access-key",
+			components: []*model.InfraComponentHealthRequest{{Name: "subscriber", Healthy: true}},
+			connected:  true,
+			wantEvent:  "Infra Healthy",
+		},
+		{
+			name:      "unhealthy component",
+			accessKey: "access-key",
+			components: []*model.InfraComponentHealthRequest{
+				{Name: "subscriber", Healthy: true},
+				{Name: "event-tracker", Healthy: false, Message: &message},
+			},
+			previous:  &dbChaosInfra.Heartbeat{IsHealthy: true},
+			connected: true,
+			wantEvent: "Infra Unhealthy",
+		},
+		{
+			name:       "unchanged health",
+			accessKey:  "access-key",
+			components: []*model.InfraComponentHealthRequest{{Name: "subscriber", Healthy: true}},
+			previous:   &dbChaosInfra.Heartbeat{IsHealthy: true},
+			connected:  true,
+		},
+		{
+			name:       "heartbeat of an infra which isn't connected",
+			accessKey:  "access-key",
+			components: []*model.InfraComponentHealthRequest{{Name: "subscriber", Healthy: true}},
+			previous:   &dbChaosInfra.Heartbeat{IsHealthy: true},
+		},
+		{
+			name:      "wrong access key",
+			accessKey: "other-key",
+			wantErr:   true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mongodbMockOperator := new(dbMocks.MongoOperator)
+			service := &infraService{infraOperator: dbChaosInfra.NewInfrastructureOperator(mongodbMockOperator)}
+			state := store.NewStore()
+			if tc.connected {
+				state.ConnectedInfra["infra"] = make(chan *model.InfraActionResponse)
+			}
+			infraEvents := make(chan *model.InfraEventResponse, 1)
+			state.SubscribeInfraEvents("project", infraEvents)
+
+			infra := dbChaosInfra.ChaosInfra{InfraID: "infra", ProjectID: "project", AccessKey: "access-key", IsRegistered: true, Heartbeat: tc.previous}
+			mongodbMockOperator.On("Get", mock.Anything, mongodb.ChaosInfraCollection, mock.Anything).Return(mongo.NewSingleResultFromDocument(infra, nil, nil), nil)
+			var update bson.D
+			mongodbMockOperator.On("UpdateMany", mock.Anything, mongodb.ChaosInfraCollection, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				update = args.Get(3).(bson.D)
+			}).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+
+			_, err := service.InfraHeartbeat(model.InfraHeartbeatRequest{
+				InfraID:    &model.InfraIdentity{InfraID: "infra", AccessKey: tc.accessKey, Version: "3.2.1"},
+				Components: tc.components,
+			}, *state)
+			if tc.wantErr {
+				assert.Error(t, err)
+				mongodbMockOperator.AssertNotCalled(t, "UpdateMany", mock.Anything, mongodb.ChaosInfraCollection, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+
+			// the infra is only active while it's connected
+			set := update.Map()["$set"].(bson.D).Map()
+			assert.Equal(t, tc.connected, set["is_active"])
+			assert.Len(t, set["heartbeat"].(dbChaosInfra.Heartbeat).Components, len(tc.components))
+
+			if tc.wantEvent == "" {
+				assert.Len(t, infraEvents, 0)
+				return
+			}
+			if assert.Len(t, infraEvents, 1) {
+				event := <-infraEvents
+				assert.Equal(t, tc.wantEvent, event.EventName)
+				assert.Equal(t, tc.wantEvent == "Infra Healthy", event.Infra.Health.IsHealthy)
+			}
+		})
+	}
+}
+
+func TestCheckInfraHeartbeats(t *testing.T) {
+	config := utils.Config
+	defer func() { utils.Config = config }()
+	utils.Config.InfraHeartbeatTimeout = 90 * time.Second
+
+	mongodbMockOperator := new(dbMocks.MongoOperator)
+	service := &infraService{infraOperator: dbChaosInfra.NewInfrastructureOperator(mongodbMockOperator)}
+	state := store.NewStore()
+	infraEvents := make(chan *model.InfraEventResponse, 2)
+	state.SubscribeInfraEvents("project", infraEvents)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.NoError(t, state.ConnectInfra(ctx, "stale", make(chan *model.InfraActionResponse, 1), false))
+
+	heartbeat := &dbChaosInfra.Heartbeat{UpdatedAt: time.Now().Add(-time.Hour).UnixMilli(), IsHealthy: true}
+	stale := []interface{}{
+		dbChaosInfra.ChaosInfra{InfraID: "stale", ProjectID: "project", IsActive: true, Heartbeat: heartbeat},
+		dbChaosInfra.ChaosInfra{InfraID: "marked", ProjectID: "project", IsActive: true, Heartbeat: heartbeat},
+	}
+	cursor, err := mongo.NewCursorFromDocuments(stale, nil, nil)
+	assert.NoError(t, err)
+	var query bson.D
+	mongodbMockOperator.On("List", mock.Anything, mongodb.ChaosInfraCollection, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		query = args.Get(2).(bson.D)
+	}).Return(cursor, nil).Once()
+	mongodbMockOperator.On("Update", mock.Anything, mongodb.ChaosInfraCollection, mock.Anything, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+	// the second infra was marked by another replica
+	mongodbMockOperator.On("Update", mock.Anything, mongodb.ChaosInfraCollection, mock.Anything, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{MatchedCount: 0}, nil).Once()
+
+	assert.NoError(t, service.checkInfraHeartbeats(*state))
+	mongodbMockOperator.AssertExpectations(t)
+
+	// the stale infra can connect again
+	assert.False(t, state.Bus.IsInfraConnected("stale"))
+
+	// the infras are stale after the timeout
+	deadline := query.Map()["heartbeat.updated_at"].(bson.D).Map()["$lt"].(int64)
+	assert.InDelta(t, time.Now().Add(-utils.Config.InfraHeartbeatTimeout).UnixMilli(), deadline, float64(time.Second.Milliseconds()))
+
+	if assert.Len(t, infraEvents, 1) {
+		event := <-infraEvents
+		assert.Equal(t, "stale", event.Infra.InfraID)
+		assert.Equal(t, "Infra Unhealthy", event.EventName)
+		assert.False(t, event.Infra.IsActive)
+		assert.False(t, event.Infra.Health.IsHealthy)
+	}
+
+	mongodbMockOperator.On("List", mock.Anything, mongodb.ChaosInfraCollection, mock.Anything, mock.Anything).Return((*mongo.Cursor)(nil), errors.New("connection refused")).Once()
+	assert.Error(t, service.checkInfraHeartbeats(*state))
+}
+
+func TestUpgradeLegacyInfra(t *testing.T) {
+	config := utils.Config
+	defer func() { utils.Config = config }()
+	utils.Config.Version = "3.2.1"
+	utils.Config.InfraCompatibleVersions = `["3.1.0"]`
+
+	mongodbMockOperator := new(dbMocks.MongoOperator)
+	service := &infraService{
+		infraOperator:        dbChaosInfra.NewInfrastructureOperator(mongodbMockOperator),
+		infraUpgradeOperator: dbInfraUpgrade.NewInfraUpgradeOperator(mongodbMockOperator),
+	}
+	infra := dbChaosInfra.ChaosInfra{InfraID: "infra", ProjectID: "project", Version: "3.1.0", IsInfraConfirmed: true}
+	mongodbMockOperator.On("Get", mock.Anything, mongodb.ChaosInfraCollection, mock.Anything).Return(mongo.NewSingleResultFromDocument(infra, nil, nil), nil).Once()
+
+	// the subscriber of a previous release ignores the upgrade, it's neither recorded nor queued
+	_, err := service.UpgradeInfra(context.Background(), "project", "infra", *store.NewStore())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "apply its upgrade manifest")
+	}
+	mongodbMockOperator.AssertExpectations(t)
+}
+
+func TestInfraUpgradeInProgressFilter(t *testing.T) {
+	// the range of the unique index matches exactly the upgrades which aren't done
+	filter := mongodb.InfraUpgradeInProgressFilter[0].Value.(bson.D)
+	from, to := filter[0].Value.(string), filter[1].Value.(string)
+	for _, status := range model.AllInfraUpgradeStatus {
+		upgrade := dbInfraUpgrade.InfraUpgrade{Status: status}
+		inRange := string(status) >= from && string(status) <= to
+		assert.Equal(t, !upgrade.IsDone(), inRange, "status %s", status)
+	}
+}
+
+func TestInsertConcurrentInfraUpgrade(t *testing.T) {
+	mongodbMockOperator := new(dbMocks.MongoOperator)
+	operator := dbInfraUpgrade.NewInfraUpgradeOperator(mongodbMockOperator)
+	duplicate := mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "E11000 duplicate key error"}}}
+	mongodbMockOperator.On("Create", mock.Anything, mongodb.InfraUpgradeCollection, mock.Anything).Return(duplicate).Once()
+
+	err := operator.InsertInfraUpgrade(context.Background(), dbInfraUpgrade.InfraUpgrade{UpgradeID: "upgrade", InfraID: "infra", Status: model.InfraUpgradeStatusPending})
+	assert.ErrorIs(t, err, dbInfraUpgrade.ErrUpgradeInProgress)
+}
+
+func TestPodLogOfUnknownRequest(t *testing.T) {
+	config := utils.Config
+	defer func() { utils.Config = config }()
+	utils.Config.Version = "3.2.1"
+
+	mongodbMockOperator := new(dbMocks.MongoOperator)
+	service := &infraService{infraOperator: dbChaosInfra.NewInfrastructureOperator(mongodbMockOperator)}
+	infra := dbChaosInfra.ChaosInfra{InfraID: "infra", ProjectID: "project", AccessKey: "access-key", IsRegistered: true}
+	mongodbMockOperator.On("Get", mock.Anything, mongodb.ChaosInfraCollection, mock.Anything).Return(mongo.NewSingleResultFromDocument(infra, nil, nil), nil)
+	state := store.NewStore()
+	request := model.PodLog{
+		InfraID:   &model.InfraIdentity{InfraID: "infra", AccessKey: "access-key", Version: "3.2.1"},
+		RequestID: "request",
+		Log:       "{}",
+	}
+
+	// the infra stops streaming the logs of a request which isn't in progress
+	_, err := service.PodLog(request, *state)
+	assert.ErrorIs(t, err, store.ErrNoSuchPodLogRequest)
+
+	podLog := make(chan *model.PodLogResponse, 1)
+	state.SubscribePodLog("request", podLog)
+	_, err = service.PodLog(request, *state)
+	assert.NoError(t, err)
+	assert.Len(t, podLog, 1)
+}
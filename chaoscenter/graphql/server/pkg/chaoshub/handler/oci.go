@@ -0,0 +1,503 @@
+package handler
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/utils"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	ociManifestMediaType       = "application/vnd.oci.image.manifest.v1+json"
+	dockerManifestMediaType    = "application/vnd.docker.distribution.manifest.v2+json"
+	ociRequestTimeout          = 5 * time.Minute
+	maxOCIManifestSize         = 4 << 20
+	dockerHubRegistry          = "registry-1.docker.io"
+	dockerHubCredentialsServer = "index.docker.io"
+	// maxOCIHubEntries is the number of files and directories a hub artifact may hold
+	maxOCIHubEntries = 10000
+	// maxOCIHubExpansion bounds the size of the extracted hub, as a multiple of the size of its layer
+	maxOCIHubExpansion = 10
+	// OCIProjectLabel labels the pull secrets of the namespace of the portal with the project which may use
+	// them to pull its hubs
+	OCIProjectLabel = "litmuschaos.io/project-id"
+)
+
+// ociHTTPClient sends the requests to the registries
+var ociHTTPClient = &http.Client{Timeout: ociRequestTimeout}
+
+// OCIReference is the reference of the OCI artifact of a hub, like registry.example.com/hubs/charts:3.0 or
+// registry.example.com/hubs/charts@sha256:<digest>
+type OCIReference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// OCICredentials authenticate the pulls of the OCI artifacts of the hubs
+type OCICredentials struct {
+	Username string
+	Password string
+}
+
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+// ParseOCIReference parses the reference of an OCI artifact, the images of Docker Hub may omit the registry
+func ParseOCIReference(reference string) (OCIReference, error) {
+	reference = strings.TrimPrefix(strings.TrimSpace(reference), "oci://")
+	var ref OCIReference
+	if i := strings.Index(reference, "@"); i >= 0 {
+		reference, ref.Digest = reference[:i], reference[i+1:]
+		if !strings.HasPrefix(ref.Digest, "sha256:") || len(ref.Digest) != len("sha256:")+64 {
+			return ref, fmt.Errorf("unsupported digest %s, only sha256 digests are supported", ref.Digest)
+		}
+	}
+	if i := strings.LastIndex(reference, ":"); i > strings.LastIndex(reference, "/") {
+		reference, ref.Tag = reference[:i], reference[i+1:]
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+
+	parts := strings.SplitN(reference, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		ref.Registry, ref.Repository = parts[0], parts[1]
+	} else {
+		ref.Registry, ref.Repository = dockerHubRegistry, reference
+		if len(parts) == 1 {
+			ref.Repository = "library/" + reference
+		}
+	}
+	if ref.Registry == "docker.io" || ref.Registry == dockerHubCredentialsServer {
+		ref.Registry = dockerHubRegistry
+	}
+	if ref.Repository == "" {
+		return ref, fmt.Errorf("invalid OCI reference %s", reference)
+	}
+	return ref, nil
+}
+
+// RegistryCredentials returns the credentials of a registry in a .dockerconfigjson, or nil if there are none
+func RegistryCredentials(dockerConfig []byte, registry string) (*OCICredentials, error) {
+	var config struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(dockerConfig, &config); err != nil {
+		return nil, errors.New("invalid docker config : " + err.Error())
+	}
+
+	for server, auth := range config.Auths {
+		host := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+		host = strings.SplitN(host, "/", 2)[0]
+		if host == "docker.io" {
+			host = dockerHubCredentialsServer
+		}
+		if host != registry && !(registry == dockerHubRegistry && host == dockerHubCredentialsServer) {
+			continue
+		}
+		if auth.Username != "" {
+			return &OCICredentials{Username: auth.Username, Password: auth.Password}, nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return nil, errors.New("invalid auth of registry " + server + " : " + err.Error())
+		}
+		credentials := strings.SplitN(string(decoded), ":", 2)
+		if len(credentials) != 2 {
+			return nil, errors.New("invalid auth of registry " + server)
+		}
+		return &OCICredentials{Username: credentials[0], Password: credentials[1]}, nil
+	}
+	return nil, nil
+}
+
+// ProjectDockerConfig returns the .dockerconfigjson of a pull secret, the secret has to be labelled with the
+// project so that a project can't pull its hubs with the credentials of another project
+func ProjectDockerConfig(secret *corev1.Secret, projectID string) ([]byte, error) {
+	if secret.Labels[OCIProjectLabel] != projectID {
+		return nil, fmt.Errorf("pull secret %s isn't labelled with %s=%s", secret.Name, OCIProjectLabel, projectID)
+	}
+	dockerConfig, ok := secret.Data[corev1.DockerConfigJsonKey]
+	if !ok {
+		return nil, fmt.Errorf("could not find %s value in secret %s", corev1.DockerConfigJsonKey, secret.Name)
+	}
+	return dockerConfig, nil
+}
+
+// DownloadOCIHub pulls the OCI artifact of a hub and extracts its layer to the hub directory, the digests of
+// the manifest and of the layer are verified. It returns the digest of the manifest
+func DownloadOCIHub(hubDetails model.CreateRemoteChaosHub, projectID string, credentials *OCICredentials) (string, error) {
+	ref, err := ParseOCIReference(hubDetails.RepoURL)
+	if err != nil {
+		return "", err
+	}
+	maxSize, err := strconv.ParseInt(utils.Config.RemoteHubMaxSize, 10, 64)
+	if err != nil {
+		return "", err
+	}
+	client := &ociClient{
+		reference:   ref,
+		credentials: credentials,
+		client:      ociHTTPClient,
+	}
+
+	manifest, digest, err := client.getManifest()
+	if err != nil {
+		return "", err
+	}
+	layer, err := hubLayer(manifest)
+	if err != nil {
+		return "", err
+	}
+	if layer.Size > maxSize {
+		return "", fmt.Errorf("err: File size exceeded the threshold %d", layer.Size)
+	}
+
+	dirPath := DefaultPath + projectID
+	if err := os.MkdirAll(dirPath, 0755); err != nil {
+		return "", err
+	}
+	archive, err := os.CreateTemp(dirPath, hubDetails.Name+"-*.tar.gz")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		archive.Close()
+		if err := os.Remove(archive.Name()); err != nil {
+			log.Warnf("failed to remove archive: %v", err)
+		}
+	}()
+	if err := client.getBlob(layer, archive, maxSize); err != nil {
+		return "", err
+	}
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	// the hub is extracted aside, so that a failed pull keeps the previous hub
+	hubPath := dirPath + "/" + hubDetails.Name
+	extractPath := hubPath + ".oci"
+	if err := os.RemoveAll(extractPath); err != nil {
+		return "", err
+	}
+	if err := extractTarGz(archive, extractPath, layer.Size*maxOCIHubExpansion); err != nil {
+		_ = os.RemoveAll(extractPath)
+		return "", err
+	}
+	if err := os.RemoveAll(hubPath); err != nil {
+		return "", err
+	}
+	if err := os.Rename(extractPath, hubPath); err != nil {
+		return "", err
+	}
+	log.WithFields(log.Fields{"hub": hubDetails.Name, "digest": digest}).Info("pulled OCI hub")
+	return digest, nil
+}
+
+// hubLayer returns the gzipped tarball layer of the hub artifact
+func hubLayer(manifest ociManifest) (ociDescriptor, error) {
+	for _, layer := range manifest.Layers {
+		if strings.HasSuffix(layer.MediaType, "tar+gzip") || strings.HasSuffix(layer.MediaType, "tar.gzip") {
+			return layer, nil
+		}
+	}
+	return ociDescriptor{}, errors.New("no gzipped tarball layer found in the OCI artifact")
+}
+
+// ociClient pulls the artifacts of a repository with the OCI distribution API
+type ociClient struct {
+	reference   OCIReference
+	credentials *OCICredentials
+	client      *http.Client
+	token       string
+}
+
+func (c *ociClient) getManifest() (ociManifest, string, error) {
+	var manifest ociManifest
+	reference := c.reference.Digest
+	if reference == "" {
+		reference = c.reference.Tag
+	}
+	resp, err := c.get("/manifests/"+reference, ociManifestMediaType+", "+dockerManifestMediaType)
+	if err != nil {
+		return manifest, "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxOCIManifestSize))
+	if err != nil {
+		return manifest, "", err
+	}
+	sum := sha256.Sum256(data)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+	if c.reference.Digest != "" && digest != c.reference.Digest {
+		return manifest, "", fmt.Errorf("digest of the manifest %s doesn't match %s", digest, c.reference.Digest)
+	}
+	if header := resp.Header.Get("Docker-Content-Digest"); header != "" && header != digest {
+		return manifest, "", fmt.Errorf("digest of the manifest %s doesn't match the digest %s sent by the registry", digest, header)
+	}
+
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, "", errors.New("invalid OCI manifest : " + err.Error())
+	}
+	mediaType := manifest.MediaType
+	if mediaType == "" {
+		mediaType = resp.Header.Get("Content-Type")
+	}
+	if mediaType != "" && mediaType != ociManifestMediaType && mediaType != dockerManifestMediaType {
+		return manifest, "", fmt.Errorf("unsupported OCI manifest type %s", mediaType)
+	}
+	return manifest, digest, nil
+}
+
+// getBlob downloads a blob to the writer and verifies its digest
+func (c *ociClient) getBlob(blob ociDescriptor, w io.Writer, maxSize int64) error {
+	if !strings.HasPrefix(blob.Digest, "sha256:") {
+		return fmt.Errorf("unsupported digest %s, only sha256 digests are supported", blob.Digest)
+	}
+	resp, err := c.get("/blobs/"+blob.Digest, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(w, hash), io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return err
+	}
+	if n > maxSize {
+		return fmt.Errorf("err: File size exceeded the threshold %d", maxSize)
+	}
+	if digest := "sha256:" + hex.EncodeToString(hash.Sum(nil)); digest != blob.Digest {
+		return fmt.Errorf("digest of the layer %s doesn't match %s", digest, blob.Digest)
+	}
+	return nil
+}
+
+// get sends a request to the repository API, authenticating with the challenge of the registry if needed
+func (c *ociClient) get(path, accept string) (*http.Response, error) {
+	requestURL := "https://" + c.reference.Registry + "/v2/" + c.reference.Repository + path
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		} else if c.credentials != nil && attempt > 0 {
+			req.SetBasicAuth(c.credentials.Username, c.credentials.Password)
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusOK {
+			return resp, nil
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 {
+			return nil, fmt.Errorf("GET %s failed with status %s", requestURL, resp.Status)
+		}
+		if err := c.authenticate(resp.Header.Get("WWW-Authenticate")); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// authenticate gets a token from the token service of a Bearer challenge, the Basic challenges are answered
+// with the credentials directly
+func (c *ociClient) authenticate(challenge string) error {
+	scheme, params := parseChallenge(challenge)
+	if strings.EqualFold(scheme, "Basic") {
+		if c.credentials == nil {
+			return errors.New("registry " + c.reference.Registry + " requires credentials")
+		}
+		return nil
+	}
+	if !strings.EqualFold(scheme, "Bearer") || params["realm"] == "" {
+		return fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+
+	query := url.Values{}
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = "repository:" + c.reference.Repository + ":pull"
+	}
+	query.Set("scope", scope)
+	req, err := http.NewRequest(http.MethodGet, params["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if c.credentials != nil {
+		req.SetBasicAuth(c.credentials.Username, c.credentials.Password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get a token from %s : %s", params["realm"], resp.Status)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return err
+	}
+	c.token = token.Token
+	if c.token == "" {
+		c.token = token.AccessToken
+	}
+	if c.token == "" {
+		return errors.New("no token returned by " + params["realm"])
+	}
+	return nil
+}
+
+// parseChallenge parses a WWW-Authenticate header like Bearer realm="...",service="...",scope="..."
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	if len(parts) < 2 {
+		return parts[0], params
+	}
+	rest := parts[1]
+	for rest != "" {
+		eq := strings.Index(rest, "=")
+		if eq < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(rest[:eq]))
+		rest = rest[eq+1:]
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else if comma := strings.Index(rest, ","); comma >= 0 {
+			value, rest = rest[:comma], rest[comma:]
+		} else {
+			value, rest = rest, ""
+		}
+		params[key] = value
+		rest = strings.TrimLeft(rest, ", ")
+	}
+	return parts[0], params
+}
+
+// extractTarGz extracts a gzipped tarball of at most maxSize bytes and maxOCIHubEntries entries to a directory,
+// the hub may be wrapped in a single top directory
+func extractTarGz(r io.Reader, extractPath string, maxSize int64) error {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for entries := 0; ; entries++ {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if entries >= maxOCIHubEntries {
+			return fmt.Errorf("the hub has more than %d files", maxOCIHubEntries)
+		}
+
+		path := filepath.Join(extractPath, header.Name)
+		if !strings.HasPrefix(path, filepath.Clean(extractPath)+string(os.PathSeparator)) {
+			return fmt.Errorf("illegal file path: %s", header.Name)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+			n, err := io.Copy(file, io.LimitReader(tarReader, maxSize+1))
+			file.Close()
+			if err != nil {
+				return err
+			}
+			if maxSize -= n; maxSize < 0 {
+				return errors.New("err: the extracted hub exceeded the size threshold")
+			}
+		}
+	}
+	return unwrapTopDirectory(extractPath)
+}
+
+// unwrapTopDirectory moves the content of the single top directory of the hub without faults directory up
+func unwrapTopDirectory(extractPath string) error {
+	entries, err := os.ReadDir(extractPath)
+	if err != nil {
+		return err
+	}
+	if len(entries) != 1 || !entries[0].IsDir() || entries[0].Name() == "faults" {
+		return nil
+	}
+	topPath := filepath.Join(extractPath, entries[0].Name())
+	tempPath := extractPath + ".top"
+	if err := os.Rename(topPath, tempPath); err != nil {
+		return err
+	}
+	if err := os.Remove(extractPath); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, extractPath)
+}
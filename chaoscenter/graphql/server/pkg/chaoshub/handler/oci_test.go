@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/utils"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseOCIReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	tests := []struct {
+		reference string
+		want      OCIReference
+		wantErr   bool
+	}{
+		{
+			reference: "oci://registry.example.com/hubs/charts:3.0",
+			want:      OCIReference{Registry: "registry.example.com", Repository: "hubs/charts", Tag: "3.0"},
+		},
+		{
+			reference: "localhost:5000/charts@" + digest,
+			want:      OCIReference{Registry: "localhost:5000", Repository: "charts", Digest: digest},
+		},
+		{
+			reference: "charts",
+			want:      OCIReference{Registry: dockerHubRegistry, Repository: "library/charts", Tag: "latest"},
+		},
+		{
+			reference: "docker.io/litmus/charts",
+			want:      OCIReference{Registry: dockerHubRegistry, Repository: "litmus/charts", Tag: "latest"},
+		},
+		{
+			reference: "registry.example.com/charts@md5:abc",
+			wantErr:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.reference, func(t *testing.T) {
+			got, err := ParseOCIReference(tc.reference)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRegistryCredentials(t *testing.T) {
+	dockerConfig := []byte(`{"auths":{"https://index.docker.io/v1/":{"auth":"dXNlcjpwYXNz"},"registry.example.com":{"username":"admin","password":"secret"}}}`)
+
+	credentials, err := RegistryCredentials(dockerConfig, dockerHubRegistry)
+	assert.NoError(t, err)
+	assert.Equal(t, &OCICredentials{Username: "user", Password: "pass"}, credentials)
+
+	credentials, err = RegistryCredentials(dockerConfig, "registry.example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, &OCICredentials{Username: "admin", Password: "secret"}, credentials)
+
+	credentials, err = RegistryCredentials(dockerConfig, "quay.io")
+	assert.NoError(t, err)
+	assert.Nil(t, credentials)
+}
+
+func TestProjectDockerConfig(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Labels: map[string]string{OCIProjectLabel: "project"}},
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`)},
+	}
+
+	dockerConfig, err := ProjectDockerConfig(secret, "project")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`{"auths":{}}`), dockerConfig)
+
+	// the secrets of the other projects aren't used
+	_, err = ProjectDockerConfig(secret, "other")
+	assert.Error(t, err)
+	secret.Labels = nil
+	_, err = ProjectDockerConfig(secret, "project")
+	assert.Error(t, err)
+}
+
+// tarGz returns a gzipped tarball of files of the given size
+func tarGz(t *testing.T, files int, size int) *bytes.Buffer {
+	var archive bytes.Buffer
+	gzipWriter := gzip.NewWriter(&archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for i := 0; i < files; i++ {
+		assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: "faults/" + uuid.New().String(), Mode: 0644, Size: int64(size), Typeflag: tar.TypeReg}))
+		_, err := tarWriter.Write(make([]byte, size))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tarWriter.Close())
+	assert.NoError(t, gzipWriter.Close())
+	return &archive
+}
+
+func TestExtractTarGzLimits(t *testing.T) {
+	extractPath := t.TempDir() + "/hub"
+
+	assert.NoError(t, extractTarGz(tarGz(t, 2, 100), extractPath, 200))
+
+	// the archives expanding past the size are rejected, along with the ones holding too many files
+	assert.Error(t, extractTarGz(tarGz(t, 1, 1<<20), extractPath, 1<<19))
+	assert.Error(t, extractTarGz(tarGz(t, 2, 100), extractPath, 150))
+	assert.Error(t, extractTarGz(tarGz(t, maxOCIHubEntries+1, 0), extractPath, 1<<20))
+}
+
+func TestDownloadOCIHub(t *testing.T) {
+	// given
+	var archive bytes.Buffer
+	gzipWriter := gzip.NewWriter(&archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	content := []byte("name: pod-delete")
+	assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: "hub/faults/kubernetes/pod-delete.yaml", Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+	_, err := tarWriter.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, tarWriter.Close())
+	assert.NoError(t, gzipWriter.Close())
+
+	layerSum := sha256.Sum256(archive.Bytes())
+	layerDigest := "sha256:" + hex.EncodeToString(layerSum[:])
+	manifest, err := json.Marshal(ociManifest{
+		MediaType: ociManifestMediaType,
+		Layers:    []ociDescriptor{{MediaType: "application/vnd.oci.image.layer.v1.tar+gzip", Digest: layerDigest, Size: int64(archive.Len())}},
+	})
+	assert.NoError(t, err)
+	manifestSum := sha256.Sum256(manifest)
+	manifestDigest := "sha256:" + hex.EncodeToString(manifestSum[:])
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			_, _ = w.Write([]byte(`{"token":"pull-token"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer pull-token" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="https://`+r.Host+`/token",service="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v2/hubs/charts/manifests/"):
+			_, _ = w.Write(manifest)
+		case r.URL.Path == "/v2/hubs/charts/blobs/"+layerDigest:
+			_, _ = w.Write(archive.Bytes())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	ociHTTPClient = server.Client()
+	utils.Config.RemoteHubMaxSize = "1000000000"
+
+	projectID := uuid.New().String()
+	hubDetails := model.CreateRemoteChaosHub{
+		Name:    "oci-hub",
+		RepoURL: strings.TrimPrefix(server.URL, "https://") + "/hubs/charts:3.0",
+	}
+	defer os.RemoveAll(DefaultPath + projectID)
+
+	// when
+	digest, err := DownloadOCIHub(hubDetails, projectID, nil)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, manifestDigest, digest)
+	data, err := os.ReadFile(DefaultPath + projectID + "/oci-hub/faults/kubernetes/pod-delete.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, content, data)
+
+	// a mismatching digest is rejected
+	hubDetails.RepoURL = strings.TrimPrefix(server.URL, "https://") + "/hubs/charts@sha256:" + strings.Repeat("0", 64)
+	_, err = DownloadOCIHub(hubDetails, projectID, nil)
+	assert.Error(t, err)
+}
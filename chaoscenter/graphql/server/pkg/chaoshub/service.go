@@ -17,10 +17,14 @@ import (
 	chaosHubOps "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/chaoshub/ops"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
 	dbSchemaChaosHub "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_hub"
+	dbSchemaImageRegistry "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/image_registry"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/k8s"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/metrics"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/utils"
 	log "github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 const (
@@ -51,13 +55,15 @@ type Service interface {
 }
 
 type chaosHubService struct {
-	chaosHubOperator *dbSchemaChaosHub.Operator
+	chaosHubOperator      *dbSchemaChaosHub.Operator
+	imageRegistryOperator *dbSchemaImageRegistry.Operator
 }
 
 // NewService returns a new instance of Service
-func NewService(chaosHubOperator *dbSchemaChaosHub.Operator) Service {
+func NewService(chaosHubOperator *dbSchemaChaosHub.Operator, imageRegistryOperator *dbSchemaImageRegistry.Operator) Service {
 	return &chaosHubService{
-		chaosHubOperator: chaosHubOperator,
+		chaosHubOperator:      chaosHubOperator,
+		imageRegistryOperator: imageRegistryOperator,
 	}
 }
 
@@ -137,6 +143,18 @@ func (c *chaosHubService) AddRemoteChaosHub(ctx context.Context, chaosHub model.
 	if IsExist == true {
 		return nil, errors.New("Name Already exists")
 	}
+	hubType := model.HubTypeRemote
+	if chaosHub.HubType != nil {
+		hubType = *chaosHub.HubType
+	}
+	if hubType != model.HubTypeRemote && hubType != model.HubTypeOci {
+		return nil, errors.New("unsupported remote hub type " + hubType.String())
+	}
+	if hubType == model.HubTypeOci {
+		if _, err := handler.ParseOCIReference(chaosHub.RepoURL); err != nil {
+			return nil, err
+		}
+	}
 	description := ""
 	if chaosHub.Description != nil {
 		description = *chaosHub.Description
@@ -162,7 +180,7 @@ func (c *chaosHubService) AddRemoteChaosHub(ctx context.Context, chaosHub model.
 			Tags:        chaosHub.Tags,
 		},
 		IsPrivate: false,
-		HubType:   string(model.HubTypeRemote),
+		HubType:   string(hubType),
 		AuthType:  string(model.AuthTypeNone),
 		Audit: mongodb.Audit{
 			CreatedAt: currentTime.UnixMilli(),
@@ -186,7 +204,11 @@ func (c *chaosHubService) AddRemoteChaosHub(ctx context.Context, chaosHub model.
 		return nil, err
 	}
 
-	err = handler.DownloadRemoteHub(chaosHub, projectID)
+	if hubType == model.HubTypeOci {
+		newHub.Digest, err = c.downloadOCIHub(ctx, newHub.ID, chaosHub, projectID)
+	} else {
+		err = handler.DownloadRemoteHub(chaosHub, projectID)
+	}
 	if err != nil {
 		err = fmt.Errorf("Hub configurations saved successfully. Failed to connect the remote repo: " + err.Error())
 		log.Error(err)
@@ -292,6 +314,11 @@ func (c *chaosHubService) SyncChaosHub(ctx context.Context, hubID string, projec
 		if err != nil {
 			return "", err
 		}
+	} else if chaosHub.HubType == string(model.HubTypeOci) {
+		_, err = c.downloadOCIHub(ctx, hubID, model.CreateRemoteChaosHub{Name: chaosHub.Name, RepoURL: chaosHub.RepoURL}, projectID)
+		if err != nil {
+			return "", err
+		}
 	} else {
 		err = chaosHubOps.GitSyncHandlerForProjects(syncHubInput, projectID)
 		if err != nil {
@@ -327,7 +354,18 @@ func (c *chaosHubService) UpdateChaosHub(ctx context.Context, chaosHub model.Upd
 		return nil, err
 	}
 	clonePath := DefaultPath + prevChaosHub.ProjectID + "/" + prevChaosHub.Name
-	if prevChaosHub.HubType == string(model.HubTypeRemote) {
+	if prevChaosHub.HubType == string(model.HubTypeOci) {
+		if prevChaosHub.Name != chaosHub.Name || prevChaosHub.RepoURL != chaosHub.RepoURL {
+			err = os.RemoveAll(clonePath)
+			if err != nil {
+				return nil, err
+			}
+			_, err = c.downloadOCIHub(ctx, chaosHub.ID, model.CreateRemoteChaosHub{Name: chaosHub.Name, RepoURL: chaosHub.RepoURL}, projectID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else if prevChaosHub.HubType == string(model.HubTypeRemote) {
 		if prevChaosHub.Name != chaosHub.Name || prevChaosHub.RepoURL != chaosHub.RepoURL {
 			remoteHub := model.CreateRemoteChaosHub{
 				Name:    chaosHub.Name,
@@ -651,6 +689,10 @@ func (c *chaosHubService) ListChaosHubs(ctx context.Context, projectID string, r
 			CreatedBy:        &model.UserDetails{Username: hub.CreatedBy.Username},
 			UpdatedBy:        &model.UserDetails{Username: hub.UpdatedBy.Username},
 		}
+		if hub.Digest != "" {
+			digest := hub.Digest
+			hubDetail.Digest = &digest
+		}
 		hubDetails = append(hubDetails, hubDetail)
 	}
 
@@ -712,6 +754,9 @@ func (c *chaosHubService) GetChaosHub(ctx context.Context, chaosHubID string, pr
 		UpdatedBy:        &model.UserDetails{Username: hub.UpdatedBy.Username},
 	}
 
+	if hub.Digest != "" {
+		hubDetail.Digest = &hub.Digest
+	}
 	return hubDetail, nil
 }
 
@@ -945,16 +990,20 @@ func (c *chaosHubService) RecurringHubSync() {
 					SSHPrivateKey: chaosHub.SSHPrivateKey,
 					IsDefault:     false,
 				}
-				if chaosHub.HubType != model.HubTypeRemote {
-					err := chaosHubOps.GitSyncHandlerForProjects(chartsInput, chaosHub.ProjectID)
-					if err != nil {
-						log.Error(err)
-					}
-				} else {
-					err := handler.SyncRemoteRepo(chartsInput, chaosHub.ProjectID)
-					if err != nil {
-						log.Error(err)
-					}
+				var err error
+				start := time.Now()
+				switch chaosHub.HubType {
+				case model.HubTypeRemote:
+					err = handler.SyncRemoteRepo(chartsInput, chaosHub.ProjectID)
+				case model.HubTypeOci:
+					_, err = c.downloadOCIHub(context.Background(), chaosHub.ID, model.CreateRemoteChaosHub{Name: chaosHub.Name, RepoURL: chaosHub.RepoURL}, chaosHub.ProjectID)
+				default:
+					err = chaosHubOps.GitSyncHandlerForProjects(chartsInput, chaosHub.ProjectID)
+				}
+				metrics.ChaosHubSyncDuration.WithLabelValues(chaosHub.HubType.String()).Observe(time.Since(start).Seconds())
+				if err != nil {
+					metrics.ChaosHubSyncFailures.WithLabelValues(chaosHub.HubType.String()).Inc()
+					log.Error(err)
 				}
 			}
 		}
@@ -1039,3 +1088,56 @@ func (c *chaosHubService) SyncDefaultChaosHubs() {
 		time.Sleep(DefaultHubSyncTimeInterval)
 	}
 }
+
+// downloadOCIHub pulls the OCI artifact of a hub with the credentials of the image registry of the project and
+// records the digest it resolved to, so that the tags pulled by the hub can be traced back to their artifacts
+func (c *chaosHubService) downloadOCIHub(ctx context.Context, hubID string, chaosHub model.CreateRemoteChaosHub, projectID string) (string, error) {
+	ref, err := handler.ParseOCIReference(chaosHub.RepoURL)
+	if err != nil {
+		return "", err
+	}
+	credentials, err := c.registryCredentials(ctx, projectID, ref.Registry)
+	if err != nil {
+		return "", err
+	}
+	digest, err := handler.DownloadOCIHub(chaosHub, projectID, credentials)
+	if err != nil {
+		return "", err
+	}
+
+	query := bson.D{{"hub_id", hubID}, {"project_id", projectID}}
+	update := bson.D{{"$set", bson.D{{"digest", digest}}}}
+	if err := c.chaosHubOperator.UpdateChaosHub(ctx, query, update); err != nil {
+		return "", err
+	}
+	return digest, nil
+}
+
+// registryCredentials returns the credentials of the registry in the pull secret of the image registry of the
+// project, the artifacts are pulled anonymously if the project has no pull secret or no credentials for it. The
+// pull secret is read from the namespace of the portal, as its namespace in the image registry is the one of
+// the infrastructures, and it has to be labelled with the project
+func (c *chaosHubService) registryCredentials(ctx context.Context, projectID, registry string) (*handler.OCICredentials, error) {
+	imageRegistry, err := c.imageRegistryOperator.GetImageRegistry(ctx, bson.D{{"project_id", projectID}})
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if imageRegistry.SecretName == nil || *imageRegistry.SecretName == "" {
+		return nil, nil
+	}
+
+	secret, err := k8s.GetSecret(*imageRegistry.SecretName)
+	if k8serrors.IsNotFound(err) {
+		log.WithField("secretName", *imageRegistry.SecretName).Warn("pull secret of the image registry not found, pulling the hub anonymously")
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.New("failed to get the pull secret of the image registry : " + err.Error())
+	}
+	dockerConfig, err := handler.ProjectDockerConfig(secret, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return handler.RegistryCredentials(dockerConfig, registry)
+}
@@ -0,0 +1,216 @@
+package data_store
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+)
+
+const (
+	// listInfraActionsLimit is the number of latest actions returned by List
+	listInfraActionsLimit = 100
+	// maxInfraActionAttempts is the number of times an action is delivered to an infra which doesn't acknowledge it
+	maxInfraActionAttempts = 5
+	// infraActionTTL is the time after which a pending action isn't delivered anymore
+	infraActionTTL = 24 * time.Hour
+)
+
+var (
+	infraActionExpiredError  = "the action expired before the infra acknowledged it"
+	infraActionNotAckedError = fmt.Sprintf("the infra didn't acknowledge the action after %d deliveries", maxInfraActionAttempts)
+)
+
+// ErrInfraActionNotFound is returned when an infra acknowledges an action which wasn't sent to it
+var ErrInfraActionNotFound = errors.New("no such action found for the infra")
+
+// InfraActionQueue keeps the actions sent to the infras until the infras acknowledge them, so that the actions
+// requested while an infra is disconnected are delivered when it connects
+type InfraActionQueue interface {
+	// Enqueue records a pending action for the infra
+	Enqueue(infraID string, action *model.InfraActionResponse) error
+	// Next marks the oldest pending action of the infra of the request types, or of any type if they're empty, as
+	// delivered and returns it, or nil if there's none. The pending actions older than infraActionTTL are marked
+	// as failed instead
+	Next(infraID string, requestTypes []string) (*model.InfraActionResponse, error)
+	// Requeue marks the actions delivered to the infra which weren't acknowledged as pending, the ones delivered
+	// maxInfraActionAttempts times are marked as failed instead
+	Requeue(infraID string) error
+	// Acknowledge records the state of an action reported by the infra
+	Acknowledge(infraID, requestID string, state model.InfraActionState, actionError *string) error
+	// Cancel marks an action which the infra hasn't acknowledged yet as failed, so that it isn't delivered again
+	Cancel(infraID, requestID string, reason string) error
+	// List returns the latest actions of the project matching the request
+	List(projectID string, request *model.ListInfraActionsRequest) ([]*model.InfraActionStatus, error)
+}
+
+// validateAck returns an error if the state can't be reported by an infra
+func validateAck(state model.InfraActionState) error {
+	if state != model.InfraActionStateApplied && state != model.InfraActionStateFailed {
+		return errors.New("an infra can only acknowledge an action as " + string(model.InfraActionStateApplied) +
+			" or " + string(model.InfraActionStateFailed))
+	}
+	return nil
+}
+
+// memoryInfraActionQueue keeps the actions in memory, they are lost when the server restarts
+type memoryInfraActionQueue struct {
+	mutex   sync.Mutex
+	actions []*memoryInfraAction
+}
+
+type memoryInfraAction struct {
+	projectID string
+	action    *model.InfraActionResponse
+	status    model.InfraActionStatus
+	createdAt time.Time
+	attempts  int
+}
+
+// NewMemoryInfraActionQueue returns an InfraActionQueue keeping the actions in memory
+func NewMemoryInfraActionQueue() InfraActionQueue {
+	return &memoryInfraActionQueue{}
+}
+
+func (m *memoryInfraActionQueue) Enqueue(infraID string, action *model.InfraActionResponse) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	createdAt := time.Now()
+	now := strconv.FormatInt(createdAt.UnixMilli(), 10)
+	m.actions = append(m.actions, &memoryInfraAction{
+		projectID: action.ProjectID,
+		action:    action,
+		createdAt: createdAt,
+		status: model.InfraActionStatus{
+			RequestID:    action.Action.RequestID,
+			InfraID:      infraID,
+			RequestType:  action.Action.RequestType,
+			ExternalData: action.Action.ExternalData,
+			State:        model.InfraActionStatePending,
+			Username:     action.Action.Username,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		},
+	})
+	return nil
+}
+
+func (m *memoryInfraActionQueue) Next(infraID string, requestTypes []string) (*model.InfraActionResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	expiry := time.Now().Add(-infraActionTTL)
+	for _, action := range m.actions {
+		if action.status.InfraID != infraID || action.status.State != model.InfraActionStatePending {
+			continue
+		}
+		if action.createdAt.Before(expiry) {
+			m.setState(action, model.InfraActionStateFailed, &infraActionExpiredError)
+			continue
+		}
+		if len(requestTypes) > 0 && !contains(requestTypes, action.status.RequestType) {
+			continue
+		}
+		action.attempts++
+		m.setState(action, model.InfraActionStateDelivered, nil)
+		return action.action, nil
+	}
+	return nil, nil
+}
+
+func (m *memoryInfraActionQueue) Requeue(infraID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, action := range m.actions {
+		if action.status.InfraID != infraID || action.status.State != model.InfraActionStateDelivered {
+			continue
+		}
+		if action.attempts >= maxInfraActionAttempts {
+			m.setState(action, model.InfraActionStateFailed, &infraActionNotAckedError)
+		} else {
+			m.setState(action, model.InfraActionStatePending, nil)
+		}
+	}
+	return nil
+}
+
+func (m *memoryInfraActionQueue) Acknowledge(infraID, requestID string, state model.InfraActionState, actionError *string) error {
+	if err := validateAck(state); err != nil {
+		return err
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, action := range m.actions {
+		if action.status.InfraID == infraID && action.status.RequestID == requestID {
+			m.setState(action, state, actionError)
+			return nil
+		}
+	}
+	return ErrInfraActionNotFound
+}
+
+func (m *memoryInfraActionQueue) Cancel(infraID, requestID string, reason string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, action := range m.actions {
+		if action.status.InfraID == infraID && action.status.RequestID == requestID &&
+			(action.status.State == model.InfraActionStatePending || action.status.State == model.InfraActionStateDelivered) {
+			m.setState(action, model.InfraActionStateFailed, &reason)
+		}
+	}
+	return nil
+}
+
+func (m *memoryInfraActionQueue) List(projectID string, request *model.ListInfraActionsRequest) ([]*model.InfraActionStatus, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	statuses := []*model.InfraActionStatus{}
+	for i := len(m.actions) - 1; i >= 0 && len(statuses) < listInfraActionsLimit; i-- {
+		action := m.actions[i]
+		if action.projectID != projectID || !matchInfraAction(action.status, request) {
+			continue
+		}
+		status := action.status
+		statuses = append(statuses, &status)
+	}
+	return statuses, nil
+}
+
+func (m *memoryInfraActionQueue) setState(action *memoryInfraAction, state model.InfraActionState, actionError *string) {
+	action.status.State = state
+	action.status.Error = actionError
+	action.status.UpdatedAt = strconv.FormatInt(time.Now().UnixMilli(), 10)
+}
+
+// matchInfraAction returns true if the action matches the filters of the request
+func matchInfraAction(status model.InfraActionStatus, request *model.ListInfraActionsRequest) bool {
+	if request == nil {
+		return true
+	}
+	if request.InfraID != nil && status.InfraID != *request.InfraID {
+		return false
+	}
+	if len(request.RequestIDs) > 0 && !contains(request.RequestIDs, status.RequestID) {
+		return false
+	}
+	if len(request.States) > 0 {
+		for _, state := range request.States {
+			if state == status.State {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
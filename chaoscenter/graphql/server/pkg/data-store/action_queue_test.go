@@ -0,0 +1,154 @@
+package data_store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestInfraAction(requestID string) *model.InfraActionResponse {
+	return &model.InfraActionResponse{
+		ProjectID: "project",
+		Action:    &model.ActionPayload{RequestID: requestID, RequestType: "create"},
+	}
+}
+
+func receiveInfraAction(t *testing.T, infraAction <-chan *model.InfraActionResponse) *model.InfraActionResponse {
+	select {
+	case action := <-infraAction:
+		return action
+	case <-time.After(time.Second):
+		t.Fatal("no action was sent to the infra")
+		return nil
+	}
+}
+
+func TestMemoryInfraActionQueue(t *testing.T) {
+	queue := NewMemoryInfraActionQueue()
+	assert.NoError(t, queue.Enqueue("infra", newTestInfraAction("first")))
+	assert.NoError(t, queue.Enqueue("infra", newTestInfraAction("second")))
+
+	action, err := queue.Next("infra", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", action.Action.RequestID)
+	action, err = queue.Next("other-infra", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, action)
+
+	// the delivered action is sent again before the pending ones
+	assert.NoError(t, queue.Requeue("infra"))
+	action, err = queue.Next("infra", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", action.Action.RequestID)
+
+	assert.Error(t, queue.Acknowledge("infra", "first", model.InfraActionStatePending, nil))
+	assert.ErrorIs(t, queue.Acknowledge("other-infra", "first", model.InfraActionStateApplied, nil), ErrInfraActionNotFound)
+	actionError := "invalid manifest"
+	assert.NoError(t, queue.Acknowledge("infra", "first", model.InfraActionStateFailed, &actionError))
+
+	statuses, err := queue.List("project", nil)
+	assert.NoError(t, err)
+	if assert.Len(t, statuses, 2) {
+		assert.Equal(t, "second", statuses[0].RequestID)
+		assert.Equal(t, model.InfraActionStatePending, statuses[0].State)
+		assert.Equal(t, model.InfraActionStateFailed, statuses[1].State)
+		assert.Equal(t, &actionError, statuses[1].Error)
+	}
+
+	statuses, err = queue.List("project", &model.ListInfraActionsRequest{States: []model.InfraActionState{model.InfraActionStateFailed}})
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+	statuses, err = queue.List("other-project", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, statuses)
+}
+
+func TestMemoryInfraActionQueueExpiry(t *testing.T) {
+	queue := NewMemoryInfraActionQueue()
+	assert.NoError(t, queue.Enqueue("infra", newTestInfraAction("unacknowledged")))
+	assert.NoError(t, queue.Enqueue("infra", newTestInfraAction("expired")))
+	queue.(*memoryInfraActionQueue).actions[1].createdAt = time.Now().Add(-infraActionTTL - time.Minute)
+
+	// the action isn't delivered again once it's been delivered maxInfraActionAttempts times
+	for i := 0; i < maxInfraActionAttempts; i++ {
+		action, err := queue.Next("infra", nil)
+		assert.NoError(t, err)
+		if assert.NotNil(t, action) {
+			assert.Equal(t, "unacknowledged", action.Action.RequestID)
+		}
+		assert.NoError(t, queue.Requeue("infra"))
+	}
+
+	// the expired action isn't delivered
+	action, err := queue.Next("infra", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, action)
+
+	statuses, err := queue.List("project", nil)
+	assert.NoError(t, err)
+	if assert.Len(t, statuses, 2) {
+		assert.Equal(t, model.InfraActionStateFailed, statuses[0].State)
+		assert.Equal(t, &infraActionExpiredError, statuses[0].Error)
+		assert.Equal(t, model.InfraActionStateFailed, statuses[1].State)
+		assert.Equal(t, &infraActionNotAckedError, statuses[1].Error)
+	}
+}
+
+func TestQueueInfraAction(t *testing.T) {
+	store := NewStore()
+
+	// the action is kept while the infra is disconnected
+	assert.NoError(t, store.QueueInfraAction("infra", newTestInfraAction("first")))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	infraAction := make(chan *model.InfraActionResponse)
+	assert.NoError(t, store.ConnectInfra(ctx, "infra", infraAction, false))
+	assert.Equal(t, "first", receiveInfraAction(t, infraAction).Action.RequestID)
+
+	assert.NoError(t, store.QueueInfraAction("infra", newTestInfraAction("second")))
+	assert.Equal(t, "second", receiveInfraAction(t, infraAction).Action.RequestID)
+	assert.NoError(t, store.Actions.Acknowledge("infra", "second", model.InfraActionStateApplied, nil))
+
+	cancel()
+	store.DisconnectInfra("infra", infraAction)
+
+	// the action which wasn't acknowledged is sent again on the next connection
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	infraAction = make(chan *model.InfraActionResponse)
+	assert.NoError(t, store.ConnectInfra(ctx, "infra", infraAction, false))
+	assert.Equal(t, "first", receiveInfraAction(t, infraAction).Action.RequestID)
+	select {
+	case action := <-infraAction:
+		t.Fatalf("unexpected action %s", action.Action.RequestID)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestQueueInfraActionToLegacyInfra(t *testing.T) {
+	store := NewStore()
+	assert.NoError(t, store.QueueInfraAction("infra", newTestInfraAction("create")))
+	upgrade := newTestInfraAction("upgrade")
+	upgrade.Action.RequestType = "upgrade"
+	assert.NoError(t, store.QueueInfraAction("infra", upgrade))
+
+	// the legacy infra can't run the actions nor upgrade itself, it gets none of them
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	infraAction := make(chan *model.InfraActionResponse)
+	assert.NoError(t, store.ConnectInfra(ctx, "infra", infraAction, true))
+	select {
+	case action := <-infraAction:
+		t.Fatalf("unexpected action %s", action.Action.RequestID)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	statuses, err := store.Actions.List("project", &model.ListInfraActionsRequest{RequestIDs: []string{"create"}})
+	assert.NoError(t, err)
+	if assert.Len(t, statuses, 1) {
+		assert.Equal(t, model.InfraActionStatePending, statuses[0].State)
+	}
+}
@@ -0,0 +1,102 @@
+package data_store
+
+import (
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+)
+
+// StateBus sends the infra actions, the events and the responses to the subscriptions, which may be connected
+// to any replica of the server
+type StateBus interface {
+	// SendInfraAction sends an action to the subscription of the infra, without queuing it
+	SendInfraAction(infraID string, action *model.InfraActionResponse) error
+	// NotifyInfraActions tells the subscription of the infra that actions were queued for it
+	NotifyInfraActions(infraID string) error
+	// PublishInfraEvent sends an infra event to the subscriptions of the project
+	PublishInfraEvent(projectID string, event *model.InfraEventResponse) error
+	// SendPodLog sends the response of a pod log request
+	SendPodLog(requestID string, podLog *model.PodLogResponse) error
+	// SendKubeObject sends the response of a kube object request
+	SendKubeObject(requestID string, kubeObject *model.KubeObjectResponse) error
+	// OpenPodLog records a pod log request made on this replica
+	OpenPodLog(requestID string) error
+	// ClosePodLog removes the record of a pod log request made on this replica
+	ClosePodLog(requestID string)
+	// IsPodLogOpen returns true if the pod log request is in progress on any replica
+	IsPodLogOpen(requestID string) bool
+	// ConnectInfra records an infra connected to this replica, it fails if the infra is connected to another one
+	ConnectInfra(infraID string) error
+	// DisconnectInfra removes the record of an infra connected to this replica
+	DisconnectInfra(infraID string)
+	// DisconnectStaleInfra drops the subscription of an infra which missed its heartbeats, on any replica
+	DisconnectStaleInfra(infraID string) error
+	// IsInfraConnected returns true if the infra is connected to any replica
+	IsInfraConnected(infraID string) bool
+}
+
+// memoryStateBus delivers the messages to the subscriptions of a single replica
+type memoryStateBus struct {
+	store *StateData
+}
+
+// NewMemoryStateBus returns the StateBus of a server running as a single replica
+func NewMemoryStateBus(store *StateData) StateBus {
+	return &memoryStateBus{store: store}
+}
+
+func (m *memoryStateBus) SendInfraAction(infraID string, action *model.InfraActionResponse) error {
+	m.store.deliverInfraAction(infraID, action)
+	return nil
+}
+
+func (m *memoryStateBus) NotifyInfraActions(infraID string) error {
+	m.store.deliverInfraActionsQueued(infraID)
+	return nil
+}
+
+func (m *memoryStateBus) PublishInfraEvent(projectID string, event *model.InfraEventResponse) error {
+	m.store.deliverInfraEvent(projectID, event)
+	return nil
+}
+
+func (m *memoryStateBus) SendPodLog(requestID string, podLog *model.PodLogResponse) error {
+	m.store.deliverPodLog(requestID, podLog)
+	return nil
+}
+
+func (m *memoryStateBus) SendKubeObject(requestID string, kubeObject *model.KubeObjectResponse) error {
+	m.store.deliverKubeObject(requestID, kubeObject)
+	return nil
+}
+
+// OpenPodLog has nothing to record, the pod log requests are only made on this replica
+func (m *memoryStateBus) OpenPodLog(requestID string) error {
+	return nil
+}
+
+func (m *memoryStateBus) ClosePodLog(requestID string) {}
+
+func (m *memoryStateBus) IsPodLogOpen(requestID string) bool {
+	m.store.Mutex.Lock()
+	defer m.store.Mutex.Unlock()
+	_, ok := m.store.ExperimentLog[requestID]
+	return ok
+}
+
+// ConnectInfra has nothing to record, the infras are only connected to this replica
+func (m *memoryStateBus) ConnectInfra(infraID string) error {
+	return nil
+}
+
+func (m *memoryStateBus) DisconnectInfra(infraID string) {}
+
+func (m *memoryStateBus) DisconnectStaleInfra(infraID string) error {
+	m.store.deliverStaleInfra(infraID)
+	return nil
+}
+
+func (m *memoryStateBus) IsInfraConnected(infraID string) bool {
+	m.store.Mutex.Lock()
+	defer m.store.Mutex.Unlock()
+	_, ok := m.store.ConnectedInfra[infraID]
+	return ok
+}
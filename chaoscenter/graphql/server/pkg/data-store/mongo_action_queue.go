@@ -0,0 +1,137 @@
+package data_store
+
+import (
+	"context"
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	dbInfraAction "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/infra_action"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// mongoInfraActionQueue keeps the actions in the database, shared by all the replicas
+type mongoInfraActionQueue struct {
+	operator *dbInfraAction.Operator
+}
+
+// NewMongoInfraActionQueue returns an InfraActionQueue keeping the actions in the database
+func NewMongoInfraActionQueue(operator *dbInfraAction.Operator) InfraActionQueue {
+	return &mongoInfraActionQueue{operator: operator}
+}
+
+func (m *mongoInfraActionQueue) Enqueue(infraID string, action *model.InfraActionResponse) error {
+	ctx, cancel := context.WithTimeout(context.Background(), busTimeout)
+	defer cancel()
+	now := time.Now().UnixMilli()
+	return m.operator.InsertInfraAction(ctx, dbInfraAction.InfraAction{
+		RequestID:    action.Action.RequestID,
+		ProjectID:    action.ProjectID,
+		InfraID:      infraID,
+		RequestType:  action.Action.RequestType,
+		K8sManifest:  action.Action.K8sManifest,
+		Namespace:    action.Action.Namespace,
+		ExternalData: action.Action.ExternalData,
+		Username:     action.Action.Username,
+		State:        model.InfraActionStatePending,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+}
+
+func (m *mongoInfraActionQueue) Next(infraID string, requestTypes []string) (*model.InfraActionResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), busTimeout)
+	defer cancel()
+	now := time.Now()
+	query := bson.D{{"infra_id", infraID}, {"state", model.InfraActionStatePending}, {"created_at", bson.D{{"$lt", now.Add(-infraActionTTL).UnixMilli()}}}}
+	update := bson.D{{"$set", bson.D{{"state", model.InfraActionStateFailed}, {"error", infraActionExpiredError}, {"updated_at", now.UnixMilli()}}}}
+	if err := m.operator.UpdateInfraActions(ctx, query, update); err != nil {
+		return nil, err
+	}
+
+	query = bson.D{{"infra_id", infraID}, {"state", model.InfraActionStatePending}}
+	if len(requestTypes) > 0 {
+		query = append(query, bson.E{Key: "request_type", Value: bson.D{{"$in", requestTypes}}})
+	}
+	update = bson.D{
+		{"$set", bson.D{{"state", model.InfraActionStateDelivered}, {"updated_at", now.UnixMilli()}}},
+		{"$inc", bson.D{{"attempts", 1}}},
+	}
+	action, err := m.operator.FindOneAndUpdateInfraAction(ctx, query, update)
+	if err != nil || action == nil {
+		return nil, err
+	}
+	return action.GetOutputInfraAction(), nil
+}
+
+func (m *mongoInfraActionQueue) Requeue(infraID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), busTimeout)
+	defer cancel()
+	now := time.Now().UnixMilli()
+	query := bson.D{{"infra_id", infraID}, {"state", model.InfraActionStateDelivered}, {"attempts", bson.D{{"$gte", maxInfraActionAttempts}}}}
+	update := bson.D{{"$set", bson.D{{"state", model.InfraActionStateFailed}, {"error", infraActionNotAckedError}, {"updated_at", now}}}}
+	if err := m.operator.UpdateInfraActions(ctx, query, update); err != nil {
+		return err
+	}
+
+	query = bson.D{{"infra_id", infraID}, {"state", model.InfraActionStateDelivered}}
+	update = bson.D{{"$set", bson.D{{"state", model.InfraActionStatePending}, {"updated_at", now}}}}
+	return m.operator.UpdateInfraActions(ctx, query, update)
+}
+
+func (m *mongoInfraActionQueue) Acknowledge(infraID, requestID string, state model.InfraActionState, actionError *string) error {
+	if err := validateAck(state); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), busTimeout)
+	defer cancel()
+	query := bson.D{{"infra_id", infraID}, {"request_id", requestID}}
+	update := bson.D{{"$set", bson.D{{"state", state}, {"error", actionError}, {"updated_at", time.Now().UnixMilli()}}}}
+	matched, err := m.operator.UpdateInfraAction(ctx, query, update)
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return ErrInfraActionNotFound
+	}
+	return nil
+}
+
+func (m *mongoInfraActionQueue) Cancel(infraID, requestID string, reason string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), busTimeout)
+	defer cancel()
+	query := bson.D{
+		{"infra_id", infraID},
+		{"request_id", requestID},
+		{"state", bson.D{{"$in", bson.A{model.InfraActionStatePending, model.InfraActionStateDelivered}}}},
+	}
+	update := bson.D{{"$set", bson.D{{"state", model.InfraActionStateFailed}, {"error", reason}, {"updated_at", time.Now().UnixMilli()}}}}
+	_, err := m.operator.UpdateInfraAction(ctx, query, update)
+	return err
+}
+
+func (m *mongoInfraActionQueue) List(projectID string, request *model.ListInfraActionsRequest) ([]*model.InfraActionStatus, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), busTimeout)
+	defer cancel()
+	query := bson.D{{"project_id", projectID}}
+	if request != nil {
+		if request.InfraID != nil {
+			query = append(query, bson.E{Key: "infra_id", Value: *request.InfraID})
+		}
+		if len(request.RequestIDs) > 0 {
+			query = append(query, bson.E{Key: "request_id", Value: bson.D{{"$in", request.RequestIDs}}})
+		}
+		if len(request.States) > 0 {
+			query = append(query, bson.E{Key: "state", Value: bson.D{{"$in", request.States}}})
+		}
+	}
+
+	actions, err := m.operator.ListInfraActions(ctx, query, listInfraActionsLimit)
+	if err != nil {
+		return nil, err
+	}
+	statuses := []*model.InfraActionStatus{}
+	for _, action := range actions {
+		statuses = append(statuses, action.GetOutputInfraActionStatus())
+	}
+	return statuses, nil
+}
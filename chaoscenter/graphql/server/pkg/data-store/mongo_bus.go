@@ -0,0 +1,263 @@
+package data_store
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	dbStateBus "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/state_bus"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	busTimeout          = 10 * time.Second
+	busRetryInterval    = 5 * time.Second
+	connectedInfraTTL   = 90 * time.Second
+	connectedInfraRenew = 30 * time.Second
+	// podLogRequestTTL is longer than the infras stream the logs of a request for
+	podLogRequestTTL = 2 * time.Hour
+	// busDeliveryBuffer is the number of messages kept for a subscriber which is slow to receive them
+	busDeliveryBuffer = 100
+)
+
+// mongoStateBus publishes the messages to a collection watched by all the replicas with a change stream, each
+// replica delivers them to the subscriptions connected to it
+type mongoStateBus struct {
+	operator  *dbStateBus.Operator
+	store     *StateData
+	replicaID string
+
+	// deliveries are the messages waiting for their subscribers, by kind and key
+	deliveryMu sync.Mutex
+	deliveries map[string]chan dbStateBus.Message
+}
+
+// NewMongoStateBus returns the StateBus of a server running as several replicas, it delivers the messages
+// published by all the replicas to the store until the context is done
+func NewMongoStateBus(ctx context.Context, operator *dbStateBus.Operator, store *StateData) StateBus {
+	bus := &mongoStateBus{
+		operator:   operator,
+		store:      store,
+		replicaID:  uuid.New().String(),
+		deliveries: make(map[string]chan dbStateBus.Message),
+	}
+	go bus.watch(ctx)
+	go bus.renewConnectedInfras(ctx)
+	return bus
+}
+
+func (m *mongoStateBus) SendInfraAction(infraID string, action *model.InfraActionResponse) error {
+	return m.publish(dbStateBus.Message{Kind: dbStateBus.InfraActionMessage, Key: infraID, InfraAction: action})
+}
+
+func (m *mongoStateBus) NotifyInfraActions(infraID string) error {
+	return m.publish(dbStateBus.Message{Kind: dbStateBus.InfraActionsQueuedMessage, Key: infraID})
+}
+
+func (m *mongoStateBus) PublishInfraEvent(projectID string, event *model.InfraEventResponse) error {
+	return m.publish(dbStateBus.Message{Kind: dbStateBus.InfraEventMessage, Key: projectID, InfraEvent: event})
+}
+
+func (m *mongoStateBus) SendPodLog(requestID string, podLog *model.PodLogResponse) error {
+	return m.publish(dbStateBus.Message{Kind: dbStateBus.PodLogMessage, Key: requestID, PodLog: podLog})
+}
+
+func (m *mongoStateBus) SendKubeObject(requestID string, kubeObject *model.KubeObjectResponse) error {
+	return m.publish(dbStateBus.Message{Kind: dbStateBus.KubeObjectMessage, Key: requestID, KubeObject: kubeObject})
+}
+
+func (m *mongoStateBus) OpenPodLog(requestID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), busTimeout)
+	defer cancel()
+	return m.operator.OpenPodLog(ctx, requestID, time.Now().Add(podLogRequestTTL))
+}
+
+func (m *mongoStateBus) ClosePodLog(requestID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), busTimeout)
+	defer cancel()
+	if err := m.operator.ClosePodLog(ctx, requestID); err != nil {
+		logrus.WithError(err).WithField("request_id", requestID).Error("failed to close the pod log request")
+	}
+}
+
+// IsPodLogOpen returns true if the record of the request can't be read, so that the logs aren't stopped
+// because of a transient error
+func (m *mongoStateBus) IsPodLogOpen(requestID string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), busTimeout)
+	defer cancel()
+	open, err := m.operator.IsPodLogOpen(ctx, requestID)
+	if err != nil {
+		logrus.WithError(err).WithField("request_id", requestID).Error("failed to get the pod log request")
+		return true
+	}
+	return open
+}
+
+func (m *mongoStateBus) ConnectInfra(infraID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), busTimeout)
+	defer cancel()
+	err := m.operator.ClaimInfra(ctx, infraID, m.replicaID, time.Now().Add(connectedInfraTTL))
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrInfraAlreadyConnected
+	}
+	return err
+}
+
+func (m *mongoStateBus) DisconnectInfra(infraID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), busTimeout)
+	defer cancel()
+	if err := m.operator.ReleaseInfra(ctx, infraID, m.replicaID); err != nil {
+		logrus.WithError(err).WithField("infra_id", infraID).Error("failed to release the connected infra")
+	}
+}
+
+func (m *mongoStateBus) DisconnectStaleInfra(infraID string) error {
+	return m.publish(dbStateBus.Message{Kind: dbStateBus.StaleInfraMessage, Key: infraID})
+}
+
+func (m *mongoStateBus) IsInfraConnected(infraID string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), busTimeout)
+	defer cancel()
+	connected, err := m.operator.IsInfraConnected(ctx, infraID)
+	if err != nil {
+		logrus.WithError(err).WithField("infra_id", infraID).Error("failed to get the connected infra")
+		return false
+	}
+	return connected
+}
+
+func (m *mongoStateBus) publish(message dbStateBus.Message) error {
+	ctx, cancel := context.WithTimeout(context.Background(), busTimeout)
+	defer cancel()
+	message.ReplicaID = m.replicaID
+	message.CreatedAt = time.Now()
+	return m.operator.InsertMessage(ctx, message)
+}
+
+// watch delivers the published messages, the stream is resumed after the last message when it fails. The
+// messages are dispatched to their subscribers without waiting for them, so a slow subscriber doesn't hold
+// back the messages of the others
+func (m *mongoStateBus) watch(ctx context.Context) {
+	var resumeToken bson.Raw
+	for {
+		stream, err := m.operator.WatchMessages(ctx, resumeToken)
+		if err == nil {
+			for stream.Next(ctx) {
+				var event struct {
+					Message dbStateBus.Message `bson:"fullDocument"`
+				}
+				if err := stream.Decode(&event); err != nil {
+					logrus.WithError(err).Error("failed to decode the state bus message")
+				} else {
+					m.dispatch(event.Message)
+				}
+				resumeToken = stream.ResumeToken()
+			}
+			if err := stream.Err(); err != nil && ctx.Err() == nil {
+				logrus.WithError(err).Error("state bus change stream closed")
+			}
+			stream.Close(context.Background())
+		} else {
+			logrus.WithError(err).Error("failed to watch the state bus")
+			// the token may have expired from the oplog
+			resumeToken = nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(busRetryInterval):
+		}
+	}
+}
+
+// dispatch queues a message for its subscriber without blocking, the messages of a subscriber are delivered
+// in order by a goroutine which stops once they are all delivered. The message is dropped if the subscriber
+// is too far behind
+func (m *mongoStateBus) dispatch(message dbStateBus.Message) {
+	key := string(message.Kind) + "/" + message.Key
+
+	m.deliveryMu.Lock()
+	defer m.deliveryMu.Unlock()
+	queue, ok := m.deliveries[key]
+	if !ok {
+		queue = make(chan dbStateBus.Message, busDeliveryBuffer)
+		m.deliveries[key] = queue
+		go m.deliverQueued(key, queue)
+	}
+	select {
+	case queue <- message:
+	default:
+		logrus.WithField("key", message.Key).Warnf("dropped the %s state bus message, the subscriber is too far behind", message.Kind)
+	}
+}
+
+// deliverQueued delivers the messages queued for a subscriber until there are none left
+func (m *mongoStateBus) deliverQueued(key string, queue chan dbStateBus.Message) {
+	for {
+		m.deliveryMu.Lock()
+		select {
+		case message := <-queue:
+			m.deliveryMu.Unlock()
+			m.deliver(message)
+		default:
+			delete(m.deliveries, key)
+			m.deliveryMu.Unlock()
+			return
+		}
+	}
+}
+
+func (m *mongoStateBus) deliver(message dbStateBus.Message) {
+	switch message.Kind {
+	case dbStateBus.InfraActionMessage:
+		m.store.deliverInfraAction(message.Key, message.InfraAction)
+	case dbStateBus.InfraActionsQueuedMessage:
+		m.store.deliverInfraActionsQueued(message.Key)
+	case dbStateBus.InfraEventMessage:
+		m.store.deliverInfraEvent(message.Key, message.InfraEvent)
+	case dbStateBus.PodLogMessage:
+		m.store.deliverPodLog(message.Key, message.PodLog)
+	case dbStateBus.KubeObjectMessage:
+		m.store.deliverKubeObject(message.Key, message.KubeObject)
+	case dbStateBus.StaleInfraMessage:
+		m.store.deliverStaleInfra(message.Key)
+	default:
+		logrus.Warnf("unknown state bus message %s", message.Kind)
+	}
+}
+
+// renewConnectedInfras keeps the infras connected to this replica recorded, the records of a replica which
+// stopped expire so that the infras can connect to another one
+func (m *mongoStateBus) renewConnectedInfras(ctx context.Context) {
+	ticker := time.NewTicker(connectedInfraRenew)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		m.store.Mutex.Lock()
+		infraIDs := make([]string, 0, len(m.store.ConnectedInfra))
+		for infraID := range m.store.ConnectedInfra {
+			infraIDs = append(infraIDs, infraID)
+		}
+		m.store.Mutex.Unlock()
+		if len(infraIDs) == 0 {
+			continue
+		}
+
+		renewCtx, cancel := context.WithTimeout(ctx, busTimeout)
+		err := m.operator.RefreshInfras(renewCtx, m.replicaID, infraIDs, time.Now().Add(connectedInfraTTL))
+		cancel()
+		if err != nil {
+			logrus.WithError(err).Error("failed to renew the connected infras")
+		}
+	}
+}
@@ -1,30 +1,301 @@
 package data_store
 
 import (
+	"context"
+	"errors"
 	"sync"
+	"time"
 
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/sirupsen/logrus"
 )
 
-// Application state, contains channels and mutexes used for subscriptions
+const (
+	infraActionPollInterval = 30 * time.Second
+	infraActionSendTimeout  = 10 * time.Second
+	infraEventSendTimeout   = 10 * time.Second
+	podLogSendTimeout       = 10 * time.Second
+)
+
+// ErrInfraAlreadyConnected is returned when an infra connects while it's connected to a replica
+var ErrInfraAlreadyConnected = errors.New("CLUSTER ALREADY CONNECTED")
+
+// ErrNoSuchPodLogRequest is returned for the logs of a request which isn't in progress, the infra stops streaming
+// them when it gets it
+var ErrNoSuchPodLogRequest = errors.New("no such request")
+
+// Application state, contains channels and mutexes used for subscriptions. The subscriptions are the ones
+// connected to this replica, the actions, events and responses are sent to them through the Bus
 type StateData struct {
 	InfraEventPublish      map[string][]chan *model.InfraEventResponse
 	ConnectedInfra         map[string]chan *model.InfraActionResponse
+	InfraActionQueued      map[string]chan struct{}
 	ExperimentEventPublish map[string][]chan *model.ExperimentRun
 	ExperimentLog          map[string]chan *model.PodLogResponse
 	KubeObjectData         map[string]chan *model.KubeObjectResponse
 	Mutex                  *sync.Mutex
+	Bus                    StateBus
+	Actions                InfraActionQueue
+	// infraConnections stops sending the actions to the connected infras
+	infraConnections map[string]context.CancelFunc
 }
 
+// NewStore returns the state of a single replica, delivering the messages and keeping the actions in memory
 func NewStore() *StateData {
-	return &StateData{
+	store := &StateData{
 		InfraEventPublish:      make(map[string][]chan *model.InfraEventResponse),
 		ConnectedInfra:         make(map[string]chan *model.InfraActionResponse),
+		InfraActionQueued:      make(map[string]chan struct{}),
 		ExperimentEventPublish: make(map[string][]chan *model.ExperimentRun),
 		ExperimentLog:          make(map[string]chan *model.PodLogResponse),
 		KubeObjectData:         make(map[string]chan *model.KubeObjectResponse),
 		Mutex:                  &sync.Mutex{},
+		Actions:                NewMemoryInfraActionQueue(),
+		infraConnections:       make(map[string]context.CancelFunc),
 	}
+	store.Bus = NewMemoryStateBus(store)
+	return store
 }
 
 var Store = NewStore()
+
+// ConnectInfra subscribes an infra to its actions until the context is done, the queued actions are sent to the
+// infra in order. A legacy infra, of a previous release, gets no actions since it can neither run them nor
+// upgrade itself, it has to be upgraded with its upgrade manifest. It fails if the infra is connected to any replica
+func (s *StateData) ConnectInfra(ctx context.Context, infraID string, infraAction chan *model.InfraActionResponse, legacy bool) error {
+	s.Mutex.Lock()
+	if _, ok := s.ConnectedInfra[infraID]; ok {
+		s.Mutex.Unlock()
+		return ErrInfraAlreadyConnected
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	queued := make(chan struct{}, 1)
+	s.ConnectedInfra[infraID] = infraAction
+	s.InfraActionQueued[infraID] = queued
+	s.infraConnections[infraID] = cancel
+	s.Mutex.Unlock()
+
+	// the bus isn't called under the lock, the infra is kept aside until it's connected to the replica
+	if err := s.Bus.ConnectInfra(infraID); err != nil {
+		s.Mutex.Lock()
+		s.removeInfra(infraID)
+		s.Mutex.Unlock()
+		return err
+	}
+	if !legacy {
+		go s.sendQueuedInfraActions(ctx, infraID, infraAction, queued)
+	}
+	return nil
+}
+
+// DisconnectInfra removes the subscription of an infra and returns true, unless the subscription was dropped as
+// stale and the infra may have connected again since
+func (s *StateData) DisconnectInfra(infraID string, infraAction chan *model.InfraActionResponse) bool {
+	s.Mutex.Lock()
+	if s.ConnectedInfra[infraID] != infraAction {
+		s.Mutex.Unlock()
+		return false
+	}
+	s.removeInfra(infraID)
+	s.Mutex.Unlock()
+	s.Bus.DisconnectInfra(infraID)
+	return true
+}
+
+// DisconnectStaleInfra drops the subscription of an infra which missed its heartbeats on the replica it's
+// connected to, so that the infra can connect again
+func (s *StateData) DisconnectStaleInfra(infraID string) error {
+	return s.Bus.DisconnectStaleInfra(infraID)
+}
+
+// deliverStaleInfra drops the subscription of an infra which missed its heartbeats, if it's connected to this
+// replica. The actions aren't sent to the subscription anymore
+func (s *StateData) deliverStaleInfra(infraID string) {
+	s.Mutex.Lock()
+	_, ok := s.ConnectedInfra[infraID]
+	if ok {
+		s.removeInfra(infraID)
+	}
+	s.Mutex.Unlock()
+	if ok {
+		s.Bus.DisconnectInfra(infraID)
+	}
+}
+
+// removeInfra removes the subscription of an infra, the store has to be locked
+func (s *StateData) removeInfra(infraID string) {
+	if cancel, ok := s.infraConnections[infraID]; ok {
+		cancel()
+	}
+	delete(s.ConnectedInfra, infraID)
+	delete(s.InfraActionQueued, infraID)
+	delete(s.infraConnections, infraID)
+}
+
+// QueueInfraAction queues an action until the infra acknowledges it, the action is sent right away if the infra
+// is connected to any replica
+func (s *StateData) QueueInfraAction(infraID string, action *model.InfraActionResponse) error {
+	if err := s.Actions.Enqueue(infraID, action); err != nil {
+		return err
+	}
+	return s.Bus.NotifyInfraActions(infraID)
+}
+
+// sendQueuedInfraActions sends the queued actions to a connected infra, when they're queued and periodically
+// in case a notification was lost. The actions delivered to a previous connection which weren't acknowledged
+// are sent again
+func (s *StateData) sendQueuedInfraActions(ctx context.Context, infraID string, infraAction chan<- *model.InfraActionResponse, queued <-chan struct{}) {
+	if err := s.Actions.Requeue(infraID); err != nil {
+		logrus.WithError(err).WithField("infra_id", infraID).Error("failed to requeue the infra actions")
+	}
+	ticker := time.NewTicker(infraActionPollInterval)
+	defer ticker.Stop()
+	for {
+		for {
+			action, err := s.Actions.Next(infraID, nil)
+			if err != nil {
+				logrus.WithError(err).WithField("infra_id", infraID).Error("failed to get the queued infra actions")
+				break
+			}
+			if action == nil {
+				break
+			}
+			// the action is sent again on the next connection if this one is closed
+			select {
+			case infraAction <- action:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-queued:
+		case <-ticker.C:
+		}
+	}
+}
+
+// SubscribeInfraEvents subscribes to the infra events of a project, the returned function unsubscribes
+func (s *StateData) SubscribeInfraEvents(projectID string, infraEvent chan *model.InfraEventResponse) func() {
+	s.Mutex.Lock()
+	s.InfraEventPublish[projectID] = append(s.InfraEventPublish[projectID], infraEvent)
+	s.Mutex.Unlock()
+	return func() {
+		s.Mutex.Lock()
+		defer s.Mutex.Unlock()
+		for i, observer := range s.InfraEventPublish[projectID] {
+			if observer == infraEvent {
+				s.InfraEventPublish[projectID] = append(s.InfraEventPublish[projectID][:i], s.InfraEventPublish[projectID][i+1:]...)
+				break
+			}
+		}
+		if len(s.InfraEventPublish[projectID]) == 0 {
+			delete(s.InfraEventPublish, projectID)
+		}
+	}
+}
+
+// SubscribePodLog subscribes to the chunks of the logs of a pod log request, the subscription is removed after the
+// last chunk. The request is recorded until the returned function unsubscribes
+func (s *StateData) SubscribePodLog(requestID string, podLog chan *model.PodLogResponse) func() {
+	s.Mutex.Lock()
+	s.ExperimentLog[requestID] = podLog
+	s.Mutex.Unlock()
+	if err := s.Bus.OpenPodLog(requestID); err != nil {
+		logrus.WithError(err).WithField("request_id", requestID).Error("failed to record the pod log request")
+	}
+	return func() {
+		s.Mutex.Lock()
+		delete(s.ExperimentLog, requestID)
+		s.Mutex.Unlock()
+		s.Bus.ClosePodLog(requestID)
+	}
+}
+
+// SubscribeKubeObject subscribes to the response of a kube object request, the returned function unsubscribes
+func (s *StateData) SubscribeKubeObject(requestID string, kubeObject chan *model.KubeObjectResponse) func() {
+	s.Mutex.Lock()
+	s.KubeObjectData[requestID] = kubeObject
+	s.Mutex.Unlock()
+	return func() {
+		s.Mutex.Lock()
+		delete(s.KubeObjectData, requestID)
+		s.Mutex.Unlock()
+	}
+}
+
+// deliverInfraAction sends an action to the infra if it's connected to this replica, the action is dropped if the
+// infra doesn't receive it in time
+func (s *StateData) deliverInfraAction(infraID string, action *model.InfraActionResponse) {
+	s.Mutex.Lock()
+	observer, ok := s.ConnectedInfra[infraID]
+	s.Mutex.Unlock()
+	if !ok {
+		return
+	}
+	select {
+	case observer <- action:
+	case <-time.After(infraActionSendTimeout):
+		logrus.WithField("infra_id", infraID).Warnf("dropped the %s request sent to the infra", action.Action.RequestType)
+	}
+}
+
+// deliverInfraActionsQueued wakes up the sending of the queued actions if the infra is connected to this replica
+func (s *StateData) deliverInfraActionsQueued(infraID string) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	if queued, ok := s.InfraActionQueued[infraID]; ok {
+		select {
+		case queued <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// deliverInfraEvent sends an event to the subscriptions of the project on this replica, the event is dropped for
+// a subscription which doesn't receive it in time
+func (s *StateData) deliverInfraEvent(projectID string, event *model.InfraEventResponse) {
+	s.Mutex.Lock()
+	observers := append([]chan *model.InfraEventResponse{}, s.InfraEventPublish[projectID]...)
+	s.Mutex.Unlock()
+	for _, observer := range observers {
+		select {
+		case observer <- event:
+		case <-time.After(infraEventSendTimeout):
+			logrus.WithField("project_id", projectID).Warn("dropped the infra event, the subscriber isn't reading them")
+		}
+	}
+}
+
+// deliverPodLog sends a chunk of the logs of a pod log request, if the request was made on this replica. The
+// channel isn't closed as the chunks may be delivered concurrently, the subscriber stops at the last chunk
+func (s *StateData) deliverPodLog(requestID string, podLog *model.PodLogResponse) {
+	s.Mutex.Lock()
+	reqChan, ok := s.ExperimentLog[requestID]
+	if podLog.Done {
+		delete(s.ExperimentLog, requestID)
+	}
+	s.Mutex.Unlock()
+	if !ok {
+		return
+	}
+	select {
+	case reqChan <- podLog:
+	case <-time.After(podLogSendTimeout):
+		logrus.WithField("request_id", requestID).Warn("dropped the pod logs, the subscriber isn't reading them")
+	}
+}
+
+// deliverKubeObject sends the response of a kube object request and ends it, if the request was made on this replica
+func (s *StateData) deliverKubeObject(requestID string, kubeObject *model.KubeObjectResponse) {
+	s.Mutex.Lock()
+	reqChan, ok := s.KubeObjectData[requestID]
+	delete(s.KubeObjectData, requestID)
+	s.Mutex.Unlock()
+	if ok {
+		reqChan <- kubeObject
+		close(reqChan)
+	}
+}
@@ -0,0 +1,154 @@
+package data_store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	dbStateBus "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/state_bus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectInfra(t *testing.T) {
+	store := NewStore()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	infraAction := make(chan *model.InfraActionResponse, 1)
+
+	assert.NoError(t, store.ConnectInfra(ctx, "infra", infraAction, false))
+	assert.ErrorIs(t, store.ConnectInfra(ctx, "infra", make(chan *model.InfraActionResponse, 1), false), ErrInfraAlreadyConnected)
+	assert.True(t, store.Bus.IsInfraConnected("infra"))
+
+	action := &model.InfraActionResponse{ProjectID: "project"}
+	assert.NoError(t, store.Bus.SendInfraAction("infra", action))
+	assert.Equal(t, action, <-infraAction)
+
+	assert.True(t, store.DisconnectInfra("infra", infraAction))
+	assert.False(t, store.Bus.IsInfraConnected("infra"))
+	assert.NoError(t, store.Bus.SendInfraAction("infra", action))
+	assert.Len(t, infraAction, 0)
+}
+
+func TestDisconnectStaleInfra(t *testing.T) {
+	store := NewStore()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stale := make(chan *model.InfraActionResponse, 1)
+	assert.NoError(t, store.ConnectInfra(ctx, "infra", stale, false))
+
+	// the infra can connect again once its stale subscription is dropped
+	assert.NoError(t, store.DisconnectStaleInfra("infra"))
+	assert.False(t, store.Bus.IsInfraConnected("infra"))
+	infraAction := make(chan *model.InfraActionResponse, 1)
+	assert.NoError(t, store.ConnectInfra(ctx, "infra", infraAction, false))
+
+	// closing the stale subscription doesn't disconnect the new one
+	assert.False(t, store.DisconnectInfra("infra", stale))
+	assert.True(t, store.Bus.IsInfraConnected("infra"))
+	action := &model.InfraActionResponse{ProjectID: "project"}
+	assert.NoError(t, store.Bus.SendInfraAction("infra", action))
+	assert.Equal(t, action, <-infraAction)
+	assert.Len(t, stale, 0)
+
+	// the infras which aren't connected are ignored
+	assert.NoError(t, store.DisconnectStaleInfra("other"))
+}
+
+func TestSubscribeInfraEvents(t *testing.T) {
+	store := NewStore()
+	first := make(chan *model.InfraEventResponse, 1)
+	second := make(chan *model.InfraEventResponse, 1)
+	store.SubscribeInfraEvents("project", first)
+	unsubscribe := store.SubscribeInfraEvents("project", second)
+
+	event := &model.InfraEventResponse{EventID: "event"}
+	assert.NoError(t, store.Bus.PublishInfraEvent("project", event))
+	assert.Equal(t, event, <-first)
+	assert.Equal(t, event, <-second)
+
+	unsubscribe()
+	assert.NoError(t, store.Bus.PublishInfraEvent("project", event))
+	assert.Equal(t, event, <-first)
+	assert.Len(t, second, 0)
+}
+
+func TestPublishInfraEventToBlockedSubscriber(t *testing.T) {
+	store := NewStore()
+	blocked := make(chan *model.InfraEventResponse)
+	store.SubscribeInfraEvents("project", blocked)
+	go store.Bus.PublishInfraEvent("project", &model.InfraEventResponse{EventID: "event"})
+
+	// the store isn't locked while the event waits for the subscriber
+	subscribed := make(chan struct{})
+	go func() {
+		store.SubscribeInfraEvents("other", make(chan *model.InfraEventResponse, 1))
+		close(subscribed)
+	}()
+	select {
+	case <-subscribed:
+	case <-time.After(time.Second):
+		t.Fatal("the store is locked while an event waits for its subscriber")
+	}
+	assert.Equal(t, "event", (<-blocked).EventID)
+}
+
+func TestSendPodLog(t *testing.T) {
+	store := NewStore()
+	podLog := make(chan *model.PodLogResponse, 2)
+	store.SubscribePodLog("request", podLog)
+	assert.True(t, store.Bus.IsPodLogOpen("request"))
+
+	chunk := &model.PodLogResponse{Log: "chunk"}
+	last := &model.PodLogResponse{Log: "last", Done: true}
+	assert.NoError(t, store.Bus.SendPodLog("request", chunk))
+	assert.NoError(t, store.Bus.SendPodLog("request", last))
+	assert.Equal(t, chunk, <-podLog)
+	assert.Equal(t, last, <-podLog)
+
+	// the request is done, a late response is dropped
+	assert.False(t, store.Bus.IsPodLogOpen("request"))
+	assert.NoError(t, store.Bus.SendPodLog("request", chunk))
+	assert.Len(t, podLog, 0)
+}
+
+func TestSendKubeObject(t *testing.T) {
+	store := NewStore()
+	kubeObject := make(chan *model.KubeObjectResponse, 1)
+	unsubscribe := store.SubscribeKubeObject("request", kubeObject)
+	unsubscribe()
+
+	assert.NoError(t, store.Bus.SendKubeObject("request", &model.KubeObjectResponse{InfraID: "infra"}))
+	assert.Len(t, kubeObject, 0)
+}
+
+func TestMongoStateBusDispatch(t *testing.T) {
+	store := NewStore()
+	bus := &mongoStateBus{store: store, deliveries: make(map[string]chan dbStateBus.Message)}
+	blocked := make(chan *model.InfraEventResponse)
+	store.SubscribeInfraEvents("project", blocked)
+	other := make(chan *model.InfraEventResponse, 1)
+	store.SubscribeInfraEvents("other", other)
+
+	// the messages of a blocked subscriber neither block the change stream nor the other subscribers
+	dispatched := make(chan struct{})
+	go func() {
+		for i := 0; i < busDeliveryBuffer+10; i++ {
+			bus.dispatch(dbStateBus.Message{Kind: dbStateBus.InfraEventMessage, Key: "project", InfraEvent: &model.InfraEventResponse{EventID: "event"}})
+		}
+		bus.dispatch(dbStateBus.Message{Kind: dbStateBus.InfraEventMessage, Key: "other", InfraEvent: &model.InfraEventResponse{EventID: "other"}})
+		close(dispatched)
+	}()
+	select {
+	case <-dispatched:
+	case <-time.After(time.Second):
+		t.Fatal("the state bus messages are dispatched while waiting for a blocked subscriber")
+	}
+	select {
+	case event := <-other:
+		assert.Equal(t, "other", event.EventID)
+	case <-time.After(time.Second):
+		t.Fatal("the state bus message wasn't delivered while another subscriber is blocked")
+	}
+	assert.Equal(t, "event", (<-blocked).EventID)
+}
@@ -0,0 +1,55 @@
+package audit_log
+
+import (
+	"context"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Operator is the append-only interface to the audit log, it has no update or delete operations on purpose
+type Operator struct {
+	operator mongodb.MongoOperator
+}
+
+// NewAuditLogOperator returns a new instance of Operator
+func NewAuditLogOperator(mongodbOperator mongodb.MongoOperator) *Operator {
+	return &Operator{
+		operator: mongodbOperator,
+	}
+}
+
+// InsertAuditLog appends an entry to the audit log
+func (a *Operator) InsertAuditLog(ctx context.Context, auditLog AuditLog) error {
+	err := a.operator.Create(ctx, mongodb.AuditLogCollection, auditLog)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetAggregateAuditLogs takes a mongo pipeline to retrieve the audit logs from the database
+func (a *Operator) GetAggregateAuditLogs(ctx context.Context, pipeline mongo.Pipeline) (*mongo.Cursor, error) {
+	results, err := a.operator.Aggregate(ctx, mongodb.AuditLogCollection, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// GetAuditLogsCursor returns a cursor over the audit logs matching the query, from the oldest to the latest,
+// so that large exports are streamed instead of being loaded in memory
+func (a *Operator) GetAuditLogsCursor(ctx context.Context, query bson.D) (*mongo.Cursor, error) {
+	opts := options.Find().SetSort(bson.D{{"timestamp", 1}})
+	results, err := a.operator.List(ctx, mongodb.AuditLogCollection, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
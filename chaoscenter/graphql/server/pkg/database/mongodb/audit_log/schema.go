@@ -0,0 +1,79 @@
+package audit_log
+
+import (
+	"strconv"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+)
+
+// Actor contains the details of the user who performed an audited action
+type Actor struct {
+	UserID   string `bson:"user_id,omitempty"`
+	Username string `bson:"username,omitempty"`
+	ClientIP string `bson:"client_ip,omitempty"`
+	ApiToken bool   `bson:"api_token"`
+}
+
+// AuditLog is an immutable record of a mutating action, written by both the GraphQL and the authentication servers
+type AuditLog struct {
+	AuditID   string            `bson:"audit_id"`
+	Timestamp int64             `bson:"timestamp"`
+	Source    model.AuditSource `bson:"source"`
+	Actor     Actor             `bson:"actor"`
+	ProjectID string            `bson:"project_id,omitempty"`
+	Operation string            `bson:"operation"`
+	TargetID  string            `bson:"target_id,omitempty"`
+	// Before and After are the JSON states of the target before and after the action, with the secrets redacted
+	Before  string             `bson:"before,omitempty"`
+	After   string             `bson:"after,omitempty"`
+	Outcome model.AuditOutcome `bson:"outcome"`
+	Error   string             `bson:"error,omitempty"`
+}
+
+type TotalFilteredData struct {
+	Count int `bson:"count"`
+}
+
+type AggregatedAuditLogs struct {
+	TotalFilteredAuditLogs []TotalFilteredData `bson:"total_filtered_audit_logs"`
+	AuditLogs              []AuditLog          `bson:"audit_logs"`
+}
+
+// GetOutputAuditLog returns the audit log as a GraphQL response
+func (a AuditLog) GetOutputAuditLog() *model.AuditLog {
+	auditLog := &model.AuditLog{
+		AuditID:   a.AuditID,
+		Timestamp: strconv.FormatInt(a.Timestamp, 10),
+		Source:    a.Source,
+		Actor: &model.AuditActor{
+			APIToken: a.Actor.ApiToken,
+		},
+		Operation: a.Operation,
+		Outcome:   a.Outcome,
+	}
+	if a.Actor.UserID != "" {
+		auditLog.Actor.UserID = &a.Actor.UserID
+	}
+	if a.Actor.Username != "" {
+		auditLog.Actor.Username = &a.Actor.Username
+	}
+	if a.Actor.ClientIP != "" {
+		auditLog.Actor.ClientIP = &a.Actor.ClientIP
+	}
+	if a.ProjectID != "" {
+		auditLog.ProjectID = &a.ProjectID
+	}
+	if a.TargetID != "" {
+		auditLog.TargetID = &a.TargetID
+	}
+	if a.Before != "" {
+		auditLog.Before = &a.Before
+	}
+	if a.After != "" {
+		auditLog.After = &a.After
+	}
+	if a.Error != "" {
+		auditLog.Error = &a.Error
+	}
+	return auditLog
+}
@@ -89,11 +89,13 @@ type GetExperimentDetails struct {
 }
 
 type ExperimentRevision struct {
-	RevisionID         string             `bson:"revision_id"`
-	ExperimentManifest string             `bson:"experiment_manifest"`
-	UpdatedAt          int64              `bson:"updated_at"`
-	Weightages         []*WeightagesInput `bson:"weightages"`
-	Probes             []Probes           `bson:"probes"`
+	RevisionID         string              `bson:"revision_id"`
+	ExperimentManifest string              `bson:"experiment_manifest"`
+	UpdatedAt          int64               `bson:"updated_at"`
+	Weightages         []*WeightagesInput  `bson:"weightages"`
+	Probes             []Probes            `bson:"probes"`
+	ScoringStrategy    *ScoringStrategy    `bson:"scoring_strategy,omitempty"`
+	AcceptanceCriteria *AcceptanceCriteria `bson:"acceptance_criteria,omitempty"`
 }
 
 // WeightagesInput contains the required fields to be stored in the database for a weightages input
@@ -102,6 +104,21 @@ type WeightagesInput struct {
 	Weightage int    `bson:"weightage"`
 }
 
+// ScoringStrategy contains the strategy used to calculate the resiliency score of the experiment runs
+type ScoringStrategy struct {
+	Type                  string   `bson:"type"`
+	CriticalFaults        []string `bson:"critical_faults,omitempty"`
+	RecoveryTimeThreshold int      `bson:"recovery_time_threshold,omitempty"`
+	RecoveryTimePenalty   float64  `bson:"recovery_time_penalty,omitempty"`
+}
+
+// AcceptanceCriteria contains the criteria the experiment runs have to meet to pass their gate
+type AcceptanceCriteria struct {
+	MinResiliencyScore *float64 `bson:"min_resiliency_score,omitempty"`
+	RequiredFaults     []string `bson:"required_faults,omitempty"`
+	MaxFailedProbes    *int     `bson:"max_failed_probes,omitempty"`
+}
+
 type FaultEventMetadata struct {
 	FaultName             string   `bson:"fault_name"`
 	ServiceIdentifier     []string `bson:"service_identifier"`
@@ -141,6 +158,8 @@ type FlattenedExperimentRun struct {
 	Completed              bool                              `bson:"completed"`
 	IsRemoved              bool                              `bson:"is_removed"`
 	RunSequence            int64                             `bson:"run_sequence"`
+	GateVerdict            string                            `bson:"gate_verdict,omitempty"`
+	GateVerdictReasons     []string                          `bson:"gate_verdict_reasons,omitempty"`
 }
 
 type ExperimentDetails struct {
@@ -175,3 +194,95 @@ type AggregatedExperimentsWithProbes struct {
 	ScheduledExperiments     []ChaosExperimentsWithRunDetails `bson:"scheduled_experiments"`
 	ProbesMatched            []ProbesMatched                  `bson:"probes_matched"`
 }
+
+// NewScoringStrategy converts the scoring strategy of an experiment request to the one stored on the revision
+func NewScoringStrategy(input *model.ResiliencyScoringStrategyInput) *ScoringStrategy {
+	if input == nil {
+		return nil
+	}
+	strategy := &ScoringStrategy{
+		Type:           string(input.Type),
+		CriticalFaults: input.CriticalFaults,
+	}
+	if input.RecoveryTimeThreshold != nil {
+		strategy.RecoveryTimeThreshold = *input.RecoveryTimeThreshold
+	}
+	if input.RecoveryTimePenalty != nil {
+		strategy.RecoveryTimePenalty = *input.RecoveryTimePenalty
+	}
+	return strategy
+}
+
+// GetInputScoringStrategy returns the scoring strategy as an experiment request input
+func (s *ScoringStrategy) GetInputScoringStrategy() *model.ResiliencyScoringStrategyInput {
+	if s == nil {
+		return nil
+	}
+	return &model.ResiliencyScoringStrategyInput{
+		Type:                  model.ResiliencyScoringStrategyType(s.Type),
+		CriticalFaults:        s.CriticalFaults,
+		RecoveryTimeThreshold: &s.RecoveryTimeThreshold,
+		RecoveryTimePenalty:   &s.RecoveryTimePenalty,
+	}
+}
+
+// GetOutputScoringStrategy returns the scoring strategy as an experiment response
+func (s *ScoringStrategy) GetOutputScoringStrategy() *model.ResiliencyScoringStrategy {
+	if s == nil {
+		return nil
+	}
+	return &model.ResiliencyScoringStrategy{
+		Type:                  model.ResiliencyScoringStrategyType(s.Type),
+		CriticalFaults:        s.CriticalFaults,
+		RecoveryTimeThreshold: &s.RecoveryTimeThreshold,
+		RecoveryTimePenalty:   &s.RecoveryTimePenalty,
+	}
+}
+
+// NewAcceptanceCriteria converts the acceptance criteria of an experiment request to the ones stored on the revision
+func NewAcceptanceCriteria(input *model.AcceptanceCriteriaInput) *AcceptanceCriteria {
+	if input == nil {
+		return nil
+	}
+	return &AcceptanceCriteria{
+		MinResiliencyScore: input.MinResiliencyScore,
+		RequiredFaults:     input.RequiredFaults,
+		MaxFailedProbes:    input.MaxFailedProbes,
+	}
+}
+
+// GetInputAcceptanceCriteria returns the acceptance criteria as an experiment request input
+func (a *AcceptanceCriteria) GetInputAcceptanceCriteria() *model.AcceptanceCriteriaInput {
+	if a == nil {
+		return nil
+	}
+	return &model.AcceptanceCriteriaInput{
+		MinResiliencyScore: a.MinResiliencyScore,
+		RequiredFaults:     a.RequiredFaults,
+		MaxFailedProbes:    a.MaxFailedProbes,
+	}
+}
+
+// GetOutputAcceptanceCriteria returns the acceptance criteria as an experiment response
+func (a *AcceptanceCriteria) GetOutputAcceptanceCriteria() *model.AcceptanceCriteria {
+	if a == nil {
+		return nil
+	}
+	return &model.AcceptanceCriteria{
+		MinResiliencyScore: a.MinResiliencyScore,
+		RequiredFaults:     a.RequiredFaults,
+		MaxFailedProbes:    a.MaxFailedProbes,
+	}
+}
+
+// GetGateVerdict returns the gate verdict of the experiment run, which is pending until the run is completed
+func (run *FlattenedExperimentRun) GetGateVerdict() *model.GateVerdict {
+	verdict := model.GateVerdictPending
+	if run.Completed {
+		if run.GateVerdict == "" {
+			return nil
+		}
+		verdict = model.GateVerdict(run.GateVerdict)
+	}
+	return &verdict
+}
@@ -116,23 +116,35 @@ func (c *Operator) UpdateExperimentRun(ctx context.Context, wfRun ChaosExperimen
 			}
 		}
 
-		update := bson.D{
-			{"$set", bson.D{
-				{"experiment_run_id", wfRun.ExperimentRunID},
-				{"phase", wfRun.Phase},
-				{"resiliency_score", wfRun.ResiliencyScore},
-				{"faults_passed", wfRun.FaultsPassed},
-				{"faults_failed", wfRun.FaultsFailed},
-				{"faults_awaited", wfRun.FaultsAwaited},
-				{"faults_stopped", wfRun.FaultsStopped},
-				{"faults_na", wfRun.FaultsNA},
-				{"total_faults", wfRun.TotalFaults},
-				{"execution_data", wfRun.ExecutionData},
-				{"completed", wfRun.Completed},
-				{"updated_by", wfRun.UpdatedBy},
-				{"updated_at", wfRun.UpdatedAt},
-				{"is_removed", wfRun.IsRemoved},
-			}}}
+		set := bson.D{
+			{"experiment_run_id", wfRun.ExperimentRunID},
+			{"phase", wfRun.Phase},
+			{"resiliency_score", wfRun.ResiliencyScore},
+			{"faults_passed", wfRun.FaultsPassed},
+			{"faults_failed", wfRun.FaultsFailed},
+			{"faults_awaited", wfRun.FaultsAwaited},
+			{"faults_stopped", wfRun.FaultsStopped},
+			{"faults_na", wfRun.FaultsNA},
+			{"total_faults", wfRun.TotalFaults},
+			{"execution_data", wfRun.ExecutionData},
+			{"completed", wfRun.Completed},
+			{"updated_by", wfRun.UpdatedBy},
+			{"updated_at", wfRun.UpdatedAt},
+			{"is_removed", wfRun.IsRemoved},
+			{"gate_verdict", wfRun.GateVerdict},
+			{"gate_verdict_reasons", wfRun.GateVerdictReasons},
+		}
+
+		// events carrying a sequence number are applied only if they are newer than the stored one
+		if wfRun.EventSequence > 0 {
+			updateQuery = append(updateQuery, bson.E{"$or", bson.A{
+				bson.D{{"event_sequence", bson.D{{"$exists", false}}}},
+				bson.D{{"event_sequence", bson.D{{"$lt", wfRun.EventSequence}}}},
+			}})
+			set = append(set, bson.E{"event_sequence", wfRun.EventSequence})
+		}
+
+		update := bson.D{{"$set", set}}
 
 		result, err := c.operator.Update(ctx, mongodb.ChaosExperimentRunsCollection, updateQuery, update)
 		if err != nil {
@@ -24,6 +24,10 @@ type ChaosExperimentRun struct {
 	TotalFaults     *int     `bson:"total_faults,omitempty"`
 	RunSequence     int      `bson:"run_sequence"`
 	Completed       bool     `bson:"completed"`
+	EventSequence   int64    `bson:"event_sequence,omitempty"`
+	// GateVerdict is the verdict of the acceptance criteria, set once the run is completed
+	GateVerdict        string   `bson:"gate_verdict,omitempty"`
+	GateVerdictReasons []string `bson:"gate_verdict_reasons,omitempty"`
 }
 
 type Probes struct {
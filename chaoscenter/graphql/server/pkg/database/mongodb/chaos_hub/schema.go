@@ -25,10 +25,16 @@ type ChaosHub struct {
 	SSHPublicKey            *string `bson:"ssh_public_key"`
 	LastSyncedAt            int64   `bson:"last_synced_at"`
 	IsDefault               bool    `bson:"is_default"`
+	// Digest is the digest of the OCI artifact the hub was last pulled from
+	Digest string `bson:"digest,omitempty"`
 }
 
 // GetOutputChaosHub ...
 func (c *ChaosHub) GetOutputChaosHub() *model.ChaosHub {
+	var digest *string
+	if c.Digest != "" {
+		digest = &c.Digest
+	}
 	return &model.ChaosHub{
 		ID:            c.ID,
 		ProjectID:     c.ProjectID,
@@ -49,6 +55,7 @@ func (c *ChaosHub) GetOutputChaosHub() *model.ChaosHub {
 		CreatedAt:     strconv.FormatInt(c.CreatedAt, 10),
 		UpdatedAt:     strconv.FormatInt(c.UpdatedAt, 10),
 		LastSyncedAt:  strconv.FormatInt(c.LastSyncedAt, 10),
+		Digest:        digest,
 	}
 }
 
@@ -80,6 +80,16 @@ func (c *Operator) UpdateInfra(ctx context.Context, query bson.D, update bson.D)
 	return nil
 }
 
+// UpdateOneInfra updates the first chaos_infra matching the query, it returns false if no chaos_infra matched
+func (c *Operator) UpdateOneInfra(ctx context.Context, query bson.D, update bson.D) (bool, error) {
+	result, err := c.operator.Update(ctx, mongodb.ChaosInfraCollection, query, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
 // GetInfraWithProjectID takes projectID parameters to retrieve the chaos_infra details
 func (c *Operator) GetInfraWithProjectID(projectID string) ([]*ChaosInfra, error) {
 	var query bson.D
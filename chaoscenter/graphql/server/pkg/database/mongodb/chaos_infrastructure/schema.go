@@ -1,6 +1,9 @@
 package chaos_infrastructure
 
 import (
+	"strconv"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
 )
 
@@ -28,6 +31,7 @@ type ChaosInfra struct {
 	Tolerations             []*Toleration `bson:"tolerations,omitempty"`
 	StartTime               string        `bson:"start_time"`
 	Version                 string        `bson:"version"`
+	Heartbeat               *Heartbeat    `bson:"heartbeat,omitempty"`
 }
 
 type TotalFilteredData struct {
@@ -59,6 +63,7 @@ type ChaosInfraDetails struct {
 	SkipSSL                 *bool            `bson:"skip_ssl"`
 	InfraNsExists           *bool            `bson:"infra_ns_exists"`
 	InfraSaExists           *bool            `bson:"infra_sa_exists"`
+	Heartbeat               *Heartbeat       `bson:"heartbeat,omitempty"`
 }
 
 type AggregatedGetInfras struct {
@@ -101,3 +106,41 @@ type AggregatedInfraStats struct {
 	TotalActiveInfrastructure     []TotalCount `bson:"total_active_infras"`
 	TotalConfirmedInfrastructures []TotalCount `bson:"total_confirmed_infras"`
 }
+
+// Heartbeat contains the health reported by an infra in its last heartbeat, the infras which don't send
+// heartbeats don't have one
+type Heartbeat struct {
+	UpdatedAt  int64             `bson:"updated_at"`
+	IsHealthy  bool              `bson:"is_healthy"`
+	NodeCount  *int              `bson:"node_count,omitempty"`
+	K8sVersion *string           `bson:"k8s_version,omitempty"`
+	Components []ComponentHealth `bson:"components"`
+}
+
+type ComponentHealth struct {
+	Name    string  `bson:"name"`
+	Healthy bool    `bson:"healthy"`
+	Message *string `bson:"message,omitempty"`
+}
+
+// GetOutputHealth returns the timestamp of the heartbeat and the health of the infra
+func (h *Heartbeat) GetOutputHealth() (*string, *model.InfraHealth) {
+	if h == nil {
+		return nil, nil
+	}
+	lastHeartbeat := strconv.FormatInt(h.UpdatedAt, 10)
+	health := &model.InfraHealth{
+		IsHealthy:  h.IsHealthy,
+		NodeCount:  h.NodeCount,
+		K8sVersion: h.K8sVersion,
+		Components: []*model.InfraComponentHealth{},
+	}
+	for _, component := range h.Components {
+		health.Components = append(health.Components, &model.InfraComponentHealth{
+			Name:    component.Name,
+			Healthy: component.Healthy,
+			Message: component.Message,
+		})
+	}
+	return &lastHeartbeat, health
+}
@@ -40,6 +40,20 @@ func (g *GetCollectionStruct) getCollection(collectionType int) (*mongo.Collecti
 		return mongoClient.(*MongoClient).EnvironmentCollection, nil
 	case ChaosProbeCollection:
 		return mongoClient.(*MongoClient).ChaosProbeCollection, nil
+	case NotificationChannelCollection:
+		return mongoClient.(*MongoClient).NotificationChannelCollection, nil
+	case AuditLogCollection:
+		return mongoClient.(*MongoClient).AuditLogCollection, nil
+	case StateBusCollection:
+		return mongoClient.(*MongoClient).StateBusCollection, nil
+	case ConnectedInfraCollection:
+		return mongoClient.(*MongoClient).ConnectedInfraCollection, nil
+	case InfraActionCollection:
+		return mongoClient.(*MongoClient).InfraActionCollection, nil
+	case InfraUpgradeCollection:
+		return mongoClient.(*MongoClient).InfraUpgradeCollection, nil
+	case PodLogRequestCollection:
+		return mongoClient.(*MongoClient).PodLogRequestCollection, nil
 	default:
 		return nil, errors.New("unknown collection name")
 	}
@@ -1,6 +1,8 @@
 package gitops
 
 import (
+	"strconv"
+
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
 )
 
@@ -15,11 +17,74 @@ type GitConfigDB struct {
 	Password      *string        `bson:"password"`
 	Token         *string        `bson:"token"`
 	SSHPrivateKey *string        `bson:"ssh_private_key"`
+	// PollInterval is the interval in seconds at which the repository is polled, the default one is used if unset
+	PollInterval   int                    `bson:"poll_interval,omitempty"`
+	WebhookSecret  string                 `bson:"webhook_secret,omitempty"`
+	LastSyncAt     int64                  `bson:"last_sync_at,omitempty"`
+	LastSyncStatus model.GitOpsSyncStatus `bson:"last_sync_status,omitempty"`
+	LastSyncError  string                 `bson:"last_sync_error,omitempty"`
+	WriteMode      model.GitOpsWriteMode  `bson:"write_mode,omitempty"`
+	Provider       *model.GitOpsProvider  `bson:"provider,omitempty"`
+	ProviderAPIURL *string                `bson:"provider_api_url,omitempty"`
+	PendingChanges []PendingChange        `bson:"pending_changes,omitempty"`
+	Conflicts      []Conflict             `bson:"conflicts,omitempty"`
+}
+
+// Conflict is an experiment edited both in ChaosCenter and in the repo whose changes couldn't be merged
+type Conflict struct {
+	ConflictID          string   `bson:"conflict_id"`
+	ExperimentID        string   `bson:"experiment_id"`
+	ExperimentName      string   `bson:"experiment_name"`
+	File                string   `bson:"file"`
+	ConflictingPaths    []string `bson:"conflicting_paths"`
+	BaseManifest        string   `bson:"base_manifest"`
+	ChaosCenterManifest string   `bson:"chaos_center_manifest"`
+	GitManifest         string   `bson:"git_manifest"`
+	CreatedAt           int64    `bson:"created_at"`
+}
+
+// GetOutputConflict ...
+func (c Conflict) GetOutputConflict() *model.GitOpsConflict {
+	return &model.GitOpsConflict{
+		ConflictID:          c.ConflictID,
+		ExperimentID:        c.ExperimentID,
+		ExperimentName:      c.ExperimentName,
+		File:                c.File,
+		ConflictingPaths:    c.ConflictingPaths,
+		BaseManifest:        c.BaseManifest,
+		ChaosCenterManifest: c.ChaosCenterManifest,
+		GitManifest:         c.GitManifest,
+		CreatedAt:           strconv.FormatInt(c.CreatedAt, 10),
+	}
+}
+
+// PendingChange is a change pushed to a branch whose pull request isn't merged or closed yet
+type PendingChange struct {
+	Branch            string `bson:"branch"`
+	File              string `bson:"file"`
+	Title             string `bson:"title"`
+	PullRequestNumber int    `bson:"pull_request_number"`
+	PullRequestURL    string `bson:"pull_request_url"`
+	CreatedAt         int64  `bson:"created_at"`
+	CreatedBy         string `bson:"created_by"`
+}
+
+// GetOutputPendingChange ...
+func (p PendingChange) GetOutputPendingChange() *model.GitOpsPendingChange {
+	return &model.GitOpsPendingChange{
+		Branch:            p.Branch,
+		File:              p.File,
+		Title:             p.Title,
+		PullRequestNumber: p.PullRequestNumber,
+		PullRequestURL:    p.PullRequestURL,
+		CreatedAt:         strconv.FormatInt(p.CreatedAt, 10),
+		CreatedBy:         &p.CreatedBy,
+	}
 }
 
 // GetGitConfigDB ...
 func GetGitConfigDB(projectID string, config model.GitConfig) GitConfigDB {
-	return GitConfigDB{
+	gitConfig := GitConfigDB{
 		ProjectID:     projectID,
 		RepositoryURL: config.RepoURL,
 		Branch:        config.Branch,
@@ -30,4 +95,14 @@ func GetGitConfigDB(projectID string, config model.GitConfig) GitConfigDB {
 		Token:         config.Token,
 		SSHPrivateKey: config.SSHPrivateKey,
 	}
+	if config.PollInterval != nil {
+		gitConfig.PollInterval = *config.PollInterval
+	}
+	gitConfig.WriteMode = model.GitOpsWriteModeDirectPush
+	if config.WriteMode != nil {
+		gitConfig.WriteMode = *config.WriteMode
+	}
+	gitConfig.Provider = config.Provider
+	gitConfig.ProviderAPIURL = config.ProviderAPIURL
+	return gitConfig
 }
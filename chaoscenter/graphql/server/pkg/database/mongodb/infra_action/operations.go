@@ -0,0 +1,90 @@
+package infra_action
+
+import (
+	"context"
+	"errors"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Operator struct {
+	operator mongodb.MongoOperator
+}
+
+// NewInfraActionOperator returns a new instance of Operator
+func NewInfraActionOperator(mongodbOperator mongodb.MongoOperator) *Operator {
+	return &Operator{
+		operator: mongodbOperator,
+	}
+}
+
+// InsertInfraAction queues an action for an infra
+func (i *Operator) InsertInfraAction(ctx context.Context, action InfraAction) error {
+	err := i.operator.Create(ctx, mongodb.InfraActionCollection, action)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// FindOneAndUpdateInfraAction updates the oldest action matching the query and returns it updated, or nil if
+// no action matches
+func (i *Operator) FindOneAndUpdateInfraAction(ctx context.Context, query, update bson.D) (*InfraAction, error) {
+	collection, err := i.operator.GetCollection(mongodb.InfraActionCollection)
+	if err != nil {
+		return nil, err
+	}
+	opts := options.FindOneAndUpdate().SetSort(bson.D{{"created_at", 1}}).SetReturnDocument(options.After)
+
+	var action InfraAction
+	err = collection.FindOneAndUpdate(ctx, query, update, opts).Decode(&action)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &action, nil
+}
+
+// UpdateInfraAction updates an action and returns the number of matched actions
+func (i *Operator) UpdateInfraAction(ctx context.Context, query, update bson.D) (int64, error) {
+	result, err := i.operator.Update(ctx, mongodb.InfraActionCollection, query, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.MatchedCount, nil
+}
+
+// UpdateInfraActions updates all the actions matching the query
+func (i *Operator) UpdateInfraActions(ctx context.Context, query, update bson.D) error {
+	_, err := i.operator.UpdateMany(ctx, mongodb.InfraActionCollection, query, update)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListInfraActions returns the latest actions matching the query
+func (i *Operator) ListInfraActions(ctx context.Context, query bson.D, limit int64) ([]InfraAction, error) {
+	opts := options.Find().SetSort(bson.D{{"created_at", -1}}).SetLimit(limit)
+	results, err := i.operator.List(ctx, mongodb.InfraActionCollection, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []InfraAction
+	err = results.All(ctx, &actions)
+	if err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
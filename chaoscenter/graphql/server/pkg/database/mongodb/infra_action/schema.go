@@ -0,0 +1,54 @@
+package infra_action
+
+import (
+	"strconv"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+)
+
+// InfraAction is an action queued for an infra until the infra acknowledges it
+type InfraAction struct {
+	RequestID    string                 `bson:"request_id"`
+	ProjectID    string                 `bson:"project_id"`
+	InfraID      string                 `bson:"infra_id"`
+	RequestType  string                 `bson:"request_type"`
+	K8sManifest  string                 `bson:"k8s_manifest"`
+	Namespace    string                 `bson:"namespace"`
+	ExternalData *string                `bson:"external_data,omitempty"`
+	Username     *string                `bson:"username,omitempty"`
+	State        model.InfraActionState `bson:"state"`
+	Error        *string                `bson:"error,omitempty"`
+	Attempts     int                    `bson:"attempts"`
+	CreatedAt    int64                  `bson:"created_at"`
+	UpdatedAt    int64                  `bson:"updated_at"`
+}
+
+// GetOutputInfraAction returns the action sent to the infra subscription
+func (a *InfraAction) GetOutputInfraAction() *model.InfraActionResponse {
+	return &model.InfraActionResponse{
+		ProjectID: a.ProjectID,
+		Action: &model.ActionPayload{
+			RequestID:    a.RequestID,
+			RequestType:  a.RequestType,
+			K8sManifest:  a.K8sManifest,
+			Namespace:    a.Namespace,
+			ExternalData: a.ExternalData,
+			Username:     a.Username,
+		},
+	}
+}
+
+// GetOutputInfraActionStatus returns the status of the action
+func (a *InfraAction) GetOutputInfraActionStatus() *model.InfraActionStatus {
+	return &model.InfraActionStatus{
+		RequestID:    a.RequestID,
+		InfraID:      a.InfraID,
+		RequestType:  a.RequestType,
+		ExternalData: a.ExternalData,
+		State:        a.State,
+		Error:        a.Error,
+		Username:     a.Username,
+		CreatedAt:    strconv.FormatInt(a.CreatedAt, 10),
+		UpdatedAt:    strconv.FormatInt(a.UpdatedAt, 10),
+	}
+}
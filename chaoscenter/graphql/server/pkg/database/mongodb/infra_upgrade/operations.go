@@ -0,0 +1,77 @@
+package infra_upgrade
+
+import (
+	"context"
+	"errors"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrUpgradeInProgress is returned when an upgrade is recorded for an infra which is already being upgraded
+var ErrUpgradeInProgress = errors.New("the infra is already being upgraded")
+
+type Operator struct {
+	operator mongodb.MongoOperator
+}
+
+// NewInfraUpgradeOperator returns a new instance of Operator
+func NewInfraUpgradeOperator(mongodbOperator mongodb.MongoOperator) *Operator {
+	return &Operator{
+		operator: mongodbOperator,
+	}
+}
+
+// InsertInfraUpgrade records a new upgrade of an infra, it fails with ErrUpgradeInProgress if the infra is
+// already being upgraded
+func (i *Operator) InsertInfraUpgrade(ctx context.Context, upgrade InfraUpgrade) error {
+	err := i.operator.Create(ctx, mongodb.InfraUpgradeCollection, upgrade)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrUpgradeInProgress
+	}
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetLatestInfraUpgrade returns the latest upgrade matching the query, or nil if there's none
+func (i *Operator) GetLatestInfraUpgrade(ctx context.Context, query bson.D) (*InfraUpgrade, error) {
+	upgrades, err := i.ListInfraUpgrades(ctx, query, 1)
+	if err != nil || len(upgrades) == 0 {
+		return nil, err
+	}
+
+	return &upgrades[0], nil
+}
+
+// UpdateInfraUpgrade updates an upgrade and returns the number of matched upgrades
+func (i *Operator) UpdateInfraUpgrade(ctx context.Context, query, update bson.D) (int64, error) {
+	result, err := i.operator.Update(ctx, mongodb.InfraUpgradeCollection, query, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.MatchedCount, nil
+}
+
+// ListInfraUpgrades returns the latest upgrades matching the query
+func (i *Operator) ListInfraUpgrades(ctx context.Context, query bson.D, limit int64) ([]InfraUpgrade, error) {
+	opts := options.Find().SetSort(bson.D{{"created_at", -1}}).SetLimit(limit)
+	results, err := i.operator.List(ctx, mongodb.InfraUpgradeCollection, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var upgrades []InfraUpgrade
+	err = results.All(ctx, &upgrades)
+	if err != nil {
+		return nil, err
+	}
+
+	return upgrades, nil
+}
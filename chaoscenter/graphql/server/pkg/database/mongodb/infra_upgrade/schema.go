@@ -0,0 +1,43 @@
+package infra_upgrade
+
+import (
+	"strconv"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+)
+
+// InfraUpgrade records an upgrade of an infra and the progress reported by the infra
+type InfraUpgrade struct {
+	UpgradeID   string                   `bson:"upgrade_id"`
+	ProjectID   string                   `bson:"project_id"`
+	InfraID     string                   `bson:"infra_id"`
+	FromVersion string                   `bson:"from_version"`
+	ToVersion   string                   `bson:"to_version"`
+	Status      model.InfraUpgradeStatus `bson:"status"`
+	Stage       *string                  `bson:"stage,omitempty"`
+	Error       *string                  `bson:"error,omitempty"`
+	Username    *string                  `bson:"username,omitempty"`
+	CreatedAt   int64                    `bson:"created_at"`
+	UpdatedAt   int64                    `bson:"updated_at"`
+}
+
+// GetOutputInfraUpgrade returns the upgrade in the format of the graphql schema
+func (u *InfraUpgrade) GetOutputInfraUpgrade() *model.InfraUpgrade {
+	return &model.InfraUpgrade{
+		UpgradeID:   u.UpgradeID,
+		InfraID:     u.InfraID,
+		FromVersion: u.FromVersion,
+		ToVersion:   u.ToVersion,
+		Status:      u.Status,
+		Stage:       u.Stage,
+		Error:       u.Error,
+		Username:    u.Username,
+		CreatedAt:   strconv.FormatInt(u.CreatedAt, 10),
+		UpdatedAt:   strconv.FormatInt(u.UpdatedAt, 10),
+	}
+}
+
+// IsDone returns true if the upgrade reached a final status
+func (u *InfraUpgrade) IsDone() bool {
+	return u.Status != model.InfraUpgradeStatusPending && u.Status != model.InfraUpgradeStatusInProgress
+}
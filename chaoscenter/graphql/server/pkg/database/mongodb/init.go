@@ -13,6 +13,11 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// InfraUpgradeInProgressFilter matches the infra upgrades which aren't done, for the unique index allowing a
+// single upgrade of an infra at a time. The partial indexes of MongoDB 4.2 don't support $in, so the Pending
+// and InProgress statuses are matched as the range between them
+var InfraUpgradeInProgressFilter = bson.D{{"status", bson.D{{"$gte", "InProgress"}, {"$lte", "Pending"}}}}
+
 // Enum for Database collections
 const (
 	ChaosInfraCollection = iota
@@ -26,6 +31,13 @@ const (
 	ProjectCollection
 	EnvironmentCollection
 	ChaosProbeCollection
+	NotificationChannelCollection
+	AuditLogCollection
+	StateBusCollection
+	ConnectedInfraCollection
+	InfraActionCollection
+	InfraUpgradeCollection
+	PodLogRequestCollection
 )
 
 // MongoInterface requires a MongoClient that implements the Initialize method to create the Mongo DB client
@@ -50,6 +62,13 @@ type MongoClient struct {
 	ProjectCollection             *mongo.Collection
 	EnvironmentCollection         *mongo.Collection
 	ChaosProbeCollection          *mongo.Collection
+	NotificationChannelCollection *mongo.Collection
+	AuditLogCollection            *mongo.Collection
+	StateBusCollection            *mongo.Collection
+	ConnectedInfraCollection      *mongo.Collection
+	InfraActionCollection         *mongo.Collection
+	InfraUpgradeCollection        *mongo.Collection
+	PodLogRequestCollection       *mongo.Collection
 }
 
 var (
@@ -67,6 +86,13 @@ var (
 		UserCollection:                "user",
 		ProjectCollection:             "project",
 		EnvironmentCollection:         "environment",
+		NotificationChannelCollection: "notificationChannels",
+		AuditLogCollection:            "auditLogs",
+		StateBusCollection:            "stateBus",
+		ConnectedInfraCollection:      "connectedInfras",
+		InfraActionCollection:         "infraActions",
+		InfraUpgradeCollection:        "infraUpgrades",
+		PodLogRequestCollection:       "podLogRequests",
 	}
 
 	DbName            = "litmus"
@@ -292,4 +318,189 @@ func (m *MongoClient) initAllCollection() {
 	if err != nil {
 		logrus.WithError(err).Error("failed to create indexes for chaosProbes collection")
 	}
+
+	// Initialize notification channels collection
+	err = m.Database.CreateCollection(context.TODO(), Collections[NotificationChannelCollection], nil)
+	if err != nil {
+		logrus.WithError(err).Error("failed to create notificationChannels collection")
+	}
+
+	m.NotificationChannelCollection = m.Database.Collection(Collections[NotificationChannelCollection])
+	_, err = m.NotificationChannelCollection.Indexes().CreateMany(backgroundContext, []mongo.IndexModel{
+		{
+			Keys: bson.M{
+				"channel_id": 1,
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{"project_id", 1},
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Error("failed to create indexes for notificationChannels collection")
+	}
+
+	// Initialize audit logs collection, it's shared with the authentication server
+	err = m.Database.CreateCollection(context.TODO(), Collections[AuditLogCollection], nil)
+	if err != nil {
+		logrus.WithError(err).Error("failed to create auditLogs collection")
+	}
+
+	m.AuditLogCollection = m.Database.Collection(Collections[AuditLogCollection])
+	_, err = m.AuditLogCollection.Indexes().CreateMany(backgroundContext, []mongo.IndexModel{
+		{
+			Keys: bson.M{
+				"audit_id": 1,
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{"project_id", 1},
+				{"timestamp", -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{"timestamp", -1},
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Error("failed to create indexes for auditLogs collection")
+	}
+
+	// Initialize state bus collections, the messages are only kept for the change streams of the replicas
+	err = m.Database.CreateCollection(context.TODO(), Collections[StateBusCollection], nil)
+	if err != nil {
+		logrus.WithError(err).Error("failed to create stateBus collection")
+	}
+
+	m.StateBusCollection = m.Database.Collection(Collections[StateBusCollection])
+	_, err = m.StateBusCollection.Indexes().CreateMany(backgroundContext, []mongo.IndexModel{
+		{
+			Keys: bson.M{
+				"created_at": 1,
+			},
+			Options: options.Index().SetExpireAfterSeconds(60),
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Error("failed to create indexes for stateBus collection")
+	}
+
+	err = m.Database.CreateCollection(context.TODO(), Collections[PodLogRequestCollection], nil)
+	if err != nil {
+		logrus.WithError(err).Error("failed to create podLogRequests collection")
+	}
+
+	m.PodLogRequestCollection = m.Database.Collection(Collections[PodLogRequestCollection])
+	_, err = m.PodLogRequestCollection.Indexes().CreateMany(backgroundContext, []mongo.IndexModel{
+		{
+			Keys: bson.M{
+				"request_id": 1,
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.M{
+				"expires_at": 1,
+			},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Error("failed to create indexes for podLogRequests collection")
+	}
+
+	err = m.Database.CreateCollection(context.TODO(), Collections[ConnectedInfraCollection], nil)
+	if err != nil {
+		logrus.WithError(err).Error("failed to create connectedInfras collection")
+	}
+
+	m.ConnectedInfraCollection = m.Database.Collection(Collections[ConnectedInfraCollection])
+	_, err = m.ConnectedInfraCollection.Indexes().CreateMany(backgroundContext, []mongo.IndexModel{
+		{
+			Keys: bson.M{
+				"infra_id": 1,
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.M{
+				"expires_at": 1,
+			},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Error("failed to create indexes for connectedInfras collection")
+	}
+
+	// Initialize infra actions collection
+	err = m.Database.CreateCollection(context.TODO(), Collections[InfraActionCollection], nil)
+	if err != nil {
+		logrus.WithError(err).Error("failed to create infraActions collection")
+	}
+
+	m.InfraActionCollection = m.Database.Collection(Collections[InfraActionCollection])
+	_, err = m.InfraActionCollection.Indexes().CreateMany(backgroundContext, []mongo.IndexModel{
+		{
+			Keys: bson.M{
+				"request_id": 1,
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{"infra_id", 1},
+				{"state", 1},
+				{"created_at", 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{"project_id", 1},
+				{"created_at", -1},
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Error("failed to create indexes for infraActions collection")
+	}
+
+	// Initialize infra upgrades collection
+	err = m.Database.CreateCollection(context.TODO(), Collections[InfraUpgradeCollection], nil)
+	if err != nil {
+		logrus.WithError(err).Error("failed to create infraUpgrades collection")
+	}
+
+	m.InfraUpgradeCollection = m.Database.Collection(Collections[InfraUpgradeCollection])
+	_, err = m.InfraUpgradeCollection.Indexes().CreateMany(backgroundContext, []mongo.IndexModel{
+		{
+			Keys: bson.M{
+				"upgrade_id": 1,
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{"infra_id", 1},
+				{"created_at", -1},
+			},
+		},
+		{
+			// a single upgrade of an infra can be in progress
+			Keys: bson.M{
+				"infra_id": 1,
+			},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(InfraUpgradeInProgressFilter),
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Error("failed to create indexes for infraUpgrades collection")
+	}
 }
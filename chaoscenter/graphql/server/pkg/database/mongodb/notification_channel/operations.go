@@ -0,0 +1,72 @@
+package notification_channel
+
+import (
+	"context"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type Operator struct {
+	operator mongodb.MongoOperator
+}
+
+// NewNotificationChannelOperator returns a new instance of Operator
+func NewNotificationChannelOperator(mongodbOperator mongodb.MongoOperator) *Operator {
+	return &Operator{
+		operator: mongodbOperator,
+	}
+}
+
+// InsertNotificationChannel takes the details of a notification channel and inserts them into the database collection
+func (n *Operator) InsertNotificationChannel(ctx context.Context, channel NotificationChannel) error {
+	err := n.operator.Create(ctx, mongodb.NotificationChannelCollection, channel)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetNotificationChannel returns the notification channel matching the query
+func (n *Operator) GetNotificationChannel(ctx context.Context, query bson.D) (NotificationChannel, error) {
+	var channel NotificationChannel
+	result, err := n.operator.Get(ctx, mongodb.NotificationChannelCollection, query)
+	if err != nil {
+		return NotificationChannel{}, err
+	}
+
+	err = result.Decode(&channel)
+	if err != nil {
+		return NotificationChannel{}, err
+	}
+
+	return channel, nil
+}
+
+// GetNotificationChannels returns all the notification channels matching the query
+func (n *Operator) GetNotificationChannels(ctx context.Context, query bson.D) ([]NotificationChannel, error) {
+	var channels []NotificationChannel
+	results, err := n.operator.List(ctx, mongodb.NotificationChannelCollection, query)
+	if err != nil {
+		return []NotificationChannel{}, err
+	}
+
+	err = results.All(ctx, &channels)
+	if err != nil {
+		return []NotificationChannel{}, err
+	}
+
+	return channels, nil
+}
+
+// UpdateNotificationChannel takes query and update parameters to update the notification channel in the database
+func (n *Operator) UpdateNotificationChannel(ctx context.Context, query bson.D, update bson.D) error {
+	_, err := n.operator.Update(ctx, mongodb.NotificationChannelCollection, query, update)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
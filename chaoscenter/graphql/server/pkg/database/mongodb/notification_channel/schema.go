@@ -0,0 +1,185 @@
+package notification_channel
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
+)
+
+// NotificationChannel contains the details of an external system notified of the lifecycle events of a project
+type NotificationChannel struct {
+	mongodb.Audit           `bson:",inline"`
+	mongodb.ResourceDetails `bson:",inline"`
+	ProjectID               string                        `bson:"project_id"`
+	ChannelID               string                        `bson:"channel_id"`
+	Type                    model.NotificationChannelType `bson:"type"`
+	Enabled                 bool                          `bson:"enabled"`
+	Webhook                 *WebhookConfig                `bson:"webhook,omitempty"`
+	Slack                   *SlackConfig                  `bson:"slack,omitempty"`
+	SMTP                    *SMTPConfig                   `bson:"smtp,omitempty"`
+	Filter                  Filter                        `bson:"filter"`
+}
+
+// WebhookConfig contains the details of a generic webhook
+type WebhookConfig struct {
+	URL    string `bson:"url"`
+	Secret string `bson:"secret,omitempty"`
+}
+
+// SlackConfig contains the details of a Slack compatible incoming webhook
+type SlackConfig struct {
+	WebhookURL string `bson:"webhook_url"`
+}
+
+// SMTPConfig contains the details of the SMTP server used to send emails
+type SMTPConfig struct {
+	Host     string   `bson:"host"`
+	Port     int      `bson:"port"`
+	Username string   `bson:"username,omitempty"`
+	Password string   `bson:"password,omitempty"`
+	From     string   `bson:"from"`
+	To       []string `bson:"to"`
+}
+
+// Filter contains the filters of the events a channel is notified of
+type Filter struct {
+	EventTypes     []model.NotificationEventType `bson:"event_types,omitempty"`
+	ExperimentTags []string                      `bson:"experiment_tags,omitempty"`
+	Phases         []string                      `bson:"phases,omitempty"`
+	EnvironmentIDs []string                      `bson:"environment_ids,omitempty"`
+}
+
+// NewFilter returns the filter of a notification channel request
+func NewFilter(input *model.NotificationFilterInput) Filter {
+	if input == nil {
+		return Filter{}
+	}
+	return Filter{
+		EventTypes:     input.EventTypes,
+		ExperimentTags: input.ExperimentTags,
+		Phases:         input.Phases,
+		EnvironmentIDs: input.EnvironmentIDs,
+	}
+}
+
+// NewWebhookConfig returns the webhook config of a notification channel request
+func NewWebhookConfig(input *model.WebhookConfigInput) *WebhookConfig {
+	if input == nil {
+		return nil
+	}
+	config := &WebhookConfig{URL: input.URL}
+	if input.Secret != nil {
+		config.Secret = *input.Secret
+	}
+	return config
+}
+
+// UpdateWebhookConfig returns the webhook config of a channel updated with a notification channel request, the
+// secret is kept unless the request sets it. An empty secret stops signing the payloads
+func UpdateWebhookConfig(config *WebhookConfig, input *model.WebhookConfigInput) *WebhookConfig {
+	updated := NewWebhookConfig(input)
+	if updated != nil && config != nil && input.Secret == nil {
+		updated.Secret = config.Secret
+	}
+	return updated
+}
+
+// NewSlackConfig returns the Slack config of a notification channel request
+func NewSlackConfig(input *model.SlackConfigInput) *SlackConfig {
+	if input == nil {
+		return nil
+	}
+	return &SlackConfig{WebhookURL: input.WebhookURL}
+}
+
+// NewSMTPConfig returns the SMTP config of a notification channel request
+func NewSMTPConfig(input *model.SMTPConfigInput) *SMTPConfig {
+	if input == nil {
+		return nil
+	}
+	config := &SMTPConfig{
+		Host: input.Host,
+		Port: input.Port,
+		From: input.From,
+		To:   input.To,
+	}
+	if input.Username != nil {
+		config.Username = *input.Username
+	}
+	if input.Password != nil {
+		config.Password = *input.Password
+	}
+	return config
+}
+
+// UpdateSMTPConfig returns the SMTP config of a channel updated with a notification channel request, the
+// credentials are kept unless the request sets them
+func UpdateSMTPConfig(config *SMTPConfig, input *model.SMTPConfigInput) *SMTPConfig {
+	updated := NewSMTPConfig(input)
+	if updated == nil || config == nil {
+		return updated
+	}
+	if input.Username == nil {
+		updated.Username = config.Username
+	}
+	if input.Password == nil {
+		updated.Password = config.Password
+	}
+	return updated
+}
+
+// GetOutputNotificationChannel returns the notification channel as a GraphQL response, without its secrets
+func (n NotificationChannel) GetOutputNotificationChannel() *model.NotificationChannel {
+	channel := &model.NotificationChannel{
+		ProjectID:   n.ProjectID,
+		ChannelID:   n.ChannelID,
+		Name:        n.Name,
+		Description: &n.Description,
+		Tags:        n.Tags,
+		Type:        n.Type,
+		Enabled:     n.Enabled,
+		Filter: &model.NotificationFilter{
+			EventTypes:     n.Filter.EventTypes,
+			ExperimentTags: n.Filter.ExperimentTags,
+			Phases:         n.Filter.Phases,
+			EnvironmentIDs: n.Filter.EnvironmentIDs,
+		},
+		CreatedAt: strconv.FormatInt(n.CreatedAt, 10),
+		UpdatedAt: strconv.FormatInt(n.UpdatedAt, 10),
+		CreatedBy: &model.UserDetails{Username: n.CreatedBy.Username},
+		UpdatedBy: &model.UserDetails{Username: n.UpdatedBy.Username},
+		IsRemoved: &n.IsRemoved,
+	}
+	if n.Webhook != nil {
+		channel.Webhook = &model.WebhookConfig{
+			URL:      n.Webhook.URL,
+			IsSigned: n.Webhook.Secret != "",
+		}
+	}
+	if n.Slack != nil {
+		channel.Slack = &model.SlackConfig{WebhookHost: webhookHost(n.Slack.WebhookURL)}
+	}
+	if n.SMTP != nil {
+		channel.SMTP = &model.SMTPConfig{
+			Host: n.SMTP.Host,
+			Port: n.SMTP.Port,
+			From: n.SMTP.From,
+			To:   n.SMTP.To,
+		}
+		if n.SMTP.Username != "" {
+			channel.SMTP.Username = &n.SMTP.Username
+		}
+	}
+	return channel
+}
+
+// webhookHost returns the host of a webhook URL, the rest of the URL is the secret of the webhook
+func webhookHost(webhookURL string) string {
+	parsedURL, err := url.Parse(webhookURL)
+	if err != nil {
+		return ""
+	}
+	return parsedURL.Host
+}
@@ -0,0 +1,116 @@
+package state_bus
+
+import (
+	"context"
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Operator struct {
+	operator mongodb.MongoOperator
+}
+
+// NewStateBusOperator returns a new instance of Operator
+func NewStateBusOperator(mongodbOperator mongodb.MongoOperator) *Operator {
+	return &Operator{
+		operator: mongodbOperator,
+	}
+}
+
+// InsertMessage publishes a message to the change streams of the replicas
+func (s *Operator) InsertMessage(ctx context.Context, message Message) error {
+	err := s.operator.Create(ctx, mongodb.StateBusCollection, message)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// WatchMessages returns a change stream of the published messages, resumed after the token if there is one
+func (s *Operator) WatchMessages(ctx context.Context, resumeToken bson.Raw) (*mongo.ChangeStream, error) {
+	collection, err := s.operator.GetCollection(mongodb.StateBusCollection)
+	if err != nil {
+		return nil, err
+	}
+	pipeline := mongo.Pipeline{
+		bson.D{{"$match", bson.D{{"operationType", "insert"}}}},
+	}
+	opts := options.ChangeStream()
+	if resumeToken != nil {
+		opts.SetResumeAfter(resumeToken)
+	}
+
+	return collection.Watch(ctx, pipeline, opts)
+}
+
+// ClaimInfra records the infra as connected to the replica, unless it's connected to another replica. It returns
+// a duplicate key error if it is
+func (s *Operator) ClaimInfra(ctx context.Context, infraID, replicaID string, expiresAt time.Time) error {
+	query := bson.D{
+		{"infra_id", infraID},
+		{"$or", bson.A{
+			bson.D{{"replica_id", replicaID}},
+			bson.D{{"expires_at", bson.D{{"$lt", time.Now()}}}},
+		}},
+	}
+	update := bson.D{{"$set", bson.D{{"replica_id", replicaID}, {"expires_at", expiresAt}}}}
+	_, err := s.operator.Update(ctx, mongodb.ConnectedInfraCollection, query, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// RefreshInfras extends the expiry of the infras connected to the replica
+func (s *Operator) RefreshInfras(ctx context.Context, replicaID string, infraIDs []string, expiresAt time.Time) error {
+	query := bson.D{{"replica_id", replicaID}, {"infra_id", bson.D{{"$in", infraIDs}}}}
+	update := bson.D{{"$set", bson.D{{"expires_at", expiresAt}}}}
+	_, err := s.operator.UpdateMany(ctx, mongodb.ConnectedInfraCollection, query, update)
+	return err
+}
+
+// ReleaseInfra removes the record of the infra connected to the replica
+func (s *Operator) ReleaseInfra(ctx context.Context, infraID, replicaID string) error {
+	_, err := s.operator.Delete(ctx, mongodb.ConnectedInfraCollection, bson.D{{"infra_id", infraID}, {"replica_id", replicaID}})
+	return err
+}
+
+// IsInfraConnected returns true if the infra is connected to any replica
+func (s *Operator) IsInfraConnected(ctx context.Context, infraID string) (bool, error) {
+	count, err := s.operator.CountDocuments(ctx, mongodb.ConnectedInfraCollection, bson.D{
+		{"infra_id", infraID},
+		{"expires_at", bson.D{{"$gt", time.Now()}}},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// OpenPodLog records a pod log request in progress
+func (s *Operator) OpenPodLog(ctx context.Context, requestID string, expiresAt time.Time) error {
+	return s.operator.Create(ctx, mongodb.PodLogRequestCollection, PodLogRequest{RequestID: requestID, ExpiresAt: expiresAt})
+}
+
+// ClosePodLog removes the record of a pod log request
+func (s *Operator) ClosePodLog(ctx context.Context, requestID string) error {
+	_, err := s.operator.Delete(ctx, mongodb.PodLogRequestCollection, bson.D{{"request_id", requestID}})
+	return err
+}
+
+// IsPodLogOpen returns true if the pod log request is in progress on any replica
+func (s *Operator) IsPodLogOpen(ctx context.Context, requestID string) (bool, error) {
+	count, err := s.operator.CountDocuments(ctx, mongodb.PodLogRequestCollection, bson.D{
+		{"request_id", requestID},
+		{"expires_at", bson.D{{"$gt", time.Now()}}},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,46 @@
+package state_bus
+
+import (
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+)
+
+// MessageKind is the kind of the state sent to the subscriptions
+type MessageKind string
+
+const (
+	InfraActionMessage        MessageKind = "infra_action"
+	InfraActionsQueuedMessage MessageKind = "infra_actions_queued"
+	InfraEventMessage         MessageKind = "infra_event"
+	PodLogMessage             MessageKind = "pod_log"
+	KubeObjectMessage         MessageKind = "kube_object"
+	StaleInfraMessage         MessageKind = "stale_infra"
+)
+
+// Message is published by a replica to the subscriptions of all the replicas. The key is the infra ID of the
+// infra actions and the stale infras, the project ID of the events and the request ID of the pod logs and kube objects
+type Message struct {
+	Kind        MessageKind                `bson:"kind"`
+	Key         string                     `bson:"key"`
+	ReplicaID   string                     `bson:"replica_id"`
+	InfraAction *model.InfraActionResponse `bson:"infra_action,omitempty"`
+	InfraEvent  *model.InfraEventResponse  `bson:"infra_event,omitempty"`
+	PodLog      *model.PodLogResponse      `bson:"pod_log,omitempty"`
+	KubeObject  *model.KubeObjectResponse  `bson:"kube_object,omitempty"`
+	CreatedAt   time.Time                  `bson:"created_at"`
+}
+
+// PodLogRequest records a pod log request in progress on any replica, the record expires after the longest
+// the logs are streamed for
+type PodLogRequest struct {
+	RequestID string    `bson:"request_id"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// ConnectedInfra records the replica an infra is connected to, the record expires unless the replica refreshes it
+type ConnectedInfra struct {
+	InfraID   string    `bson:"infra_id"`
+	ReplicaID string    `bson:"replica_id"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
@@ -0,0 +1,324 @@
+package gitops
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/google/uuid"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	dataStore "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/data-store"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_experiment"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/gitops"
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// mergeExperimentChange merges the change of an experiment file with the changes made to the experiment in
+// ChaosCenter since the last sync, during the SyncDBToGit operation. It returns the merged manifest and
+// whether it differs from the file, the conflicts are recorded and ErrGitOpsConflict is returned
+func (g *gitOpsService) mergeExperimentChange(data, wfID, file string, config GitConfig) (string, bool, error) {
+	experiments, err := g.chaosExperimentOps.GetExperiments(bson.D{{"experiment_id", wfID}, {"project_id", config.ProjectID}, {"is_removed", false}})
+	if err != nil || len(experiments) == 0 || len(experiments[0].Revision) == 0 {
+		return data, false, nil
+	}
+	experiment := experiments[0]
+	ours := experiment.Revision[len(experiment.Revision)-1].ExperimentManifest
+
+	// the experiment is as it was synced from the repo
+	if experiment.UpdatedBy.Username == gitOpsUsername {
+		return data, false, nil
+	}
+
+	base, err := g.baseManifest(experiment, data, file, config)
+	if err != nil {
+		return "", false, err
+	}
+	if base == "" || equalManifests(base, ours) || equalManifests(ours, data) {
+		return data, false, g.removeConflicts(config.ProjectID, bson.D{{"file", file}})
+	}
+
+	merged, conflicts, err := mergeManifests(base, ours, data)
+	if err != nil {
+		return "", false, err
+	}
+	if len(conflicts) > 0 {
+		conflict := gitops.Conflict{
+			ConflictID:          uuid.New().String(),
+			ExperimentID:        wfID,
+			ExperimentName:      experiment.Name,
+			File:                file,
+			ConflictingPaths:    conflicts,
+			BaseManifest:        base,
+			ChaosCenterManifest: ours,
+			GitManifest:         data,
+			CreatedAt:           time.Now().UnixMilli(),
+		}
+		if err := g.recordConflict(config.ProjectID, conflict); err != nil {
+			return "", false, err
+		}
+		return "", false, ErrGitOpsConflict
+	}
+	logrus.Info("Merged the changes of experiment ", experiment.Name, " made in ChaosCenter and in git")
+	return merged, !equalManifests(merged, data), g.removeConflicts(config.ProjectID, bson.D{{"file", file}})
+}
+
+// baseManifest returns the manifest of the experiment at the last sync, which is the file at the latest
+// synced commit or, if the file is new there, the revision of the experiment the file was written from
+func (g *gitOpsService) baseManifest(experiment chaos_experiment.ChaosExperimentRequest, data, file string, config GitConfig) (string, error) {
+	if config.LatestCommit != "" {
+		content, err := config.GitFileAtCommit(config.LatestCommit, file)
+		if err != nil {
+			return "", err
+		}
+		if content != nil {
+			base, err := yaml.YAMLToJSON(content)
+			if err != nil {
+				return "", err
+			}
+			return string(base), nil
+		}
+	}
+
+	revisionID := gjson.Get(data, "metadata.labels.revision_id").String()
+	if revisionID == "" {
+		return "", nil
+	}
+	for _, revision := range experiment.Revision {
+		if revision.RevisionID == revisionID {
+			return revision.ExperimentManifest, nil
+		}
+	}
+	return "", nil
+}
+
+// recordConflict replaces the conflict of the file with a new one
+func (g *gitOpsService) recordConflict(projectID string, conflict gitops.Conflict) error {
+	if err := g.removeConflicts(projectID, bson.D{{"file", conflict.File}}); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(backgroundContext, timeout)
+	defer cancel()
+	query := bson.D{{"project_id", projectID}}
+	update := bson.D{{"$push", bson.D{{"conflicts", conflict}}}}
+	return g.gitOpsOperator.UpdateGitConfig(ctx, query, update)
+}
+
+// removeConflicts removes the conflicts matching the filter
+func (g *gitOpsService) removeConflicts(projectID string, filter bson.D) error {
+	ctx, cancel := context.WithTimeout(backgroundContext, timeout)
+	defer cancel()
+	query := bson.D{{"project_id", projectID}}
+	update := bson.D{{"$pull", bson.D{{"conflicts", filter}}}}
+	return g.gitOpsOperator.UpdateGitConfig(ctx, query, update)
+}
+
+// recordClosedChange records a conflict for the change of a pull request closed without being merged, as the
+// experiment keeps the change in ChaosCenter while the repo doesn't have it. An empty manifest is an experiment
+// missing on that side
+func (g *gitOpsService) recordClosedChange(config GitConfig, change gitops.PendingChange) error {
+	file := ProjectDataPath + "/" + config.ProjectID + "/" + change.File
+	conflict := gitops.Conflict{
+		ConflictID:       uuid.New().String(),
+		ExperimentName:   strings.TrimSuffix(change.File, ".yaml"),
+		File:             file,
+		ConflictingPaths: []string{},
+		CreatedAt:        time.Now().UnixMilli(),
+	}
+
+	content, err := os.ReadFile(config.LocalPath + "/" + file)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if content != nil {
+		data, err := yaml.YAMLToJSON(content)
+		if err != nil {
+			return err
+		}
+		conflict.GitManifest = string(data)
+		conflict.BaseManifest = string(data)
+		conflict.ExperimentID = gjson.Get(conflict.GitManifest, "metadata.labels.experiment_id").String()
+	}
+
+	experiments, err := g.chaosExperimentOps.GetExperiments(bson.D{{"name", conflict.ExperimentName}, {"project_id", config.ProjectID}, {"is_removed", false}})
+	if err != nil {
+		return err
+	}
+	if len(experiments) > 0 && len(experiments[0].Revision) > 0 {
+		conflict.ExperimentID = experiments[0].ExperimentID
+		conflict.ChaosCenterManifest = experiments[0].Revision[len(experiments[0].Revision)-1].ExperimentManifest
+	}
+
+	// the experiment is the same on both sides
+	if conflict.ExperimentID == "" || conflict.ChaosCenterManifest == conflict.GitManifest ||
+		(conflict.ChaosCenterManifest != "" && conflict.GitManifest != "" && equalManifests(conflict.ChaosCenterManifest, conflict.GitManifest)) {
+		return nil
+	}
+	logrus.Info("Pull request ", change.PullRequestURL, " of experiment ", conflict.ExperimentName, " was closed without being merged")
+	return g.recordConflict(config.ProjectID, conflict)
+}
+
+// conflictOf returns the unresolved conflict of a file
+func conflictOf(config gitops.GitConfigDB, file string) *gitops.Conflict {
+	for i := range config.Conflicts {
+		if config.Conflicts[i].File == file {
+			return &config.Conflicts[i]
+		}
+	}
+	return nil
+}
+
+// mergeWithGit returns the merge function of an experiment edited in ChaosCenter, which merges it with the
+// changes made to its file in git since the last sync. The merged manifest is set in the experiment
+func mergeWithGit(projectID string, experiment *model.ChaosExperimentRequest) func(config gitops.GitConfigDB, current []byte) ([]byte, error) {
+	return func(config gitops.GitConfigDB, current []byte) ([]byte, error) {
+		file := ProjectDataPath + "/" + projectID + "/" + experiment.ExperimentName + ".yaml"
+		if conflict := conflictOf(config, file); conflict != nil {
+			return nil, errors.New(ErrGitOpsConflict.Error() + ", resolve the conflict " + conflict.ConflictID + " first")
+		}
+		// the pull requests are merged on review
+		if current == nil || config.LatestCommit == "" || config.WriteMode == model.GitOpsWriteModePullRequest {
+			return nil, nil
+		}
+
+		gitConfig := GetGitOpsConfig(config)
+		base, err := gitConfig.GitFileAtCommit(config.LatestCommit, file)
+		if err != nil || base == nil {
+			return nil, err
+		}
+		if string(base) == string(current) {
+			return nil, nil
+		}
+		baseManifest, err := yaml.YAMLToJSON(base)
+		if err != nil {
+			return nil, err
+		}
+		theirs, err := yaml.YAMLToJSON(current)
+		if err != nil {
+			return nil, err
+		}
+		merged, conflicts, err := mergeManifests(string(baseManifest), experiment.ExperimentManifest, string(theirs))
+		if err != nil {
+			return nil, err
+		}
+		if len(conflicts) > 0 {
+			return nil, errors.New(ErrGitOpsConflict.Error() + ", conflicting fields : " + strings.Join(conflicts, ", "))
+		}
+		if equalManifests(merged, experiment.ExperimentManifest) {
+			return nil, nil
+		}
+		logrus.Info("Merged the changes of experiment ", experiment.ExperimentName, " made in git")
+		experiment.ExperimentManifest = merged
+		return yaml.JSONToYAML([]byte(merged))
+	}
+}
+
+// ListGitOpsConflicts returns the unresolved conflicts of the project
+func (g *gitOpsService) ListGitOpsConflicts(ctx context.Context, projectID string) ([]*model.GitOpsConflict, error) {
+	config, err := g.gitOpsOperator.GetGitConfig(ctx, projectID)
+	if err != nil {
+		return nil, errors.New("Cannot get Git Config from DB : " + err.Error())
+	}
+	conflicts := []*model.GitOpsConflict{}
+	if config == nil {
+		return conflicts, nil
+	}
+	for _, conflict := range config.Conflicts {
+		conflicts = append(conflicts, conflict.GetOutputConflict())
+	}
+	return conflicts, nil
+}
+
+// ResolveGitOpsConflict resolves a conflict by pushing the experiment in ChaosCenter to git, or by applying
+// the experiment in git to ChaosCenter
+func (g *gitOpsService) ResolveGitOpsConflict(ctx context.Context, projectID, conflictID string, resolution model.GitOpsConflictResolution) (bool, error) {
+	config, err := g.gitOpsOperator.GetGitConfig(ctx, projectID)
+	if err != nil {
+		return false, errors.New("Cannot get Git Config from DB : " + err.Error())
+	}
+	if config == nil {
+		return false, ErrGitOpsDisabled
+	}
+	var conflict *gitops.Conflict
+	for i := range config.Conflicts {
+		if config.Conflicts[i].ConflictID == conflictID {
+			conflict = &config.Conflicts[i]
+		}
+	}
+	if conflict == nil {
+		return false, errors.New("no such conflict found : " + conflictID)
+	}
+
+	switch resolution {
+	case model.GitOpsConflictResolutionChaosCenter:
+		experiments, err := g.chaosExperimentOps.GetExperiments(bson.D{{"experiment_id", conflict.ExperimentID}, {"project_id", projectID}, {"is_removed", false}})
+		if err != nil {
+			return false, err
+		}
+		// the experiment was deleted in ChaosCenter
+		if len(experiments) == 0 && conflict.ChaosCenterManifest == "" {
+			err = g.commitFileToGit(ctx, projectID, conflict.ExperimentName+".yaml", nil, "Resolved conflict of Experiment : "+conflict.ExperimentName, nil)
+			if err != nil {
+				return false, err
+			}
+			if err := g.removeConflicts(projectID, bson.D{{"conflict_id", conflictID}}); err != nil {
+				return false, errors.New("Failed to remove the conflict : " + err.Error())
+			}
+			return true, nil
+		}
+		if len(experiments) == 0 || len(experiments[0].Revision) == 0 {
+			return false, errors.New("No such experiment found : " + conflict.ExperimentID)
+		}
+		manifest := experiments[0].Revision[len(experiments[0].Revision)-1].ExperimentManifest
+		data, err := yaml.JSONToYAML([]byte(manifest))
+		if err != nil {
+			return false, errors.New("Cannot convert manifest to yaml : " + err.Error())
+		}
+		err = g.commitFileToGit(ctx, projectID, experiments[0].Name+".yaml", data, "Resolved conflict of Experiment : "+experiments[0].Name, nil)
+		if err != nil {
+			return false, err
+		}
+		if err := g.removeConflicts(projectID, bson.D{{"conflict_id", conflictID}}); err != nil {
+			return false, errors.New("Failed to remove the conflict : " + err.Error())
+		}
+
+	case model.GitOpsConflictResolutionGit:
+		gitLock.Lock(projectID, nil)
+		defer gitLock.Unlock(projectID, nil)
+		err = g.applyGitManifest(ctx, *conflict, GetGitOpsConfig(*config))
+		if err != nil {
+			return false, errors.New("Cannot apply the experiment in git : " + err.Error())
+		}
+		if err := g.removeConflicts(projectID, bson.D{{"conflict_id", conflictID}}); err != nil {
+			return false, errors.New("Failed to remove the conflict : " + err.Error())
+		}
+
+	default:
+		return false, errors.New("unsupported resolution " + string(resolution))
+	}
+	return true, nil
+}
+
+// applyGitManifest applies the experiment in git of a conflict to ChaosCenter, the experiment is deleted if it
+// isn't in git and restored if it was deleted in ChaosCenter
+func (g *gitOpsService) applyGitManifest(ctx context.Context, conflict gitops.Conflict, config GitConfig) error {
+	query := bson.D{{"experiment_id", conflict.ExperimentID}, {"project_id", config.ProjectID}}
+	if conflict.GitManifest == "" {
+		experiment, err := g.chaosExperimentOps.GetExperiment(ctx, query)
+		if err != nil {
+			return err
+		}
+		return g.chaosExperimentService.ProcessExperimentDelete(query, experiment, gitOpsUsername, dataStore.Store)
+	}
+	if conflict.ChaosCenterManifest == "" {
+		update := bson.D{{"$set", bson.D{{"is_removed", false}}}}
+		if err := g.chaosExperimentOps.UpdateChaosExperiment(ctx, query, update); err != nil {
+			return err
+		}
+	}
+	return g.updateExperiment(ctx, conflict.GitManifest, conflict.ExperimentID, conflict.File, config)
+}
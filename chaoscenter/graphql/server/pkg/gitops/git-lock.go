@@ -58,3 +58,47 @@ func getKey(repo string, branch *string) string {
 	split := regexp.MustCompile(`[:/]`).Split(key, -1)
 	return strings.Join(split[len(split)-2:], "/") + "/" + *branch
 }
+
+// SyncQueue coalesces the syncs of the projects, a project has at most one sync running and one pending. The
+// syncs requested while one is pending are served by the pending one, as a sync pulls the latest commit
+type SyncQueue struct {
+	mutex   sync.Mutex
+	pending map[string]bool
+}
+
+// NewSyncQueue returns a instance of SyncQueue
+func NewSyncQueue() *SyncQueue {
+	return &SyncQueue{
+		pending: map[string]bool{},
+	}
+}
+
+// Trigger runs the sync of a project in the background, unless one is already running in which case it's
+// run again once the running one completes. It returns false if the sync was coalesced with a pending one
+func (q *SyncQueue) Trigger(projectID string, run func()) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	pending, running := q.pending[projectID]
+	if running {
+		q.pending[projectID] = true
+		return !pending
+	}
+	q.pending[projectID] = false
+
+	go func() {
+		for {
+			run()
+
+			q.mutex.Lock()
+			if !q.pending[projectID] {
+				delete(q.pending, projectID)
+				q.mutex.Unlock()
+				return
+			}
+			q.pending[projectID] = false
+			q.mutex.Unlock()
+		}
+	}()
+	return true
+}
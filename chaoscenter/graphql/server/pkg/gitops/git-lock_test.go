@@ -0,0 +1,61 @@
+package gitops
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSyncQueueTrigger(t *testing.T) {
+	queue := NewSyncQueue()
+	release := make(chan struct{})
+	done := make(chan struct{}, 10)
+	var runs int32
+	run := func() {
+		atomic.AddInt32(&runs, 1)
+		<-release
+		done <- struct{}{}
+	}
+
+	if !queue.Trigger("project", run) {
+		t.Fatal("Trigger() coalesced the first sync")
+	}
+	// the syncs triggered while one is running are coalesced into a single pending one
+	if !queue.Trigger("project", run) {
+		t.Error("Trigger() coalesced the sync pending after the running one")
+	}
+	for i := 0; i < 5; i++ {
+		if queue.Trigger("project", run) {
+			t.Error("Trigger() didn't coalesce the sync with the pending one")
+		}
+	}
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%d syncs completed, want 2", i)
+		}
+	}
+	select {
+	case <-done:
+		t.Fatal("Trigger() ran more than one pending sync")
+	case <-time.After(100 * time.Millisecond):
+	}
+	if got := atomic.LoadInt32(&runs); got != 2 {
+		t.Errorf("Trigger() ran %d syncs, want 2", got)
+	}
+
+	// a sync triggered once they complete runs again
+	queue.mutex.Lock()
+	_, running := queue.pending["project"]
+	queue.mutex.Unlock()
+	if running {
+		t.Error("the project is still running once its syncs completed")
+	}
+	if !queue.Trigger("project", run) {
+		t.Error("Trigger() coalesced a sync triggered once the others completed")
+	}
+	<-done
+}
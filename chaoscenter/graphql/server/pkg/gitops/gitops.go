@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/transport"
@@ -28,17 +29,20 @@ import (
 
 // GitConfig structure for the GitOps settings
 type GitConfig struct {
-	ProjectID     string
-	RepositoryURL string
-	LocalPath     string
-	RemoteName    string
-	Branch        string
-	LatestCommit  string
-	UserName      *string
-	Password      *string
-	AuthType      model.AuthType
-	Token         *string
-	SSHPrivateKey *string
+	ProjectID      string
+	RepositoryURL  string
+	LocalPath      string
+	RemoteName     string
+	Branch         string
+	LatestCommit   string
+	UserName       *string
+	Password       *string
+	AuthType       model.AuthType
+	Token          *string
+	SSHPrivateKey  *string
+	WriteMode      model.GitOpsWriteMode
+	Provider       *model.GitOpsProvider
+	ProviderAPIURL *string
 }
 
 type GitUser struct {
@@ -78,17 +82,20 @@ func GitUserFromContext(ctx context.Context) GitUser {
 // GetGitOpsConfig is used for constructing the GitConfig from dbSchemaGitOps.GitConfigDB
 func GetGitOpsConfig(repoData gitops.GitConfigDB) GitConfig {
 	gitConfig := GitConfig{
-		ProjectID:     repoData.ProjectID,
-		RepositoryURL: repoData.RepositoryURL,
-		RemoteName:    "origin",
-		Branch:        repoData.Branch,
-		LocalPath:     DefaultPath + repoData.ProjectID,
-		LatestCommit:  repoData.LatestCommit,
-		UserName:      repoData.UserName,
-		Password:      repoData.Password,
-		AuthType:      model.AuthType(repoData.AuthType),
-		Token:         repoData.Token,
-		SSHPrivateKey: repoData.SSHPrivateKey,
+		ProjectID:      repoData.ProjectID,
+		RepositoryURL:  repoData.RepositoryURL,
+		RemoteName:     "origin",
+		Branch:         repoData.Branch,
+		LocalPath:      DefaultPath + repoData.ProjectID,
+		LatestCommit:   repoData.LatestCommit,
+		UserName:       repoData.UserName,
+		Password:       repoData.Password,
+		AuthType:       model.AuthType(repoData.AuthType),
+		Token:          repoData.Token,
+		SSHPrivateKey:  repoData.SSHPrivateKey,
+		WriteMode:      repoData.WriteMode,
+		Provider:       repoData.Provider,
+		ProviderAPIURL: repoData.ProviderAPIURL,
 	}
 
 	return gitConfig
@@ -211,7 +218,9 @@ func (c GitConfig) UnsafeGitPull() error {
 	}
 	log.WithFields(log.Fields{"cleanStatus": cleanStatus}).Info("executed GitGetStatus()... ")
 	if !cleanStatus {
-		log.Info("resetting Repo...: " + c.ProjectID)
+		// the uncommitted changes are never pushed, the experiments they belong to are merged with the repo
+		// on the next sync as they are still in the DB
+		log.Warn("resetting Repo, discarding the uncommitted changes...: " + c.ProjectID)
 		return c.handlerForDirtyStatus()
 	}
 	return c.GitPull()
@@ -330,6 +339,102 @@ func (c GitConfig) GitPush() error {
 	return err
 }
 
+// GitCreateBranch creates a branch from the current HEAD and checks it out
+func (c GitConfig) GitCreateBranch(branch string) error {
+	_, w, err := c.getRepositoryWorktreeReference()
+	if err != nil {
+		return err
+	}
+	return w.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(branch),
+		Create: true,
+	})
+}
+
+// GitPushBranch pushes a branch to the remote set in GitConfig, always needs auth credentials
+func (c GitConfig) GitPushBranch(branch string) error {
+	if c.AuthType == model.AuthTypeNone {
+		return errors.New("cannot write/push without credentials, auth type = none")
+	}
+
+	r, _, err := c.getRepositoryWorktreeReference()
+	if err != nil {
+		return err
+	}
+	auth, err := c.getAuthMethod()
+	if err != nil {
+		return err
+	}
+	ref := plumbing.NewBranchReferenceName(branch)
+	return r.Push(&git.PushOptions{
+		RemoteName: c.RemoteName,
+		Auth:       auth,
+		RefSpecs:   []gitconfig.RefSpec{gitconfig.RefSpec(ref + ":" + ref)},
+	})
+}
+
+// GitRestoreBranch checks out the branch in GitConfig again, discarding the local changes, and deletes the
+// local copy of a branch pushed for a pull request so that it isn't pushed along with the branch later
+func (c GitConfig) GitRestoreBranch(branch string) error {
+	r, w, err := c.getRepositoryWorktreeReference()
+	if err != nil {
+		return err
+	}
+	err = w.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(c.Branch),
+		Force:  true,
+	})
+	if err != nil {
+		return err
+	}
+	return r.Storer.RemoveReference(plumbing.NewBranchReferenceName(branch))
+}
+
+// GitHead returns the hash of the commit checked out in the repo
+func (c GitConfig) GitHead() (string, error) {
+	r, _, err := c.getRepositoryWorktreeReference()
+	if err != nil {
+		return "", err
+	}
+	ref, err := r.Head()
+	if err != nil {
+		return "", err
+	}
+	return ref.Hash().String(), nil
+}
+
+// GitResetTo executes "git reset --hard <commit>", dropping the local commits which couldn't be pushed
+func (c GitConfig) GitResetTo(commit string) error {
+	_, w, err := c.getRepositoryWorktreeReference()
+	if err != nil {
+		return err
+	}
+	return w.Reset(&git.ResetOptions{Commit: plumbing.NewHash(commit), Mode: git.HardReset})
+}
+
+// GitFileAtCommit returns the content of a file at a commit, or nil if the file didn't exist
+func (c GitConfig) GitFileAtCommit(commit, file string) ([]byte, error) {
+	r, _, err := c.getRepositoryWorktreeReference()
+	if err != nil {
+		return nil, err
+	}
+	commitObject, err := r.CommitObject(plumbing.NewHash(commit))
+	if err != nil {
+		return nil, err
+	}
+	fileObject, err := commitObject.File(file)
+	if err == object.ErrFileNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	content, err := fileObject.Contents()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content), nil
+}
+
 // GitCommit saves the changes in the repo and commits them with the message provided
 func (c GitConfig) GitCommit(user GitUser, message string, deleteFile *string) (string, error) {
 	_, w, err := c.getRepositoryWorktreeReference()
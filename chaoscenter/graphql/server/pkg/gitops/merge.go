@@ -0,0 +1,125 @@
+package gitops
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrGitOpsConflict is returned when an experiment was edited differently in ChaosCenter and in the repo
+var ErrGitOpsConflict = errors.New("experiment was changed both in ChaosCenter and in git")
+
+// absent stands for the fields missing from one side of a merge
+type absent struct{}
+
+// mergeManifests merges the changes made to the base JSON manifest by ours and theirs. The objects are
+// merged field by field and the lists of the same length item by item, it returns the paths of the fields
+// changed differently on both sides if the manifests can't be merged
+func mergeManifests(base, ours, theirs string) (string, []string, error) {
+	var baseValue, ourValue, theirValue interface{}
+	if err := json.Unmarshal([]byte(base), &baseValue); err != nil {
+		return "", nil, errors.New("invalid base manifest : " + err.Error())
+	}
+	if err := json.Unmarshal([]byte(ours), &ourValue); err != nil {
+		return "", nil, errors.New("invalid manifest : " + err.Error())
+	}
+	if err := json.Unmarshal([]byte(theirs), &theirValue); err != nil {
+		return "", nil, errors.New("invalid manifest : " + err.Error())
+	}
+
+	merged, conflicts := mergeValues("", baseValue, ourValue, theirValue)
+	if len(conflicts) > 0 {
+		return "", conflicts, nil
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return "", nil, err
+	}
+	return string(data), nil, nil
+}
+
+// equalManifests returns true if the JSON manifests have the same fields
+func equalManifests(a, b string) bool {
+	var aValue, bValue interface{}
+	if json.Unmarshal([]byte(a), &aValue) != nil || json.Unmarshal([]byte(b), &bValue) != nil {
+		return a == b
+	}
+	return reflect.DeepEqual(aValue, bValue)
+}
+
+func mergeValues(path string, base, ours, theirs interface{}) (interface{}, []string) {
+	switch {
+	case reflect.DeepEqual(ours, theirs), reflect.DeepEqual(base, theirs):
+		return ours, nil
+	case reflect.DeepEqual(base, ours):
+		return theirs, nil
+	}
+
+	baseMap, isBaseMap := base.(map[string]interface{})
+	ourMap, isOurMap := ours.(map[string]interface{})
+	theirMap, isTheirMap := theirs.(map[string]interface{})
+	if isBaseMap && isOurMap && isTheirMap {
+		return mergeObjects(path, baseMap, ourMap, theirMap)
+	}
+
+	baseList, isBaseList := base.([]interface{})
+	ourList, isOurList := ours.([]interface{})
+	theirList, isTheirList := theirs.([]interface{})
+	if isBaseList && isOurList && isTheirList && len(baseList) == len(ourList) && len(ourList) == len(theirList) {
+		var conflicts []string
+		merged := make([]interface{}, len(baseList))
+		for i := range baseList {
+			value, itemConflicts := mergeValues(path+"["+strconv.Itoa(i)+"]", baseList[i], ourList[i], theirList[i])
+			merged[i] = value
+			conflicts = append(conflicts, itemConflicts...)
+		}
+		return merged, conflicts
+	}
+
+	if path == "" {
+		path = "."
+	}
+	return ours, []string{path}
+}
+
+func mergeObjects(path string, base, ours, theirs map[string]interface{}) (interface{}, []string) {
+	keys := map[string]bool{}
+	for _, object := range []map[string]interface{}{base, ours, theirs} {
+		for key := range object {
+			keys[key] = true
+		}
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	var conflicts []string
+	merged := map[string]interface{}{}
+	for _, key := range sortedKeys {
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+		if strings.Contains(key, ".") {
+			fieldPath = path + "[" + strconv.Quote(key) + "]"
+		}
+		value, fieldConflicts := mergeValues(fieldPath, field(base, key), field(ours, key), field(theirs, key))
+		conflicts = append(conflicts, fieldConflicts...)
+		if _, removed := value.(absent); !removed {
+			merged[key] = value
+		}
+	}
+	return merged, conflicts
+}
+
+func field(object map[string]interface{}, key string) interface{} {
+	if value, ok := object[key]; ok {
+		return value
+	}
+	return absent{}
+}
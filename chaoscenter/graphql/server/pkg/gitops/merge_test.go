@@ -0,0 +1,62 @@
+package gitops
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeManifests(t *testing.T) {
+	const base = `{"metadata":{"name":"exp","labels":{"revision_id":"1"}},"spec":{"templates":[{"name":"a","args":["x"]},{"name":"b"}],"timeout":10}}`
+	testcases := []struct {
+		name          string
+		ours          string
+		theirs        string
+		want          string
+		wantConflicts []string
+	}{
+		{
+			name:   "changed on one side",
+			ours:   base,
+			theirs: `{"metadata":{"name":"exp","labels":{"revision_id":"1"}},"spec":{"templates":[{"name":"a","args":["x"]},{"name":"b"}],"timeout":20}}`,
+			want:   `{"metadata":{"name":"exp","labels":{"revision_id":"1"}},"spec":{"templates":[{"name":"a","args":["x"]},{"name":"b"}],"timeout":20}}`,
+		},
+		{
+			name:   "different fields changed on both sides",
+			ours:   `{"metadata":{"name":"exp","labels":{"revision_id":"2"}},"spec":{"templates":[{"name":"a","args":["x"]},{"name":"b"}],"timeout":10}}`,
+			theirs: `{"metadata":{"name":"exp","labels":{"revision_id":"1"}},"spec":{"templates":[{"name":"a","args":["y"]},{"name":"b","image":"c"}],"timeout":10}}`,
+			want:   `{"metadata":{"name":"exp","labels":{"revision_id":"2"}},"spec":{"templates":[{"name":"a","args":["y"]},{"name":"b","image":"c"}],"timeout":10}}`,
+		},
+		{
+			name:   "field removed on one side",
+			ours:   `{"metadata":{"name":"exp","labels":{"revision_id":"1"}},"spec":{"templates":[{"name":"a","args":["x"]},{"name":"b"}]}}`,
+			theirs: `{"metadata":{"name":"exp","labels":{"revision_id":"1"},"annotations":{"a":"b"}},"spec":{"templates":[{"name":"a","args":["x"]},{"name":"b"}],"timeout":10}}`,
+			want:   `{"metadata":{"name":"exp","labels":{"revision_id":"1"},"annotations":{"a":"b"}},"spec":{"templates":[{"name":"a","args":["x"]},{"name":"b"}]}}`,
+		},
+		{
+			name:          "same field changed on both sides",
+			ours:          `{"metadata":{"name":"exp","labels":{"revision_id":"1"}},"spec":{"templates":[{"name":"a","args":["x"]},{"name":"b"}],"timeout":20}}`,
+			theirs:        `{"metadata":{"name":"exp","labels":{"revision_id":"1"}},"spec":{"templates":[{"name":"a","args":["x"]},{"name":"b"}],"timeout":30}}`,
+			wantConflicts: []string{"spec.timeout"},
+		},
+		{
+			name:          "list resized on both sides",
+			ours:          `{"metadata":{"name":"exp","labels":{"revision_id":"1"}},"spec":{"templates":[{"name":"a","args":["x"]}],"timeout":10}}`,
+			theirs:        `{"metadata":{"name":"exp","labels":{"revision_id":"1"}},"spec":{"templates":[{"name":"a","args":["x"]},{"name":"b"},{"name":"c"}],"timeout":10}}`,
+			wantConflicts: []string{"spec.templates"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, conflicts, err := mergeManifests(base, tc.ours, tc.theirs)
+			if err != nil {
+				t.Fatalf("mergeManifests() error = %v", err)
+			}
+			if !reflect.DeepEqual(conflicts, tc.wantConflicts) {
+				t.Fatalf("conflicts = %v, want %v", conflicts, tc.wantConflicts)
+			}
+			if tc.wantConflicts == nil && !equalManifests(got, tc.want) {
+				t.Errorf("mergeManifests() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
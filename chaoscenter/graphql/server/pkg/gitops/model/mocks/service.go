@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_infrastructure"
@@ -44,6 +45,18 @@ func (g *GitOpsService) GetGitOpsDetails(ctx context.Context, projectID string)
 	return args.Get(0).(*model.GitConfigResponse), args.Error(1)
 }
 
+// ListGitOpsConflicts provides a mock function with given fields: ctx, projectID
+func (g *GitOpsService) ListGitOpsConflicts(ctx context.Context, projectID string) ([]*model.GitOpsConflict, error) {
+	args := g.Called(ctx, projectID)
+	return args.Get(0).([]*model.GitOpsConflict), args.Error(1)
+}
+
+// ResolveGitOpsConflict provides a mock function with given fields: ctx, projectID, conflictID, resolution
+func (g *GitOpsService) ResolveGitOpsConflict(ctx context.Context, projectID, conflictID string, resolution model.GitOpsConflictResolution) (bool, error) {
+	args := g.Called(ctx, projectID, conflictID, resolution)
+	return args.Bool(0), args.Error(1)
+}
+
 // UpsertWorkflowToGit provides a mock function with given fields: ctx, experiment
 func (g *GitOpsService) UpsertExperimentToGit(ctx context.Context, projectID string, experiment *model.ChaosExperimentRequest) error {
 	args := g.Called(ctx, projectID, experiment)
@@ -56,6 +69,18 @@ func (g *GitOpsService) DeleteExperimentFromGit(ctx context.Context, projectID s
 	return args.Error(0)
 }
 
+// UpsertResourceToGit provides a mock function with given fields: ctx, projectID, kind, name, resource
+func (g *GitOpsService) UpsertResourceToGit(ctx context.Context, projectID string, kind gitops.ResourceKind, name string, resource interface{}) error {
+	args := g.Called(ctx, projectID, kind, name, resource)
+	return args.Error(0)
+}
+
+// DeleteResourceFromGit provides a mock function with given fields: ctx, projectID, kind, name
+func (g *GitOpsService) DeleteResourceFromGit(ctx context.Context, projectID string, kind gitops.ResourceKind, name string) error {
+	args := g.Called(ctx, projectID, kind, name)
+	return args.Error(0)
+}
+
 // GitOpsSyncHandler provides a mock function with given fields: singleRun
 func (g *GitOpsService) GitOpsSyncHandler(singleRun bool) {
 	g.Called(singleRun)
@@ -65,3 +90,9 @@ func (g *GitOpsService) SyncDBToGit(ctx context.Context, config gitops.GitConfig
 	args := g.Called(ctx, config)
 	return args.Error(0)
 }
+
+// GitOpsWebhookHandler provides a mock function with given fields: ctx, projectID, header, body
+func (g *GitOpsService) GitOpsWebhookHandler(ctx context.Context, projectID string, header http.Header, body []byte) (bool, error) {
+	args := g.Called(ctx, projectID, header, body)
+	return args.Bool(0), args.Error(1)
+}
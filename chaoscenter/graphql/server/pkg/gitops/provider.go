@@ -0,0 +1,235 @@
+package gitops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+)
+
+// PullRequestState is the state of the pull request of a GitOps change
+type PullRequestState string
+
+const (
+	PullRequestOpen   PullRequestState = "Open"
+	PullRequestMerged PullRequestState = "Merged"
+	PullRequestClosed PullRequestState = "Closed"
+)
+
+// providerTimeout bounds the requests to the APIs of the git hosting services
+const providerTimeout = 30 * time.Second
+
+// PullRequest is the pull request opened for a GitOps change
+type PullRequest struct {
+	Number int
+	URL    string
+}
+
+// PullRequestProvider opens the pull requests of the GitOps changes on a git hosting service and tracks them
+// until they are merged or closed
+type PullRequestProvider interface {
+	CreatePullRequest(ctx context.Context, branch, title, body string) (*PullRequest, error)
+	GetPullRequestState(ctx context.Context, number int) (PullRequestState, error)
+}
+
+// pullRequestProviders are the supported git hosting services, the providers of the new services are plugged
+// in here. They are built from the API URL, the path of the repository, its branch and the API token
+var pullRequestProviders = map[model.GitOpsProvider]func(apiURL, repository, branch, token string) PullRequestProvider{
+	model.GitOpsProviderGitHub: func(apiURL, repository, branch, token string) PullRequestProvider {
+		return &githubProvider{apiURL: apiURL, repository: repository, base: branch, authorization: "Bearer " + token}
+	},
+	// Gitea implements the pull request API of GitHub
+	model.GitOpsProviderGitea: func(apiURL, repository, branch, token string) PullRequestProvider {
+		return &githubProvider{apiURL: apiURL, repository: repository, base: branch, authorization: "token " + token}
+	},
+	model.GitOpsProviderGitLab: func(apiURL, repository, branch, token string) PullRequestProvider {
+		return &gitlabProvider{apiURL: apiURL, project: url.PathEscape(repository), base: branch, token: token}
+	},
+}
+
+// NewPullRequestProvider returns the pull request provider of the repository in GitConfig, which
+// authenticates to the API of the provider with the token or the password of the repository
+func NewPullRequestProvider(c GitConfig) (PullRequestProvider, error) {
+	if c.Provider == nil {
+		return nil, errors.New("provider is required by the pull request write mode")
+	}
+	newProvider, ok := pullRequestProviders[*c.Provider]
+	if !ok {
+		return nil, fmt.Errorf("unsupported provider %s", *c.Provider)
+	}
+
+	var token string
+	switch {
+	case c.AuthType == model.AuthTypeToken && c.Token != nil:
+		token = *c.Token
+	case c.AuthType == model.AuthTypeBasic && c.Password != nil:
+		token = *c.Password
+	default:
+		return nil, errors.New("pull request write mode needs the token or the basic authentication")
+	}
+
+	host, repository := splitRepositoryURL(c.RepositoryURL)
+	if host == "" || repository == "" {
+		return nil, fmt.Errorf("cannot get the repository path from %s", c.RepositoryURL)
+	}
+	apiURL := defaultAPIURL(*c.Provider, host)
+	if c.ProviderAPIURL != nil && *c.ProviderAPIURL != "" {
+		apiURL = strings.TrimSuffix(*c.ProviderAPIURL, "/")
+	}
+	return newProvider(apiURL, repository, c.Branch, token), nil
+}
+
+// defaultAPIURL returns the API URL of a provider hosted at the host of the repository
+func defaultAPIURL(provider model.GitOpsProvider, host string) string {
+	switch provider {
+	case model.GitOpsProviderGitHub:
+		if host == "github.com" {
+			return "https://api.github.com"
+		}
+		return "https://" + host + "/api/v3"
+	case model.GitOpsProviderGitLab:
+		return "https://" + host + "/api/v4"
+	}
+	return "https://" + host + "/api/v1"
+}
+
+// githubProvider opens pull requests with the REST API of GitHub, or of Gitea which is compatible
+type githubProvider struct {
+	apiURL        string
+	repository    string
+	base          string
+	authorization string
+}
+
+func (g *githubProvider) CreatePullRequest(ctx context.Context, branch, title, body string) (*PullRequest, error) {
+	request := map[string]string{
+		"title": title,
+		"head":  branch,
+		"base":  g.base,
+		"body":  body,
+	}
+	var response struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	err := callProviderAPI(ctx, http.MethodPost, g.apiURL+"/repos/"+g.repository+"/pulls", g.header(), request, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &PullRequest{Number: response.Number, URL: response.HTMLURL}, nil
+}
+
+func (g *githubProvider) GetPullRequestState(ctx context.Context, number int) (PullRequestState, error) {
+	var response struct {
+		State  string `json:"state"`
+		Merged bool   `json:"merged"`
+	}
+	err := callProviderAPI(ctx, http.MethodGet, fmt.Sprintf("%s/repos/%s/pulls/%d", g.apiURL, g.repository, number), g.header(), nil, &response)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case response.Merged:
+		return PullRequestMerged, nil
+	case response.State == "closed":
+		return PullRequestClosed, nil
+	}
+	return PullRequestOpen, nil
+}
+
+func (g *githubProvider) header() http.Header {
+	return http.Header{"Authorization": []string{g.authorization}}
+}
+
+// gitlabProvider opens merge requests with the REST API of GitLab
+type gitlabProvider struct {
+	apiURL  string
+	project string
+	base    string
+	token   string
+}
+
+func (g *gitlabProvider) CreatePullRequest(ctx context.Context, branch, title, body string) (*PullRequest, error) {
+	request := map[string]interface{}{
+		"source_branch":        branch,
+		"target_branch":        g.base,
+		"title":                title,
+		"description":          body,
+		"remove_source_branch": true,
+	}
+	var response struct {
+		IID    int    `json:"iid"`
+		WebURL string `json:"web_url"`
+	}
+	err := callProviderAPI(ctx, http.MethodPost, g.apiURL+"/projects/"+g.project+"/merge_requests", g.header(), request, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &PullRequest{Number: response.IID, URL: response.WebURL}, nil
+}
+
+func (g *gitlabProvider) GetPullRequestState(ctx context.Context, number int) (PullRequestState, error) {
+	var response struct {
+		State string `json:"state"`
+	}
+	err := callProviderAPI(ctx, http.MethodGet, fmt.Sprintf("%s/projects/%s/merge_requests/%d", g.apiURL, g.project, number), g.header(), nil, &response)
+	if err != nil {
+		return "", err
+	}
+	switch response.State {
+	case "merged":
+		return PullRequestMerged, nil
+	case "closed":
+		return PullRequestClosed, nil
+	}
+	return PullRequestOpen, nil
+}
+
+func (g *gitlabProvider) header() http.Header {
+	return http.Header{"Private-Token": []string{g.token}}
+}
+
+// callProviderAPI sends a JSON request to the API of a git hosting service and decodes its JSON response
+func callProviderAPI(ctx context.Context, method, url string, header http.Header, request, response interface{}) error {
+	var body io.Reader
+	if request != nil {
+		data, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, providerTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	if request != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s %s failed with status %d: %s", method, url, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
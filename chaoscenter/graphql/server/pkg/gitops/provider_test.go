@@ -0,0 +1,211 @@
+package gitops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/gitops"
+)
+
+func TestNewPullRequestProvider(t *testing.T) {
+	var (
+		token  = "token"
+		github = model.GitOpsProviderGitHub
+		gitlab = model.GitOpsProviderGitLab
+	)
+	testcases := []struct {
+		name    string
+		config  GitConfig
+		wantURL string
+		wantErr bool
+	}{
+		{
+			name:    "github.com",
+			config:  GitConfig{RepositoryURL: "https://github.com/org/repo.git", Provider: &github, AuthType: model.AuthTypeToken, Token: &token},
+			wantURL: "https://api.github.com",
+		},
+		{
+			name:    "self-hosted gitlab",
+			config:  GitConfig{RepositoryURL: "https://gitlab.example.com/group/repo.git", Provider: &gitlab, AuthType: model.AuthTypeBasic, Password: &token},
+			wantURL: "https://gitlab.example.com/api/v4",
+		},
+		{
+			name:    "without provider",
+			config:  GitConfig{RepositoryURL: "https://github.com/org/repo.git", AuthType: model.AuthTypeToken, Token: &token},
+			wantErr: true,
+		},
+		{
+			name:    "ssh authentication",
+			config:  GitConfig{RepositoryURL: "git@github.com:org/repo.git", Provider: &github, AuthType: model.AuthTypeSSH},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider, err := NewPullRequestProvider(tc.config)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewPullRequestProvider() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var apiURL string
+			switch p := provider.(type) {
+			case *githubProvider:
+				apiURL = p.apiURL
+			case *gitlabProvider:
+				apiURL = p.apiURL
+			}
+			if apiURL != tc.wantURL {
+				t.Errorf("API URL = %s, want %s", apiURL, tc.wantURL)
+			}
+		})
+	}
+}
+
+func TestGitHubProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/org/repo/pulls":
+			var request map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request["head"] != "feature" || request["base"] != "main" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"number":7,"html_url":"https://github.com/org/repo/pull/7"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/org/repo/pulls/7":
+			w.Write([]byte(`{"state":"closed","merged":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	github := model.GitOpsProviderGitHub
+	token := "token"
+	provider, err := NewPullRequestProvider(GitConfig{
+		RepositoryURL:  "https://github.com/org/repo.git",
+		Branch:         "main",
+		Provider:       &github,
+		ProviderAPIURL: &server.URL,
+		AuthType:       model.AuthTypeToken,
+		Token:          &token,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pullRequest, err := provider.CreatePullRequest(context.Background(), "feature", "title", "body")
+	if err != nil {
+		t.Fatalf("CreatePullRequest() error = %v", err)
+	}
+	if pullRequest.Number != 7 || pullRequest.URL != "https://github.com/org/repo/pull/7" {
+		t.Errorf("unexpected pull request %+v", pullRequest)
+	}
+	state, err := provider.GetPullRequestState(context.Background(), 7)
+	if err != nil || state != PullRequestMerged {
+		t.Errorf("GetPullRequestState() = %s, %v, want %s", state, err, PullRequestMerged)
+	}
+	if _, err := provider.GetPullRequestState(context.Background(), 8); err == nil {
+		t.Error("GetPullRequestState() of a missing pull request has to fail")
+	}
+}
+
+func TestGitLabProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Private-Token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.EscapedPath() == "/projects/group%2Frepo/merge_requests":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"iid":3,"web_url":"https://gitlab.com/group/repo/-/merge_requests/3"}`))
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == "/projects/group%2Frepo/merge_requests/3":
+			w.Write([]byte(`{"state":"opened"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	gitlab := model.GitOpsProviderGitLab
+	token := "token"
+	provider, err := NewPullRequestProvider(GitConfig{
+		RepositoryURL:  "git@gitlab.com:group/repo.git",
+		Branch:         "main",
+		Provider:       &gitlab,
+		ProviderAPIURL: &server.URL,
+		AuthType:       model.AuthTypeToken,
+		Token:          &token,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pullRequest, err := provider.CreatePullRequest(context.Background(), "feature", "title", "body")
+	if err != nil {
+		t.Fatalf("CreatePullRequest() error = %v", err)
+	}
+	if pullRequest.Number != 3 {
+		t.Errorf("unexpected merge request %+v", pullRequest)
+	}
+	state, err := provider.GetPullRequestState(context.Background(), 3)
+	if err != nil || state != PullRequestOpen {
+		t.Errorf("GetPullRequestState() = %s, %v, want %s", state, err, PullRequestOpen)
+	}
+}
+
+func TestResolvedPendingChanges(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/org/repo/pulls/1":
+			w.Write([]byte(`{"state":"open","merged":false}`))
+		case "/repos/org/repo/pulls/2":
+			w.Write([]byte(`{"state":"closed","merged":true}`))
+		case "/repos/org/repo/pulls/3":
+			w.Write([]byte(`{"state":"closed","merged":false}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	github := model.GitOpsProviderGitHub
+	token := "token"
+	config := GitConfig{
+		RepositoryURL:  "https://github.com/org/repo.git",
+		Branch:         "main",
+		Provider:       &github,
+		ProviderAPIURL: &server.URL,
+		AuthType:       model.AuthTypeToken,
+		Token:          &token,
+	}
+	changes := []gitops.PendingChange{
+		{Branch: "open", PullRequestNumber: 1},
+		{Branch: "merged", PullRequestNumber: 2},
+		{Branch: "closed", PullRequestNumber: 3},
+	}
+
+	branches, closed, err := resolvedPendingChanges(config, changes)
+	if err != nil {
+		t.Fatalf("resolvedPendingChanges() error = %v", err)
+	}
+	if !reflect.DeepEqual(branches, []string{"merged", "closed"}) {
+		t.Errorf("resolvedPendingChanges() branches = %v, want the merged and the closed ones", branches)
+	}
+	// the closed changes aren't in the repo, unlike the merged ones
+	if len(closed) != 1 || closed[0].Branch != "closed" {
+		t.Errorf("resolvedPendingChanges() closed = %v, want the change closed without being merged", closed)
+	}
+}
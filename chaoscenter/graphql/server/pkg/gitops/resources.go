@@ -0,0 +1,351 @@
+package gitops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang-jwt/jwt"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/authorization"
+	dbSchemaProbe "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/probe"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/utils"
+)
+
+// ResourceKind is a kind of project resource synced with the GitOps repository along with the experiments.
+// Its value is the directory of the resources in the project directory of the repository:
+//
+//	litmus/<projectID>/<experiment name>.yaml
+//	litmus/<projectID>/probes/<probe name>.yaml
+//	litmus/<projectID>/environments/<environment ID>.yaml
+//	litmus/<projectID>/chaos-hubs/<chaos hub name>.yaml
+//	litmus/<projectID>/image-registry/image-registry.yaml
+type ResourceKind string
+
+const (
+	ResourceKindProbe         ResourceKind = "probes"
+	ResourceKindEnvironment   ResourceKind = "environments"
+	ResourceKindChaosHub      ResourceKind = "chaos-hubs"
+	ResourceKindImageRegistry ResourceKind = "image-registry"
+)
+
+// ImageRegistryResourceName is the name of the image registry file, a project has a single image registry
+const ImageRegistryResourceName = "image-registry"
+
+// gitOpsUsername is the user recorded as the actor of the changes synced from the repositories
+const gitOpsUsername = "git-ops"
+
+var resourceKinds = []ResourceKind{ResourceKindProbe, ResourceKindEnvironment, ResourceKindChaosHub, ResourceKindImageRegistry}
+
+// chaosHubResource is the chaos hub file, the credentials of the private hubs are never pushed to the
+// repository so they have to be set in ChaosCenter
+type chaosHubResource struct {
+	Name        string         `json:"name"`
+	Description *string        `json:"description,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	HubType     model.HubType  `json:"hubType"`
+	RepoURL     string         `json:"repoURL"`
+	RepoBranch  string         `json:"repoBranch,omitempty"`
+	IsPrivate   bool           `json:"isPrivate"`
+	AuthType    model.AuthType `json:"authType,omitempty"`
+}
+
+// newResource returns the file model of the resource kind, which is the GraphQL input of its creation
+func newResource(kind ResourceKind) (interface{}, error) {
+	switch kind {
+	case ResourceKindProbe:
+		return &model.ProbeRequest{}, nil
+	case ResourceKindEnvironment:
+		return &model.CreateEnvironmentRequest{}, nil
+	case ResourceKindChaosHub:
+		return &chaosHubResource{}, nil
+	case ResourceKindImageRegistry:
+		return &model.ImageRegistryInput{}, nil
+	}
+	return nil, fmt.Errorf("unsupported resource kind %s", kind)
+}
+
+// resourcePath returns the path of the resource file relative to the project directory
+func resourcePath(kind ResourceKind, name string) (string, error) {
+	if err := validateFileName(name); err != nil {
+		return "", err
+	}
+	return string(kind) + "/" + name + ".yaml", nil
+}
+
+// validateFileName checks that the name of an experiment or a resource can be used as the name of its file,
+// so that it can't refer to a file out of its directory
+func validateFileName(name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") {
+		return fmt.Errorf("invalid name %q, the names synced with git can't be empty or contain /, \\ or ..", name)
+	}
+	return nil
+}
+
+// projectFilePath returns the path in the local repo of a file of the project directory, it fails if the
+// path is out of the project directory
+func projectFilePath(config GitConfig, file string) (string, error) {
+	projectDir := filepath.Join(config.LocalPath, ProjectDataPath, config.ProjectID)
+	path := filepath.Join(projectDir, file)
+	if !strings.HasPrefix(path, projectDir+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid file %s, it is out of the project directory", file)
+	}
+	return path, nil
+}
+
+// resourceOf returns the kind and name of the resource of a file changed in the repository, it returns
+// false for the experiment files
+func resourceOf(file, projectID string) (ResourceKind, string, bool) {
+	relativePath := strings.TrimPrefix(file, ProjectDataPath+"/"+projectID+"/")
+	dir, fileName := filepath.Split(relativePath)
+	for _, kind := range resourceKinds {
+		if dir == string(kind)+"/" {
+			return kind, strings.TrimSuffix(fileName, ".yaml"), true
+		}
+	}
+	return "", "", false
+}
+
+// encodeResource returns the YAML file of a resource, the resource can be any model with the JSON fields
+// of the file model of its kind, the other fields are dropped
+func encodeResource(kind ResourceKind, resource interface{}) ([]byte, error) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	file, err := newResource(kind)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, err
+	}
+	data, err = json.Marshal(file)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.JSONToYAML(data)
+}
+
+// gitOpsContext returns a context authenticated as the git-ops user, as the services read the actor of the
+// changes from the JWT of the request. The token is short-lived and never leaves the server
+func gitOpsContext() (context.Context, error) {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS512, jwt.MapClaims{
+		"username": gitOpsUsername,
+		"exp":      time.Now().Add(time.Minute).Unix(),
+	}).SignedString([]byte(utils.Config.JwtSecret))
+	if err != nil {
+		return nil, err
+	}
+	return context.WithValue(context.Background(), authorization.AuthKey, token), nil
+}
+
+// UpsertResourceToGit adds/updates a probe, environment, chaos hub or image registry in git
+func (g *gitOpsService) UpsertResourceToGit(ctx context.Context, projectID string, kind ResourceKind, name string, resource interface{}) error {
+	data, err := encodeResource(kind, resource)
+	if err != nil {
+		return errors.New("Cannot convert resource to yaml : " + err.Error())
+	}
+	file, err := resourcePath(kind, name)
+	if err != nil {
+		return err
+	}
+	return g.commitFileToGit(ctx, projectID, file, data, "Updated "+resourceTitle(kind)+" : "+name, nil)
+}
+
+// DeleteResourceFromGit deletes a probe, environment, chaos hub or image registry from git
+func (g *gitOpsService) DeleteResourceFromGit(ctx context.Context, projectID string, kind ResourceKind, name string) error {
+	file, err := resourcePath(kind, name)
+	if err != nil {
+		return err
+	}
+	return g.commitFileToGit(ctx, projectID, file, nil, "Deleted "+resourceTitle(kind)+" : "+name, nil)
+}
+
+func resourceTitle(kind ResourceKind) string {
+	switch kind {
+	case ResourceKindProbe:
+		return "Probe"
+	case ResourceKindEnvironment:
+		return "Environment"
+	case ResourceKindChaosHub:
+		return "ChaosHub"
+	}
+	return "Image Registry"
+}
+
+// syncResource applies the change of a resource file to the DB during the SyncDBToGit operation
+func (g *gitOpsService) syncResource(kind ResourceKind, name, file string, config GitConfig) error {
+	ctx, err := gitOpsContext()
+	if err != nil {
+		return err
+	}
+
+	exists, err := PathExists(config.LocalPath + "/" + file)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return g.deleteResource(ctx, kind, name, config.ProjectID)
+	}
+
+	data, err := os.ReadFile(config.LocalPath + "/" + file)
+	if err != nil {
+		return err
+	}
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return err
+	}
+	resource, err := newResource(kind)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, resource); err != nil {
+		return err
+	}
+	return g.upsertResource(ctx, kind, name, resource, config.ProjectID)
+}
+
+func (g *gitOpsService) upsertResource(ctx context.Context, kind ResourceKind, name string, resource interface{}, projectID string) error {
+	switch r := resource.(type) {
+	case *model.ProbeRequest:
+		if r.Name != name {
+			return errors.New("file name doesn't match probe name")
+		}
+		if _, err := dbSchemaProbe.GetProbeByName(ctx, name, projectID); err != nil {
+			_, err = g.probeService.AddProbe(ctx, *r, projectID)
+			return err
+		}
+		_, err := g.probeService.UpdateProbe(ctx, *r, projectID)
+		return err
+
+	case *model.CreateEnvironmentRequest:
+		if r.EnvironmentID != name {
+			return errors.New("file name doesn't match environment ID")
+		}
+		if _, err := g.environmentService.GetEnvironment(projectID, name); err != nil {
+			_, err = g.environmentService.CreateEnvironment(ctx, projectID, r)
+			return err
+		}
+		tags := make([]*string, len(r.Tags))
+		for i := range r.Tags {
+			tags[i] = &r.Tags[i]
+		}
+		_, err := g.environmentService.UpdateEnvironment(ctx, projectID, &model.UpdateEnvironmentRequest{
+			EnvironmentID: r.EnvironmentID,
+			Name:          &r.Name,
+			Description:   r.Description,
+			Tags:          tags,
+			Type:          &r.Type,
+		})
+		return err
+
+	case *chaosHubResource:
+		if r.Name != name {
+			return errors.New("file name doesn't match chaos hub name")
+		}
+		existingHub, err := g.getChaosHubByName(ctx, name, projectID)
+		if err != nil {
+			return err
+		}
+		if existingHub == nil && (r.HubType == model.HubTypeRemote || r.HubType == model.HubTypeOci) {
+			_, err = g.chaosHubService.AddRemoteChaosHub(ctx, model.CreateRemoteChaosHub{
+				Name:        r.Name,
+				Tags:        r.Tags,
+				Description: r.Description,
+				RepoURL:     r.RepoURL,
+				HubType:     &r.HubType,
+			}, projectID)
+			return err
+		}
+		if existingHub == nil {
+			_, err = g.chaosHubService.AddChaosHub(ctx, model.CreateChaosHubRequest{
+				Name:        r.Name,
+				Tags:        r.Tags,
+				Description: r.Description,
+				RepoURL:     r.RepoURL,
+				RepoBranch:  r.RepoBranch,
+				IsPrivate:   r.IsPrivate,
+				AuthType:    r.AuthType,
+			}, projectID)
+			return err
+		}
+		// the credentials of the hub are kept as they aren't part of the file
+		_, err = g.chaosHubService.UpdateChaosHub(ctx, model.UpdateChaosHubRequest{
+			ID:            existingHub.ID,
+			Name:          r.Name,
+			Description:   r.Description,
+			Tags:          r.Tags,
+			RepoURL:       r.RepoURL,
+			RepoBranch:    r.RepoBranch,
+			IsPrivate:     r.IsPrivate,
+			AuthType:      r.AuthType,
+			Token:         existingHub.Token,
+			UserName:      existingHub.UserName,
+			Password:      existingHub.Password,
+			SSHPrivateKey: existingHub.SSHPrivateKey,
+			SSHPublicKey:  existingHub.SSHPublicKey,
+		}, projectID)
+		return err
+
+	case *model.ImageRegistryInput:
+		existingRegistry, err := g.imageRegistryService.GetImageRegistry(ctx, projectID)
+		if err != nil || existingRegistry == nil || (existingRegistry.IsRemoved != nil && *existingRegistry.IsRemoved) {
+			_, err = g.imageRegistryService.CreateImageRegistry(ctx, projectID, *r)
+			return err
+		}
+		_, err = g.imageRegistryService.UpdateImageRegistry(ctx, existingRegistry.ImageRegistryID, projectID, *r)
+		return err
+	}
+	return fmt.Errorf("unsupported resource kind %s", kind)
+}
+
+func (g *gitOpsService) deleteResource(ctx context.Context, kind ResourceKind, name, projectID string) error {
+	switch kind {
+	case ResourceKindProbe:
+		_, err := g.probeService.DeleteProbe(ctx, name, projectID)
+		return err
+
+	case ResourceKindEnvironment:
+		_, err := g.environmentService.DeleteEnvironment(ctx, projectID, name)
+		return err
+
+	case ResourceKindChaosHub:
+		existingHub, err := g.getChaosHubByName(ctx, name, projectID)
+		if err != nil || existingHub == nil {
+			return err
+		}
+		_, err = g.chaosHubService.DeleteChaosHub(ctx, existingHub.ID, projectID)
+		return err
+
+	case ResourceKindImageRegistry:
+		existingRegistry, err := g.imageRegistryService.GetImageRegistry(ctx, projectID)
+		if err != nil {
+			return err
+		}
+		_, err = g.imageRegistryService.DeleteImageRegistry(ctx, existingRegistry.ImageRegistryID, projectID)
+		return err
+	}
+	return fmt.Errorf("unsupported resource kind %s", kind)
+}
+
+// getChaosHubByName returns the chaos hub of the project with the name, or nil if there is none
+func (g *gitOpsService) getChaosHubByName(ctx context.Context, name, projectID string) (*model.ChaosHubStatus, error) {
+	hubs, err := g.chaosHubService.ListChaosHubs(ctx, projectID, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, hub := range hubs {
+		if hub.Name == name && !hub.IsDefault && !hub.IsRemoved {
+			return hub, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,104 @@
+package gitops
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+)
+
+func TestResourceOf(t *testing.T) {
+	testcases := []struct {
+		file     string
+		wantKind ResourceKind
+		wantName string
+		wantOk   bool
+	}{
+		{"litmus/project-id/probes/http-probe.yaml", ResourceKindProbe, "http-probe", true},
+		{"litmus/project-id/environments/staging.yaml", ResourceKindEnvironment, "staging", true},
+		{"litmus/project-id/chaos-hubs/enterprise-hub.yaml", ResourceKindChaosHub, "enterprise-hub", true},
+		{"litmus/project-id/image-registry/image-registry.yaml", ResourceKindImageRegistry, ImageRegistryResourceName, true},
+		{"litmus/project-id/pod-delete.yaml", "", "", false},
+		{"litmus/project-id/nested/probes/http-probe.yaml", "", "", false},
+	}
+	for _, tc := range testcases {
+		kind, name, ok := resourceOf(tc.file, "project-id")
+		if kind != tc.wantKind || name != tc.wantName || ok != tc.wantOk {
+			t.Errorf("resourceOf(%s) = %s, %s, %v, want %s, %s, %v", tc.file, kind, name, ok, tc.wantKind, tc.wantName, tc.wantOk)
+		}
+	}
+}
+
+func TestEncodeChaosHubResource(t *testing.T) {
+	token := "secret-token"
+	description := "hub of the team"
+	data, err := encodeResource(ResourceKindChaosHub, &model.ChaosHub{
+		ID:          "hub-id",
+		Name:        "enterprise-hub",
+		Description: &description,
+		RepoURL:     "https://github.com/org/chaos-charts",
+		RepoBranch:  "master",
+		HubType:     model.HubTypeGit,
+		IsPrivate:   true,
+		AuthType:    model.AuthTypeToken,
+		Token:       &token,
+	})
+	if err != nil {
+		t.Fatalf("encodeResource() error = %v", err)
+	}
+
+	file := string(data)
+	for _, want := range []string{"name: enterprise-hub", "hubType: GIT", "repoBranch: master", "authType: TOKEN"} {
+		if !strings.Contains(file, want) {
+			t.Errorf("chaos hub file doesn't contain %q:\n%s", want, file)
+		}
+	}
+	for _, unwanted := range []string{token, "hub-id"} {
+		if strings.Contains(file, unwanted) {
+			t.Errorf("chaos hub file contains %q:\n%s", unwanted, file)
+		}
+	}
+}
+
+func TestResourcePath(t *testing.T) {
+	testcases := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "http-probe", want: "probes/http-probe.yaml"},
+		{name: "probe.v2", want: "probes/probe.v2.yaml"},
+		{name: "", wantErr: true},
+		{name: "../../etc", wantErr: true},
+		{name: "..", wantErr: true},
+		{name: "nested/probe", wantErr: true},
+		{name: `..\probe`, wantErr: true},
+	}
+	for _, tc := range testcases {
+		got, err := resourcePath(ResourceKindProbe, tc.name)
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Errorf("resourcePath(%q) = %q, %v, want %q, error %v", tc.name, got, err, tc.want, tc.wantErr)
+		}
+	}
+}
+
+func TestProjectFilePath(t *testing.T) {
+	config := GitConfig{ProjectID: "project-id", LocalPath: "/tmp/gitops/project-id"}
+	testcases := []struct {
+		file    string
+		want    string
+		wantErr bool
+	}{
+		{file: "pod-delete.yaml", want: "/tmp/gitops/project-id/litmus/project-id/pod-delete.yaml"},
+		{file: "probes/http-probe.yaml", want: "/tmp/gitops/project-id/litmus/project-id/probes/http-probe.yaml"},
+		{file: "../other-project/pod-delete.yaml", wantErr: true},
+		{file: "../../../../etc.yaml", wantErr: true},
+		{file: ".", wantErr: true},
+	}
+	for _, tc := range testcases {
+		got, err := projectFilePath(config, tc.file)
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Errorf("projectFilePath(%q) = %q, %v, want %q, error %v", tc.file, got, err, tc.want, tc.wantErr)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -12,30 +13,39 @@ import (
 	"time"
 
 	"github.com/ghodss/yaml"
+	"github.com/google/uuid"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
 	chaosExperimentOps "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/chaos_experiment/ops"
 	chaosInfra "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/chaos_infrastructure"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/chaoshub"
 	dataStore "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/data-store"
 	store "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/data-store"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_experiment"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_infrastructure"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/gitops"
+	environment "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/environment/handler"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/grpc"
+	imageRegistry "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/image_registry"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/metrics"
+	probe "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/probe/handler"
 	"github.com/sirupsen/logrus"
-	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 	"go.mongodb.org/mongo-driver/bson"
-	grpc2 "google.golang.org/grpc"
 )
 
 const (
 	timeout  = time.Second * 5
 	tempPath = "/tmp/gitops_test/"
+	// DefaultPollInterval is the interval in seconds at which the repositories are polled by default
+	DefaultPollInterval = 120
+	// MinPollInterval is the shortest interval in seconds at which a repository may be polled
+	MinPollInterval = 30
 )
 
 var (
 	gitLock           = NewGitLock()
+	webhookSyncs      = NewSyncQueue()
 	backgroundContext = context.Background()
 )
 
@@ -45,9 +55,14 @@ type Service interface {
 	DisableGitOpsHandler(ctx context.Context, projectID string) (bool, error)
 	UpdateGitOpsDetailsHandler(ctx context.Context, projectID string, config model.GitConfig) (bool, error)
 	GetGitOpsDetails(ctx context.Context, projectID string) (*model.GitConfigResponse, error)
+	ListGitOpsConflicts(ctx context.Context, projectID string) ([]*model.GitOpsConflict, error)
+	ResolveGitOpsConflict(ctx context.Context, projectID, conflictID string, resolution model.GitOpsConflictResolution) (bool, error)
 	UpsertExperimentToGit(ctx context.Context, projectID string, experiment *model.ChaosExperimentRequest) error
 	DeleteExperimentFromGit(ctx context.Context, projectID string, experiment *model.ChaosExperimentRequest) error
+	UpsertResourceToGit(ctx context.Context, projectID string, kind ResourceKind, name string, resource interface{}) error
+	DeleteResourceFromGit(ctx context.Context, projectID string, kind ResourceKind, name string) error
 	GitOpsSyncHandler(singleRun bool)
+	GitOpsWebhookHandler(ctx context.Context, projectID string, header http.Header, body []byte) (bool, error)
 	SyncDBToGit(ctx context.Context, config GitConfig) error
 }
 
@@ -55,14 +70,23 @@ type gitOpsService struct {
 	gitOpsOperator         *gitops.Operator
 	chaosExperimentOps     chaos_experiment.Operator
 	chaosExperimentService chaosExperimentOps.Service
+	probeService           probe.Service
+	environmentService     environment.EnvironmentHandler
+	imageRegistryService   imageRegistry.Service
+	chaosHubService        chaoshub.Service
 }
 
 // NewGitOpsService returns a new instance of a gitOpsService
-func NewGitOpsService(gitOpsOperator *gitops.Operator, chaosExperimentService chaosExperimentOps.Service, chaosExperimentOps chaos_experiment.Operator) Service {
+func NewGitOpsService(gitOpsOperator *gitops.Operator, chaosExperimentService chaosExperimentOps.Service, chaosExperimentOps chaos_experiment.Operator,
+	probeService probe.Service, environmentService environment.EnvironmentHandler, imageRegistryService imageRegistry.Service, chaosHubService chaoshub.Service) Service {
 	return &gitOpsService{
 		gitOpsOperator:         gitOpsOperator,
 		chaosExperimentService: chaosExperimentService,
 		chaosExperimentOps:     chaosExperimentOps,
+		probeService:           probeService,
+		environmentService:     environmentService,
+		imageRegistryService:   imageRegistryService,
+		chaosHubService:        chaosHubService,
 	}
 }
 
@@ -113,21 +137,24 @@ func (g *gitOpsService) EnableGitOpsHandler(ctx context.Context, projectID strin
 	gitLock.Lock(config.RepoURL, &config.Branch)
 	defer gitLock.Unlock(config.RepoURL, &config.Branch)
 
-	var conn *grpc2.ClientConn
-	client, conn := grpc.GetAuthGRPCSvcClient(conn)
-	defer func() {
-		if err := conn.Close(); err != nil {
-			log.Warnf("failed to close connection: %v", err)
-		}
-	}()
-
-	_, err := grpc.GetProjectById(client, projectID)
+	_, err := grpc.GetProjectById(grpc.GetAuthGRPCSvcClient(), projectID)
 	if err != nil {
 		return false, errors.New("Failed to setup GitOps : " + err.Error())
 	}
 
+	if err := validatePollInterval(config.PollInterval); err != nil {
+		return false, err
+	}
+
 	logrus.Info("Enabling GitOps")
 	gitDB := gitops.GetGitConfigDB(projectID, config)
+	if err := validateWriteMode(gitDB); err != nil {
+		return false, err
+	}
+	gitDB.WebhookSecret, err = NewWebhookSecret()
+	if err != nil {
+		return false, errors.New("Failed to generate webhook secret : " + err.Error())
+	}
 
 	commit, err := SetupGitOps(GitUserFromContext(ctx), GetGitOpsConfig(gitDB))
 	if err != nil {
@@ -177,9 +204,31 @@ func (g *gitOpsService) UpdateGitOpsDetailsHandler(ctx context.Context, projectI
 	if existingConfig == nil {
 		return false, errors.New("GitOps Disabled ")
 	}
+	if err := validatePollInterval(config.PollInterval); err != nil {
+		return false, err
+	}
 
 	logrus.Info("Enabling GitOps")
 	gitDB := gitops.GetGitConfigDB(projectID, config)
+	if err := validateWriteMode(gitDB); err != nil {
+		return false, err
+	}
+	// the pull requests of the pending changes are still tracked if they target the same branch
+	if gitDB.WriteMode == model.GitOpsWriteModePullRequest && gitDB.RepositoryURL == existingConfig.RepositoryURL && gitDB.Branch == existingConfig.Branch {
+		gitDB.PendingChanges = existingConfig.PendingChanges
+	}
+	// the conflicts are resolved against the same branch
+	if gitDB.RepositoryURL == existingConfig.RepositoryURL && gitDB.Branch == existingConfig.Branch {
+		gitDB.Conflicts = existingConfig.Conflicts
+	}
+	// the webhooks configured in the git provider keep working after the update
+	gitDB.WebhookSecret = existingConfig.WebhookSecret
+	if gitDB.WebhookSecret == "" {
+		gitDB.WebhookSecret, err = NewWebhookSecret()
+		if err != nil {
+			return false, errors.New("Failed to generate webhook secret : " + err.Error())
+		}
+	}
 
 	gitConfig := GetGitOpsConfig(gitDB)
 	originalPath := gitConfig.LocalPath
@@ -221,12 +270,33 @@ func (g *gitOpsService) GetGitOpsDetails(ctx context.Context, projectID string)
 			Enabled:   false,
 		}, nil
 	}
+	pollInterval := pollIntervalOf(*config)
 	resp := model.GitConfigResponse{
-		Enabled:   true,
-		ProjectID: config.ProjectID,
-		Branch:    &config.Branch,
-		RepoURL:   &config.RepositoryURL,
-		AuthType:  &config.AuthType,
+		Enabled:        true,
+		ProjectID:      config.ProjectID,
+		Branch:         &config.Branch,
+		RepoURL:        &config.RepositoryURL,
+		AuthType:       &config.AuthType,
+		PollInterval:   &pollInterval,
+		WebhookSecret:  &config.WebhookSecret,
+		WriteMode:      &config.WriteMode,
+		Provider:       config.Provider,
+		ProviderAPIURL: config.ProviderAPIURL,
+	}
+	if config.WriteMode == "" {
+		writeMode := model.GitOpsWriteModeDirectPush
+		resp.WriteMode = &writeMode
+	}
+	for _, change := range config.PendingChanges {
+		resp.PendingChanges = append(resp.PendingChanges, change.GetOutputPendingChange())
+	}
+	if config.LastSyncAt != 0 {
+		lastSyncTime := strconv.FormatInt(config.LastSyncAt, 10)
+		resp.LastSyncTime = &lastSyncTime
+		resp.LastSyncStatus = &config.LastSyncStatus
+	}
+	if config.LastSyncError != "" {
+		resp.LastSyncError = &config.LastSyncError
 	}
 	switch config.AuthType {
 
@@ -245,6 +315,31 @@ func (g *gitOpsService) GetGitOpsDetails(ctx context.Context, projectID string)
 
 // UpsertExperimentToGit adds/updates experiment to git
 func (g *gitOpsService) UpsertExperimentToGit(ctx context.Context, projectID string, experiment *model.ChaosExperimentRequest) error {
+	data, err := yaml.JSONToYAML([]byte(experiment.ExperimentManifest))
+	if err != nil {
+		return errors.New("Cannot convert manifest to yaml : " + err.Error())
+	}
+	if err := validateFileName(experiment.ExperimentName); err != nil {
+		return err
+	}
+	return g.commitFileToGit(ctx, projectID, experiment.ExperimentName+".yaml", data, "Updated Experiment : "+experiment.ExperimentName, mergeWithGit(projectID, experiment))
+}
+
+// DeleteExperimentFromGit deletes experiment from git
+func (g *gitOpsService) DeleteExperimentFromGit(ctx context.Context, projectID string, experiment *model.ChaosExperimentRequest) error {
+	logrus.Info("Deleting Experiment...")
+	if err := validateFileName(experiment.ExperimentName); err != nil {
+		return err
+	}
+	return g.commitFileToGit(ctx, projectID, experiment.ExperimentName+".yaml", nil, "Deleted Experiment : "+experiment.ExperimentName, nil)
+}
+
+// commitFileToGit writes a file of the project directory, or deletes it if the data is nil, and pushes the
+// change to git after syncing the DB with the repo. In the PullRequest write mode the change is pushed to a
+// new branch and stays pending until its pull request is merged. The optional merge function merges the data
+// with the file in the synced repo, it returns nil to write the data as is
+func (g *gitOpsService) commitFileToGit(ctx context.Context, projectID, file string, data []byte, message string,
+	merge func(config gitops.GitConfigDB, current []byte) ([]byte, error)) error {
 	gitLock.Lock(projectID, nil)
 	defer gitLock.Unlock(projectID, nil)
 	config, err := g.gitOpsOperator.GetGitConfig(ctx, projectID)
@@ -264,26 +359,89 @@ func (g *gitOpsService) UpsertExperimentToGit(ctx context.Context, projectID str
 		return errors.New("Sync Error | " + err.Error())
 	}
 
-	experimentPath := gitConfig.LocalPath + "/" + ProjectDataPath + "/" + gitConfig.ProjectID + "/" + experiment.ExperimentName + ".yaml"
-
-	data, err := yaml.JSONToYAML([]byte(experiment.ExperimentManifest))
+	filePath := ProjectDataPath + "/" + gitConfig.ProjectID + "/" + file
+	// the file is written and deleted only if it is in the project directory
+	localPath, err := projectFilePath(gitConfig, file)
 	if err != nil {
-		return errors.New("Cannot convert manifest to yaml : " + err.Error())
+		return err
+	}
+	if merge != nil && data != nil {
+		current, err := os.ReadFile(localPath)
+		if err != nil && !os.IsNotExist(err) {
+			return errors.New("Cannot read " + file + " from git : " + err.Error())
+		}
+		merged, err := merge(*config, current)
+		if err != nil {
+			return err
+		}
+		if merged != nil {
+			data = merged
+		}
 	}
 
-	err = os.WriteFile(experimentPath, data, 0644)
+	head, err := gitConfig.GitHead()
 	if err != nil {
-		return errors.New("Cannot write experiment to git : " + err.Error())
+		return errors.New("Cannot get the head of the repo : " + err.Error())
 	}
 
-	commit, err := gitConfig.GitCommit(GitUserFromContext(ctx), "Updated Experiment : "+experiment.ExperimentName, nil)
+	var branch string
+	if gitConfig.WriteMode == model.GitOpsWriteModePullRequest {
+		branch = "litmus/gitops-" + uuid.New().String()
+		err = gitConfig.GitCreateBranch(branch)
+		if err != nil {
+			return errors.New("Cannot create branch " + branch + " : " + err.Error())
+		}
+		defer func() {
+			if err := gitConfig.GitRestoreBranch(branch); err != nil {
+				logrus.Error("Cannot restore branch ", gitConfig.Branch, " of repo : ", gitConfig.ProjectID, err.Error())
+			}
+		}()
+	}
+
+	var deleteFile *string
+	if data == nil {
+		exists, err := PathExists(localPath)
+		if err != nil {
+			return errors.New("Cannot delete " + file + " from git : " + err.Error())
+		}
+		if !exists {
+			logrus.Error("File not found in git : ", localPath)
+			return nil
+		}
+		err = os.Remove(localPath)
+		if err != nil {
+			return errors.New("Cannot delete " + file + " from git : " + err.Error())
+		}
+		deleteFile = &filePath
+	} else {
+		err = os.MkdirAll(filepath.Dir(localPath), 0755)
+		if err != nil {
+			return errors.New("Cannot write " + file + " to git : " + err.Error())
+		}
+		err = os.WriteFile(localPath, data, 0644)
+		if err != nil {
+			return errors.New("Cannot write " + file + " to git : " + err.Error())
+		}
+	}
+
+	commit, err := gitConfig.GitCommit(GitUserFromContext(ctx), message, deleteFile)
 	if err != nil {
-		return errors.New("Cannot commit experiment to git : " + err.Error())
+		logrus.Error("Error", err)
+		return errors.New("Cannot commit " + file + " to git : " + err.Error())
+	}
+
+	if branch != "" {
+		return g.openPullRequest(ctx, gitConfig, branch, filePath, message)
 	}
 
 	err = gitConfig.GitPush()
 	if err != nil {
-		return errors.New("Cannot push experiment to git : " + err.Error())
+		logrus.Error("Error", err)
+		// the commit is dropped, so that the next sync pulls the remote changes instead of failing to merge them
+		if err := gitConfig.GitResetTo(head); err != nil {
+			logrus.Error("Cannot reset repo : ", gitConfig.ProjectID, err.Error())
+		}
+		return errors.New("Cannot push " + file + " to git : " + err.Error())
 	}
 
 	query := bson.D{{"project_id", gitConfig.ProjectID}}
@@ -296,63 +454,72 @@ func (g *gitOpsService) UpsertExperimentToGit(ctx context.Context, projectID str
 	return nil
 }
 
-// DeleteExperimentFromGit deletes experiment from git
-func (g *gitOpsService) DeleteExperimentFromGit(ctx context.Context, projectID string, experiment *model.ChaosExperimentRequest) error {
-	logrus.Info("Deleting Experiment...")
-	gitLock.Lock(projectID, nil)
-	defer gitLock.Unlock(projectID, nil)
-
-	config, err := g.gitOpsOperator.GetGitConfig(ctx, projectID)
+// openPullRequest pushes the branch of a change and opens its pull request, the change is pending until
+// the pull request is merged or closed
+func (g *gitOpsService) openPullRequest(ctx context.Context, gitConfig GitConfig, branch, file, title string) error {
+	provider, err := NewPullRequestProvider(gitConfig)
 	if err != nil {
-		return errors.New("Cannot get Git Config from DB : " + err.Error())
-	}
-	if config == nil {
-		return nil
+		return err
 	}
-	gitLock.Lock(config.RepositoryURL, &config.Branch)
-	defer gitLock.Unlock(config.RepositoryURL, &config.Branch)
-
-	gitConfig := GetGitOpsConfig(*config)
 
-	err = g.SyncDBToGit(ctx, gitConfig)
+	err = gitConfig.GitPushBranch(branch)
 	if err != nil {
-		return errors.New("Sync Error | " + err.Error())
+		logrus.Error("Error", err)
+		return errors.New("Cannot push branch " + branch + " to git : " + err.Error())
 	}
 
-	experimentPath := ProjectDataPath + "/" + gitConfig.ProjectID + "/" + experiment.ExperimentName + ".yaml"
-	exists, err := PathExists(gitConfig.LocalPath + "/" + experimentPath)
+	user := GitUserFromContext(ctx)
+	pullRequest, err := provider.CreatePullRequest(ctx, branch, title, "Change made by "+user.username+" in ChaosCenter to "+file)
 	if err != nil {
-		return errors.New("Cannot delete experiment from git : " + err.Error())
+		return errors.New("Cannot open pull request for branch " + branch + " : " + err.Error())
 	}
-	if !exists {
-		logrus.Error("File not found in git : ", gitConfig.LocalPath+"/"+experimentPath)
-		return nil
+
+	change := gitops.PendingChange{
+		Branch:            branch,
+		File:              file,
+		Title:             title,
+		PullRequestNumber: pullRequest.Number,
+		PullRequestURL:    pullRequest.URL,
+		CreatedAt:         time.Now().UnixMilli(),
+		CreatedBy:         user.username,
 	}
-	err = os.RemoveAll(gitConfig.LocalPath + "/" + experimentPath)
+	query := bson.D{{"project_id", gitConfig.ProjectID}}
+	update := bson.D{{"$push", bson.D{{"pending_changes", change}}}}
+	err = g.gitOpsOperator.UpdateGitConfig(ctx, query, update)
 	if err != nil {
-		return errors.New("Cannot delete experiment from git : " + err.Error())
+		return errors.New("Failed to update git config : " + err.Error())
 	}
+	return nil
+}
 
-	commit, err := gitConfig.GitCommit(GitUserFromContext(ctx), "Deleted Experiment : "+experiment.ExperimentName, &experimentPath)
-	if err != nil {
-		logrus.Error("Error", err)
-		return errors.New("Cannot commit experiment[delete] to git : " + err.Error())
+// resolvedPendingChanges returns the branches of the pending changes whose pull requests were merged or closed,
+// and the changes whose pull requests were closed without being merged
+func resolvedPendingChanges(config GitConfig, changes []gitops.PendingChange) ([]string, []gitops.PendingChange, error) {
+	if len(changes) == 0 {
+		return nil, nil, nil
 	}
-
-	err = gitConfig.GitPush()
+	provider, err := NewPullRequestProvider(config)
 	if err != nil {
-		logrus.Error("Error", err)
-		return errors.New("Cannot push experiment[delete] to git : " + err.Error())
+		return nil, nil, err
 	}
 
-	query := bson.D{{"project_id", gitConfig.ProjectID}}
-	update := bson.D{{"$set", bson.D{{"latest_commit", commit}}}}
-	err = g.gitOpsOperator.UpdateGitConfig(ctx, query, update)
-	if err != nil {
-		return errors.New("Failed to update git config : " + err.Error())
+	var (
+		branches []string
+		closed   []gitops.PendingChange
+	)
+	for _, change := range changes {
+		state, err := provider.GetPullRequestState(backgroundContext, change.PullRequestNumber)
+		if err != nil {
+			return branches, closed, err
+		}
+		if state != PullRequestOpen {
+			branches = append(branches, change.Branch)
+		}
+		if state == PullRequestClosed {
+			closed = append(closed, change)
+		}
 	}
-
-	return nil
+	return branches, closed, nil
 }
 
 // GitSyncHelper sync a particular repo with DB
@@ -380,27 +547,83 @@ func (g *gitOpsService) gitSyncHelper(config gitops.GitConfigDB, wg *sync.WaitGr
 
 	gitConfig := GetGitOpsConfig(*conf)
 
+	// the states of the pull requests are checked before the sync, so that the merged changes are pulled
+	// before they stop being pending
+	resolvedBranches, closedChanges, err := resolvedPendingChanges(gitConfig, conf.PendingChanges)
+	if err != nil {
+		logrus.Error("Failed to get the pull requests of repo : ", conf.ProjectID, err.Error())
+	}
+
+	status, syncError := model.GitOpsSyncStatusSuccess, ""
 	err = g.SyncDBToGit(nil, gitConfig)
 	if err != nil {
+		metrics.GitOpsSyncFailures.Inc()
 		logrus.Error("Repo Sync ERROR: ", conf.ProjectID, err.Error())
+		status, syncError = model.GitOpsSyncStatusFailure, err.Error()
+		resolvedBranches, closedChanges = nil, nil
+	}
+
+	// the closed changes stay in ChaosCenter but not in the repo, they stay pending until they're recorded
+	// as conflicts
+	for _, change := range closedChanges {
+		if err := g.recordClosedChange(gitConfig, change); err != nil {
+			logrus.Error("Failed to record the closed change of repo : ", conf.ProjectID, change.Branch, err.Error())
+			resolvedBranches = removeBranch(resolvedBranches, change.Branch)
+		}
+	}
+
+	// the sync may outlast the context of the config lookup
+	ctx, cancel = context.WithTimeout(backgroundContext, timeout)
+	defer cancel()
+	query := bson.D{{"project_id", conf.ProjectID}}
+	update := bson.D{{"$set", bson.D{
+		{"last_sync_at", time.Now().UnixMilli()},
+		{"last_sync_status", status},
+		{"last_sync_error", syncError},
+	}}}
+	if len(resolvedBranches) > 0 {
+		update = append(update, bson.E{"$pull", bson.D{{"pending_changes", bson.D{{"branch", bson.D{{"$in", resolvedBranches}}}}}}})
+	}
+	if err := g.gitOpsOperator.UpdateGitConfig(ctx, query, update); err != nil {
+		logrus.Error("Failed to record the sync status of repo : ", conf.ProjectID, err.Error())
+	}
+}
+
+// removeBranch returns the branches without the given one
+func removeBranch(branches []string, branch string) []string {
+	var remaining []string
+	for _, b := range branches {
+		if b != branch {
+			remaining = append(remaining, b)
+		}
 	}
+	return remaining
 }
 
-// GitOpsSyncHandler syncs all repos in the DB
+// GitOpsSyncHandler polls the repos in the DB, each repo is synced once its poll interval elapsed since its
+// last sync, which may have been triggered by a push webhook
 func (g *gitOpsService) GitOpsSyncHandler(singleRun bool) {
 	const syncGroupSize = 10
-	const syncInterval = 2 * time.Minute
+	const syncCheckInterval = 15 * time.Second
 	for {
 
 		ctx, cancel := context.WithTimeout(backgroundContext, timeout)
-		logrus.Info("Running GitOps DB Sync...")
-		configs, err := g.gitOpsOperator.GetAllGitConfig(ctx)
+		logrus.Debug("Running GitOps DB Sync...")
+		allConfigs, err := g.gitOpsOperator.GetAllGitConfig(ctx)
 
 		cancel()
 		if err != nil {
 			logrus.Error("Failed to get git configs from db : ", err) //condition
 		}
 
+		var configs []gitops.GitConfigDB
+		now := time.Now()
+		for _, config := range allConfigs {
+			if singleRun || isSyncDue(config, now) {
+				configs = append(configs, config)
+			}
+		}
+
 		count := len(configs)
 		if count > 0 {
 			logrus.Info("Updating : ", configs) // condition
@@ -425,8 +648,64 @@ func (g *gitOpsService) GitOpsSyncHandler(singleRun bool) {
 		if singleRun {
 			break
 		}
-		time.Sleep(syncInterval)
+		time.Sleep(syncCheckInterval)
+	}
+}
+
+// GitOpsWebhookHandler authenticates a push webhook of the project and triggers the sync of its repo if the
+// push updated the GitOps branch, it returns whether a sync was triggered
+func (g *gitOpsService) GitOpsWebhookHandler(ctx context.Context, projectID string, header http.Header, body []byte) (bool, error) {
+	config, err := g.gitOpsOperator.GetGitConfig(ctx, projectID)
+	if err != nil {
+		return false, errors.New("Cannot get Git Config from DB : " + err.Error())
+	}
+	if config == nil {
+		return false, ErrGitOpsDisabled
+	}
+
+	event, err := ParsePushEvent(header, body, config.WebhookSecret)
+	if err != nil {
+		return false, err
 	}
+	if !event.Matches(config.RepositoryURL, config.Branch) {
+		return false, nil
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"projectId": projectID,
+		"provider":  event.Provider,
+	}).Info("Syncing GitOps repo on push webhook")
+	webhookSyncs.Trigger(projectID, func() { g.gitSyncHelper(*config, nil) })
+	return true, nil
+}
+
+// isSyncDue returns true if the poll interval of the repo elapsed since its last sync
+func isSyncDue(config gitops.GitConfigDB, now time.Time) bool {
+	nextSync := time.UnixMilli(config.LastSyncAt).Add(time.Duration(pollIntervalOf(config)) * time.Second)
+	return !now.Before(nextSync)
+}
+
+func pollIntervalOf(config gitops.GitConfigDB) int {
+	if config.PollInterval == 0 {
+		return DefaultPollInterval
+	}
+	return config.PollInterval
+}
+
+// validateWriteMode checks that the pull requests of the PullRequest write mode can be opened
+func validateWriteMode(config gitops.GitConfigDB) error {
+	if config.WriteMode != model.GitOpsWriteModePullRequest {
+		return nil
+	}
+	_, err := NewPullRequestProvider(GetGitOpsConfig(config))
+	return err
+}
+
+func validatePollInterval(pollInterval *int) error {
+	if pollInterval != nil && *pollInterval < MinPollInterval {
+		return fmt.Errorf("poll interval has to be at least %d seconds", MinPollInterval)
+	}
+	return nil
 }
 
 // SyncDBToGit syncs the DB with the GitRepo for the project
@@ -451,11 +730,19 @@ func (g *gitOpsService) SyncDBToGit(ctx context.Context, config GitConfig) error
 		return nil
 	}
 	logrus.Info(latestCommit, " ", config.LatestCommit, "File Changes: ", files)
-	newExperiments := false
+	newExperiments, mergedExperiments := false, false
 	for file := range files {
 		if !strings.HasSuffix(file, ".yaml") {
 			continue
 		}
+		// probes, environments, chaos hubs and the image registry
+		if kind, name, ok := resourceOf(file, config.ProjectID); ok {
+			err = g.syncResource(kind, name, file, config)
+			if err != nil {
+				logrus.Error("Error while syncing " + string(kind) + " db entry : " + file + " | " + err.Error())
+			}
+			continue
+		}
 		// check if file was deleted or not
 		exists, err := PathExists(config.LocalPath + "/" + file)
 		if err != nil {
@@ -467,6 +754,9 @@ func (g *gitOpsService) SyncDBToGit(ctx context.Context, config GitConfig) error
 				logrus.Error("Error while deleting experiment db entry : " + file + " | " + err.Error())
 				continue
 			}
+			if err := g.removeConflicts(config.ProjectID, bson.D{{"file", file}}); err != nil {
+				logrus.Error("Error while removing the conflicts of experiment : " + file + " | " + err.Error())
+			}
 			continue
 		}
 		// read changes [new additions/updates]
@@ -498,7 +788,26 @@ func (g *gitOpsService) SyncDBToGit(ctx context.Context, config GitConfig) error
 				newExperiments = true
 			}
 		} else {
-			err = g.updateExperiment(ctx, string(data), wfID, file, config)
+			merged, changed, err := g.mergeExperimentChange(string(data), wfID, file, config)
+			if err != nil {
+				logrus.Error("Error while merging experiment : " + file + " | " + err.Error())
+				continue
+			}
+			// the merged experiment is pushed back, the pending changes are pushed by their pull requests
+			if changed && config.WriteMode != model.GitOpsWriteModePullRequest {
+				yamlData, err := yaml.JSONToYAML([]byte(merged))
+				if err != nil {
+					logrus.Error("Error converting merged experiment to yaml : " + file + " | " + err.Error())
+					continue
+				}
+				err = os.WriteFile(config.LocalPath+"/"+file, yamlData, 0644)
+				if err != nil {
+					logrus.Error("Error writing merged experiment to git : " + file + " | " + err.Error())
+					continue
+				}
+				mergedExperiments = true
+			}
+			err = g.updateExperiment(ctx, merged, wfID, file, config)
 			if err != nil {
 				logrus.Error("Error while updating experiment db entry : " + file + " | " + err.Error())
 				continue
@@ -506,9 +815,13 @@ func (g *gitOpsService) SyncDBToGit(ctx context.Context, config GitConfig) error
 		}
 
 	}
-	// push experiments with experiment_id added
-	if newExperiments {
-		latestCommit, err = config.GitCommit(GitUserFromContext(ctx), "Updated New Experiments", nil)
+	// push experiments with experiment_id added and the merged experiments
+	if newExperiments || mergedExperiments {
+		message := "Updated New Experiments"
+		if mergedExperiments {
+			message = "Merged Experiments changed in ChaosCenter"
+		}
+		latestCommit, err = config.GitCommit(GitUserFromContext(ctx), message, nil)
 		if err != nil {
 			return errors.New("Cannot commit experiments to git : " + err.Error())
 		}
@@ -619,6 +932,12 @@ func (g *gitOpsService) updateExperiment(ctx context.Context, data, wfID, file s
 		IsCustomExperiment:    experiment[0].IsCustomExperiment,
 		InfraID:               experiment[0].InfraID,
 	}
+	// keep the scoring strategy and the acceptance criteria of the experiment, as they aren't part of the manifest
+	if len(experiment[0].Revision) > 0 {
+		latestRevision := experiment[0].Revision[len(experiment[0].Revision)-1]
+		experimentData.ScoringStrategy = latestRevision.ScoringStrategy.GetInputScoringStrategy()
+		experimentData.AcceptanceCriteria = latestRevision.AcceptanceCriteria.GetInputAcceptanceCriteria()
+	}
 
 	revID := ""
 	updateRevision := false
@@ -0,0 +1,200 @@
+package gitops
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// GitProvider is the git hosting service sending the push webhooks
+type GitProvider string
+
+const (
+	GitProviderGitHub    GitProvider = "GitHub"
+	GitProviderGitLab    GitProvider = "GitLab"
+	GitProviderGitea     GitProvider = "Gitea"
+	GitProviderBitbucket GitProvider = "Bitbucket"
+)
+
+var (
+	// ErrGitOpsDisabled is returned for the webhooks of the projects without GitOps
+	ErrGitOpsDisabled = errors.New("gitops is disabled for the project")
+	// ErrInvalidWebhookSignature is returned for the webhooks which aren't signed with the secret of the project
+	ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+	// ErrUnsupportedWebhookEvent is returned for the webhook events other than pushes, like the pings sent
+	// when a webhook is created
+	ErrUnsupportedWebhookEvent = errors.New("unsupported webhook event")
+)
+
+// urlPaths are the paths of the repository URLs in the push payloads of the supported git providers
+var urlPaths = []string{
+	"repository.clone_url",
+	"repository.ssh_url",
+	"repository.html_url",
+	"repository.git_http_url",
+	"repository.git_ssh_url",
+	"project.git_http_url",
+	"project.git_ssh_url",
+	"project.web_url",
+	"repository.links.html.href",
+	"repository.links.clone.#.href",
+}
+
+// PushEvent is the part of a push webhook needed to find whether the GitOps repository of a project changed
+type PushEvent struct {
+	Provider       GitProvider
+	Branches       []string
+	RepositoryURLs []string
+}
+
+// NewWebhookSecret returns a random secret for the push webhooks of a project
+func NewWebhookSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// ParsePushEvent authenticates a webhook sent by GitHub, GitLab, Gitea or Bitbucket with the secret of the
+// project and returns its push event. ErrUnsupportedWebhookEvent is returned for the authenticated webhooks
+// of other events
+func ParsePushEvent(header http.Header, body []byte, secret string) (*PushEvent, error) {
+	if secret == "" {
+		return nil, ErrInvalidWebhookSignature
+	}
+
+	var (
+		provider GitProvider
+		isPush   bool
+		valid    bool
+	)
+	// Gitea sends the GitHub headers too, so it has to be detected first
+	switch {
+	case header.Get("X-Gitea-Event") != "":
+		provider = GitProviderGitea
+		isPush = header.Get("X-Gitea-Event") == "push"
+		valid = validSignature(header.Get("X-Gitea-Signature"), body, secret)
+	case header.Get("X-Gitlab-Event") != "":
+		provider = GitProviderGitLab
+		isPush = header.Get("X-Gitlab-Event") == "Push Hook"
+		valid = subtle.ConstantTimeCompare([]byte(header.Get("X-Gitlab-Token")), []byte(secret)) == 1
+	case header.Get("X-Event-Key") != "":
+		provider = GitProviderBitbucket
+		isPush = header.Get("X-Event-Key") == "repo:push" || header.Get("X-Event-Key") == "repo:refs_changed"
+		valid = validSignature(strings.TrimPrefix(header.Get("X-Hub-Signature"), "sha256="), body, secret)
+	case header.Get("X-GitHub-Event") != "":
+		provider = GitProviderGitHub
+		isPush = header.Get("X-GitHub-Event") == "push"
+		valid = validSignature(strings.TrimPrefix(header.Get("X-Hub-Signature-256"), "sha256="), body, secret)
+	default:
+		return nil, errors.New("unsupported git provider")
+	}
+	if !valid {
+		return nil, ErrInvalidWebhookSignature
+	}
+	if !isPush {
+		return nil, ErrUnsupportedWebhookEvent
+	}
+
+	event := &PushEvent{Provider: provider}
+	if provider == GitProviderBitbucket {
+		// Bitbucket Cloud lists the new heads of the changes and Bitbucket Server their refs
+		for _, change := range gjson.GetBytes(body, "push.changes").Array() {
+			if change.Get("new.type").String() == "branch" {
+				event.Branches = append(event.Branches, change.Get("new.name").String())
+			}
+		}
+		for _, change := range gjson.GetBytes(body, "changes").Array() {
+			if change.Get("ref.type").String() == "BRANCH" {
+				event.Branches = append(event.Branches, change.Get("ref.displayId").String())
+			}
+		}
+	} else if ref := gjson.GetBytes(body, "ref").String(); strings.HasPrefix(ref, "refs/heads/") {
+		event.Branches = append(event.Branches, strings.TrimPrefix(ref, "refs/heads/"))
+	}
+
+	for _, path := range urlPaths {
+		for _, url := range gjson.GetBytes(body, path).Array() {
+			if url.String() != "" {
+				event.RepositoryURLs = append(event.RepositoryURLs, url.String())
+			}
+		}
+	}
+	return event, nil
+}
+
+// Matches returns true if the push event updated the branch of the repository, the repository of the
+// events without URLs is assumed to match as the webhooks are authenticated with the secret of the project
+func (e PushEvent) Matches(repositoryURL, branch string) bool {
+	branchMatches := false
+	for _, b := range e.Branches {
+		if b == branch {
+			branchMatches = true
+			break
+		}
+	}
+	if !branchMatches {
+		return false
+	}
+	if len(e.RepositoryURLs) == 0 {
+		return true
+	}
+
+	repository := normalizeRepositoryURL(repositoryURL)
+	for _, url := range e.RepositoryURLs {
+		if normalizeRepositoryURL(url) == repository {
+			return true
+		}
+	}
+	return false
+}
+
+// validSignature checks the hex encoded HMAC-SHA256 signature of a webhook body
+func validSignature(signature string, body []byte, secret string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// normalizeRepositoryURL returns the host and path of an HTTP(S) or SSH repository URL, so that the clone,
+// SSH and web URLs of a repository are equal
+func normalizeRepositoryURL(url string) string {
+	host, path := splitRepositoryURL(url)
+	return strings.ToLower(host + "/" + path)
+}
+
+// splitRepositoryURL returns the host and the path of an HTTP(S) or SSH repository URL, without the
+// credentials and the .git suffix
+func splitRepositoryURL(url string) (string, string) {
+	url = strings.TrimSpace(url)
+	scheme := strings.Index(url, "://")
+	if scheme >= 0 {
+		url = url[scheme+3:]
+	}
+	if i := strings.Index(url, "@"); i >= 0 && (strings.Index(url, "/") < 0 || i < strings.Index(url, "/")) {
+		url = url[i+1:]
+	}
+	// the SSH URLs without scheme separate the host and the path with a colon, like git@github.com:org/repo
+	separator := "/"
+	if scheme < 0 && strings.Contains(url, ":") {
+		separator = ":"
+	}
+	host, path := url, ""
+	if i := strings.Index(url, separator); i >= 0 {
+		host, path = url[:i], url[i+1:]
+	}
+	path = strings.TrimSuffix(path, "/")
+	return host, strings.TrimSuffix(path, ".git")
+}
@@ -0,0 +1,137 @@
+package gitops
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+)
+
+func sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestParsePushEvent(t *testing.T) {
+	const secret = "webhook-secret"
+	var (
+		githubBody    = []byte(`{"ref":"refs/heads/main","repository":{"clone_url":"https://github.com/org/repo.git","ssh_url":"git@github.com:org/repo.git"}}`)
+		gitlabBody    = []byte(`{"ref":"refs/heads/main","project":{"git_http_url":"https://gitlab.com/org/repo.git"}}`)
+		bitbucketBody = []byte(`{"push":{"changes":[{"new":{"type":"branch","name":"main"}}]},"repository":{"links":{"html":{"href":"https://bitbucket.org/org/repo"}}}}`)
+	)
+	testcases := []struct {
+		name    string
+		header  map[string]string
+		body    []byte
+		secret  string
+		want    GitProvider
+		wantErr error
+	}{
+		{
+			name:   "github push",
+			header: map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + sign(githubBody, secret)},
+			body:   githubBody,
+			secret: secret,
+			want:   GitProviderGitHub,
+		},
+		{
+			name:   "gitea push",
+			header: map[string]string{"X-Gitea-Event": "push", "X-GitHub-Event": "push", "X-Gitea-Signature": sign(githubBody, secret)},
+			body:   githubBody,
+			secret: secret,
+			want:   GitProviderGitea,
+		},
+		{
+			name:   "gitlab push",
+			header: map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": secret},
+			body:   gitlabBody,
+			secret: secret,
+			want:   GitProviderGitLab,
+		},
+		{
+			name:   "bitbucket push",
+			header: map[string]string{"X-Event-Key": "repo:push", "X-Hub-Signature": "sha256=" + sign(bitbucketBody, secret)},
+			body:   bitbucketBody,
+			secret: secret,
+			want:   GitProviderBitbucket,
+		},
+		{
+			name:    "invalid signature",
+			header:  map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=" + sign(githubBody, "other-secret")},
+			body:    githubBody,
+			secret:  secret,
+			wantErr: ErrInvalidWebhookSignature,
+		},
+		{
+			name:    "project without secret",
+			header:  map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": ""},
+			body:    gitlabBody,
+			wantErr: ErrInvalidWebhookSignature,
+		},
+		{
+			name:    "ping",
+			header:  map[string]string{"X-GitHub-Event": "ping", "X-Hub-Signature-256": "sha256=" + sign([]byte(`{}`), secret)},
+			body:    []byte(`{}`),
+			secret:  secret,
+			wantErr: ErrUnsupportedWebhookEvent,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			for key, value := range tc.header {
+				header.Set(key, value)
+			}
+			event, err := ParsePushEvent(header, tc.body, tc.secret)
+			if err != tc.wantErr {
+				t.Fatalf("ParsePushEvent() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if event.Provider != tc.want {
+				t.Errorf("Provider = %s, want %s", event.Provider, tc.want)
+			}
+			if !event.Matches("git@"+hostOf(tc.want)+":org/repo.git", "main") {
+				t.Errorf("event %+v doesn't match the main branch of org/repo", event)
+			}
+			if event.Matches("https://"+hostOf(tc.want)+"/org/repo.git", "develop") {
+				t.Errorf("event %+v matches the develop branch", event)
+			}
+		})
+	}
+}
+
+func hostOf(provider GitProvider) string {
+	switch provider {
+	case GitProviderGitLab:
+		return "gitlab.com"
+	case GitProviderBitbucket:
+		return "bitbucket.org"
+	}
+	return "github.com"
+}
+
+func TestPushEventMatches(t *testing.T) {
+	event := PushEvent{
+		Branches:       []string{"main"},
+		RepositoryURLs: []string{"https://github.com/org/repo.git"},
+	}
+	testcases := []struct {
+		repositoryURL string
+		want          bool
+	}{
+		{"https://github.com/org/repo", true},
+		{"https://user@github.com/Org/Repo.git/", true},
+		{"ssh://git@github.com/org/repo.git", true},
+		{"git@github.com:org/repo.git", true},
+		{"https://github.com/org/other-repo.git", false},
+	}
+	for _, tc := range testcases {
+		if got := event.Matches(tc.repositoryURL, "main"); got != tc.want {
+			t.Errorf("Matches(%s) = %v, want %v", tc.repositoryURL, got, tc.want)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package grpc
 import (
 	"context"
 	"errors"
+	"sync"
+	"time"
 
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/protos"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/utils"
@@ -11,26 +13,50 @@ import (
 	"google.golang.org/grpc"
 )
 
-// GetAuthGRPCSvcClient returns an RPC client for Authentication service
-func GetAuthGRPCSvcClient(conn *grpc.ClientConn) (protos.AuthRpcServiceClient, *grpc.ClientConn) {
-	conn, err := grpc.Dial(utils.Config.LitmusAuthGrpcEndpoint+utils.Config.LitmusAuthGrpcPort, grpc.WithBlock(), grpc.WithInsecure())
-	if err != nil {
-		logrus.Fatalf("did not connect: %s", err)
-	}
-	return protos.NewAuthRpcServiceClient(conn), conn
+const (
+	// authRequestTimeout bounds the requests to the Authentication service, so the resolvers fail instead
+	// of hanging while the service is unreachable
+	authRequestTimeout = 10 * time.Second
+	// authHealthCheckInterval is the interval the pooled connections to the Authentication service are checked at
+	authHealthCheckInterval = 10 * time.Second
+)
+
+var (
+	authPool     *ConnPool
+	authPoolOnce sync.Once
+)
+
+// GetAuthGRPCSvcClient returns an RPC client for Authentication service, backed by a pool of long-lived connections
+// shared by all the callers. The connections are owned by the pool and mustn't be closed by the callers
+func GetAuthGRPCSvcClient() protos.AuthRpcServiceClient {
+	authPoolOnce.Do(func() {
+		pool, err := NewConnPool(utils.Config.LitmusAuthGrpcEndpoint+utils.Config.LitmusAuthGrpcPort,
+			utils.Config.AuthGrpcPoolSize, grpc.WithInsecure())
+		if err != nil {
+			logrus.Fatalf("did not connect: %s", err)
+		}
+		go pool.HealthCheck(context.Background(), authHealthCheckInterval)
+		authPool = pool
+	})
+	return protos.NewAuthRpcServiceClient(authPool.Conn())
 }
 
 // ValidatorGRPCRequest sends a request to Authentication server to ensure
 // user permission over the project
 func ValidatorGRPCRequest(client protos.AuthRpcServiceClient,
-	jwt string, projectID string, requiredRoles []string, invitation string) error {
+	jwt string, projectID string, requiredRoles []string, invitation string, operation string, clientIP string) error {
+
+	ctx, cancel := context.WithTimeout(context.Background(), authRequestTimeout)
+	defer cancel()
 
-	resp, err := client.ValidateRequest(context.Background(),
+	resp, err := client.ValidateRequest(ctx,
 		&protos.ValidationRequest{
 			Jwt:           jwt,
 			ProjectId:     projectID,
 			RequiredRoles: requiredRoles,
 			Invitation:    invitation,
+			Operation:     operation,
+			ClientIP:      clientIP,
 		})
 	if err != nil {
 		return err
@@ -44,7 +70,10 @@ func ValidatorGRPCRequest(client protos.AuthRpcServiceClient,
 // GetProjectById returns the project details based on its uid
 func GetProjectById(client protos.AuthRpcServiceClient,
 	projectId string) (*protos.GetProjectByIdResponse, error) {
-	resp, err := client.GetProjectById(context.Background(), &protos.GetProjectByIdRequest{ProjectID: projectId})
+	ctx, cancel := context.WithTimeout(context.Background(), authRequestTimeout)
+	defer cancel()
+
+	resp, err := client.GetProjectById(ctx, &protos.GetProjectByIdRequest{ProjectID: projectId})
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +83,10 @@ func GetProjectById(client protos.AuthRpcServiceClient,
 // GetUserById returns the project details based on its uid
 func GetUserById(client protos.AuthRpcServiceClient,
 	userId string) (*protos.GetUserByIdResponse, error) {
-	resp, err := client.GetUserById(context.Background(), &protos.GetUserByIdRequest{UserID: userId})
+	ctx, cancel := context.WithTimeout(context.Background(), authRequestTimeout)
+	defer cancel()
+
+	resp, err := client.GetUserById(ctx, &protos.GetUserByIdRequest{UserID: userId})
 	if err != nil {
 		return nil, err
 	}
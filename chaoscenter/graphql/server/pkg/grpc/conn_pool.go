@@ -0,0 +1,120 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// ConnPool is a fixed size pool of long-lived connections to a gRPC server, which are handed out round-robin
+// so the requests are spread over several HTTP/2 connections instead of dialing a new one for each request
+type ConnPool struct {
+	target string
+	opts   []grpc.DialOption
+	next   uint64
+
+	mu    sync.RWMutex
+	conns []*grpc.ClientConn
+}
+
+// NewConnPool dials size connections to the target. The connections are established in the background,
+// so the pool can be created before the server is reachable
+func NewConnPool(target string, size int, opts ...grpc.DialOption) (*ConnPool, error) {
+	if size < 1 {
+		size = 1
+	}
+
+	pool := &ConnPool{
+		target: target,
+		opts:   opts,
+		conns:  make([]*grpc.ClientConn, 0, size),
+	}
+	for i := 0; i < size; i++ {
+		conn, err := grpc.Dial(target, opts...)
+		if err != nil {
+			pool.Close()
+			return nil, err
+		}
+		pool.conns = append(pool.conns, conn)
+	}
+
+	return pool, nil
+}
+
+// Conn returns the next healthy connection of the pool. If none of the connections is healthy the next one
+// is returned anyway, the requests sent on it wait for it to connect or fail fast while it's backing off
+func (p *ConnPool) Conn() *grpc.ClientConn {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	size := uint64(len(p.conns))
+	start := atomic.AddUint64(&p.next, 1)
+	for i := uint64(0); i < size; i++ {
+		conn := p.conns[(start+i)%size]
+		if isHealthy(conn.GetState()) {
+			return conn
+		}
+	}
+	return p.conns[start%size]
+}
+
+// HealthCheck checks the connections of the pool every interval until the context is done. The connections
+// which were shut down are replaced, and the ones in failure are reconnected without waiting for their backoff
+func (p *ConnPool) HealthCheck(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.checkConns()
+		}
+	}
+}
+
+func (p *ConnPool) checkConns() {
+	p.mu.RLock()
+	conns := append([]*grpc.ClientConn(nil), p.conns...)
+	p.mu.RUnlock()
+
+	for i, conn := range conns {
+		switch conn.GetState() {
+		case connectivity.Shutdown:
+			replacement, err := grpc.Dial(p.target, p.opts...)
+			if err != nil {
+				logrus.WithField("target", p.target).Errorf("failed to replace gRPC connection, error: %v", err)
+				continue
+			}
+			p.mu.Lock()
+			p.conns[i] = replacement
+			p.mu.Unlock()
+		case connectivity.TransientFailure:
+			conn.ResetConnectBackoff()
+		case connectivity.Idle:
+			conn.Connect()
+		}
+	}
+}
+
+// Close closes all the connections of the pool
+func (p *ConnPool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, conn := range p.conns {
+		if err := conn.Close(); err != nil {
+			logrus.WithField("target", p.target).Warnf("failed to close gRPC connection: %v", err)
+		}
+	}
+}
+
+func isHealthy(state connectivity.State) bool {
+	return state == connectivity.Ready || state == connectivity.Idle
+}
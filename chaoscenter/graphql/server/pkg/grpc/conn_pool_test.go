@@ -0,0 +1,130 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/protos"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// fakeAuthServer allows every validation request
+type fakeAuthServer struct {
+	protos.UnimplementedAuthRpcServiceServer
+}
+
+func (f *fakeAuthServer) ValidateRequest(ctx context.Context, request *protos.ValidationRequest) (*protos.ValidationResponse, error) {
+	return &protos.ValidationResponse{IsValid: true}, nil
+}
+
+// startFakeAuthServer serves a fakeAuthServer on a loopback TCP port, so the benchmarks include the TCP and HTTP/2 handshakes
+func startFakeAuthServer(tb testing.TB) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	server := grpc.NewServer()
+	protos.RegisterAuthRpcServiceServer(server, &fakeAuthServer{})
+	go server.Serve(lis)
+	tb.Cleanup(server.Stop)
+
+	return lis.Addr().String()
+}
+
+func TestConnPool(t *testing.T) {
+	target := startFakeAuthServer(t)
+	pool, err := NewConnPool(target, 3, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	t.Run("connections are handed out round-robin", func(t *testing.T) {
+		seen := map[*grpc.ClientConn]bool{}
+		for i := 0; i < 3; i++ {
+			seen[pool.Conn()] = true
+		}
+		if len(seen) != 3 {
+			t.Errorf("got %d distinct connections, want 3", len(seen))
+		}
+	})
+
+	t.Run("requests are served by the pooled connections", func(t *testing.T) {
+		for i := 0; i < 6; i++ {
+			err := ValidatorGRPCRequest(protos.NewAuthRpcServiceClient(pool.Conn()), "jwt", "project-id", nil, "Accepted", "GetWorkflowRun", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	t.Run("connections shut down are replaced", func(t *testing.T) {
+		closed := pool.conns[0]
+		closed.Close()
+		pool.checkConns()
+
+		if pool.conns[0] == closed {
+			t.Fatal("connection which was shut down wasn't replaced")
+		}
+		if state := pool.conns[0].GetState(); state == connectivity.Shutdown {
+			t.Errorf("replacement connection state = %s", state)
+		}
+	})
+}
+
+// BenchmarkValidatorGRPCRequest compares dialing a connection for every request, as the resolvers used to,
+// with sending the requests on the pooled connections
+func BenchmarkValidatorGRPCRequest(b *testing.B) {
+	target := startFakeAuthServer(b)
+
+	b.Run("dial per request", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			conn, err := grpc.Dial(target, grpc.WithBlock(), grpc.WithInsecure())
+			if err != nil {
+				b.Fatal(err)
+			}
+			err = ValidatorGRPCRequest(protos.NewAuthRpcServiceClient(conn), "jwt", "project-id", nil, "Accepted", "GetWorkflowRun", "")
+			conn.Close()
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("pooled", func(b *testing.B) {
+		pool, err := NewConnPool(target, 4, grpc.WithInsecure())
+		if err != nil {
+			b.Fatal(err)
+		}
+		defer pool.Close()
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			err := ValidatorGRPCRequest(protos.NewAuthRpcServiceClient(pool.Conn()), "jwt", "project-id", nil, "Accepted", "GetWorkflowRun", "")
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("pooled parallel", func(b *testing.B) {
+		pool, err := NewConnPool(target, 4, grpc.WithInsecure())
+		if err != nil {
+			b.Fatal(err)
+		}
+		defer pool.Close()
+
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				err := ValidatorGRPCRequest(protos.NewAuthRpcServiceClient(pool.Conn()), "jwt", "project-id", nil, "Accepted", "GetWorkflowRun", "")
+				if err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/audit"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/authorization"
+)
+
+// AuditExportHandler exports the audit logs as JSON lines for SIEM ingestion. The audit logs of a project are
+// exported to its owners, and the ones of every project, including the logins and the user management
+// actions, to the admins when the projectID query parameter is omitted. The optional operation, actor,
+// targetID, outcome, source, startTime and endTime query parameters filter the exported audit logs
+func AuditExportHandler(auditService audit.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		projectID := c.Query("projectID")
+
+		filter := &model.AuditLogFilterInput{
+			Operations: c.QueryArray("operation"),
+		}
+		for param, value := range map[string]**string{
+			"actor":     &filter.Actor,
+			"targetID":  &filter.TargetID,
+			"startTime": &filter.StartTime,
+			"endTime":   &filter.EndTime,
+		} {
+			if v, ok := c.GetQuery(param); ok {
+				*value = &v
+			}
+		}
+		if v, ok := c.GetQuery("outcome"); ok {
+			outcome := model.AuditOutcome(v)
+			if !outcome.IsValid() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unsupported outcome %q", v)})
+				return
+			}
+			filter.Outcome = &outcome
+		}
+		if v, ok := c.GetQuery("source"); ok {
+			source := model.AuditSource(v)
+			if !source.IsValid() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unsupported source %q", v)})
+				return
+			}
+			filter.Source = &source
+		}
+		if _, err := audit.NewAuditLogQuery(projectID, filter); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		var (
+			ctx context.Context
+			ok  bool
+		)
+		if projectID == "" {
+			ctx, ok = authorizeAdminRequest(c, authorization.ListAuditLogs)
+		} else {
+			ctx, ok = authorizeProjectRequest(c, projectID, authorization.ListAuditLogs)
+		}
+		if !ok {
+			return
+		}
+
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=audit-logs-%d.jsonl", time.Now().Unix()))
+		c.Status(http.StatusOK)
+		if err := auditService.ExportAuditLogs(ctx, projectID, filter, c.Writer); err != nil {
+			// the export is streamed, so the error can't be reported in the response anymore
+			logrus.WithField("projectId", projectID).Errorf("failed to export audit logs, error: %v", err)
+		}
+	}
+}
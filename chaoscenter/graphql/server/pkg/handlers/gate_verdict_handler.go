@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/authorization"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
+	dbChaosExperimentRun "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_experiment_run"
+)
+
+const (
+	// defaultVerdictWait is the time a verdict request waits for the run to complete when no timeout is given
+	defaultVerdictWait = 30 * time.Second
+	// maxVerdictWait is the longest time a verdict request may wait for the run to complete
+	maxVerdictWait = 10 * time.Minute
+	// verdictPollInterval is the interval at which the experiment run is checked for completion
+	verdictPollInterval = 2 * time.Second
+)
+
+// Exit codes of the gate verdicts, following the shell convention so CI jobs can exit with them directly
+const (
+	VerdictExitCodePass    = 0
+	VerdictExitCodeFail    = 1
+	VerdictExitCodePending = 2
+)
+
+// GateVerdictResponse is the verdict of an experiment run returned to CI jobs
+type GateVerdictResponse struct {
+	NotifyID        string            `json:"notifyID"`
+	ExperimentID    string            `json:"experimentID"`
+	ExperimentRunID string            `json:"experimentRunID"`
+	Phase           string            `json:"phase"`
+	Completed       bool              `json:"completed"`
+	ResiliencyScore *float64          `json:"resiliencyScore,omitempty"`
+	Verdict         model.GateVerdict `json:"verdict"`
+	Reasons         []string          `json:"reasons,omitempty"`
+	ExitCode        int               `json:"exitCode"`
+}
+
+// GateVerdictHandler long-polls the experiment run triggered with the given notifyID until it is
+// completed or the timeout (in seconds) expires, and returns its gate verdict with an exit code
+func GateVerdictHandler(mongodbOperator mongodb.MongoOperator) gin.HandlerFunc {
+	experimentRunOperator := dbChaosExperimentRun.NewChaosExperimentRunOperator(mongodbOperator)
+
+	return func(c *gin.Context) {
+		notifyID := c.Param("notifyID")
+		projectID := c.Query("projectID")
+
+		wait := defaultVerdictWait
+		if timeout := c.Query("timeout"); timeout != "" {
+			seconds, err := strconv.Atoi(timeout)
+			if err != nil || seconds < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "timeout has to be a positive number of seconds"})
+				return
+			}
+			wait = time.Duration(seconds) * time.Second
+			if wait > maxVerdictWait {
+				wait = maxVerdictWait
+			}
+		}
+
+		ctx, ok := authorizeProjectRequest(c, projectID, authorization.GetWorkflowRun)
+		if !ok {
+			return
+		}
+
+		query := bson.D{
+			{"project_id", projectID},
+			{"notify_id", notifyID},
+			{"is_removed", false},
+		}
+
+		deadline := time.Now().Add(wait)
+		for {
+			run, err := experimentRunOperator.GetExperimentRun(query)
+			if err != nil && err != mongo.ErrNoDocuments {
+				logrus.WithField("notifyID", notifyID).Error(err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+
+			if err == nil && (run.Completed || !time.Now().Before(deadline)) {
+				c.JSON(http.StatusOK, newGateVerdictResponse(notifyID, run))
+				return
+			}
+			if err == mongo.ErrNoDocuments && !time.Now().Before(deadline) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "no experiment run found for notifyID " + notifyID})
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(verdictPollInterval):
+			}
+		}
+	}
+}
+
+func newGateVerdictResponse(notifyID string, run dbChaosExperimentRun.ChaosExperimentRun) GateVerdictResponse {
+	response := GateVerdictResponse{
+		NotifyID:        notifyID,
+		ExperimentID:    run.ExperimentID,
+		ExperimentRunID: run.ExperimentRunID,
+		Phase:           run.Phase,
+		Completed:       run.Completed,
+		ResiliencyScore: run.ResiliencyScore,
+		Verdict:         model.GateVerdictPending,
+		Reasons:         run.GateVerdictReasons,
+		ExitCode:        VerdictExitCodePending,
+	}
+	if !run.Completed {
+		return response
+	}
+
+	// runs completed before the gates were introduced have no verdict, so they are judged by their phase
+	response.Verdict = model.GateVerdict(run.GateVerdict)
+	if run.GateVerdict == "" {
+		response.Verdict = model.GateVerdictFail
+		if model.ExperimentRunStatus(run.Phase) == model.ExperimentRunStatusCompleted {
+			response.Verdict = model.GateVerdictPass
+		}
+	}
+
+	response.ExitCode = VerdictExitCodeFail
+	if response.Verdict == model.GateVerdictPass {
+		response.ExitCode = VerdictExitCodePass
+	}
+	return response
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/gitops"
+)
+
+// maxWebhookPayloadSize is the largest push webhook payload accepted, GitHub caps its payloads at 25MB
+const maxWebhookPayloadSize = 25 << 20
+
+// GitOpsWebhookHandler receives the push webhooks of GitHub, GitLab, Gitea and Bitbucket for the GitOps
+// repository of a project and triggers its sync right away instead of waiting for the next poll. The
+// webhooks are authenticated with the webhook secret returned by getGitOpsDetails
+func GitOpsWebhookHandler(gitOpsService gitops.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		projectID := c.Param("projectID")
+
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookPayloadSize))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read the webhook payload"})
+			return
+		}
+
+		triggered, err := gitOpsService.GitOpsWebhookHandler(c.Request.Context(), projectID, c.Request.Header, body)
+		switch {
+		case errors.Is(err, gitops.ErrUnsupportedWebhookEvent):
+			c.JSON(http.StatusOK, gin.H{"message": "event ignored"})
+		case errors.Is(err, gitops.ErrGitOpsDisabled):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, gitops.ErrInvalidWebhookSignature):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case err != nil:
+			logrus.WithField("projectId", projectID).Errorf("failed to handle gitops webhook, error: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case !triggered:
+			c.JSON(http.StatusOK, gin.H{"message": "push doesn't update the gitops branch"})
+		default:
+			c.JSON(http.StatusAccepted, gin.H{"message": "sync triggered"})
+		}
+	}
+}
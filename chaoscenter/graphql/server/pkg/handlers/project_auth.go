@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/authorization"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
+)
+
+// authorizeProjectRequest validates the bearer token of a REST request against the roles required for
+// the operation in the project, writing the error response if the request isn't allowed
+func authorizeProjectRequest(c *gin.Context, projectID string, operation authorization.RoleQuery) (context.Context, bool) {
+	if projectID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "projectID query parameter is required"})
+		return nil, false
+	}
+	return authorizeRequest(c, projectID, operation)
+}
+
+// authorizeAdminRequest validates that the bearer token of a REST request belongs to an admin, for the
+// requests which aren't scoped to a project. The API tokens are checked against their scope and allowed
+// IPs like for the project requests
+func authorizeAdminRequest(c *gin.Context, operation authorization.RoleQuery) (context.Context, bool) {
+	return authorizeRequest(c, "", operation)
+}
+
+// authorizeRequest validates the bearer token of a REST request with the authentication server, the
+// requests without a project are only allowed to the admins
+func authorizeRequest(c *gin.Context, projectID string, operation authorization.RoleQuery) (context.Context, bool) {
+	jwt := strings.TrimPrefix(c.Request.Header.Get("Authorization"), authorization.BearerSchema)
+	if jwt == "" || authorization.IsRevokedToken(jwt, mongodb.MgoClient) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or revoked token"})
+		return nil, false
+	}
+
+	ctx := context.WithValue(c.Request.Context(), authorization.AuthKey, jwt)
+	ctx = context.WithValue(ctx, authorization.ClientIPKey, c.ClientIP())
+	if err := authorization.ValidateRole(ctx, projectID, operation, model.InvitationAccepted.String()); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return ctx, true
+}
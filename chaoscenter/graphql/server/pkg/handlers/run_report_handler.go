@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/authorization"
+	chaosExperimentRun "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/chaos_experiment_run"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
+	dbChaosExperiment "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_experiment"
+	dbChaosExperimentRun "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_experiment_run"
+)
+
+// RunReportHandler downloads the report of an experiment run as JUnit XML, a JSON summary or a SARIF log,
+// depending on the format query parameter, so that CI systems can show the chaos results natively
+func RunReportHandler(mongodbOperator mongodb.MongoOperator) gin.HandlerFunc {
+	experimentOperator := dbChaosExperiment.NewChaosExperimentOperator(mongodbOperator)
+	experimentRunOperator := dbChaosExperimentRun.NewChaosExperimentRunOperator(mongodbOperator)
+
+	return func(c *gin.Context) {
+		experimentRunID := c.Param("experimentRunID")
+		projectID := c.Query("projectID")
+		format := c.DefaultQuery("format", chaosExperimentRun.ReportFormatJUnit)
+
+		switch format {
+		case chaosExperimentRun.ReportFormatJUnit, chaosExperimentRun.ReportFormatJSON, chaosExperimentRun.ReportFormatSARIF:
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unsupported report format %q", format)})
+			return
+		}
+
+		if _, ok := authorizeProjectRequest(c, projectID, authorization.GetWorkflowRun); !ok {
+			return
+		}
+
+		run, err := experimentRunOperator.GetExperimentRun(bson.D{
+			{"project_id", projectID},
+			{"experiment_run_id", experimentRunID},
+			{"is_removed", false},
+		})
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "no experiment run found with experimentRunID " + experimentRunID})
+			return
+		} else if err != nil {
+			logrus.WithField("experimentRunID", experimentRunID).Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		experiment, err := experimentOperator.GetExperiment(c.Request.Context(), bson.D{
+			{"experiment_id", run.ExperimentID},
+			{"project_id", projectID},
+		})
+		if err != nil && err != mongo.ErrNoDocuments {
+			logrus.WithField("experimentRunID", experimentRunID).Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		report, err := chaosExperimentRun.NewRunReport(experiment.Name, run)
+		if err != nil {
+			logrus.WithField("experimentRunID", experimentRunID).Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		var (
+			body        []byte
+			contentType string
+			extension   string
+		)
+		switch format {
+		case chaosExperimentRun.ReportFormatJUnit:
+			body, err = report.JUnit()
+			contentType, extension = "application/xml", "xml"
+		case chaosExperimentRun.ReportFormatJSON:
+			body, err = report.JSON()
+			contentType, extension = "application/json", "json"
+		case chaosExperimentRun.ReportFormatSARIF:
+			body, err = report.SARIF()
+			contentType, extension = "application/sarif+json", "sarif"
+		}
+		if err != nil {
+			logrus.WithField("experimentRunID", experimentRunID).Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.%s", experimentRunID, extension))
+		c.Data(http.StatusOK, contentType, body)
+	}
+}
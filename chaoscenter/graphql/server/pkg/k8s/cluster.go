@@ -11,6 +11,7 @@ import (
 
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/utils"
 
+	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -265,3 +266,13 @@ func GetTLSCert(secretName string) (string, error) {
 	}
 	return "", fmt.Errorf("could not find tls.crt value in provided TLS Secret %v", secretName)
 }
+
+// GetSecret returns a secret of the namespace of the portal
+func GetSecret(secretName string) (*v1.Secret, error) {
+	clientset, err := GetGenericK8sClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.CoreV1().Secrets(utils.Config.LitmusPortalNamespace).Get(context.Background(), secretName, metaV1.GetOptions{})
+}
@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/data-store"
+)
+
+const namespace = "litmus"
+
+var (
+	// ExperimentRuns counts the experiment runs which completed, by project and phase
+	ExperimentRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "experiment_runs_total",
+		Help:      "Number of completed experiment runs by project and phase",
+	}, []string{"project_id", "phase"})
+
+	// ResiliencyScore observes the resiliency scores of the completed experiment runs
+	ResiliencyScore = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "experiment_run_resiliency_score",
+		Help:      "Resiliency score of the completed experiment runs",
+		Buckets:   prometheus.LinearBuckets(10, 10, 10),
+	}, []string{"project_id"})
+
+	// ChaosHubSyncDuration observes the time taken to sync a chaos hub
+	ChaosHubSyncDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "chaos_hub_sync_duration_seconds",
+		Help:      "Time taken to sync a chaos hub",
+		Buckets:   prometheus.ExponentialBuckets(0.5, 2, 10),
+	}, []string{"hub_type"})
+
+	// ChaosHubSyncFailures counts the chaos hub syncs which failed
+	ChaosHubSyncFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "chaos_hub_sync_failures_total",
+		Help:      "Number of failed chaos hub syncs",
+	}, []string{"hub_type"})
+
+	// GitOpsSyncFailures counts the GitOps repository syncs which failed
+	GitOpsSyncFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gitops_sync_failures_total",
+		Help:      "Number of failed GitOps repository syncs",
+	})
+
+	resolverDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "graphql_resolver_duration_seconds",
+		Help:      "Latency of the root GraphQL resolvers by operation",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation_type", "operation"})
+)
+
+func init() {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "connected_infras",
+		Help:      "Number of chaos infrastructures connected to the server",
+	}, func() float64 {
+		data_store.Store.Mutex.Lock()
+		defer data_store.Store.Mutex.Unlock()
+		return float64(len(data_store.Store.ConnectedInfra))
+	})
+
+	// channels of the subscriptions of each kind registered in the application state
+	subscriptions := map[string]func(*data_store.StateData) int{
+		"infra_events": func(s *data_store.StateData) int {
+			count := 0
+			for _, channels := range s.InfraEventPublish {
+				count += len(channels)
+			}
+			return count
+		},
+		"infra_connect": func(s *data_store.StateData) int {
+			return len(s.ConnectedInfra)
+		},
+		"pod_logs": func(s *data_store.StateData) int {
+			return len(s.ExperimentLog)
+		},
+		"kube_objects": func(s *data_store.StateData) int {
+			return len(s.KubeObjectData)
+		},
+	}
+	for kind, count := range subscriptions {
+		count := count
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Name:        "active_subscriptions",
+			Help:        "Number of active subscription channels by kind",
+			ConstLabels: prometheus.Labels{"kind": kind},
+		}, func() float64 {
+			data_store.Store.Mutex.Lock()
+			defer data_store.Store.Mutex.Unlock()
+			return float64(count(data_store.Store))
+		})
+	}
+}
+
+// ObserveExperimentRun records a completed experiment run
+func ObserveExperimentRun(projectID, phase string, resiliencyScore float64) {
+	ExperimentRuns.WithLabelValues(projectID, phase).Inc()
+	ResiliencyScore.WithLabelValues(projectID).Observe(resiliencyScore)
+}
+
+// Handler serves the metrics to the scrapers presenting the token as a Bearer token, the metrics are labelled
+// with the IDs of the projects so they aren't served without it
+func Handler(token string) http.Handler {
+	metrics := promhttp.Handler()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" || !ok || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		metrics.ServeHTTP(w, r)
+	})
+}
+
+// ResolverLatency is a gqlgen extension which records the latency of the root resolvers, labelled
+// by the operation type and the name of the query, mutation or subscription
+type ResolverLatency struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.FieldInterceptor
+} = ResolverLatency{}
+
+func (ResolverLatency) ExtensionName() string {
+	return "ResolverLatency"
+}
+
+func (ResolverLatency) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (ResolverLatency) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || !isRootObject(fc.Object) {
+		return next(ctx)
+	}
+
+	start := time.Now()
+	res, err := next(ctx)
+	resolverDuration.WithLabelValues(fc.Object, fc.Field.Name).Observe(time.Since(start).Seconds())
+	return res, err
+}
+
+func isRootObject(object string) bool {
+	return object == "Query" || object == "Mutation" || object == "Subscription"
+}
@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/utils"
+)
+
+// webhookDialer connects to the webhooks, the addresses are checked once they are resolved so that a host
+// can't be resolved to an internal address after the URL is checked
+var webhookDialer = &net.Dialer{
+	Timeout: sendTimeout,
+	Control: checkWebhookAddress,
+}
+
+// newWebhookTransport returns the transport of the webhooks, which refuses to connect to the internal addresses
+func newWebhookTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = webhookDialer.DialContext
+	return transport
+}
+
+// checkWebhookAddress refuses the connections to the loopback, link-local, private and unspecified addresses,
+// unless they are in the NotificationAllowedNetworks. The webhooks could reach the services of the cluster
+// or the metadata of the cloud instances otherwise
+func checkWebhookAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return permanentError{err}
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return permanentError{fmt.Errorf("invalid address %s", address)}
+	}
+
+	for _, cidr := range utils.Config.NotificationAllowedNetworks {
+		if _, allowed, err := net.ParseCIDR(cidr); err == nil && allowed.Contains(ip) {
+			return nil
+		}
+	}
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsPrivate() ||
+		ip.IsUnspecified() || ip.IsMulticast() {
+		return permanentError{fmt.Errorf("address %s of the webhook isn't allowed, it's internal", ip)}
+	}
+	return nil
+}
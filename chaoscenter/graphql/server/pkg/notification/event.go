@@ -0,0 +1,88 @@
+package notification
+
+import (
+	"fmt"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	dbNotificationChannel "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/notification_channel"
+)
+
+// Event is a lifecycle event of a project sent to its notification channels
+type Event struct {
+	Type            model.NotificationEventType `json:"type"`
+	ProjectID       string                      `json:"projectID"`
+	ExperimentID    string                      `json:"experimentID,omitempty"`
+	ExperimentName  string                      `json:"experimentName,omitempty"`
+	ExperimentRunID string                      `json:"experimentRunID,omitempty"`
+	ExperimentTags  []string                    `json:"experimentTags,omitempty"`
+	Phase           string                      `json:"phase,omitempty"`
+	ResiliencyScore *float64                    `json:"resiliencyScore,omitempty"`
+	FailedProbes    int                         `json:"failedProbes,omitempty"`
+	InfraID         string                      `json:"infraID,omitempty"`
+	InfraName       string                      `json:"infraName,omitempty"`
+	EnvironmentID   string                      `json:"environmentID,omitempty"`
+	Timestamp       int64                       `json:"timestamp"`
+}
+
+// Matches returns true if the event passes all the filters of a channel. Events without
+// experiment details, like the infra events, don't match channels filtering experiment tags or phases
+func (e Event) Matches(filter dbNotificationChannel.Filter) bool {
+	if len(filter.EventTypes) > 0 {
+		found := false
+		for _, eventType := range filter.EventTypes {
+			if eventType == e.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(filter.ExperimentTags) > 0 && !containsAny(filter.ExperimentTags, e.ExperimentTags) {
+		return false
+	}
+	if len(filter.Phases) > 0 && !containsAny(filter.Phases, []string{e.Phase}) {
+		return false
+	}
+	if len(filter.EnvironmentIDs) > 0 && !containsAny(filter.EnvironmentIDs, []string{e.EnvironmentID}) {
+		return false
+	}
+	return true
+}
+
+// Summary returns a human readable description of the event
+func (e Event) Summary() string {
+	switch e.Type {
+	case model.NotificationEventTypeExperimentRunStarted:
+		return fmt.Sprintf("Experiment %s started a new run %s", e.ExperimentName, e.ExperimentRunID)
+	case model.NotificationEventTypeExperimentRunCompleted:
+		summary := fmt.Sprintf("Experiment %s run %s completed with phase %s", e.ExperimentName, e.ExperimentRunID, e.Phase)
+		if e.ResiliencyScore != nil {
+			summary += fmt.Sprintf(" and resiliency score %v", *e.ResiliencyScore)
+		}
+		return summary
+	case model.NotificationEventTypeProbeFailed:
+		return fmt.Sprintf("%d probe(s) failed in experiment %s run %s", e.FailedProbes, e.ExperimentName, e.ExperimentRunID)
+	case model.NotificationEventTypeInfraConnected:
+		return fmt.Sprintf("Chaos infrastructure %s connected", e.InfraName)
+	case model.NotificationEventTypeInfraDisconnected:
+		return fmt.Sprintf("Chaos infrastructure %s disconnected", e.InfraName)
+	case model.NotificationEventTypeInfraDeleted:
+		return fmt.Sprintf("Chaos infrastructure %s deleted", e.InfraName)
+	default:
+		return string(e.Type)
+	}
+}
+
+func containsAny(values []string, candidates []string) bool {
+	for _, value := range values {
+		for _, candidate := range candidates {
+			if value == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
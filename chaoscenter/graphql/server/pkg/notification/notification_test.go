@@ -0,0 +1,279 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	dbNotificationChannel "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/notification_channel"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/utils"
+)
+
+func TestEvent_Matches(t *testing.T) {
+	runEvent := Event{
+		Type:           model.NotificationEventTypeExperimentRunCompleted,
+		ExperimentTags: []string{"checkout", "nightly"},
+		Phase:          "Completed",
+		EnvironmentID:  "staging",
+	}
+	infraEvent := Event{
+		Type:          model.NotificationEventTypeInfraDisconnected,
+		EnvironmentID: "staging",
+	}
+
+	testcases := []struct {
+		name   string
+		event  Event
+		filter dbNotificationChannel.Filter
+		want   bool
+	}{
+		{
+			name:  "empty filter",
+			event: runEvent,
+			want:  true,
+		},
+		{
+			name:  "all filters match",
+			event: runEvent,
+			filter: dbNotificationChannel.Filter{
+				EventTypes:     []model.NotificationEventType{model.NotificationEventTypeExperimentRunCompleted},
+				ExperimentTags: []string{"nightly"},
+				Phases:         []string{"Completed", "Error"},
+				EnvironmentIDs: []string{"staging"},
+			},
+			want: true,
+		},
+		{
+			name:   "event type doesn't match",
+			event:  runEvent,
+			filter: dbNotificationChannel.Filter{EventTypes: []model.NotificationEventType{model.NotificationEventTypeProbeFailed}},
+			want:   false,
+		},
+		{
+			name:   "environment doesn't match",
+			event:  runEvent,
+			filter: dbNotificationChannel.Filter{EnvironmentIDs: []string{"production"}},
+			want:   false,
+		},
+		{
+			name:   "infra event with an environment filter",
+			event:  infraEvent,
+			filter: dbNotificationChannel.Filter{EnvironmentIDs: []string{"staging"}},
+			want:   true,
+		},
+		{
+			name:   "infra event with an experiment tag filter",
+			event:  infraEvent,
+			filter: dbNotificationChannel.Filter{ExperimentTags: []string{"nightly"}},
+			want:   false,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.event.Matches(tc.filter); got != tc.want {
+				t.Errorf("Matches() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWebhookSender(t *testing.T) {
+	retryBackoff = time.Millisecond
+	// the test servers listen on the loopback
+	allowedNetworks := utils.Config.NotificationAllowedNetworks
+	defer func() { utils.Config.NotificationAllowedNetworks = allowedNetworks }()
+	utils.Config.NotificationAllowedNetworks = []string{"127.0.0.0/8"}
+
+	event := Event{
+		Type:      model.NotificationEventTypeInfraDeleted,
+		ProjectID: "project-id",
+		InfraName: "infra",
+	}
+
+	testcases := []struct {
+		name         string
+		statuses     []int
+		wantErr      bool
+		wantAttempts int
+	}{
+		{
+			name:         "delivered on the first attempt",
+			statuses:     []int{http.StatusOK},
+			wantAttempts: 1,
+		},
+		{
+			name:         "delivered after a server error",
+			statuses:     []int{http.StatusBadGateway, http.StatusAccepted},
+			wantAttempts: 2,
+		},
+		{
+			name:         "client errors are not retried",
+			statuses:     []int{http.StatusNotFound},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "gives up after the maximum attempts",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			wantErr:      true,
+			wantAttempts: maxAttempts,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				attempts    int
+				deliveryIDs = map[string]bool{}
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if got, want := r.Header.Get(SignatureHeader), Sign("secret", body); got != want {
+					t.Errorf("signature = %s, want %s", got, want)
+				}
+				if r.Header.Get(EventHeader) != string(event.Type) {
+					t.Errorf("event header = %s, want %s", r.Header.Get(EventHeader), event.Type)
+				}
+				deliveryIDs[r.Header.Get(DeliveryHeader)] = true
+
+				w.WriteHeader(tc.statuses[attempts])
+				attempts++
+			}))
+			defer server.Close()
+
+			sender, err := NewSender(dbNotificationChannel.NotificationChannel{
+				Type:    model.NotificationChannelTypeWebhook,
+				Webhook: &dbNotificationChannel.WebhookConfig{URL: server.URL, Secret: "secret"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = sendWithRetry(sender, event)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("sendWithRetry() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if attempts != tc.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tc.wantAttempts)
+			}
+			if len(deliveryIDs) != 1 {
+				t.Errorf("retries have to reuse the delivery ID, got %v", deliveryIDs)
+			}
+		})
+	}
+}
+
+func TestWebhookSenderRefusesInternalAddresses(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+	}))
+	defer server.Close()
+
+	sender, err := NewSender(dbNotificationChannel.NotificationChannel{
+		Type:  model.NotificationChannelTypeSlack,
+		Slack: &dbNotificationChannel.SlackConfig{WebhookURL: server.URL},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sendWithRetry(sender, Event{Type: model.NotificationEventTypeInfraDeleted})
+	var permanent permanentError
+	if !errors.As(err, &permanent) {
+		t.Errorf("sendWithRetry() error = %v, want a permanent error", err)
+	}
+	if attempts != 0 {
+		t.Errorf("the webhook on the loopback received %d requests", attempts)
+	}
+}
+
+func TestCheckWebhookAddress(t *testing.T) {
+	allowedNetworks := utils.Config.NotificationAllowedNetworks
+	defer func() { utils.Config.NotificationAllowedNetworks = allowedNetworks }()
+	utils.Config.NotificationAllowedNetworks = []string{"10.1.0.0/16"}
+
+	testcases := map[string]bool{
+		"203.0.113.10:443":    true,
+		"[2001:db8::1]:443":   true,
+		"10.1.2.3:8080":       true,
+		"127.0.0.1:80":        false,
+		"[::1]:80":            false,
+		"169.254.169.254:80":  false,
+		"10.96.0.1:443":       false,
+		"192.168.1.1:80":      false,
+		"0.0.0.0:80":          false,
+		"[fe80::1%eth0]:8080": false,
+	}
+	for address, wantAllowed := range testcases {
+		if err := checkWebhookAddress("tcp", address, nil); (err == nil) != wantAllowed {
+			t.Errorf("checkWebhookAddress(%s) error = %v, want allowed %v", address, err, wantAllowed)
+		}
+	}
+}
+
+func TestSMTPSenderTimeout(t *testing.T) {
+	// the server accepts the connections but never greets the client
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	sender := &smtpSender{config: dbNotificationChannel.SMTPConfig{Host: "127.0.0.1", Port: addr.Port, From: "litmus@example.com", To: []string{"sre@example.com"}}}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- sender.Send(ctx, "delivery", Event{Type: model.NotificationEventTypeInfraDeleted}) }()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Send() succeeded without a greeting from the server")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Send() kept waiting for the stalled server")
+	}
+}
+
+func TestUpdateChannelConfigKeepsSecrets(t *testing.T) {
+	secret, password, empty := "secret", "password", ""
+
+	webhook := dbNotificationChannel.UpdateWebhookConfig(&dbNotificationChannel.WebhookConfig{URL: "https://old.example.com", Secret: secret}, &model.WebhookConfigInput{URL: "https://new.example.com"})
+	if webhook.URL != "https://new.example.com" || webhook.Secret != secret {
+		t.Errorf("UpdateWebhookConfig() = %+v, want the new URL and the previous secret", webhook)
+	}
+	webhook = dbNotificationChannel.UpdateWebhookConfig(webhook, &model.WebhookConfigInput{URL: webhook.URL, Secret: &empty})
+	if webhook.Secret != "" {
+		t.Errorf("UpdateWebhookConfig() kept the secret %q, want it removed", webhook.Secret)
+	}
+
+	smtp := dbNotificationChannel.UpdateSMTPConfig(&dbNotificationChannel.SMTPConfig{Host: "smtp.example.com", Port: 587, Username: "litmus", Password: password}, &model.SMTPConfigInput{Host: "smtp.example.com", Port: 465, From: "litmus@example.com", To: []string{"sre@example.com"}})
+	if smtp.Port != 465 || smtp.Username != "litmus" || smtp.Password != password {
+		t.Errorf("UpdateSMTPConfig() = %+v, want the new port and the previous credentials", smtp)
+	}
+}
+
+func TestGetOutputNotificationChannelMasksSlackWebhook(t *testing.T) {
+	channel := dbNotificationChannel.NotificationChannel{
+		Type:  model.NotificationChannelTypeSlack,
+		Slack: &dbNotificationChannel.SlackConfig{WebhookURL: "https://hooks.slack.com/services/T000/B000/XXXX"},
+	}
+	if got := channel.GetOutputNotificationChannel().Slack.WebhookHost; got != "hooks.slack.com" {
+		t.Errorf("GetOutputNotificationChannel() returned the Slack webhook host %q, want hooks.slack.com", got)
+	}
+}
@@ -0,0 +1,246 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	dbNotificationChannel "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/notification_channel"
+)
+
+const (
+	// SignatureHeader is the header carrying the HMAC-SHA256 signature of the webhook payloads
+	SignatureHeader = "X-Litmus-Signature"
+	// EventHeader is the header carrying the type of the event posted to the webhooks
+	EventHeader = "X-Litmus-Event"
+	// DeliveryHeader is the header carrying the unique ID of a delivery, which is the same across its retries
+	DeliveryHeader = "X-Litmus-Delivery"
+
+	sendTimeout = 10 * time.Second
+)
+
+var (
+	// maxAttempts is the number of times a notification is sent before giving up
+	maxAttempts = 3
+	// retryBackoff is the wait before the first retry, it doubles with every attempt
+	retryBackoff = 2 * time.Second
+
+	httpClient = &http.Client{Timeout: sendTimeout, Transport: newWebhookTransport()}
+)
+
+// Sender delivers the events to a notification channel
+type Sender interface {
+	Send(ctx context.Context, deliveryID string, event Event) error
+}
+
+// permanentError is returned for the failures which won't succeed on a retry
+type permanentError struct {
+	err error
+}
+
+func (p permanentError) Error() string {
+	return p.err.Error()
+}
+
+// NewSender returns the sender of the notification channel's type
+func NewSender(channel dbNotificationChannel.NotificationChannel) (Sender, error) {
+	switch {
+	case channel.Type == model.NotificationChannelTypeWebhook && channel.Webhook != nil:
+		return &webhookSender{config: *channel.Webhook}, nil
+	case channel.Type == model.NotificationChannelTypeSlack && channel.Slack != nil:
+		return &slackSender{config: *channel.Slack}, nil
+	case channel.Type == model.NotificationChannelTypeSMTP && channel.SMTP != nil:
+		return &smtpSender{config: *channel.SMTP}, nil
+	default:
+		return nil, fmt.Errorf("notification channel %s has no config for type %s", channel.ChannelID, channel.Type)
+	}
+}
+
+// sendWithRetry sends the event, retrying with an exponential backoff on failures which may be transient
+func sendWithRetry(sender Sender, event Event) error {
+	var (
+		deliveryID = uuid.New().String()
+		backoff    = retryBackoff
+		err        error
+	)
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		err = sender.Send(ctx, deliveryID, event)
+		cancel()
+
+		var permanent permanentError
+		if err == nil || errors.As(err, &permanent) {
+			return err
+		}
+		if attempt < maxAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", maxAttempts, err)
+}
+
+// Sign returns the HMAC-SHA256 signature of a webhook payload, as sent in the SignatureHeader
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// postJSON posts the payload to the url, the client errors except timeouts and rate limits are permanent
+func postJSON(ctx context.Context, url string, payload []byte, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return permanentError{err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("unexpected response status %s", resp.Status)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return permanentError{err}
+	}
+	return err
+}
+
+// webhookSender posts the events as JSON to a generic webhook
+type webhookSender struct {
+	config dbNotificationChannel.WebhookConfig
+}
+
+func (w *webhookSender) Send(ctx context.Context, deliveryID string, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return permanentError{err}
+	}
+
+	headers := map[string]string{
+		EventHeader:    string(event.Type),
+		DeliveryHeader: deliveryID,
+	}
+	if w.config.Secret != "" {
+		headers[SignatureHeader] = Sign(w.config.Secret, payload)
+	}
+	return postJSON(ctx, w.config.URL, payload, headers)
+}
+
+// slackSender posts the events to a Slack compatible incoming webhook
+type slackSender struct {
+	config dbNotificationChannel.SlackConfig
+}
+
+func (s *slackSender) Send(ctx context.Context, deliveryID string, event Event) error {
+	payload, err := json.Marshal(map[string]string{
+		"text": event.Summary(),
+	})
+	if err != nil {
+		return permanentError{err}
+	}
+	return postJSON(ctx, s.config.WebhookURL, payload, nil)
+}
+
+// smtpSender emails the events through an SMTP server
+type smtpSender struct {
+	config dbNotificationChannel.SMTPConfig
+}
+
+// Send sends the email like smtp.SendMail, the connection is closed once the context is done so that a stalled
+// server doesn't keep the sender waiting
+func (s *smtpSender) Send(ctx context.Context, deliveryID string, event Event) error {
+	dialer := net.Dialer{Timeout: sendTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port)))
+	if err != nil {
+		return err
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(sendTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.config.Host}); err != nil {
+			return err
+		}
+	}
+	if s.config.Username != "" {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return permanentError{errors.New("smtp server doesn't support AUTH")}
+		}
+		if err := client.Auth(smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(s.config.From); err != nil {
+		return err
+	}
+	for _, to := range s.config.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(s.message(deliveryID, event)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func (s *smtpSender) message(deliveryID string, event Event) []byte {
+	// the summary contains user provided names, which mustn't break out of the subject header
+	summary := strings.NewReplacer("\r", " ", "\n", " ").Replace(event.Summary())
+	details, _ := json.MarshalIndent(event, "", "  ")
+
+	var msg strings.Builder
+	msg.WriteString("From: " + s.config.From + "\r\n")
+	msg.WriteString("To: " + strings.Join(s.config.To, ", ") + "\r\n")
+	msg.WriteString("Subject: [Litmus] " + summary + "\r\n")
+	msg.WriteString("Message-ID: <" + deliveryID + "@litmus>\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n")
+	msg.WriteString(summary + "\r\n\r\n")
+	msg.Write(details)
+	msg.WriteString("\r\n")
+	return []byte(msg.String())
+}
@@ -0,0 +1,311 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/authorization"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
+	dbNotificationChannel "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/notification_channel"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Service is the interface for the notification service
+type Service interface {
+	CreateNotificationChannel(ctx context.Context, projectID string, request model.CreateNotificationChannelRequest) (*model.NotificationChannel, error)
+	UpdateNotificationChannel(ctx context.Context, projectID string, request model.UpdateNotificationChannelRequest) (*model.NotificationChannel, error)
+	DeleteNotificationChannel(ctx context.Context, projectID string, channelID string) (string, error)
+	GetNotificationChannel(ctx context.Context, projectID string, channelID string) (*model.NotificationChannel, error)
+	ListNotificationChannels(ctx context.Context, projectID string) ([]*model.NotificationChannel, error)
+	Notify(event Event)
+}
+
+// notificationService is the implementation of Service interface
+type notificationService struct {
+	notificationChannelOperator *dbNotificationChannel.Operator
+}
+
+// NewNotificationService returns a new instance of notificationService
+func NewNotificationService(notificationChannelOperator *dbNotificationChannel.Operator) Service {
+	return &notificationService{
+		notificationChannelOperator: notificationChannelOperator,
+	}
+}
+
+func (n *notificationService) CreateNotificationChannel(ctx context.Context, projectID string, request model.CreateNotificationChannelRequest) (*model.NotificationChannel, error) {
+	tkn := ctx.Value(authorization.AuthKey).(string)
+	username, err := authorization.GetUsername(tkn)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		currentTime = time.Now().UnixMilli()
+		description = ""
+		enabled     = true
+	)
+	if request.Description != nil {
+		description = *request.Description
+	}
+	if request.Enabled != nil {
+		enabled = *request.Enabled
+	}
+	if request.Tags == nil {
+		request.Tags = []string{}
+	}
+
+	channel := dbNotificationChannel.NotificationChannel{
+		ProjectID: projectID,
+		ChannelID: uuid.New().String(),
+		ResourceDetails: mongodb.ResourceDetails{
+			Name:        request.Name,
+			Description: description,
+			Tags:        request.Tags,
+		},
+		Type:    request.Type,
+		Enabled: enabled,
+		Webhook: dbNotificationChannel.NewWebhookConfig(request.Webhook),
+		Slack:   dbNotificationChannel.NewSlackConfig(request.Slack),
+		SMTP:    dbNotificationChannel.NewSMTPConfig(request.SMTP),
+		Filter:  dbNotificationChannel.NewFilter(request.Filter),
+		Audit: mongodb.Audit{
+			CreatedAt: currentTime,
+			UpdatedAt: currentTime,
+			IsRemoved: false,
+			CreatedBy: mongodb.UserDetailResponse{
+				Username: username,
+			},
+			UpdatedBy: mongodb.UserDetailResponse{
+				Username: username,
+			},
+		},
+	}
+	if err := validateChannel(channel); err != nil {
+		return nil, err
+	}
+
+	err = n.notificationChannelOperator.InsertNotificationChannel(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	return channel.GetOutputNotificationChannel(), nil
+}
+
+func (n *notificationService) UpdateNotificationChannel(ctx context.Context, projectID string, request model.UpdateNotificationChannelRequest) (*model.NotificationChannel, error) {
+	tkn := ctx.Value(authorization.AuthKey).(string)
+	username, err := authorization.GetUsername(tkn)
+	if err != nil {
+		return nil, err
+	}
+
+	query := bson.D{
+		{"project_id", projectID},
+		{"channel_id", request.ChannelID},
+		{"is_removed", false},
+	}
+	channel, err := n.notificationChannelOperator.GetNotificationChannel(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Name != nil {
+		channel.Name = *request.Name
+	}
+	if request.Description != nil {
+		channel.Description = *request.Description
+	}
+	if request.Tags != nil {
+		channel.Tags = request.Tags
+	}
+	if request.Enabled != nil {
+		channel.Enabled = *request.Enabled
+	}
+	if request.Webhook != nil {
+		channel.Webhook = dbNotificationChannel.UpdateWebhookConfig(channel.Webhook, request.Webhook)
+	}
+	if request.Slack != nil {
+		channel.Slack = dbNotificationChannel.NewSlackConfig(request.Slack)
+	}
+	if request.SMTP != nil {
+		channel.SMTP = dbNotificationChannel.UpdateSMTPConfig(channel.SMTP, request.SMTP)
+	}
+	if request.Filter != nil {
+		channel.Filter = dbNotificationChannel.NewFilter(request.Filter)
+	}
+	channel.UpdatedAt = time.Now().UnixMilli()
+	channel.UpdatedBy = mongodb.UserDetailResponse{
+		Username: username,
+	}
+	if err := validateChannel(channel); err != nil {
+		return nil, err
+	}
+
+	update := bson.D{
+		{"$set", bson.D{
+			{"name", channel.Name},
+			{"description", channel.Description},
+			{"tags", channel.Tags},
+			{"enabled", channel.Enabled},
+			{"webhook", channel.Webhook},
+			{"slack", channel.Slack},
+			{"smtp", channel.SMTP},
+			{"filter", channel.Filter},
+			{"updated_at", channel.UpdatedAt},
+			{"updated_by", channel.UpdatedBy},
+		}},
+	}
+	err = n.notificationChannelOperator.UpdateNotificationChannel(ctx, query, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return channel.GetOutputNotificationChannel(), nil
+}
+
+func (n *notificationService) DeleteNotificationChannel(ctx context.Context, projectID string, channelID string) (string, error) {
+	tkn := ctx.Value(authorization.AuthKey).(string)
+	username, err := authorization.GetUsername(tkn)
+	if err != nil {
+		return "", err
+	}
+
+	query := bson.D{
+		{"project_id", projectID},
+		{"channel_id", channelID},
+		{"is_removed", false},
+	}
+	_, err = n.notificationChannelOperator.GetNotificationChannel(ctx, query)
+	if err != nil {
+		return "couldn't fetch notification channel details", err
+	}
+
+	update := bson.D{
+		{"$set", bson.D{
+			{"is_removed", true},
+			{"updated_at", time.Now().UnixMilli()},
+			{"updated_by", mongodb.UserDetailResponse{
+				Username: username,
+			}},
+		}},
+	}
+	err = n.notificationChannelOperator.UpdateNotificationChannel(ctx, query, update)
+	if err != nil {
+		return "couldn't delete notification channel", err
+	}
+	return "successfully deleted notification channel", nil
+}
+
+func (n *notificationService) GetNotificationChannel(ctx context.Context, projectID string, channelID string) (*model.NotificationChannel, error) {
+	channel, err := n.notificationChannelOperator.GetNotificationChannel(ctx, bson.D{
+		{"project_id", projectID},
+		{"channel_id", channelID},
+		{"is_removed", false},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return channel.GetOutputNotificationChannel(), nil
+}
+
+func (n *notificationService) ListNotificationChannels(ctx context.Context, projectID string) ([]*model.NotificationChannel, error) {
+	channels, err := n.notificationChannelOperator.GetNotificationChannels(ctx, bson.D{
+		{"project_id", projectID},
+		{"is_removed", false},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*model.NotificationChannel, 0, len(channels))
+	for _, channel := range channels {
+		response = append(response, channel.GetOutputNotificationChannel())
+	}
+	return response, nil
+}
+
+// Notify sends the event to the enabled channels of its project whose filters match it. The
+// channels are notified in the background, so slow or unreachable channels don't hold up the caller
+func (n *notificationService) Notify(event Event) {
+	if event.Timestamp == 0 {
+		event.Timestamp = time.Now().UnixMilli()
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		channels, err := n.notificationChannelOperator.GetNotificationChannels(ctx, bson.D{
+			{"project_id", event.ProjectID},
+			{"enabled", true},
+			{"is_removed", false},
+		})
+		cancel()
+		if err != nil {
+			logrus.WithField("projectID", event.ProjectID).Errorf("failed to get notification channels, error: %v", err)
+			return
+		}
+
+		for _, channel := range channels {
+			if !event.Matches(channel.Filter) {
+				continue
+			}
+
+			sender, err := NewSender(channel)
+			if err != nil {
+				logrus.WithField("channelID", channel.ChannelID).Error(err)
+				continue
+			}
+			go func(channel dbNotificationChannel.NotificationChannel) {
+				if err := sendWithRetry(sender, event); err != nil {
+					logrus.WithFields(logrus.Fields{
+						"projectID": event.ProjectID,
+						"channelID": channel.ChannelID,
+						"event":     event.Type,
+					}).Errorf("failed to send notification, error: %v", err)
+				}
+			}(channel)
+		}
+	}()
+}
+
+// validateChannel checks that the channel has the config of its type
+func validateChannel(channel dbNotificationChannel.NotificationChannel) error {
+	switch channel.Type {
+	case model.NotificationChannelTypeWebhook:
+		if channel.Webhook == nil {
+			return errors.New("webhook config is required for webhook channels")
+		}
+		return validateURL(channel.Webhook.URL)
+	case model.NotificationChannelTypeSlack:
+		if channel.Slack == nil {
+			return errors.New("slack config is required for slack channels")
+		}
+		return validateURL(channel.Slack.WebhookURL)
+	case model.NotificationChannelTypeSMTP:
+		if channel.SMTP == nil {
+			return errors.New("smtp config is required for smtp channels")
+		}
+		if channel.SMTP.Host == "" || channel.SMTP.Port <= 0 {
+			return errors.New("smtp host and port are required")
+		}
+		if len(channel.SMTP.To) == 0 {
+			return errors.New("at least one recipient is required for smtp channels")
+		}
+		return nil
+	default:
+		return errors.New("unsupported notification channel type")
+	}
+}
+
+func validateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("invalid url, only http and https urls are supported")
+	}
+	return nil
+}
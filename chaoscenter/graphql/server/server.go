@@ -4,10 +4,30 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/api/middleware"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/audit"
+	chaosExperimentOps "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/chaos_experiment/ops"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/chaos_infrastructure"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/chaoshub"
 	handler2 "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/chaoshub/handler"
+	data_store "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/data-store"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb"
+	dbAuditLog "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/audit_log"
+	dbChaosExperiment "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_experiment"
+	dbChaosExperimentRun "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_experiment_run"
 	dbSchemaChaosHub "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_hub"
+	dbChaosInfra "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/chaos_infrastructure"
+	dbEnvironments "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/environments"
+	dbGitOps "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/gitops"
+	dbImageRegistry "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/image_registry"
+	dbInfraAction "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/infra_action"
+	dbInfraUpgrade "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/infra_upgrade"
+	dbNotificationChannel "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/notification_channel"
+	dbStateBus "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/state_bus"
+	envHandler "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/environment/handler"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/gitops"
+	imageRegistry "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/image_registry"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/notification"
+	probe "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/probe/handler"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/projects"
 
 	"context"
@@ -32,6 +52,7 @@ import (
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/generated"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/config"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/handlers"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/metrics"
 	pb "github.com/litmuschaos/litmus/chaoscenter/graphql/server/protos"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
@@ -47,6 +68,10 @@ func init() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	// the infras send a heartbeat every 30 seconds
+	if utils.Config.InfraHeartbeatTimeout < 30*time.Second {
+		log.Fatalf("INFRA_HEARTBEAT_TIMEOUT %s is shorter than the 30s interval of the heartbeats", utils.Config.InfraHeartbeatTimeout)
+	}
 
 }
 
@@ -75,6 +100,11 @@ func validateVersion() error {
 func setupGin() *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
+	// the client IPs are read from the forwarded headers only when the requests come from the trusted proxies,
+	// the clients could set them to get past the IP allow-lists of the API tokens otherwise
+	if err := router.SetTrustedProxies(utils.Config.TrustedProxies); err != nil {
+		log.Fatal(err)
+	}
 	router.Use(middleware.DefaultStructuredLogger())
 	router.Use(gin.Recovery())
 	router.Use(cors.New(cors.Config{
@@ -104,6 +134,19 @@ func main() {
 	}
 	go startGRPCServer(utils.Config.RpcPort, mongodbOperator) // start GRPC serve
 
+	// keeping the infra actions until the infras acknowledge them
+	data_store.Store.Actions = data_store.NewMongoInfraActionQueue(dbInfraAction.NewInfraActionOperator(mongodbOperator))
+
+	// sharing the subscriptions with the other replicas
+	switch utils.Config.StateBus {
+	case "memory":
+		// the store delivers to its own subscriptions by default
+	case "mongo":
+		data_store.Store.Bus = data_store.NewMongoStateBus(context.Background(), dbStateBus.NewStateBusOperator(mongodbOperator), data_store.Store)
+	default:
+		log.Fatalf("unsupported state bus %s", utils.Config.StateBus)
+	}
+
 	srv := handler.New(generated.NewExecutableSchema(graph.NewConfig(mongodbOperator)))
 	srv.AddTransport(transport.POST{})
 	srv.AddTransport(transport.GET{})
@@ -118,10 +161,30 @@ func main() {
 
 	// to be removed in production
 	srv.Use(extension.Introspection{})
+	srv.Use(metrics.ResolverLatency{})
+	// recording the mutations in the audit log
+	auditService := audit.NewAuditService(dbAuditLog.NewAuditLogOperator(mongodbOperator))
+	srv.Use(audit.NewRecorder(auditService, mongodbOperator))
 
 	// go routine for syncing chaos hubs
-	go chaoshub.NewService(dbSchemaChaosHub.NewChaosHubOperator(mongodbOperator)).RecurringHubSync()
-	go chaoshub.NewService(dbSchemaChaosHub.NewChaosHubOperator(mongodbOperator)).SyncDefaultChaosHubs()
+	chaosHubService := chaoshub.NewService(dbSchemaChaosHub.NewChaosHubOperator(mongodbOperator), dbImageRegistry.NewImageRegistryOperator(mongodbOperator))
+	go chaosHubService.RecurringHubSync()
+	go chaosHubService.SyncDefaultChaosHubs()
+
+	// go routine for marking the infras which missed their heartbeats
+	infraService := chaos_infrastructure.NewChaosInfrastructureService(dbChaosInfra.NewInfrastructureOperator(mongodbOperator),
+		dbEnvironments.NewEnvironmentOperator(mongodbOperator), dbInfraUpgrade.NewInfraUpgradeOperator(mongodbOperator),
+		notification.NewNotificationService(dbNotificationChannel.NewNotificationChannelOperator(mongodbOperator)))
+	go infraService.RecurringHeartbeatCheck(data_store.Store)
+
+	// go routine for polling the gitops repositories, the push webhooks sync them right away
+	chaosExperimentOperator := dbChaosExperiment.NewChaosExperimentOperator(mongodbOperator)
+	probeService := probe.NewProbeService()
+	chaosExperimentService := chaosExperimentOps.NewChaosExperimentService(chaosExperimentOperator, dbChaosInfra.NewInfrastructureOperator(mongodbOperator), dbChaosExperimentRun.NewChaosExperimentRunOperator(mongodbOperator), probeService)
+	gitOpsService := gitops.NewGitOpsService(dbGitOps.NewGitOpsOperator(mongodbOperator), chaosExperimentService, *chaosExperimentOperator,
+		probeService, envHandler.NewEnvironmentService(dbEnvironments.NewEnvironmentOperator(mongodbOperator)),
+		imageRegistry.NewImageRegistryService(dbImageRegistry.NewImageRegistryOperator(mongodbOperator)), chaosHubService)
+	go gitOpsService.GitOpsSyncHandler(false)
 
 	// routers
 	router.GET("/", handlers.PlaygroundHandler())
@@ -136,10 +199,18 @@ func main() {
 	//general routers
 	router.GET("/status", handlers.StatusHandler())
 	router.GET("/readiness", handlers.ReadinessHandler())
+	router.GET("/metrics", gin.WrapH(metrics.Handler(utils.Config.MetricsToken)))
+	router.GET("/verdict/:notifyID", handlers.GateVerdictHandler(mongodbOperator))
+	router.GET("/report/:experimentRunID", handlers.RunReportHandler(mongodbOperator))
+	router.GET("/audit/export", handlers.AuditExportHandler(auditService))
+	router.POST("/gitops/webhook/:projectID", handlers.GitOpsWebhookHandler(gitOpsService))
 
 	projectEventChannel := make(chan string)
 	go projects.ProjectEvents(projectEventChannel, mongodb.MgoClient, mongodbOperator)
 
+	// invalidating the cached authorization decisions when the memberships change
+	go authorization.WatchAuthChanges(context.Background(), mongodb.MgoClient)
+
 	log.Infof("chaos manager running at http://localhost:%s", utils.Config.HttpPort)
 	log.Fatal(http.ListenAndServe(":"+utils.Config.HttpPort, router))
 }
@@ -1,5 +1,7 @@
 package utils
 
+import "time"
+
 var (
 	SupportedPrivateGitRepository = []string{"github", "gitlab"}
 )
@@ -42,6 +44,30 @@ type Configuration struct {
 	DefaultHubBranchName        string `required:"true" split_words:"true"`
 	CustomChaosHubPath          string `split_words:"true" default:"/tmp/"`
 	DefaultChaosHubPath         string `split_words:"true" default:"/tmp/default/"`
+
+	// AuthGrpcPoolSize is the number of connections kept open to the Authentication service
+	AuthGrpcPoolSize int `split_words:"true" default:"4"`
+	// AuthDecisionCacheTtl is how long the allowed authorization decisions are cached, 0 disables the cache
+	AuthDecisionCacheTtl time.Duration `split_words:"true" default:"10s"`
+	// StateBus is how the subscriptions are shared by the replicas of the server, memory for a single replica
+	// or mongo to run several replicas
+	StateBus string `split_words:"true" default:"memory"`
+	// InfraHeartbeatTimeout is how long an infra can go without sending a heartbeat before it's marked unhealthy,
+	// it can't be shorter than the 30s interval of the heartbeats
+	InfraHeartbeatTimeout time.Duration `split_words:"true" default:"90s"`
+	// InfraUpgradeTimeout is how long an infra can take to report the end of an upgrade before another upgrade
+	// can be requested
+	InfraUpgradeTimeout time.Duration `split_words:"true" default:"30m"`
+	// TrustedProxies are the addresses of the proxies whose forwarded headers are trusted for the client IPs,
+	// none are trusted by default
+	TrustedProxies []string `split_words:"true"`
+	// NotificationAllowedNetworks are the CIDRs of the internal networks the webhooks of the notification
+	// channels may be posted to, like the one of an HTTP proxy. The loopback, link-local and private addresses
+	// are refused otherwise
+	NotificationAllowedNetworks []string `split_words:"true"`
+	// MetricsToken is the Bearer token the Prometheus scrapers present to get the metrics, they aren't served
+	// when it isn't set
+	MetricsToken string `split_words:"true"`
 }
 
 var Config Configuration
@@ -0,0 +1,290 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// outboxRetryInterval is the interval at which undelivered events are retried
+	outboxRetryInterval = 10 * time.Second
+
+	// maxOutboxBackoff is the longest delay between two attempts to deliver an event
+	maxOutboxBackoff = 10 * time.Minute
+
+	// outboxAlertAttempts is the number of failed attempts after which an undelivered event is
+	// reported as an error, the event is kept and retried until the server accepts it
+	outboxAlertAttempts = 20
+
+	outboxFileSuffix = ".json"
+
+	// outboxDeadLetterDir is the directory of the outbox where the events rejected by the server are moved
+	outboxDeadLetterDir = "dead-letter"
+)
+
+// permanentErrors are the parts of the errors returned by the server for the events it rejects whatever
+// the number of attempts, like the duplicate or invalid events. These events are dead-lettered instead of
+// holding the events queued after them
+var permanentErrors = []string{
+	"discarded due the duplicate event",
+	"VERSION MISMATCH",
+	"unable to parse probes",
+	"invalid character",
+	"cannot unmarshal",
+	"unexpected end of JSON input",
+}
+
+// OutboxDir is the directory where undelivered workflow events are persisted
+var OutboxDir = os.Getenv("OUTBOX_DIR")
+
+// outboxEntry is a workflow event payload waiting to be acknowledged by the server
+type outboxEntry struct {
+	Sequence    int64  `json:"sequence"`
+	RunID       string `json:"runID"`
+	Payload     []byte `json:"payload"`
+	Attempts    int    `json:"attempts"`
+	NextAttempt int64  `json:"nextAttempt,omitempty"`
+}
+
+// Outbox persists workflow event payloads on disk until the server accepts them,
+// so that events survive failed requests and subscriber restarts
+type Outbox struct {
+	mu      sync.Mutex
+	dir     string
+	entries []*outboxEntry
+	lastSeq int64
+}
+
+// NewOutbox opens the outbox in the given directory, loading the events which
+// were not delivered before the last shutdown
+func NewOutbox(dir string) (*Outbox, error) {
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "subscriber-outbox")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outbox directory: %w", err)
+	}
+
+	o := &Outbox{dir: dir}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), outboxFileSuffix) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read outbox entry %s: %w", file.Name(), err)
+		}
+		var entry outboxEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			logrus.WithError(err).Warn("Discarding corrupted outbox entry ", file.Name())
+			_ = os.Remove(filepath.Join(dir, file.Name()))
+			continue
+		}
+		o.entries = append(o.entries, &entry)
+	}
+
+	sort.Slice(o.entries, func(i, j int) bool {
+		return o.entries[i].Sequence < o.entries[j].Sequence
+	})
+	if len(o.entries) > 0 {
+		o.lastSeq = o.entries[len(o.entries)-1].Sequence
+		logrus.Infof("Loaded %d undelivered events from the outbox", len(o.entries))
+	}
+
+	return o, nil
+}
+
+// NextSequence returns a strictly increasing event sequence number. It is
+// derived from the wall clock so that it keeps increasing across restarts,
+// even if the outbox directory has been lost; the server receives it as an
+// Int64 since it doesn't fit in a graphql Int
+func (o *Outbox) NextSequence() int64 {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	seq := time.Now().UnixNano()
+	if seq <= o.lastSeq {
+		seq = o.lastSeq + 1
+	}
+	o.lastSeq = seq
+	return seq
+}
+
+// Enqueue persists the payload of an event before it is sent to the server. The
+// event is queued even if it can't be persisted, it's then only lost if the
+// subscriber restarts before delivering it
+func (o *Outbox) Enqueue(sequence int64, runID string, payload []byte) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	entry := &outboxEntry{
+		Sequence: sequence,
+		RunID:    runID,
+		Payload:  payload,
+	}
+	o.entries = append(o.entries, entry)
+	return o.write(entry)
+}
+
+// Len returns the number of events waiting to be delivered
+func (o *Outbox) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return len(o.entries)
+}
+
+// Due returns true if there are events waiting to be delivered and the first
+// one isn't backing off
+func (o *Outbox) Due() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return len(o.entries) > 0 && time.Now().UnixMilli() >= o.entries[0].NextAttempt
+}
+
+// Flush sends the pending events in order. Delivery stops at the first failure
+// so that the server never receives the events of a run out of order; the
+// event is kept and retried with an increasing backoff until the server
+// accepts it. The events the server rejects permanently are moved to the dead
+// letters and the delivery goes on. Nothing is sent while the first event is
+// backing off
+func (o *Outbox) Flush(send func(payload []byte) (string, error)) (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var response string
+	for len(o.entries) > 0 {
+		entry := o.entries[0]
+		now := time.Now()
+		if now.UnixMilli() < entry.NextAttempt {
+			return response, nil
+		}
+
+		body, err := send(entry.Payload)
+		if err == nil {
+			err = responseError(body)
+			if err != nil && isPermanentError(err) {
+				logrus.WithError(err).Errorf("Event %d of workflow run %s is rejected by the server, moving it to the dead letters", entry.Sequence, entry.RunID)
+				if err := o.deadLetter(entry); err != nil {
+					logrus.WithError(err).Warn("Failed to move the rejected event to the dead letters")
+				}
+				o.entries = o.entries[1:]
+				continue
+			}
+		}
+		if err != nil {
+			entry.Attempts++
+			entry.NextAttempt = now.Add(outboxBackoff(entry.Attempts)).UnixMilli()
+			if entry.Attempts%outboxAlertAttempts == 0 {
+				logrus.WithError(err).Errorf("Event %d of workflow run %s is still undelivered after %d attempts, the %d queued events are held until the server accepts it",
+					entry.Sequence, entry.RunID, entry.Attempts, len(o.entries))
+			}
+			if writeErr := o.write(entry); writeErr != nil {
+				logrus.WithError(writeErr).Warn("Failed to update outbox entry")
+			}
+			return response, err
+		}
+
+		response = body
+		if err := os.Remove(o.path(entry)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logrus.WithError(err).Warn("Failed to remove delivered outbox entry")
+		}
+		o.entries = o.entries[1:]
+	}
+
+	return response, nil
+}
+
+// outboxBackoff returns the delay before the next attempt to deliver an event,
+// which doubles with each failed attempt up to maxOutboxBackoff
+func outboxBackoff(attempts int) time.Duration {
+	delay := outboxRetryInterval
+	for i := 1; i < attempts && delay < maxOutboxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxOutboxBackoff {
+		return maxOutboxBackoff
+	}
+	return delay
+}
+
+// isPermanentError checks if the server rejects the event whatever the number of attempts
+func isPermanentError(err error) bool {
+	for _, permanent := range permanentErrors {
+		if strings.Contains(err.Error(), permanent) {
+			return true
+		}
+	}
+	return false
+}
+
+// deadLetter moves the entry of an event rejected by the server to the dead letters directory, where it's
+// kept for investigation but never sent again
+func (o *Outbox) deadLetter(entry *outboxEntry) error {
+	dir := filepath.Join(o.dir, outboxDeadLetterDir)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	deadLetterPath := filepath.Join(dir, filepath.Base(o.path(entry)))
+	err := os.Rename(o.path(entry), deadLetterPath)
+	if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	// the event couldn't be persisted when it was queued
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(deadLetterPath, data, 0o640)
+}
+
+func (o *Outbox) write(entry *outboxEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that a crash never leaves a partial entry behind
+	tmp := o.path(entry) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o640); err != nil {
+		return fmt.Errorf("failed to persist outbox entry: %w", err)
+	}
+	return os.Rename(tmp, o.path(entry))
+}
+
+func (o *Outbox) path(entry *outboxEntry) string {
+	return filepath.Join(o.dir, strconv.FormatInt(entry.Sequence, 10)+outboxFileSuffix)
+}
+
+// responseError extracts the errors returned by the server in a graphql response
+func responseError(body string) error {
+	var response struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		return fmt.Errorf("invalid response from the server: %s", body)
+	}
+	if len(response.Errors) > 0 {
+		return errors.New(response.Errors[0].Message)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package events
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const acceptedResponse = `{"data": {"chaosExperimentRun": "Workflow Run Accepted"}}`
+
+// recordSend returns a send function recording the payloads, which fails once the accepted ones are sent
+func recordSend(sent *[]string, accepted int) func(payload []byte) (string, error) {
+	return func(payload []byte) (string, error) {
+		if len(*sent) >= accepted {
+			return "", errors.New("connection refused")
+		}
+		*sent = append(*sent, string(payload))
+		return acceptedResponse, nil
+	}
+}
+
+func TestOutboxNextSequence(t *testing.T) {
+	outbox, err := NewOutbox(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewOutbox() error = %v", err)
+	}
+
+	// the sequence keeps increasing if the clock goes back
+	outbox.lastSeq = time.Now().Add(time.Hour).UnixNano()
+	previous := outbox.NextSequence()
+	for i := 0; i < 100; i++ {
+		sequence := outbox.NextSequence()
+		if sequence <= previous {
+			t.Fatalf("NextSequence() = %d after %d", sequence, previous)
+		}
+		previous = sequence
+	}
+	if previous <= math.MaxInt32 {
+		t.Errorf("NextSequence() = %d, want a clock based sequence", previous)
+	}
+}
+
+func TestOutboxFlush(t *testing.T) {
+	dir := t.TempDir()
+	outbox, err := NewOutbox(dir)
+	if err != nil {
+		t.Fatalf("NewOutbox() error = %v", err)
+	}
+	for _, payload := range []string{"first", "second", "third"} {
+		if err := outbox.Enqueue(outbox.NextSequence(), "run", []byte(payload)); err != nil {
+			t.Fatalf("Enqueue() error = %v", err)
+		}
+	}
+
+	// the delivery stops at the first failure so that the events stay in order
+	var sent []string
+	if _, err := outbox.Flush(recordSend(&sent, 1)); err == nil {
+		t.Fatal("Flush() didn't return the delivery error")
+	}
+	if !reflect.DeepEqual(sent, []string{"first"}) || outbox.Len() != 2 {
+		t.Fatalf("Flush() sent %v and kept %d events, want the first one sent and the others kept", sent, outbox.Len())
+	}
+
+	// the failed event is backing off
+	if outbox.Due() {
+		t.Error("Due() = true right after a failed attempt")
+	}
+	if _, err := outbox.Flush(recordSend(&sent, 3)); err != nil || len(sent) != 1 {
+		t.Fatalf("Flush() sent %v while backing off, error = %v", sent, err)
+	}
+
+	// the undelivered events are loaded again after a restart
+	outbox, err = NewOutbox(dir)
+	if err != nil {
+		t.Fatalf("NewOutbox() error = %v", err)
+	}
+	if outbox.Len() != 2 || outbox.entries[0].Attempts != 1 {
+		t.Fatalf("NewOutbox() loaded %d events, want the 2 undelivered ones with their attempts", outbox.Len())
+	}
+	outbox.entries[0].NextAttempt = 0
+	response, err := outbox.Flush(recordSend(&sent, 3))
+	if err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if response != acceptedResponse || !reflect.DeepEqual(sent, []string{"first", "second", "third"}) || outbox.Len() != 0 {
+		t.Errorf("Flush() = %q and sent %v, want all the events sent in order", response, sent)
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 0 {
+		t.Errorf("the delivered events left %d files in the outbox", len(files))
+	}
+}
+
+func TestOutboxKeepsUndeliveredEvents(t *testing.T) {
+	outbox, err := NewOutbox(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewOutbox() error = %v", err)
+	}
+	if err := outbox.Enqueue(outbox.NextSequence(), "run", []byte("event")); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	// the server fails to handle the event, it's kept however many times it fails
+	reject := func(payload []byte) (string, error) {
+		return `{"errors": [{"message": "server selection error: context deadline exceeded"}]}`, nil
+	}
+	for i := 0; i < 2*outboxAlertAttempts; i++ {
+		outbox.entries[0].NextAttempt = 0
+		if _, err := outbox.Flush(reject); err == nil || err.Error() != "server selection error: context deadline exceeded" {
+			t.Fatalf("Flush() error = %v, want the error returned by the server", err)
+		}
+	}
+	if outbox.Len() != 1 || outbox.entries[0].Attempts != 2*outboxAlertAttempts {
+		t.Fatalf("the outbox kept %d events, want the rejected event with all its attempts", outbox.Len())
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	testcases := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: outboxRetryInterval},
+		{attempts: 2, want: 2 * outboxRetryInterval},
+		{attempts: 4, want: 8 * outboxRetryInterval},
+		{attempts: 10, want: maxOutboxBackoff},
+		{attempts: 1000, want: maxOutboxBackoff},
+	}
+	for _, tc := range testcases {
+		if got := outboxBackoff(tc.attempts); got != tc.want {
+			t.Errorf("outboxBackoff(%d) = %v, want %v", tc.attempts, got, tc.want)
+		}
+	}
+}
+
+func TestOutboxKeepsUnpersistedEvents(t *testing.T) {
+	dir := t.TempDir()
+	outbox, err := NewOutbox(dir)
+	if err != nil {
+		t.Fatalf("NewOutbox() error = %v", err)
+	}
+
+	// the event can't be persisted, it's still delivered
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := outbox.Enqueue(outbox.NextSequence(), "run", []byte("event")); err == nil {
+		t.Fatal("Enqueue() didn't return the persistence error")
+	}
+	if outbox.Len() != 1 {
+		t.Fatalf("the outbox kept %d events, want the unpersisted one", outbox.Len())
+	}
+
+	var sent []string
+	if _, err := outbox.Flush(recordSend(&sent, 0)); err == nil {
+		t.Fatal("Flush() didn't return the delivery error")
+	}
+	outbox.entries[0].NextAttempt = 0
+	if _, err := outbox.Flush(recordSend(&sent, 1)); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if !reflect.DeepEqual(sent, []string{"event"}) || outbox.Len() != 0 {
+		t.Errorf("Flush() sent %v, want the unpersisted event", sent)
+	}
+}
+
+func TestOutboxDeadLettersRejectedEvents(t *testing.T) {
+	dir := t.TempDir()
+	outbox, err := NewOutbox(dir)
+	if err != nil {
+		t.Fatalf("NewOutbox() error = %v", err)
+	}
+	for _, payload := range []string{"duplicate", "invalid", "next"} {
+		if err := outbox.Enqueue(outbox.NextSequence(), "run", []byte(payload)); err != nil {
+			t.Fatalf("Enqueue() error = %v", err)
+		}
+	}
+
+	// the rejected events don't hold the events queued after them
+	var sent []string
+	send := func(payload []byte) (string, error) {
+		switch string(payload) {
+		case "duplicate":
+			return `{"errors": [{"message": "experiment run has been discarded due the duplicate event, workflowId: id, workflowRunId: run"}]}`, nil
+		case "invalid":
+			return `{"errors": [{"message": "unable to parse probes invalid character 'x' looking for beginning of value"}]}`, nil
+		}
+		sent = append(sent, string(payload))
+		return acceptedResponse, nil
+	}
+	response, err := outbox.Flush(send)
+	if err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if response != acceptedResponse || !reflect.DeepEqual(sent, []string{"next"}) || outbox.Len() != 0 {
+		t.Fatalf("Flush() = %q and sent %v, keeping %d events, want the next event sent", response, sent, outbox.Len())
+	}
+
+	// the rejected events are kept out of the outbox
+	deadLetters, err := os.ReadDir(filepath.Join(dir, outboxDeadLetterDir))
+	if err != nil || len(deadLetters) != 2 {
+		t.Fatalf("the dead letters hold %d events, error = %v, want the 2 rejected events", len(deadLetters), err)
+	}
+	outbox, err = NewOutbox(dir)
+	if err != nil || outbox.Len() != 0 {
+		t.Errorf("NewOutbox() loaded %d events, error = %v, want the dead letters to be ignored", outbox.Len(), err)
+	}
+}
@@ -39,6 +39,7 @@ func (ev *subscriberEvents) getChaosData(nodeStatus v1alpha13.NodeStatus, engine
 	cd.FailStep = ""
 	cd.EngineUID = string(crd.ObjectMeta.UID)
 	cd.EngineContext = string(crd.Labels["context"])
+	cd.FaultName = crd.Labels["fault_name"]
 
 	if strings.ToLower(string(crd.Status.EngineStatus)) == "stopped" {
 		cd.ExperimentVerdict = "Fail"
@@ -190,6 +191,10 @@ func (ev *subscriberEvents) GenerateWorkflowPayload(cid, accessKey, version, com
 		mutation = `{ experimentID: \"` + wfEvent.WorkflowID + `\", experimentRunID: \"` + wfEvent.UID + `\", revisionID:\"` + wfEvent.RevisionID + `\", notifyID:\"` + *wfEvent.NotifyID + `\", completed: ` + completed + `, experimentName:\"` + wfEvent.Name + `\", infraID: ` + infraID + `, updatedBy:\"` + wfEvent.UpdatedBy + `\", executionData:\"` + executionData + `\"}`
 	}
 
+	if wfEvent.Sequence > 0 {
+		mutation = strings.TrimSuffix(mutation, "}") + `, eventSequence: ` + strconv.FormatInt(wfEvent.Sequence, 10) + `}`
+	}
+
 	var payload = []byte(`{"query":"mutation { chaosExperimentRun(request:` + mutation + ` )}"}`)
 	return payload, nil
 }
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"time"
 
+	"subscriber/pkg/graphql"
 	"subscriber/pkg/types"
 
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
@@ -18,13 +19,16 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
-// 0 means no resync
 const (
+	// 0 means no resync
 	resyncPeriod time.Duration = 0
 )
 
 var eventMap map[string]types.WorkflowEvent
 
+// outbox holds the workflow events until they are delivered to the server
+var outbox *Outbox
+
 func init() {
 	eventMap = make(map[string]types.WorkflowEvent)
 }
@@ -226,6 +230,8 @@ func (ev *subscriberEvents) SendWorkflowUpdates(infraData map[string]string, eve
 
 	eventMap[event.UID] = event
 
+	event.Sequence = outbox.NextSequence()
+
 	// generate graphql payload
 	payload, err := ev.GenerateWorkflowPayload(infraData["INFRA_ID"], infraData["ACCESS_KEY"], infraData["VERSION"], "false", event)
 	if err != nil {
@@ -237,22 +243,79 @@ func (ev *subscriberEvents) SendWorkflowUpdates(infraData map[string]string, eve
 		delete(eventMap, event.UID)
 	}
 
-	body, err := ev.gqlSubscriberServer.SendRequest(infraData["SERVER_ADDR"], payload)
+	// persist the event before sending it, so it is retried if the request fails
+	err = outbox.Enqueue(event.Sequence, event.UID, payload)
 	if err != nil {
-		return "", err
+		logrus.WithError(err).Warn("Failed to persist the event of workflow run ", event.UID, ", it's retried until the subscriber restarts")
+	}
+
+	if !graphql.Connection.IsConnected() {
+		return "", nil
 	}
 
-	return body, nil
+	return ev.flushOutbox(infraData)
 }
 
+// flushOutbox delivers the pending events of the outbox to the server in order
+func (ev *subscriberEvents) flushOutbox(infraData map[string]string) (string, error) {
+	return outbox.Flush(func(payload []byte) (string, error) {
+		return ev.gqlSubscriberServer.SendRequest(infraData["SERVER_ADDR"], payload)
+	})
+}
+
+// WorkflowUpdates streams the workflow events to the server. Every event is
+// persisted in the outbox first; events which could not be delivered, either
+// because the subscriber is disconnected or because the request failed, are
+// retried in order until the server accepts them
 func (ev *subscriberEvents) WorkflowUpdates(infraData map[string]string, event chan types.WorkflowEvent) {
-	// listen on the channel for streaming event updates
-	for eventData := range event {
-		response, err := ev.SendWorkflowUpdates(infraData, eventData)
-		if err != nil {
-			logrus.Print(err.Error())
+	var err error
+	outbox, err = NewOutbox(OutboxDir)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to open the event outbox")
+	}
+
+	retry := time.NewTicker(outboxRetryInterval)
+	defer retry.Stop()
+
+	for {
+		// wait for the reconnection only if there is something to deliver
+		var ready <-chan struct{}
+		if outbox.Len() > 0 && !graphql.Connection.IsConnected() {
+			ready = graphql.Connection.Ready()
 		}
 
+		select {
+		case eventData, ok := <-event:
+			if !ok {
+				return
+			}
+			// the events which failed to be sent stay in the outbox and are retried below
+			response, err := ev.SendWorkflowUpdates(infraData, eventData)
+			if err != nil {
+				logrus.WithError(err).Warn("Failed to send the event of workflow run ", eventData.UID)
+				continue
+			}
+			if response == "" {
+				logrus.Info("Event for workflow run ", eventData.UID, " queued for delivery")
+				continue
+			}
+
+			logrus.Print("Response from the server: ", response)
+			continue
+		case <-ready:
+		case <-retry.C:
+		}
+
+		if !outbox.Due() || !graphql.Connection.IsConnected() {
+			continue
+		}
+
+		logrus.Infof("Retrying delivery of %d queued events", outbox.Len())
+		response, err := ev.flushOutbox(infraData)
+		if err != nil {
+			logrus.WithError(err).Warn("Failed to deliver queued events, will retry")
+			continue
+		}
 		logrus.Print("Response from the server: ", response)
 	}
 }
@@ -0,0 +1,54 @@
+package graphql
+
+import "sync"
+
+// ConnectionState tracks whether the subscriber currently holds an
+// acknowledged websocket connection with the control plane
+type ConnectionState struct {
+	mu        sync.RWMutex
+	connected bool
+	ready     chan struct{}
+}
+
+// Connection is the connection state shared between the request listener
+// and the event streamers
+var Connection = NewConnectionState()
+
+func NewConnectionState() *ConnectionState {
+	return &ConnectionState{
+		ready: make(chan struct{}),
+	}
+}
+
+// SetConnected updates the connection state, waking up every goroutine
+// waiting on Ready when the connection is (re-)established
+func (c *ConnectionState) SetConnected(connected bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.connected == connected {
+		return
+	}
+	c.connected = connected
+	if connected {
+		close(c.ready)
+	} else {
+		c.ready = make(chan struct{})
+	}
+}
+
+// IsConnected returns true if the websocket connection is currently live
+func (c *ConnectionState) IsConnected() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.connected
+}
+
+// Ready returns a channel which is closed once the connection is established
+func (c *ConnectionState) Ready() <-chan struct{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.ready
+}
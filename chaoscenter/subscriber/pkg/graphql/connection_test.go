@@ -0,0 +1,46 @@
+package graphql
+
+import "testing"
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestConnectionState(t *testing.T) {
+	state := NewConnectionState()
+	if state.IsConnected() {
+		t.Fatal("a new connection state is connected")
+	}
+	ready := state.Ready()
+	if isClosed(ready) {
+		t.Fatal("Ready() is closed before the connection")
+	}
+
+	// the waiting goroutines are woken up by the connection
+	state.SetConnected(true)
+	if !state.IsConnected() || !isClosed(ready) {
+		t.Fatal("SetConnected(true) didn't wake up the goroutines waiting on Ready()")
+	}
+	// setting the same state again doesn't close the channel twice
+	state.SetConnected(true)
+	if !isClosed(state.Ready()) {
+		t.Error("Ready() isn't closed while connected")
+	}
+
+	// a disconnection makes the goroutines wait for the next connection
+	state.SetConnected(false)
+	state.SetConnected(false)
+	reconnected := state.Ready()
+	if state.IsConnected() || isClosed(reconnected) {
+		t.Fatal("Ready() is closed after the disconnection")
+	}
+	state.SetConnected(true)
+	if !isClosed(reconnected) {
+		t.Error("SetConnected(true) didn't wake up the goroutines waiting for the reconnection")
+	}
+}
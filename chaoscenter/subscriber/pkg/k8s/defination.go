@@ -1,6 +1,8 @@
 package k8s
 
 import (
+	"context"
+
 	"subscriber/pkg/graphql"
 	"subscriber/pkg/types"
 
@@ -15,17 +17,22 @@ import (
 
 type SubscriberK8s interface {
 	GetLogs(podName, namespace, container string) (string, error)
-	CreatePodLog(podLog types.PodLogRequest) (types.PodLog, error)
-	SendPodLogs(infraData map[string]string, podLog types.PodLogRequest)
-	GenerateLogPayload(cid, accessKey, version string, podLog types.PodLogRequest) ([]byte, error)
+	CreatePodLog(ctx context.Context, podLog types.PodLogRequest) (types.PodLog, error)
+	SendPodLogs(ctx context.Context, infraData map[string]string, podLog types.PodLogRequest)
+	GenerateLogPayload(ctx context.Context, cid, accessKey, version string, podLog types.PodLogRequest) ([]byte, error)
 	GetKubernetesObjects(request types.KubeObjRequest) ([]*types.KubeObject, error)
 	GetObjectDataByNamespace(namespace string, dynamicClient dynamic.Interface, resourceType schema.GroupVersionResource) ([]types.ObjectData, error)
 	GenerateKubeObject(cid string, accessKey, version string, kubeobjectrequest types.KubeObjRequest) ([]byte, error)
 	SendKubeObjects(infraData map[string]string, kubeobjectrequest types.KubeObjRequest) error
 	CheckComponentStatus(componentEnv string) error
+	GetComponentHealth(componentEnv string) ([]types.ComponentHealth, error)
+	GetClusterDetails() (*int, *string, error)
+	SendHeartbeats(stopCh <-chan struct{}, infraData map[string]string)
+	SendHeartbeat(infraData map[string]string) error
 	IsAgentConfirmed() (bool, string, error)
 	AgentRegister(accessKey string) (bool, error)
 	AgentOperations(infraAction types.Action) (*unstructured.Unstructured, error)
+	UpgradeInfra(infraAction types.Action, componentEnv string, onStage func(stage string)) error
 	AgentConfirm(infraData map[string]string) ([]byte, error)
 	GetKubeConfig() (*rest.Config, error)
 	GetGenericK8sClient() (*kubernetes.Clientset, error)
@@ -0,0 +1,127 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"subscriber/pkg/types"
+
+	"github.com/sirupsen/logrus"
+	yaml2 "gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HeartbeatInterval is how often the health of the infra is sent to the server
+const HeartbeatInterval = 30 * time.Second
+
+// GetComponentHealth returns the health of the infra components, unlike CheckComponentStatus it doesn't wait for
+// them to start
+func (k8s *k8sSubscriber) GetComponentHealth(componentEnv string) ([]types.ComponentHealth, error) {
+	if componentEnv == "" {
+		return nil, errors.New("components not found in infra config")
+	}
+
+	clientset, err := k8s.GetGenericK8sClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var components InfraComponents
+	err = yaml2.Unmarshal([]byte(strings.TrimSpace(componentEnv)), &components)
+	if err != nil {
+		return nil, err
+	}
+
+	health := []types.ComponentHealth{}
+	for _, dep := range components.Deployments {
+		componentHealth := types.ComponentHealth{Name: dep, Healthy: true}
+		if err := deploymentHealth(context.TODO(), clientset, dep); err != nil {
+			message := err.Error()
+			componentHealth.Healthy = false
+			componentHealth.Message = &message
+		}
+		health = append(health, componentHealth)
+	}
+	return health, nil
+}
+
+// GetClusterDetails returns the number of nodes and the Kubernetes version of the cluster, the nodes aren't
+// counted if the infra isn't allowed to list them
+func (k8s *k8sSubscriber) GetClusterDetails() (*int, *string, error) {
+	clientset, err := k8s.GetGenericK8sClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serverVersion, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		return nil, nil, err
+	}
+	version := serverVersion.GitVersion
+
+	nodes, err := clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		logrus.WithError(err).Debug("failed to count the nodes")
+		return nil, &version, nil
+	}
+	nodeCount := len(nodes.Items)
+	return &nodeCount, &version, nil
+}
+
+// SendHeartbeats sends the health of the infra to the server periodically until stopCh is closed
+func (k8s *k8sSubscriber) SendHeartbeats(stopCh <-chan struct{}, infraData map[string]string) {
+	ticker := time.NewTicker(HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		if err := k8s.SendHeartbeat(infraData); err != nil {
+			logrus.WithError(err).Error("failed to send the heartbeat")
+		}
+
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendHeartbeat generates graphql mutation to send the health of the infra to graphql server
+func (k8s *k8sSubscriber) SendHeartbeat(infraData map[string]string) error {
+	components, err := k8s.GetComponentHealth(infraData["COMPONENTS"])
+	if err != nil {
+		return err
+	}
+
+	request := map[string]interface{}{
+		"infraID": map[string]string{
+			"infraID":   infraData["INFRA_ID"],
+			"version":   infraData["VERSION"],
+			"accessKey": infraData["ACCESS_KEY"],
+		},
+		"components": components,
+	}
+	nodeCount, k8sVersion, err := k8s.GetClusterDetails()
+	if err != nil {
+		logrus.WithError(err).Error("failed to get the cluster details")
+	}
+	if nodeCount != nil {
+		request["nodeCount"] = *nodeCount
+	}
+	if k8sVersion != nil {
+		request["k8sVersion"] = *k8sVersion
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"query":     "mutation ($request: InfraHeartbeatRequest!) { infraHeartbeat(request: $request) }",
+		"variables": map[string]interface{}{"request": request},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = k8s.gqlSubscriberServer.SendRequest(infraData["SERVER_ADDR"], payload)
+	return err
+}
@@ -0,0 +1,155 @@
+package k8s
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// recordingGql records the requests sent to the server
+type recordingGql struct {
+	payloads [][]byte
+}
+
+func (g *recordingGql) SendRequest(server string, payload []byte) (string, error) {
+	g.payloads = append(g.payloads, payload)
+	return `{"data": {"infraHeartbeat": "heartbeat received successfully"}}`, nil
+}
+
+func (g *recordingGql) MarshalGQLData(gqlData interface{}) (string, error) {
+	return "", nil
+}
+
+// fakeCluster serves the pods of the subscriber, the version of the cluster and its nodes if listNodes is true
+func fakeCluster(t *testing.T, listNodes bool) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/version":
+			w.Write([]byte(`{"gitVersion": "v1.28.2"}`))
+		case "/api/v1/nodes":
+			if !listNodes {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "Forbidden", "code": 403}`))
+				return
+			}
+			w.Write([]byte(`{"kind": "NodeList", "apiVersion": "v1", "items": [{"metadata": {"name": "first"}}, {"metadata": {"name": "second"}}]}`))
+		case "/api/v1/namespaces/litmus/pods":
+			if r.URL.Query().Get("labelSelector") != "app=subscriber" {
+				w.Write([]byte(`{"kind": "PodList", "apiVersion": "v1", "items": []}`))
+				return
+			}
+			w.Write([]byte(`{"kind": "PodList", "apiVersion": "v1", "items": [{"metadata": {"name": "subscriber"}, "status": {"phase": "Running", "containerStatuses": [{"ready": true}]}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	useFakeCluster(t, server.URL)
+}
+
+// useFakeCluster makes the subscriber use the cluster served at the URL, in the litmus namespace
+func useFakeCluster(t *testing.T, serverURL string) {
+	kubeConfig := filepath.Join(t.TempDir(), "config")
+	content := `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: ` + serverURL + `
+contexts:
+- name: test
+  context:
+    cluster: test
+current-context: test
+`
+	if err := os.WriteFile(kubeConfig, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	previousConfig, previousNamespace := KubeConfig, InfraNamespace
+	KubeConfig, InfraNamespace = &kubeConfig, "litmus"
+	t.Cleanup(func() { KubeConfig, InfraNamespace = previousConfig, previousNamespace })
+}
+
+func TestSendHeartbeat(t *testing.T) {
+	infraData := map[string]string{
+		"INFRA_ID":    "infra",
+		"VERSION":     "3.2.1",
+		"ACCESS_KEY":  "access-key",
+		"SERVER_ADDR": "http://server/query",
+		"COMPONENTS":  "DEPLOYMENTS: [\"app=subscriber\", \"app=event-tracker\"]",
+	}
+
+	testcases := []struct {
+		name          string
+		listNodes     bool
+		wantNodeCount interface{}
+	}{
+		{
+			name:          "heartbeat with the nodes",
+			listNodes:     true,
+			wantNodeCount: float64(2),
+		},
+		{
+			name:          "heartbeat of an infra not allowed to list the nodes",
+			listNodes:     false,
+			wantNodeCount: nil,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeCluster(t, tc.listNodes)
+			gql := &recordingGql{}
+			subscriber := &k8sSubscriber{gqlSubscriberServer: gql}
+
+			if err := subscriber.SendHeartbeat(infraData); err != nil {
+				t.Fatalf("SendHeartbeat() error = %v", err)
+			}
+			if len(gql.payloads) != 1 {
+				t.Fatalf("SendHeartbeat() sent %d requests, want 1", len(gql.payloads))
+			}
+
+			var payload struct {
+				Variables struct {
+					Request struct {
+						InfraID    map[string]string `json:"infraID"`
+						Components []struct {
+							Name    string  `json:"name"`
+							Healthy bool    `json:"healthy"`
+							Message *string `json:"message"`
+						} `json:"components"`
+						NodeCount  interface{} `json:"nodeCount"`
+						K8sVersion string      `json:"k8sVersion"`
+					} `json:"request"`
+				} `json:"variables"`
+			}
+			if err := json.Unmarshal(gql.payloads[0], &payload); err != nil {
+				t.Fatalf("SendHeartbeat() sent an invalid payload : %v", err)
+			}
+			request := payload.Variables.Request
+			if request.InfraID["infraID"] != "infra" || request.InfraID["accessKey"] != "access-key" || request.InfraID["version"] != "3.2.1" {
+				t.Errorf("SendHeartbeat() sent the identity %v", request.InfraID)
+			}
+			if len(request.Components) != 2 {
+				t.Fatalf("SendHeartbeat() sent %d components, want 2", len(request.Components))
+			}
+			if !request.Components[0].Healthy || request.Components[0].Message != nil {
+				t.Errorf("the running subscriber is reported unhealthy")
+			}
+			// the event tracker has no pods
+			if request.Components[1].Healthy || request.Components[1].Message == nil {
+				t.Errorf("the missing event tracker is reported healthy")
+			}
+			if request.K8sVersion != "v1.28.2" {
+				t.Errorf("SendHeartbeat() sent the Kubernetes version %q, want v1.28.2", request.K8sVersion)
+			}
+			if request.NodeCount != tc.wantNodeCount {
+				t.Errorf("SendHeartbeat() sent the node count %v, want %v", request.NodeCount, tc.wantNodeCount)
+			}
+		})
+	}
+}
@@ -1,11 +1,16 @@
 package k8s
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"io"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"subscriber/pkg/types"
 
@@ -15,26 +20,23 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
-func (k8s *k8sSubscriber) GetLogs(podName, namespace, container string) (string, error) {
-	ctx := context.TODO()
-	conf, err := k8s.GetKubeConfig()
-	if err != nil {
-		return "", err
-	}
+const (
+	// podLogFlushInterval is how often the followed logs are sent to the server
+	podLogFlushInterval = time.Second
+	// podLogChunkSize is the size of the followed logs after which they are sent without waiting
+	podLogChunkSize = 64 * 1024
+)
 
-	podLogOpts := v1.PodLogOptions{}
-	if container != "" {
-		podLogOpts.Container = container
-	}
+// errNoSuchPodLogRequest is returned when the server has no such log request in progress, as the logs were
+// cancelled or the server restarted
+var errNoSuchPodLogRequest = errors.New("no such request")
 
-	// creates the clientset
-	clientset, err := kubernetes.NewForConfig(conf)
-	if err != nil {
-		return "", err
-	}
+func (k8s *k8sSubscriber) GetLogs(podName, namespace, container string) (string, error) {
+	return k8s.getLogs(context.TODO(), podName, namespace, &v1.PodLogOptions{Container: container})
+}
 
-	req := clientset.CoreV1().Pods(namespace).GetLogs(podName, &podLogOpts)
-	podLogs, err := req.Stream(ctx)
+func (k8s *k8sSubscriber) getLogs(ctx context.Context, podName, namespace string, podLogOpts *v1.PodLogOptions) (string, error) {
+	podLogs, err := k8s.openLogs(ctx, podName, namespace, podLogOpts)
 	if err != nil {
 		return "", err
 	}
@@ -52,35 +54,65 @@ func (k8s *k8sSubscriber) GetLogs(podName, namespace, container string) (string,
 	return str, nil
 }
 
+// openLogs opens the stream of the logs of a pod, the stream is closed when the context is done
+func (k8s *k8sSubscriber) openLogs(ctx context.Context, podName, namespace string, podLogOpts *v1.PodLogOptions) (io.ReadCloser, error) {
+	conf, err := k8s.GetKubeConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// creates the clientset
+	clientset, err := kubernetes.NewForConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	req := clientset.CoreV1().Pods(namespace).GetLogs(podName, podLogOpts)
+	return req.Stream(ctx)
+}
+
+// podLogOptions returns the options of the logs of a container requested by the server, without following them
+func podLogOptions(podLog types.PodLogRequest, container string) *v1.PodLogOptions {
+	return &v1.PodLogOptions{
+		Container:    container,
+		Previous:     podLog.Previous,
+		TailLines:    podLog.TailLines,
+		SinceSeconds: podLog.SinceSeconds,
+	}
+}
+
+// formatLog escapes the logs the way the frontend expects them
+func formatLog(log string) string {
+	formatted := strconv.Quote(strings.Replace(log, `"`, `'`, -1))
+	return formatted[1 : len(formatted)-1]
+}
+
 // create pod log for normal pods and chaos-engine pods
-func (k8s *k8sSubscriber) CreatePodLog(podLog types.PodLogRequest) (types.PodLog, error) {
+func (k8s *k8sSubscriber) CreatePodLog(ctx context.Context, podLog types.PodLogRequest) (types.PodLog, error) {
 	logDetails := types.PodLog{}
-	mainLog, err := k8s.GetLogs(podLog.PodName, podLog.PodNamespace, "main")
+	mainLog, err := k8s.getLogs(ctx, podLog.PodName, podLog.PodNamespace, podLogOptions(podLog, "main"))
 	// try getting argo pod logs
 	if err != nil {
 		logrus.Errorf("Failed to get argo pod %v logs, err: %v", podLog.PodName, err)
 		logDetails.MainPod = "Failed to get argo pod logs"
 	} else {
-		logDetails.MainPod = strconv.Quote(strings.Replace(mainLog, `"`, `'`, -1))
-		logDetails.MainPod = logDetails.MainPod[1 : len(logDetails.MainPod)-1]
+		logDetails.MainPod = formatLog(mainLog)
 	}
 	// try getting experiment pod logs if requested
 	if strings.ToLower(podLog.PodType) == "chaosengine" && podLog.ChaosNamespace != nil {
 		chaosLog := make(map[string]string)
 		if podLog.ExpPod != nil {
-			expLog, err := k8s.GetLogs(*podLog.ExpPod, *podLog.ChaosNamespace, "")
+			expLog, err := k8s.getLogs(ctx, *podLog.ExpPod, *podLog.ChaosNamespace, podLogOptions(podLog, ""))
 			if err == nil {
-				chaosLog[*podLog.ExpPod] = strconv.Quote(strings.Replace(expLog, `"`, `'`, -1))
-				chaosLog[*podLog.ExpPod] = chaosLog[*podLog.ExpPod][1 : len(chaosLog[*podLog.ExpPod])-1]
+				chaosLog[*podLog.ExpPod] = formatLog(expLog)
 			} else {
 				logrus.Errorf("Failed to get experiment pod %v logs, err: %v", *podLog.ExpPod, err)
 			}
 		}
 		if podLog.RunnerPod != nil {
-			runnerLog, err := k8s.GetLogs(*podLog.RunnerPod, *podLog.ChaosNamespace, "")
+			runnerLog, err := k8s.getLogs(ctx, *podLog.RunnerPod, *podLog.ChaosNamespace, podLogOptions(podLog, ""))
 			if err == nil {
-				chaosLog[*podLog.RunnerPod] = strconv.Quote(strings.Replace(runnerLog, `"`, `'`, -1))
-				chaosLog[*podLog.RunnerPod] = chaosLog[*podLog.RunnerPod][1 : len(chaosLog[*podLog.RunnerPod])-1]
+				chaosLog[*podLog.RunnerPod] = formatLog(runnerLog)
 			} else {
 				logrus.Errorf("Failed to get runner pod %v logs, err: %v", *podLog.RunnerPod, err)
 			}
@@ -94,10 +126,16 @@ func (k8s *k8sSubscriber) CreatePodLog(podLog types.PodLogRequest) (types.PodLog
 	return logDetails, nil
 }
 
-// SendPodLogs generates graphql mutation to send events updates to graphql server
-func (k8s *k8sSubscriber) SendPodLogs(infraData map[string]string, podLog types.PodLogRequest) {
+// SendPodLogs generates graphql mutation to send events updates to graphql server, the followed logs are streamed
+// until the pods stop or the context is done
+func (k8s *k8sSubscriber) SendPodLogs(ctx context.Context, infraData map[string]string, podLog types.PodLogRequest) {
+	if podLog.Follow {
+		k8s.streamPodLogs(ctx, infraData, podLog)
+		return
+	}
+
 	// generate graphql payload
-	payload, err := k8s.GenerateLogPayload(infraData["INFRA_ID"], infraData["ACCESS_KEY"], infraData["VERSION"], podLog)
+	payload, err := k8s.GenerateLogPayload(ctx, infraData["INFRA_ID"], infraData["ACCESS_KEY"], infraData["VERSION"], podLog)
 	if err != nil {
 		logrus.WithError(err).Print("Error while retrieving the workflow logs")
 	}
@@ -108,12 +146,12 @@ func (k8s *k8sSubscriber) SendPodLogs(infraData map[string]string, podLog types.
 	logrus.Print("Response from the server: ", body)
 }
 
-func (k8s *k8sSubscriber) GenerateLogPayload(cid, accessKey, version string, podLog types.PodLogRequest) ([]byte, error) {
+func (k8s *k8sSubscriber) GenerateLogPayload(ctx context.Context, cid, accessKey, version string, podLog types.PodLogRequest) ([]byte, error) {
 	infraID := `{infraID: \"` + cid + `\", version: \"` + version + `\", accessKey: \"` + accessKey + `\"}`
 	processed := " Could not get logs "
 
 	// get the logs
-	logDetails, err := k8s.CreatePodLog(podLog)
+	logDetails, err := k8s.CreatePodLog(ctx, podLog)
 	if err == nil {
 		// process log data
 		processed, err = k8s.gqlSubscriberServer.MarshalGQLData(logDetails)
@@ -127,3 +165,180 @@ func (k8s *k8sSubscriber) GenerateLogPayload(cid, accessKey, version string, pod
 
 	return payload, nil
 }
+
+// podLogLine is a line of the logs of a followed pod
+type podLogLine struct {
+	podName string
+	chaos   bool
+	text    string
+}
+
+// podLogChunk collects the lines of the followed pods until they're sent to the server
+type podLogChunk struct {
+	main  strings.Builder
+	chaos map[string]*strings.Builder
+	size  int
+}
+
+func (c *podLogChunk) add(line podLogLine) {
+	c.size += len(line.text)
+	if !line.chaos {
+		c.main.WriteString(line.text)
+		return
+	}
+	if c.chaos == nil {
+		c.chaos = make(map[string]*strings.Builder)
+	}
+	if _, ok := c.chaos[line.podName]; !ok {
+		c.chaos[line.podName] = &strings.Builder{}
+	}
+	c.chaos[line.podName].WriteString(line.text)
+}
+
+// flush returns the collected lines and empties the chunk
+func (c *podLogChunk) flush() types.PodLog {
+	logDetails := types.PodLog{MainPod: formatLog(c.main.String())}
+	if len(c.chaos) > 0 {
+		logDetails.ChaosPod = make(map[string]string)
+		for podName, log := range c.chaos {
+			logDetails.ChaosPod[podName] = formatLog(log.String())
+		}
+	}
+	c.main.Reset()
+	c.chaos = nil
+	c.size = 0
+	return logDetails
+}
+
+// streamPodLogs follows the logs of the pods and sends them to the server in chunks, the last chunk is sent
+// when all the pods stop or the deadline of the context is exceeded. Nothing is sent once the context is
+// cancelled, and the logs stop if the server has no such request anymore
+func (k8s *k8sSubscriber) streamPodLogs(ctx context.Context, infraData map[string]string, podLog types.PodLogRequest) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lines := make(chan podLogLine)
+	var wg sync.WaitGroup
+	follow := func(podName, namespace, container string, chaos bool) {
+		podLogOpts := podLogOptions(podLog, container)
+		podLogOpts.Follow = true
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			send := func(text string) bool {
+				select {
+				case lines <- podLogLine{podName: podName, chaos: chaos, text: text}:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			}
+
+			stream, err := k8s.openLogs(ctx, podName, namespace, podLogOpts)
+			if err != nil {
+				logrus.Errorf("Failed to follow pod %v logs, err: %v", podName, err)
+				send("Failed to follow pod logs: " + err.Error() + "\n")
+				return
+			}
+			defer stream.Close()
+
+			reader := bufio.NewReader(stream)
+			for {
+				text, err := reader.ReadString('\n')
+				if text != "" && !send(text) {
+					return
+				}
+				if err != nil {
+					if err != io.EOF && ctx.Err() == nil {
+						logrus.Errorf("Failed to follow pod %v logs, err: %v", podName, err)
+					}
+					return
+				}
+			}
+		}()
+	}
+
+	follow(podLog.PodName, podLog.PodNamespace, "main", false)
+	if strings.ToLower(podLog.PodType) == "chaosengine" && podLog.ChaosNamespace != nil {
+		if podLog.ExpPod != nil {
+			follow(*podLog.ExpPod, *podLog.ChaosNamespace, "", true)
+		}
+		if podLog.RunnerPod != nil {
+			follow(*podLog.RunnerPod, *podLog.ChaosNamespace, "", true)
+		}
+	}
+	go func() {
+		wg.Wait()
+		close(lines)
+	}()
+
+	chunk := &podLogChunk{}
+	ticker := time.NewTicker(podLogFlushInterval)
+	defer ticker.Stop()
+	for {
+		var err error
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				if !errors.Is(ctx.Err(), context.Canceled) {
+					k8s.sendPodLogChunk(infraData, podLog, chunk.flush(), true)
+				}
+				return
+			}
+			chunk.add(line)
+			if chunk.size >= podLogChunkSize {
+				err = k8s.sendPodLogChunk(infraData, podLog, chunk.flush(), false)
+			}
+		case <-ticker.C:
+			if chunk.size > 0 {
+				err = k8s.sendPodLogChunk(infraData, podLog, chunk.flush(), false)
+			}
+		}
+		if errors.Is(err, errNoSuchPodLogRequest) {
+			logrus.WithField("requestID", podLog.RequestID).Info("stopped the pod logs, the server has no such request")
+			return
+		}
+	}
+}
+
+// sendPodLogChunk sends a chunk of the followed logs to the server, the last chunk ends the request. It returns
+// errNoSuchPodLogRequest if the server has no such request in progress
+func (k8s *k8sSubscriber) sendPodLogChunk(infraData map[string]string, podLog types.PodLogRequest, logDetails types.PodLog, done bool) error {
+	logs, err := json.Marshal(logDetails)
+	if err != nil {
+		logrus.WithError(err).Error("failed to marshal the pod logs")
+		return err
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"query": "mutation ($request: PodLog!) { podLog(request: $request) }",
+		"variables": map[string]interface{}{
+			"request": map[string]interface{}{
+				"infraID": map[string]string{
+					"infraID":   infraData["INFRA_ID"],
+					"version":   infraData["VERSION"],
+					"accessKey": infraData["ACCESS_KEY"],
+				},
+				"requestID":       podLog.RequestID,
+				"experimentRunID": podLog.ExperimentRunID,
+				"podName":         podLog.PodName,
+				"podType":         podLog.PodType,
+				"log":             string(logs),
+				"done":            done,
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Error("failed to marshal the pod logs")
+		return err
+	}
+
+	body, err := k8s.gqlSubscriberServer.SendRequest(infraData["SERVER_ADDR"], payload)
+	if err != nil {
+		logrus.WithError(err).WithField("requestID", podLog.RequestID).Error("failed to send the pod logs")
+		return err
+	}
+	if strings.Contains(body, errNoSuchPodLogRequest.Error()) {
+		return errNoSuchPodLogRequest
+	}
+	return nil
+}
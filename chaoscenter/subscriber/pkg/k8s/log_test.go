@@ -0,0 +1,111 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"subscriber/pkg/types"
+)
+
+// podLogGql answers the pod logs sent to the server with the response
+type podLogGql struct {
+	response string
+	sent     chan []byte
+}
+
+func (g *podLogGql) SendRequest(server string, payload []byte) (string, error) {
+	g.sent <- payload
+	return g.response, nil
+}
+
+func (g *podLogGql) MarshalGQLData(gqlData interface{}) (string, error) {
+	return "", nil
+}
+
+// followedPod serves the logs of a pod which never stops, until the request is cancelled
+func followedPod(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/litmus/pods/pod/log" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-ticker.C:
+				w.Write([]byte("line\n"))
+				w.(http.Flusher).Flush()
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	useFakeCluster(t, server.URL)
+}
+
+func TestStreamPodLogs(t *testing.T) {
+	infraData := map[string]string{"INFRA_ID": "infra", "VERSION": "3.2.1", "ACCESS_KEY": "access-key", "SERVER_ADDR": "http://server/query"}
+	podLog := types.PodLogRequest{RequestID: "request", PodName: "pod", PodNamespace: "litmus", Follow: true}
+
+	testcases := []struct {
+		name     string
+		response string
+		timeout  time.Duration
+		wantDone bool
+	}{
+		{
+			name:     "request unknown to the server",
+			response: `{"errors": [{"message": "no such request", "path": ["podLog"]}], "data": null}`,
+			timeout:  time.Minute,
+		},
+		{
+			name:     "longest stream",
+			response: `{"data": {"podLog": "LOGS SENT SUCCESSFULLY"}}`,
+			timeout:  1500 * time.Millisecond,
+			wantDone: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			followedPod(t)
+			gql := &podLogGql{response: tc.response, sent: make(chan []byte, 100)}
+			subscriber := &k8sSubscriber{gqlSubscriberServer: gql}
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+
+			streamed := make(chan struct{})
+			go func() {
+				subscriber.streamPodLogs(ctx, infraData, podLog)
+				close(streamed)
+			}()
+			select {
+			case <-streamed:
+			case <-time.After(10 * time.Second):
+				t.Fatal("the logs of the pod are still streamed")
+			}
+			close(gql.sent)
+			var last struct {
+				Variables struct {
+					Request struct {
+						Done bool `json:"done"`
+					} `json:"request"`
+				} `json:"variables"`
+			}
+			for payload := range gql.sent {
+				if err := json.Unmarshal(payload, &last); err != nil {
+					t.Fatalf("streamPodLogs() sent an invalid payload : %v", err)
+				}
+			}
+			// the logs which reach the longest duration end, the ones of an unknown request stop right away
+			if last.Variables.Request.Done != tc.wantDone {
+				t.Errorf("the last chunk of the logs is done = %v, want %v", last.Variables.Request.Done, tc.wantDone)
+			}
+		})
+	}
+}
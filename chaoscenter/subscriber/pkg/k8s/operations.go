@@ -86,31 +86,9 @@ func (k8s *k8sSubscriber) checkDeploymentStatus(components *InfraComponents, cli
 	defer wait.Done()
 	for retries < LiveCheckMaxTries {
 		for _, dep := range components.Deployments {
-			podList, err := clientset.CoreV1().Pods(InfraNamespace).List(ctx, metav1.ListOptions{LabelSelector: dep})
-			if err != nil {
-				logrus.Errorf("failed to get deployment pods %v , err : %v", dep, err.Error())
+			if err := deploymentHealth(ctx, clientset, dep); err != nil {
+				logrus.Error(err)
 				downCount += 1
-				continue
-			}
-			if len(podList.Items) == 0 {
-				logrus.Errorf("failed to get deployments pods %v , err : pods not found", dep)
-				downCount += 1
-				continue
-			}
-		outer:
-			for _, pod := range podList.Items {
-				if pod.Status.Phase != corev1.PodRunning {
-					logrus.Errorf("failed to get running pods for dep %v", dep)
-					downCount += 1
-					break
-				}
-				for _, containerStatus := range pod.Status.ContainerStatuses {
-					if !containerStatus.Ready {
-						logrus.Errorf("failed to get ready containers for pod %v, dep %v", pod.Name, dep)
-						downCount += 1
-						break outer
-					}
-				}
 			}
 		}
 		if downCount == 0 {
@@ -127,6 +105,28 @@ func (k8s *k8sSubscriber) checkDeploymentStatus(components *InfraComponents, cli
 	components.LiveStatus = false
 }
 
+// deploymentHealth returns an error if the pods of a deployment aren't running and ready
+func deploymentHealth(ctx context.Context, clientset *kubernetes.Clientset, dep string) error {
+	podList, err := clientset.CoreV1().Pods(InfraNamespace).List(ctx, metav1.ListOptions{LabelSelector: dep})
+	if err != nil {
+		return fmt.Errorf("failed to get deployment pods %v , err : %v", dep, err.Error())
+	}
+	if len(podList.Items) == 0 {
+		return fmt.Errorf("failed to get deployments pods %v , err : pods not found", dep)
+	}
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			return fmt.Errorf("failed to get running pods for dep %v", dep)
+		}
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if !containerStatus.Ready {
+				return fmt.Errorf("failed to get ready containers for pod %v, dep %v", pod.Name, dep)
+			}
+		}
+	}
+	return nil
+}
+
 func (k8s *k8sSubscriber) IsAgentConfirmed() (bool, string, error) {
 	clientset, err := k8s.GetGenericK8sClient()
 	if err != nil {
@@ -226,7 +226,7 @@ func applyRequest(requestType string, obj *unstructured.Unstructured) (*unstruct
 		if k8s_errors.IsNotFound(err) {
 			// This doesnt ever happen even if it is already deleted or not found
 
-			logrus.Info("%v not found ", obj.GetName())
+			logrus.Infof("%v not found ", obj.GetName())
 			return nil, nil
 		}
 		if err != nil {
@@ -249,7 +249,7 @@ func applyRequest(requestType string, obj *unstructured.Unstructured) (*unstruct
 			if k8s_errors.IsNotFound(err) {
 				fmt.Println(obj)
 				// This doesnt ever happen even if it is already deleted or not found
-				logrus.Info("%v not found ", obj.GetName())
+				logrus.Infof("%v not found ", obj.GetName())
 				return nil, nil
 			}
 			logrus.Info("successfully deleted for kind: ", obj.GetKind(), ", resource name: ", obj.GetName(), ", and namespace: ", obj.GetNamespace())
@@ -260,7 +260,7 @@ func applyRequest(requestType string, obj *unstructured.Unstructured) (*unstruct
 			if k8s_errors.IsNotFound(err) {
 
 				// This doesnt ever happen even if it is already deleted or not found
-				logrus.Info("%v not found ", obj.GetName())
+				logrus.Infof("%v not found ", obj.GetName())
 				return nil, nil
 			}
 			logrus.Info("successfully deleted for kind: ", obj.GetKind(), ", resource labels: ", objLabels, ", and namespace: ", obj.GetNamespace())
@@ -276,7 +276,7 @@ func applyRequest(requestType string, obj *unstructured.Unstructured) (*unstruct
 		response, err := dr.Get(ctx, obj.GetName(), metav1.GetOptions{})
 		if k8s_errors.IsNotFound(err) {
 			// This doesnt ever happen even if it is already deleted or not found
-			logrus.Info("%v not found", obj.GetName())
+			logrus.Infof("%v not found", obj.GetName())
 			return nil, nil
 		}
 		if err != nil {
@@ -0,0 +1,322 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	pkgTypes "subscriber/pkg/types"
+
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	utilYaml "k8s.io/apimachinery/pkg/util/yaml"
+	memory "k8s.io/client-go/discovery/cached"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+)
+
+// The stages of an upgrade, the components are rolled out once the resources they use are applied and the
+// subscriber is rolled out last since it's replaced by the new version
+const (
+	UpgradeStageResources  = "resources"
+	UpgradeStageComponents = "components"
+	UpgradeStageSubscriber = "subscriber"
+)
+
+const (
+	// SubscriberDeploymentName is the name of the deployment running the subscriber
+	SubscriberDeploymentName = "subscriber"
+	// UpgradeRolloutTimeout is how long a deployment can take to roll out before the upgrade is rolled back
+	UpgradeRolloutTimeout = 5 * time.Minute
+	// upgradeFieldManager owns the fields applied by the upgrades
+	upgradeFieldManager = "litmus-subscriber"
+)
+
+// UpgradeError is returned when an upgrade fails, RolledBack tells whether the infra runs the previous version
+type UpgradeError struct {
+	Stage      string
+	Err        error
+	RolledBack bool
+}
+
+func (e *UpgradeError) Error() string {
+	return fmt.Sprintf("upgrade failed at the %v stage: %v", e.Stage, e.Err)
+}
+
+func (e *UpgradeError) Unwrap() error {
+	return e.Err
+}
+
+// appliedObject is an object applied by an upgrade along with its state before the upgrade, which is nil if the
+// object didn't exist
+type appliedObject struct {
+	resource dynamic.ResourceInterface
+	object   *unstructured.Unstructured
+	previous *unstructured.Unstructured
+}
+
+// infraUpgrade applies the objects of an upgrade and keeps their previous state to roll them back
+type infraUpgrade struct {
+	clientset     *kubernetes.Clientset
+	dynamicClient dynamic.Interface
+	mapper        *restmapper.DeferredDiscoveryRESTMapper
+	namespace     string
+	applied       []appliedObject
+}
+
+// UpgradeInfra applies the manifest of an upgrade with server side apply in stages, the deployments are checked
+// after each stage and all the applied objects are rolled back if they don't become healthy. onStage is called
+// before each stage
+func (k8s *k8sSubscriber) UpgradeInfra(infraAction pkgTypes.Action, componentEnv string, onStage func(stage string)) error {
+	ctx := context.TODO()
+
+	objects, err := decodeManifest(infraAction.K8SManifest)
+	if err != nil {
+		return &UpgradeError{Stage: UpgradeStageResources, Err: err, RolledBack: true}
+	}
+
+	clientset, err := k8s.GetGenericK8sClient()
+	if err != nil {
+		return &UpgradeError{Stage: UpgradeStageResources, Err: err, RolledBack: true}
+	}
+	discoveryClient, dynamicClient, err := k8s.GetDynamicAndDiscoveryClient()
+	if err != nil {
+		return &UpgradeError{Stage: UpgradeStageResources, Err: err, RolledBack: true}
+	}
+	upgrade := &infraUpgrade{
+		clientset:     clientset,
+		dynamicClient: dynamicClient,
+		mapper:        restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+		namespace:     infraAction.Namespace,
+	}
+
+	// the components are checked with the configuration of the new version
+	resources, components, subscriber := splitUpgradeStages(objects)
+	for _, object := range resources {
+		if object.GetKind() == "ConfigMap" && object.GetName() == InfraConfigName {
+			if components, ok, _ := unstructured.NestedString(object.Object, "data", "COMPONENTS"); ok {
+				componentEnv = components
+			}
+		}
+	}
+
+	onStage(UpgradeStageResources)
+	if err := upgrade.applyAll(ctx, resources); err != nil {
+		return upgrade.fail(ctx, UpgradeStageResources, err)
+	}
+	// the new custom resource definitions are discovered again
+	upgrade.mapper.Reset()
+
+	onStage(UpgradeStageComponents)
+	if err := upgrade.applyAll(ctx, components); err != nil {
+		return upgrade.fail(ctx, UpgradeStageComponents, err)
+	}
+	if err := upgrade.waitForRollouts(ctx, components); err != nil {
+		return upgrade.fail(ctx, UpgradeStageComponents, err)
+	}
+	if err := k8s.CheckComponentStatus(componentEnv); err != nil {
+		return upgrade.fail(ctx, UpgradeStageComponents, err)
+	}
+
+	// this subscriber is replaced once the new one is available, the new one reports the end of the upgrade
+	// if this one doesn't
+	onStage(UpgradeStageSubscriber)
+	if err := upgrade.applyAll(ctx, subscriber); err != nil {
+		return upgrade.fail(ctx, UpgradeStageSubscriber, err)
+	}
+	if err := upgrade.waitForRollouts(ctx, subscriber); err != nil {
+		return upgrade.fail(ctx, UpgradeStageSubscriber, err)
+	}
+	return nil
+}
+
+// decodeManifest returns the objects of a multi document manifest
+func decodeManifest(manifest string) ([]*unstructured.Unstructured, error) {
+	decoder := utilYaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
+	var objects []*unstructured.Unstructured
+	for {
+		var object map[string]interface{}
+		err := decoder.Decode(&object)
+		if err == io.EOF {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the manifest: %v", err)
+		}
+		if len(object) == 0 {
+			continue
+		}
+		objects = append(objects, &unstructured.Unstructured{Object: object})
+	}
+}
+
+// splitUpgradeStages returns the objects applied in each stage, the namespaces and the custom resource
+// definitions are applied first since the other resources may depend on them
+func splitUpgradeStages(objects []*unstructured.Unstructured) (resources, components, subscriber []*unstructured.Unstructured) {
+	var definitions []*unstructured.Unstructured
+	for _, object := range objects {
+		switch {
+		case object.GetKind() == "Deployment" && object.GetName() == SubscriberDeploymentName:
+			subscriber = append(subscriber, object)
+		case object.GetKind() == "Deployment":
+			components = append(components, object)
+		case object.GetKind() == "Namespace" || object.GetKind() == "CustomResourceDefinition":
+			definitions = append(definitions, object)
+		default:
+			resources = append(resources, object)
+		}
+	}
+	return append(definitions, resources...), components, subscriber
+}
+
+func (u *infraUpgrade) applyAll(ctx context.Context, objects []*unstructured.Unstructured) error {
+	for _, object := range objects {
+		if err := u.apply(ctx, object); err != nil {
+			return fmt.Errorf("failed to apply %v %v: %v", object.GetKind(), object.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// apply records the current state of an object and applies the new one
+func (u *infraUpgrade) apply(ctx context.Context, object *unstructured.Unstructured) error {
+	resource, err := u.resource(object)
+	if err != nil {
+		return err
+	}
+
+	previous, err := resource.Get(ctx, object.GetName(), metav1.GetOptions{})
+	if k8s_errors.IsNotFound(err) {
+		previous = nil
+	} else if err != nil {
+		return err
+	} else {
+		cleanSnapshot(previous)
+	}
+	u.applied = append(u.applied, appliedObject{resource: resource, object: object, previous: previous})
+
+	logrus.Info("Applying upgrade for kind: ", object.GetKind(), ", resource name: ", object.GetName(), ", and namespace: ", object.GetNamespace())
+	return serverSideApply(ctx, resource, object)
+}
+
+// resource returns the REST interface of an object, the namespaced objects without a namespace are applied in
+// the namespace of the infra
+func (u *infraUpgrade) resource(object *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := object.GroupVersionKind()
+	mapping, err := u.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return u.dynamicClient.Resource(mapping.Resource), nil
+	}
+	if object.GetNamespace() == "" {
+		object.SetNamespace(u.namespace)
+	}
+	return u.dynamicClient.Resource(mapping.Resource).Namespace(object.GetNamespace()), nil
+}
+
+func serverSideApply(ctx context.Context, resource dynamic.ResourceInterface, object *unstructured.Unstructured) error {
+	data, err := object.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	force := true
+	_, err = resource.Patch(ctx, object.GetName(), k8sTypes.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: upgradeFieldManager,
+		Force:        &force,
+	})
+	return err
+}
+
+// cleanSnapshot removes the fields set by the cluster from the state of an object, so that it can be applied again
+func cleanSnapshot(object *unstructured.Unstructured) {
+	for _, field := range []string{"managedFields", "resourceVersion", "uid", "creationTimestamp", "generation", "selfLink"} {
+		unstructured.RemoveNestedField(object.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(object.Object, "metadata", "annotations", "deployment.kubernetes.io/revision")
+	unstructured.RemoveNestedField(object.Object, "status")
+}
+
+// waitForRollouts waits for the pods of the deployments to run the applied version
+func (u *infraUpgrade) waitForRollouts(ctx context.Context, deployments []*unstructured.Unstructured) error {
+	for _, deployment := range deployments {
+		if err := u.waitForRollout(ctx, deployment.GetNamespace(), deployment.GetName()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *infraUpgrade) waitForRollout(ctx context.Context, namespace, name string) error {
+	err := wait.PollImmediate(5*time.Second, UpgradeRolloutTimeout, func() (bool, error) {
+		deployment, err := u.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, condition := range deployment.Status.Conditions {
+			if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+				return false, fmt.Errorf("%v", condition.Message)
+			}
+		}
+
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		return deployment.Status.ObservedGeneration >= deployment.Generation &&
+			deployment.Status.UpdatedReplicas == replicas &&
+			deployment.Status.Replicas == replicas &&
+			deployment.Status.AvailableReplicas == replicas, nil
+	})
+	if err != nil {
+		return fmt.Errorf("deployment %v didn't roll out: %v", name, err)
+	}
+	return nil
+}
+
+// fail rolls back the applied objects and returns the error of the upgrade
+func (u *infraUpgrade) fail(ctx context.Context, stage string, err error) error {
+	logrus.WithError(err).Error("upgrade failed at the ", stage, " stage, rolling back")
+	if rollbackErr := u.rollback(ctx); rollbackErr != nil {
+		return &UpgradeError{Stage: stage, Err: fmt.Errorf("%v, rollback failed: %v", err, rollbackErr)}
+	}
+	return &UpgradeError{Stage: stage, Err: err, RolledBack: true}
+}
+
+// rollback applies the previous state of the applied objects in the reverse order and deletes the objects which
+// didn't exist, except the namespaces and the custom resource definitions which may be in use
+func (u *infraUpgrade) rollback(ctx context.Context) error {
+	var deployments []*unstructured.Unstructured
+	for i := len(u.applied) - 1; i >= 0; i-- {
+		applied := u.applied[i]
+		if applied.previous == nil {
+			kind := applied.object.GetKind()
+			if kind == "Namespace" || kind == "CustomResourceDefinition" {
+				continue
+			}
+			err := applied.resource.Delete(ctx, applied.object.GetName(), metav1.DeleteOptions{})
+			if err != nil && !k8s_errors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete %v %v: %v", kind, applied.object.GetName(), err)
+			}
+			continue
+		}
+
+		if err := serverSideApply(ctx, applied.resource, applied.previous); err != nil {
+			return fmt.Errorf("failed to restore %v %v: %v", applied.previous.GetKind(), applied.previous.GetName(), err)
+		}
+		if applied.previous.GetKind() == "Deployment" {
+			deployments = append(deployments, applied.previous)
+		}
+	}
+	return u.waitForRollouts(ctx, deployments)
+}
@@ -0,0 +1,97 @@
+package k8s
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const upgradeManifest = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: subscriber-config
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: subscriber
+---
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: event-tracker
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: eventtrackerpolicies.eventtracker.litmuschaos.io
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: litmus
+`
+
+func objectNames(objects []*unstructured.Unstructured) []string {
+	names := []string{}
+	for _, object := range objects {
+		names = append(names, object.GetKind()+"/"+object.GetName())
+	}
+	return names
+}
+
+func TestDecodeManifest(t *testing.T) {
+	objects, err := decodeManifest(upgradeManifest)
+	if err != nil {
+		t.Fatalf("decodeManifest() error = %v", err)
+	}
+	want := []string{
+		"ConfigMap/subscriber-config",
+		"Deployment/subscriber",
+		"Deployment/event-tracker",
+		"CustomResourceDefinition/eventtrackerpolicies.eventtracker.litmuschaos.io",
+		"Namespace/litmus",
+	}
+	if got := objectNames(objects); !reflect.DeepEqual(got, want) {
+		t.Errorf("decodeManifest() = %v, want %v", got, want)
+	}
+
+	// the manifests sent by the server may be in JSON
+	objects, err = decodeManifest(`{"apiVersion": "v1", "kind": "ServiceAccount", "metadata": {"name": "litmus"}}`)
+	if err != nil {
+		t.Fatalf("decodeManifest() error = %v", err)
+	}
+	if got := objectNames(objects); !reflect.DeepEqual(got, []string{"ServiceAccount/litmus"}) {
+		t.Errorf("decodeManifest() = %v, want the service account", got)
+	}
+
+	if _, err := decodeManifest("kind: [Deployment"); err == nil {
+		t.Error("decodeManifest() of an invalid manifest didn't fail")
+	}
+}
+
+func TestSplitUpgradeStages(t *testing.T) {
+	objects, err := decodeManifest(upgradeManifest)
+	if err != nil {
+		t.Fatalf("decodeManifest() error = %v", err)
+	}
+
+	resources, components, subscriber := splitUpgradeStages(objects)
+	wantResources := []string{
+		"CustomResourceDefinition/eventtrackerpolicies.eventtracker.litmuschaos.io",
+		"Namespace/litmus",
+		"ConfigMap/subscriber-config",
+	}
+	if got := objectNames(resources); !reflect.DeepEqual(got, wantResources) {
+		t.Errorf("splitUpgradeStages() resources = %v, want %v", got, wantResources)
+	}
+	if got := objectNames(components); !reflect.DeepEqual(got, []string{"Deployment/event-tracker"}) {
+		t.Errorf("splitUpgradeStages() components = %v, want the event tracker", got)
+	}
+	if got := objectNames(subscriber); !reflect.DeepEqual(got, []string{"Deployment/subscriber"}) {
+		t.Errorf("splitUpgradeStages() subscriber = %v, want the subscriber", got)
+	}
+}
@@ -0,0 +1,44 @@
+package requests
+
+import (
+	"math/rand"
+	"time"
+)
+
+const (
+	reconnectBaseDelay = 1 * time.Second
+	reconnectMaxDelay  = 60 * time.Second
+)
+
+// backoff computes exponentially growing reconnect delays with full jitter
+type backoff struct {
+	base    time.Duration
+	max     time.Duration
+	attempt int
+}
+
+func newBackoff() *backoff {
+	return &backoff{
+		base: reconnectBaseDelay,
+		max:  reconnectMaxDelay,
+	}
+}
+
+// Next returns the delay to wait before the next reconnect attempt
+func (b *backoff) Next() time.Duration {
+	ceiling := b.max
+	if b.attempt < 30 {
+		if d := b.base << uint(b.attempt); d < b.max {
+			ceiling = d
+		}
+	}
+	b.attempt++
+
+	// full jitter spreads the reconnects of many agents after a control plane restart
+	return b.base/2 + time.Duration(rand.Int63n(int64(ceiling)))
+}
+
+// Reset starts the delay sequence over, used once a connection is acknowledged
+func (b *backoff) Reset() {
+	b.attempt = 0
+}
@@ -0,0 +1,41 @@
+package requests
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	b := newBackoff()
+
+	// the delays are jittered below a ceiling which doubles up to the maximum
+	ceilings := []time.Duration{
+		reconnectBaseDelay,
+		2 * reconnectBaseDelay,
+		4 * reconnectBaseDelay,
+		8 * reconnectBaseDelay,
+		16 * reconnectBaseDelay,
+		32 * reconnectBaseDelay,
+		reconnectMaxDelay,
+		reconnectMaxDelay,
+	}
+	for attempt, ceiling := range ceilings {
+		delay := b.Next()
+		if delay < reconnectBaseDelay/2 || delay >= reconnectBaseDelay/2+ceiling {
+			t.Errorf("Next() = %v on attempt %d, want a delay in [%v, %v)", delay, attempt, reconnectBaseDelay/2, reconnectBaseDelay/2+ceiling)
+		}
+	}
+
+	// the ceiling doesn't overflow after many attempts
+	for i := 0; i < 100; i++ {
+		if delay := b.Next(); delay < reconnectBaseDelay/2 || delay >= reconnectBaseDelay/2+reconnectMaxDelay {
+			t.Fatalf("Next() = %v after %d attempts", delay, b.attempt)
+		}
+	}
+
+	// the delays start over once a connection is acknowledged
+	b.Reset()
+	if delay := b.Next(); delay >= reconnectBaseDelay/2+reconnectBaseDelay {
+		t.Errorf("Next() = %v after Reset(), want a delay below %v", delay, reconnectBaseDelay/2+reconnectBaseDelay)
+	}
+}
@@ -1,6 +1,10 @@
 package requests
 
 import (
+	"context"
+	"sync"
+
+	"subscriber/pkg/graphql"
 	"subscriber/pkg/k8s"
 	"subscriber/pkg/types"
 	"subscriber/pkg/utils"
@@ -14,11 +18,20 @@ type SubscriberRequests interface {
 type subscriberRequests struct {
 	subscriberK8s   k8s.SubscriberK8s
 	subscriberUtils utils.SubscriberUtils
+	subscriberGql   graphql.SubscriberGql
+	// logStreams cancels the log requests in progress by their request IDs
+	logStreams      map[string]context.CancelFunc
+	logStreamsMutex sync.Mutex
+	// upgradeID is the ID of the upgrade in progress, the server sends it again if the connection is lost
+	upgradeID      string
+	upgradeIDMutex sync.Mutex
 }
 
-func NewSubscriberRequests(subscriberK8s k8s.SubscriberK8s, subscriberUtils utils.SubscriberUtils) SubscriberRequests {
+func NewSubscriberRequests(subscriberK8s k8s.SubscriberK8s, subscriberUtils utils.SubscriberUtils, subscriberGql graphql.SubscriberGql) SubscriberRequests {
 	return &subscriberRequests{
 		subscriberK8s:   subscriberK8s,
 		subscriberUtils: subscriberUtils,
+		subscriberGql:   subscriberGql,
+		logStreams:      make(map[string]context.CancelFunc),
 	}
 }
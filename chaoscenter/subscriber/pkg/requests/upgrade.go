@@ -0,0 +1,103 @@
+package requests
+
+import (
+	"encoding/json"
+	"errors"
+
+	"subscriber/pkg/k8s"
+	"subscriber/pkg/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// upgradeInfra applies the manifest of the version sent by the server and reports the progress of the upgrade,
+// the upgrade is acknowledged once it's done
+func (req *subscriberRequests) upgradeInfra(infraData map[string]string, action types.Action) {
+	var upgrade types.InfraUpgradeRequest
+	if err := json.Unmarshal([]byte(action.ExternalData), &upgrade); err != nil {
+		err = errors.New("error reading infra-action request [external-data]: " + err.Error())
+		req.reportInfraUpgrade(infraData, action.RequestID, "Failed", nil, err)
+		req.ackInfraAction(infraData, action.RequestID, err)
+		return
+	}
+
+	// this subscriber was started by the upgrade, the previous one was replaced before acknowledging it
+	if infraData["VERSION"] == upgrade.Version {
+		stage := k8s.UpgradeStageSubscriber
+		req.reportInfraUpgrade(infraData, action.RequestID, "Succeeded", &stage, nil)
+		req.ackInfraAction(infraData, action.RequestID, nil)
+		return
+	}
+
+	if !req.startUpgrade(action.RequestID) {
+		logrus.WithField("upgradeID", action.RequestID).Info("upgrade already in progress")
+		return
+	}
+	defer req.stopUpgrade()
+
+	logrus.WithField("upgradeID", action.RequestID).Info("upgrading the infra from version ", infraData["VERSION"], " to ", upgrade.Version)
+	err := req.subscriberK8s.UpgradeInfra(action, infraData["COMPONENTS"], func(stage string) {
+		req.reportInfraUpgrade(infraData, action.RequestID, "InProgress", &stage, nil)
+	})
+
+	status := "Succeeded"
+	var upgradeErr *k8s.UpgradeError
+	if errors.As(err, &upgradeErr) && upgradeErr.RolledBack {
+		status = "RolledBack"
+	} else if err != nil {
+		status = "Failed"
+	}
+	req.reportInfraUpgrade(infraData, action.RequestID, status, nil, err)
+	req.ackInfraAction(infraData, action.RequestID, err)
+}
+
+// startUpgrade returns false if an upgrade is already in progress
+func (req *subscriberRequests) startUpgrade(upgradeID string) bool {
+	req.upgradeIDMutex.Lock()
+	defer req.upgradeIDMutex.Unlock()
+	if req.upgradeID != "" {
+		return false
+	}
+	req.upgradeID = upgradeID
+	return true
+}
+
+func (req *subscriberRequests) stopUpgrade() {
+	req.upgradeIDMutex.Lock()
+	defer req.upgradeIDMutex.Unlock()
+	req.upgradeID = ""
+}
+
+// reportInfraUpgrade sends the progress of an upgrade to the server
+func (req *subscriberRequests) reportInfraUpgrade(infraData map[string]string, upgradeID string, status string, stage *string, upgradeErr error) {
+	request := map[string]interface{}{
+		"infraID": map[string]string{
+			"infraID":   infraData["INFRA_ID"],
+			"version":   infraData["VERSION"],
+			"accessKey": infraData["ACCESS_KEY"],
+		},
+		"upgradeID": upgradeID,
+		"status":    status,
+	}
+	if stage != nil {
+		request["stage"] = *stage
+	}
+	if upgradeErr != nil {
+		request["error"] = upgradeErr.Error()
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"query":     "mutation ($request: InfraUpgradeStatusRequest!) { updateInfraUpgradeStatus(request: $request) }",
+		"variables": map[string]interface{}{"request": request},
+	})
+	if err != nil {
+		logrus.WithError(err).Error("failed to marshal the infra upgrade status")
+		return
+	}
+
+	body, err := req.subscriberGql.SendRequest(infraData["SERVER_ADDR"], payload)
+	if err != nil {
+		logrus.WithError(err).WithField("upgradeID", upgradeID).Error("failed to report the infra upgrade status")
+		return
+	}
+	logrus.WithField("upgradeID", upgradeID).Info("infra upgrade ", status, ": ", body)
+}
@@ -1,18 +1,25 @@
 package requests
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/url"
 	"strings"
 	"time"
 
+	"subscriber/pkg/graphql"
 	"subscriber/pkg/types"
 
 	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
 )
 
+// logStreamMaxDuration is the longest the logs of a request are followed for
+const logStreamMaxDuration = 30 * time.Minute
+
+// AgentConnect keeps the infraConnect subscription open, re-establishing the
+// websocket connection with exponential backoff whenever it is lost
 func (req *subscriberRequests) AgentConnect(infraData map[string]string) {
 	query := `{"query":"subscription {\n    infraConnect(request: {infraID: \"` + infraData["INFRA_ID"] + `\", version: \"` + infraData["VERSION"] + `\", accessKey: \"` + infraData["ACCESS_KEY"] + `\"}) {\n     action{\n      k8sManifest,\n      externalData,\n      requestID\n requestType\n     username\n     namespace\n     }\n  }\n}\n"}`
 	serverURL, err := url.Parse(infraData["SERVER_ADDR"])
@@ -25,51 +32,63 @@ func (req *subscriberRequests) AgentConnect(infraData map[string]string) {
 	}
 
 	u := url.URL{Scheme: scheme, Host: serverURL.Host, Path: serverURL.Path}
-	logrus.Info("Connecting to " + u.String())
+	retry := newBackoff()
+
+	for {
+		err := req.listen(u.String(), query, infraData, retry)
+		graphql.Connection.SetConnected(false)
+		// the server cancels the log requests of the lost connection, it may not tell this subscriber
+		req.stopLogStreams()
+
+		delay := retry.Next()
+		logrus.WithError(err).Warnf("Lost connection to the server, reconnecting in %s", delay.Round(time.Millisecond))
+		time.Sleep(delay)
+	}
+}
+
+// listen dials the server, starts the infraConnect subscription and processes
+// the received actions until the connection fails
+func (req *subscriberRequests) listen(serverURL string, query string, infraData map[string]string, retry *backoff) error {
+	logrus.Info("Connecting to " + serverURL)
 
-	c, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	c, _, err := websocket.DefaultDialer.Dial(serverURL, nil)
 	if err != nil {
-		logrus.WithError(err).Fatal("Failed to established websocket connection")
+		return errors.New("failed to establish websocket connection: " + err.Error())
 	}
 	defer c.Close()
 
-	go func() {
-		time.Sleep(1 * time.Second)
-		payload := types.OperationMessage{
-			Type: "connection_init",
-		}
-		data, err := json.Marshal(payload)
-		if err != nil {
-			logrus.WithError(err).Fatal("Failed to marshal message")
-		}
+	payload := types.OperationMessage{
+		Type: "connection_init",
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return errors.New("failed to marshal message: " + err.Error())
+	}
 
-		err = c.WriteMessage(websocket.TextMessage, data)
-		if err != nil {
-			logrus.WithError(err).Fatal("Failed to write message after init")
-			return
-		}
+	err = c.WriteMessage(websocket.TextMessage, data)
+	if err != nil {
+		return errors.New("failed to write message after init: " + err.Error())
+	}
 
-		payload = types.OperationMessage{
-			Payload: []byte(query),
-			Type:    "start",
-		}
+	payload = types.OperationMessage{
+		Payload: []byte(query),
+		Type:    "start",
+	}
 
-		data, err = json.Marshal(payload)
-		if err != nil {
-			logrus.WithError(err).Fatal("Failed to marshal message")
-		}
+	data, err = json.Marshal(payload)
+	if err != nil {
+		return errors.New("failed to marshal message: " + err.Error())
+	}
 
-		err = c.WriteMessage(websocket.TextMessage, data)
-		if err != nil {
-			logrus.WithError(err).Fatal("Failed to write message after start")
-			return
-		}
-	}()
+	err = c.WriteMessage(websocket.TextMessage, data)
+	if err != nil {
+		return errors.New("failed to write message after start: " + err.Error())
+	}
 
 	for {
 		_, message, err := c.ReadMessage()
 		if err != nil {
-			logrus.WithError(err).Fatal("Failed to read message")
+			return errors.New("failed to read message: " + err.Error())
 		}
 
 		var r types.RawData
@@ -79,8 +98,15 @@ func (req *subscriberRequests) AgentConnect(infraData map[string]string) {
 			continue
 		}
 
-		if r.Type == "connection_ack" {
+		switch r.Type {
+		case "connection_ack":
 			logrus.Info("Server connection established, Listening....")
+			retry.Reset()
+			graphql.Connection.SetConnected(true)
+		case "connection_error":
+			return errors.New("connection rejected by the server: " + string(message))
+		case "complete":
+			return errors.New("subscription completed by the server")
 		}
 		if r.Type != "data" {
 			continue
@@ -112,8 +138,7 @@ func (req *subscriberRequests) RequestProcessor(infraData map[string]string, r t
 		if err != nil {
 			return errors.New("error getting kubernetes object data: " + err.Error())
 		}
-	}
-	if strings.ToLower(r.Payload.Data.InfraConnect.Action.RequestType) == "logs" {
+	} else if strings.ToLower(r.Payload.Data.InfraConnect.Action.RequestType) == "logs" {
 		podRequest := types.PodLogRequest{
 			RequestID: r.Payload.Data.InfraConnect.Action.RequestID,
 		}
@@ -123,19 +148,105 @@ func (req *subscriberRequests) RequestProcessor(infraData map[string]string, r t
 		}
 
 		logrus.Print("Log Request: ", r.Payload.Data.InfraConnect.Action.ExternalData)
-		req.subscriberK8s.SendPodLogs(infraData, podRequest)
+		// the logs may be followed, they're sent without blocking the other requests
+		ctx := req.startLogStream(podRequest.RequestID)
+		go func() {
+			defer req.stopLogStream(podRequest.RequestID)
+			req.subscriberK8s.SendPodLogs(ctx, infraData, podRequest)
+		}()
+	} else if strings.ToLower(r.Payload.Data.InfraConnect.Action.RequestType) == "logs_cancel" {
+		logrus.Print("Log Request Cancelled: ", r.Payload.Data.InfraConnect.Action.RequestID)
+		req.stopLogStream(r.Payload.Data.InfraConnect.Action.RequestID)
+	} else if strings.ToLower(r.Payload.Data.InfraConnect.Action.RequestType) == "upgrade" {
+		// the upgrade waits for the components to roll out, it's run without blocking the other requests
+		go req.upgradeInfra(infraData, r.Payload.Data.InfraConnect.Action)
 	} else if strings.Index("create update delete get", strings.ToLower(r.Payload.Data.InfraConnect.Action.RequestType)) >= 0 {
 		_, err := req.subscriberK8s.AgentOperations(r.Payload.Data.InfraConnect.Action)
+		req.ackInfraAction(infraData, r.Payload.Data.InfraConnect.Action.RequestID, err)
 		if err != nil {
 			return errors.New("error performing infra operation: " + err.Error())
 		}
 	} else if strings.Index("workflow_delete workflow_run_delete workflow_run_stop ", strings.ToLower(r.Payload.Data.InfraConnect.Action.RequestType)) >= 0 {
 
 		err := req.subscriberUtils.WorkflowRequest(infraData, r.Payload.Data.InfraConnect.Action.RequestType, r.Payload.Data.InfraConnect.Action.ExternalData, r.Payload.Data.InfraConnect.Action.Username)
+		req.ackInfraAction(infraData, r.Payload.Data.InfraConnect.Action.RequestID, err)
 		if err != nil {
 			return errors.New("error performing events operation: " + err.Error())
 		}
+	} else {
+		// the action is failed, so that the server doesn't send it again
+		err := errors.New("unsupported request type: " + r.Payload.Data.InfraConnect.Action.RequestType)
+		req.ackInfraAction(infraData, r.Payload.Data.InfraConnect.Action.RequestID, err)
+		return err
 	}
 
 	return nil
 }
+
+// startLogStream returns the context of a log request, which is done when the request is cancelled or after
+// logStreamMaxDuration
+func (req *subscriberRequests) startLogStream(requestID string) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), logStreamMaxDuration)
+	req.logStreamsMutex.Lock()
+	defer req.logStreamsMutex.Unlock()
+	req.logStreams[requestID] = cancel
+	return ctx
+}
+
+// stopLogStream cancels a log request, it does nothing if the request is already done
+func (req *subscriberRequests) stopLogStream(requestID string) {
+	req.logStreamsMutex.Lock()
+	defer req.logStreamsMutex.Unlock()
+	if cancel, ok := req.logStreams[requestID]; ok {
+		cancel()
+		delete(req.logStreams, requestID)
+	}
+}
+
+// stopLogStreams cancels all the log requests in progress
+func (req *subscriberRequests) stopLogStreams() {
+	req.logStreamsMutex.Lock()
+	defer req.logStreamsMutex.Unlock()
+	for requestID, cancel := range req.logStreams {
+		cancel()
+		delete(req.logStreams, requestID)
+	}
+}
+
+// ackInfraAction reports to the server whether a queued action was applied, the server sends the action
+// again on the next connection until it's acknowledged
+func (req *subscriberRequests) ackInfraAction(infraData map[string]string, requestID string, actionErr error) {
+	// actions sent by older servers aren't queued
+	if requestID == "" {
+		return
+	}
+
+	request := map[string]interface{}{
+		"infraID": map[string]string{
+			"infraID":   infraData["INFRA_ID"],
+			"version":   infraData["VERSION"],
+			"accessKey": infraData["ACCESS_KEY"],
+		},
+		"requestID": requestID,
+		"state":     "Applied",
+	}
+	if actionErr != nil {
+		request["state"] = "Failed"
+		request["error"] = actionErr.Error()
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"query":     "mutation ($request: InfraActionAck!) { ackInfraAction(request: $request) }",
+		"variables": map[string]interface{}{"request": request},
+	})
+	if err != nil {
+		logrus.WithError(err).Error("failed to marshal the infra action acknowledgement")
+		return
+	}
+
+	body, err := req.subscriberGql.SendRequest(infraData["SERVER_ADDR"], payload)
+	if err != nil {
+		logrus.WithError(err).WithField("requestID", requestID).Error("failed to acknowledge the infra action")
+		return
+	}
+	logrus.WithField("requestID", requestID).Info("infra action acknowledged: ", body)
+}
@@ -0,0 +1,28 @@
+package requests
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLogStreams(t *testing.T) {
+	req := &subscriberRequests{logStreams: make(map[string]context.CancelFunc)}
+
+	first := req.startLogStream("first")
+	second := req.startLogStream("second")
+	if deadline, ok := first.Deadline(); !ok || time.Until(deadline) > logStreamMaxDuration {
+		t.Errorf("the log stream has the deadline %v, want at most %s", deadline, logStreamMaxDuration)
+	}
+
+	req.stopLogStream("first")
+	if first.Err() == nil || second.Err() != nil {
+		t.Fatal("stopLogStream() didn't cancel only its log stream")
+	}
+
+	// the log streams are cancelled when the connection is lost
+	req.stopLogStreams()
+	if second.Err() == nil || len(req.logStreams) != 0 {
+		t.Error("stopLogStreams() didn't cancel all the log streams")
+	}
+}
@@ -19,6 +19,7 @@ type WorkflowEvent struct {
 	FinishedAt        string          `json:"finishedAt"`
 	Nodes             map[string]Node `json:"nodes"`
 	UpdatedBy         string          `json:"updatedBy"`
+	Sequence          int64           `json:"sequence,omitempty"`
 }
 
 // each node/step data
@@ -38,6 +39,7 @@ type ChaosData struct {
 	EngineUID              string                `json:"engineUID"`
 	EngineContext          string                `json:"engineContext"`
 	EngineName             string                `json:"engineName"`
+	FaultName              string                `json:"faultName,omitempty"`
 	Namespace              string                `json:"namespace"`
 	ExperimentName         string                `json:"experimentName"`
 	ExperimentStatus       string                `json:"experimentStatus"`
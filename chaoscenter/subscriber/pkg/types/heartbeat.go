@@ -0,0 +1,8 @@
+package types
+
+// ComponentHealth is the health of an infra component sent in the heartbeats
+type ComponentHealth struct {
+	Name    string  `json:"name"`
+	Healthy bool    `json:"healthy"`
+	Message *string `json:"message,omitempty"`
+}
@@ -0,0 +1,6 @@
+package types
+
+// InfraUpgradeRequest is the external data of an upgrade sent by the server
+type InfraUpgradeRequest struct {
+	Version string `json:"version"`
+}
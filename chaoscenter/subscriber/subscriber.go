@@ -134,7 +134,7 @@ func init() {
 
 func main() {
 	stopCh := make(chan struct{})
-	sigCh := make(chan os.Signal)
+	sigCh := make(chan os.Signal, 1)
 	stream := make(chan types.WorkflowEvent, 10)
 
 	subscriberGraphql := graphql.NewSubscriberGql()
@@ -142,7 +142,7 @@ func main() {
 	subscriberEvents := events.NewSubscriberEventsOperator(subscriberGraphql, subscriberK8s)
 	subscriberUtils := utils.NewSubscriberUtils(subscriberEvents, subscriberK8s)
 	subscriberEventOperations := events.NewSubscriberEventsOperator(subscriberGraphql, subscriberK8s)
-	subscriberRequests := requests.NewSubscriberRequests(subscriberK8s, subscriberUtils)
+	subscriberRequests := requests.NewSubscriberRequests(subscriberK8s, subscriberUtils, subscriberGraphql)
 	//start events event watcher
 
 	subscriberEventOperations.WorkflowEventWatcher(stopCh, stream, infraData)
@@ -155,6 +155,9 @@ func main() {
 	// listen for agent actions
 	go subscriberRequests.AgentConnect(infraData)
 
+	// report the health of the infra
+	go subscriberK8s.SendHeartbeats(stopCh, infraData)
+
 	signal.Notify(sigCh, os.Kill, os.Interrupt)
 	<-sigCh
 	close(stopCh)
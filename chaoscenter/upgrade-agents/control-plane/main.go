@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"os"
 	"strings"
@@ -28,6 +29,10 @@ func init() {
 }
 
 func main() {
+	dryRun := flag.Bool("dry-run", false, "report the documents the upgrade would touch, without changing them")
+	rollback := flag.Bool("rollback", false, "roll back the DB to the VERSION, reverting the migrations applied since")
+	flag.Parse()
+
 	// logging level, dev mode enables debug logs
 	dev := os.Getenv("DEV_MODE")
 	var logger *zap.Logger
@@ -53,7 +58,24 @@ func main() {
 	if err != nil {
 		logger.Fatal("failed to create upgrade manager", zap.Error(err))
 	}
-	if mg != nil {
+	if mg == nil {
+		return
+	}
+
+	switch {
+	case *dryRun && *rollback:
+		logger.Fatal("dry-run isn't supported for rollbacks")
+	case *dryRun:
+		reports, err := mg.DryRun()
+		if err != nil {
+			logger.Fatal("failed to dry-run upgrade manager", zap.Error(err))
+		}
+		logger.Info("dry-run completed, no changes were made", zap.Int("steps", len(reports)))
+	case *rollback:
+		if err = mg.Rollback(); err != nil {
+			logger.Fatal("failed to roll back upgrade manager", zap.Error(err))
+		}
+	default:
 		// execute upgrade manager
 		if err = mg.Run(); err != nil {
 			logger.Fatal("failed to run upgrade manager", zap.Error(err))
@@ -2,5 +2,6 @@ package database
 
 const (
 	ServerConfigCollection = "server-config-collection"
+	MigrationsCollection   = "migrations"
 	DbName                 = "litmus"
 )
@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	MigrationStatusApplied    = "Applied"
+	MigrationStatusRolledBack = "RolledBack"
+)
+
+// Migration records a migration step applied by the upgrade agent
+type Migration struct {
+	ID           string `bson:"_id"`
+	Version      string `bson:"version"`
+	Step         string `bson:"step"`
+	Status       string `bson:"status"`
+	AppliedAt    int64  `bson:"applied_at"`
+	RolledBackAt int64  `bson:"rolled_back_at,omitempty"`
+}
+
+// MigrationID returns the ID of the record of a step of a version
+func MigrationID(version string, step string) string {
+	return version + "/" + step
+}
+
+// GetAppliedMigrations returns the IDs of the migration steps which are applied and not rolled back
+func GetAppliedMigrations(dbClient *mongo.Client) (map[string]bool, error) {
+	collection := dbClient.Database(DbName).Collection(MigrationsCollection)
+	cursor, err := collection.Find(context.Background(), bson.D{
+		{"status", MigrationStatusApplied},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+	if err := cursor.All(context.Background(), &migrations); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]bool, len(migrations))
+	for _, migration := range migrations {
+		applied[migration.ID] = true
+	}
+	return applied, nil
+}
+
+// RecordMigration records a migration step as applied, the context is the one of the step so that both
+// are part of the same transaction
+func RecordMigration(ctx context.Context, dbClient *mongo.Client, version string, step string) error {
+	collection := dbClient.Database(DbName).Collection(MigrationsCollection)
+	_, err := collection.ReplaceOne(ctx, bson.D{
+		{"_id", MigrationID(version, step)},
+	}, Migration{
+		ID:        MigrationID(version, step),
+		Version:   version,
+		Step:      step,
+		Status:    MigrationStatusApplied,
+		AppliedAt: time.Now().UnixNano() / int64(time.Millisecond),
+	}, options.Replace().SetUpsert(true))
+	return err
+}
+
+// RecordRollback records a migration step as rolled back
+func RecordRollback(ctx context.Context, dbClient *mongo.Client, version string, step string) error {
+	collection := dbClient.Database(DbName).Collection(MigrationsCollection)
+	_, err := collection.UpdateOne(ctx, bson.D{
+		{"_id", MigrationID(version, step)},
+	}, bson.D{{"$set", bson.D{
+		{"status", MigrationStatusRolledBack},
+		{"rolled_back_at", time.Now().UnixNano() / int64(time.Millisecond)},
+	}}})
+	return err
+}
+
+// SupportsTransactions returns true if the DB is a replica set or a sharded cluster, standalone servers
+// don't support transactions
+func SupportsTransactions(dbClient *mongo.Client) bool {
+	var result bson.M
+	err := dbClient.Database("admin").RunCommand(context.Background(), bson.D{{"isMaster", 1}}).Decode(&result)
+	if err != nil {
+		return false
+	}
+	_, isReplicaSet := result["setName"]
+	return isReplicaSet || result["msg"] == "isdbgrid"
+}
+
+// MigrationOperator records the migration steps applied to the DB and the version of the DB
+type MigrationOperator interface {
+	GetAppliedMigrations() (map[string]bool, error)
+	RecordMigration(ctx context.Context, version string, step string) error
+	RecordRollback(ctx context.Context, version string, step string) error
+	UpdateVersion(version string) error
+	SupportsTransactions() bool
+}
+
+type migrationOperator struct {
+	dbClient *mongo.Client
+}
+
+// NewMigrationOperator returns the MigrationOperator recording the migrations in the DB of the client
+func NewMigrationOperator(dbClient *mongo.Client) MigrationOperator {
+	return &migrationOperator{dbClient: dbClient}
+}
+
+func (o *migrationOperator) GetAppliedMigrations() (map[string]bool, error) {
+	return GetAppliedMigrations(o.dbClient)
+}
+
+func (o *migrationOperator) RecordMigration(ctx context.Context, version string, step string) error {
+	return RecordMigration(ctx, o.dbClient, version, step)
+}
+
+func (o *migrationOperator) RecordRollback(ctx context.Context, version string, step string) error {
+	return RecordRollback(ctx, o.dbClient, version, step)
+}
+
+func (o *migrationOperator) UpdateVersion(version string) error {
+	return UpdateVersion(o.dbClient, version)
+}
+
+func (o *migrationOperator) SupportsTransactions() bool {
+	return SupportsTransactions(o.dbClient)
+}
@@ -0,0 +1,64 @@
+package migration
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Step is a unit of a version upgrade, it is recorded in the migrations collection once applied so that an
+// interrupted upgrade resumes after the last applied step. Up and Down have to be idempotent, as a step may
+// be interrupted after changing the DB but before being recorded
+type Step struct {
+	// Name identifies the step in its version, it mustn't be changed once released
+	Name string
+	// TransactionalUp steps are applied and recorded in a single transaction when the DB supports them, and
+	// TransactionalDown steps are reverted and recorded in a single transaction. The directions dropping,
+	// renaming or creating collections or indexes can't be transactional
+	TransactionalUp   bool
+	TransactionalDown bool
+	// Up applies the step
+	Up func(ctx context.Context) error
+	// Down reverts the step, it is nil for the steps which can't be reverted
+	Down func(ctx context.Context) error
+	// DryRun reports the documents the step would touch, without changing them
+	DryRun func(ctx context.Context) ([]Impact, error)
+}
+
+// Impact describes the documents of a collection a step would touch
+type Impact struct {
+	Database   string `json:"database"`
+	Collection string `json:"collection"`
+	// Operation is what the step does to the documents, like copy, drop or rename
+	Operation string `json:"operation"`
+	// Documents is the number of documents touched
+	Documents int64 `json:"documents"`
+}
+
+// CountImpact returns the impact of an operation on all the documents of a collection
+func CountImpact(ctx context.Context, collection *mongo.Collection, operation string) (Impact, error) {
+	count, err := collection.CountDocuments(ctx, bson.D{})
+	if err != nil {
+		return Impact{}, err
+	}
+	return Impact{
+		Database:   collection.Database().Name(),
+		Collection: collection.Name(),
+		Operation:  operation,
+		Documents:  count,
+	}, nil
+}
+
+// IsNamespaceNotFound returns true if the error is returned for a missing collection, the steps dropping
+// or renaming collections ignore it to be idempotent
+func IsNamespaceNotFound(err error) bool {
+	cmdErr, ok := err.(mongo.CommandError)
+	return ok && cmdErr.Code == 26
+}
+
+// IsIndexNotFound returns true if the error is returned for a missing index
+func IsIndexNotFound(err error) bool {
+	cmdErr, ok := err.(mongo.CommandError)
+	return ok && cmdErr.Code == 27
+}
@@ -1,30 +1,52 @@
 package versions
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
 
 	v2_6_0 "github.com/litmuschaos/litmus/litmus-portal/upgrader-agents/control-plane/versions/v2.6.0"
 
 	v2_4_0 "github.com/litmuschaos/litmus/litmus-portal/upgrader-agents/control-plane/versions/v2.4.0"
 
 	"github.com/litmuschaos/litmus/litmus-portal/upgrader-agents/control-plane/pkg/database"
+	"github.com/litmuschaos/litmus/litmus-portal/upgrader-agents/control-plane/pkg/migration"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
 
+// stepTimeout bounds the time of a migration step
+const stepTimeout = 10 * time.Minute
+
 // UpgradeExecutor holds the details regarding the version and IVersionManager for a particular version
 type UpgradeExecutor struct {
 	NextVersion    string
 	VersionManager IVersionManager
 }
 
+// versionStep is a migration step of the upgrade to a version
+type versionStep struct {
+	Version string
+	Step    migration.Step
+}
+
+// StepReport lists the documents a migration step would touch, as reported by the dry-runs
+type StepReport struct {
+	Version string             `json:"version"`
+	Step    string             `json:"step"`
+	Impacts []migration.Impact `json:"impacts"`
+}
+
 // UpgradeManager provides the functionality required to upgrade from the PreviousVersion to the TargetVersion
 type UpgradeManager struct {
 	Logger          *zap.Logger
 	DBClient        *mongo.Client
+	Migrations      database.MigrationOperator
 	PreviousVersion string
 	TargetVersion   string
+	// path replaces the upgrade path of the released versions when it's set
+	path map[string]UpgradeExecutor
 }
 
 // NewUpgradeManager creates an instance of a upgrade manager with the proper configurations
@@ -48,6 +70,7 @@ func NewUpgradeManager(logger *zap.Logger, dbClient *mongo.Client) (*UpgradeMana
 	return &UpgradeManager{
 		Logger:          logger,
 		DBClient:        dbClient,
+		Migrations:      database.NewMigrationOperator(dbClient),
 		PreviousVersion: config.Value.(string),
 		TargetVersion:   currentVersion,
 	}, nil
@@ -161,52 +184,199 @@ func (m *UpgradeManager) getUpgradePath() map[string]UpgradeExecutor {
 	}
 }
 
-// verifyPath verifies whether the current upgrade from PreviousVersion to TargetVersion
+// upgradePath returns the upgrade path of the released versions, unless another path is set
+func (m *UpgradeManager) upgradePath() map[string]UpgradeExecutor {
+	if m.path != nil {
+		return m.path
+	}
+	return m.getUpgradePath()
+}
+
+// verifyPath verifies whether the upgrade from the from version to the to version
 // is possible given the configured upgrade path
-func (m *UpgradeManager) verifyPath(upgradePath map[string]UpgradeExecutor) error {
+func (m *UpgradeManager) verifyPath(upgradePath map[string]UpgradeExecutor, from string, to string) error {
 
-	_, okP := upgradePath[m.PreviousVersion]
-	_, okT := upgradePath[m.TargetVersion]
+	_, okP := upgradePath[from]
+	_, okT := upgradePath[to]
 
 	if !okP && !okT {
-		return fmt.Errorf("previous version=%v or target version=%v not found in upgrade path", m.PreviousVersion, m.TargetVersion)
+		return fmt.Errorf("previous version=%v or target version=%v not found in upgrade path", from, to)
 	}
-	versionIterator := m.PreviousVersion
+	versionIterator := from
 	for versionIterator != "" {
 		versionIterator = upgradePath[versionIterator].NextVersion
-		if versionIterator == m.TargetVersion {
+		if versionIterator == to {
 			return nil
 		}
 	}
-	return fmt.Errorf("upgrade path not found from previous version=%v to target version=%v", m.PreviousVersion, m.TargetVersion)
+	return fmt.Errorf("upgrade path not found from previous version=%v to target version=%v", from, to)
 }
 
-// Run executes all the steps required in the upgrade path from PreviousVersion to TargetVersion
+// pathSteps returns the steps of the upgrade path from the from version to the to version, in the order
+// they are applied, along with the versions they upgrade to
+func (m *UpgradeManager) pathSteps(upgradePath map[string]UpgradeExecutor, from string, to string) []versionStep {
+	var steps []versionStep
+	// loop till the target version is reached
+	for versionIterator := from; versionIterator != to; versionIterator = upgradePath[versionIterator].NextVersion {
+		// Skipping schema upgrade, if version manager not available (Only version will be upgraded)
+		executor := upgradePath[versionIterator]
+		if executor.VersionManager == nil {
+			continue
+		}
+		for _, step := range executor.VersionManager.Steps() {
+			steps = append(steps, versionStep{Version: executor.NextVersion, Step: step})
+		}
+	}
+	return steps
+}
+
+// Run executes all the steps required in the upgrade path from PreviousVersion to TargetVersion. The steps
+// applied by an interrupted run are recorded in the migrations collection and skipped
 func (m *UpgradeManager) Run() error {
-	upgradePath := m.getUpgradePath()
+	upgradePath := m.upgradePath()
 
 	// verify if upgrade possible
-	if err := m.verifyPath(upgradePath); err != nil {
+	if err := m.verifyPath(upgradePath, m.PreviousVersion, m.TargetVersion); err != nil {
 		return err
 	}
 
+	applied, err := m.Migrations.GetAppliedMigrations()
+	if err != nil {
+		return fmt.Errorf("failed to get the applied migrations, error=%w", err)
+	}
+	transactions := m.Migrations.SupportsTransactions()
+
 	// start upgrade from previous version to target version
-	versionIterator := m.PreviousVersion
-	// loop till the target version is reached
-	for versionIterator != m.TargetVersion {
-		// Skipping schema upgrade, if version manager not available (Only version will be upgraded)
-		if upgradePath[versionIterator].VersionManager != nil {
-			if err := upgradePath[versionIterator].VersionManager.Run(); err != nil {
-				return fmt.Errorf("failed to upgrade to version %v, error : %w", versionIterator, err)
+	for _, vs := range m.pathSteps(upgradePath, m.PreviousVersion, m.TargetVersion) {
+		logger := m.Logger.With(zap.String("version", vs.Version), zap.String("step", vs.Step.Name))
+		if applied[database.MigrationID(vs.Version, vs.Step.Name)] {
+			logger.Info("Skipping migration step applied by a previous run")
+			continue
+		}
+
+		err := m.runStep(vs.Step.Up, vs.Step.TransactionalUp && transactions, func(ctx context.Context) error {
+			return m.Migrations.RecordMigration(ctx, vs.Version, vs.Step.Name)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to upgrade to version %v in step %v, error : %w", vs.Version, vs.Step.Name, err)
+		}
+		logger.Info("Applied migration step")
+	}
+
+	err = m.Migrations.UpdateVersion(m.TargetVersion)
+	if err != nil {
+		return fmt.Errorf("failed to update version in server config collection, error=%w", err)
+	}
+
+	return nil
+}
+
+// DryRun reports the documents touched by the steps which the upgrade from PreviousVersion to TargetVersion
+// would apply, without changing the DB
+func (m *UpgradeManager) DryRun() ([]StepReport, error) {
+	upgradePath := m.upgradePath()
+	if err := m.verifyPath(upgradePath, m.PreviousVersion, m.TargetVersion); err != nil {
+		return nil, err
+	}
+
+	applied, err := m.Migrations.GetAppliedMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the applied migrations, error=%w", err)
+	}
+
+	var reports []StepReport
+	for _, vs := range m.pathSteps(upgradePath, m.PreviousVersion, m.TargetVersion) {
+		if applied[database.MigrationID(vs.Version, vs.Step.Name)] {
+			continue
+		}
+
+		report := StepReport{Version: vs.Version, Step: vs.Step.Name}
+		if vs.Step.DryRun != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), stepTimeout)
+			report.Impacts, err = vs.Step.DryRun(ctx)
+			cancel()
+			if err != nil {
+				return nil, fmt.Errorf("failed to dry-run version %v step %v, error : %w", vs.Version, vs.Step.Name, err)
 			}
 		}
-		versionIterator = upgradePath[versionIterator].NextVersion
+		m.Logger.Info("Migration step would be applied", zap.String("version", vs.Version),
+			zap.String("step", vs.Step.Name), zap.Any("impacts", report.Impacts))
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// Rollback reverts the steps applied between TargetVersion and PreviousVersion, in the reverse order, and
+// sets the version back to TargetVersion. Here PreviousVersion is the version of the DB and TargetVersion
+// the older version being rolled back to, which is usually the previous server-config version
+func (m *UpgradeManager) Rollback() error {
+	upgradePath := m.upgradePath()
+	if err := m.verifyPath(upgradePath, m.TargetVersion, m.PreviousVersion); err != nil {
+		return fmt.Errorf("failed to find the rollback path, error=%w", err)
+	}
+
+	applied, err := m.Migrations.GetAppliedMigrations()
+	if err != nil {
+		return fmt.Errorf("failed to get the applied migrations, error=%w", err)
+	}
+	transactions := m.Migrations.SupportsTransactions()
+
+	steps := m.pathSteps(upgradePath, m.TargetVersion, m.PreviousVersion)
+	// checking all the steps before reverting any of them, so that the DB isn't left half rolled back
+	for _, vs := range steps {
+		if applied[database.MigrationID(vs.Version, vs.Step.Name)] && vs.Step.Down == nil {
+			return fmt.Errorf("step %v of version %v can't be rolled back", vs.Step.Name, vs.Version)
+		}
 	}
 
-	err := database.UpdateVersion(m.DBClient, m.TargetVersion)
+	for i := len(steps) - 1; i >= 0; i-- {
+		vs := steps[i]
+		logger := m.Logger.With(zap.String("version", vs.Version), zap.String("step", vs.Step.Name))
+		if !applied[database.MigrationID(vs.Version, vs.Step.Name)] {
+			logger.Info("Skipping migration step which isn't applied")
+			continue
+		}
+
+		err := m.runStep(vs.Step.Down, vs.Step.TransactionalDown && transactions, func(ctx context.Context) error {
+			return m.Migrations.RecordRollback(ctx, vs.Version, vs.Step.Name)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to roll back version %v step %v, error : %w", vs.Version, vs.Step.Name, err)
+		}
+		logger.Info("Rolled back migration step")
+	}
+
+	err = m.Migrations.UpdateVersion(m.TargetVersion)
 	if err != nil {
 		return fmt.Errorf("failed to update version in server config collection, error=%w", err)
 	}
 
 	return nil
 }
+
+// runStep runs a step and records it, in a single transaction if transactional is true
+func (m *UpgradeManager) runStep(step func(ctx context.Context) error, transactional bool, record func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), stepTimeout)
+	defer cancel()
+
+	run := func(ctx context.Context) error {
+		if err := step(ctx); err != nil {
+			return err
+		}
+		return record(ctx)
+	}
+	if !transactional {
+		return run(ctx)
+	}
+
+	session, err := m.DBClient.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, run(sc)
+	})
+	return err
+}
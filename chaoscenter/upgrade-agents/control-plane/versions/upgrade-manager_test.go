@@ -0,0 +1,350 @@
+package versions
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/litmuschaos/litmus/litmus-portal/upgrader-agents/control-plane/pkg/database"
+	"github.com/litmuschaos/litmus/litmus-portal/upgrader-agents/control-plane/pkg/migration"
+	v2_4_0 "github.com/litmuschaos/litmus/litmus-portal/upgrader-agents/control-plane/versions/v2.4.0"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// fakeMigrations records the migrations in memory
+type fakeMigrations struct {
+	applied      map[string]bool
+	recorded     []string
+	rolledBack   []string
+	version      string
+	transactions bool
+}
+
+func (f *fakeMigrations) GetAppliedMigrations() (map[string]bool, error) {
+	applied := map[string]bool{}
+	for id, ok := range f.applied {
+		applied[id] = ok
+	}
+	return applied, nil
+}
+
+func (f *fakeMigrations) RecordMigration(ctx context.Context, version string, step string) error {
+	f.applied[database.MigrationID(version, step)] = true
+	f.recorded = append(f.recorded, database.MigrationID(version, step))
+	return nil
+}
+
+func (f *fakeMigrations) RecordRollback(ctx context.Context, version string, step string) error {
+	delete(f.applied, database.MigrationID(version, step))
+	f.rolledBack = append(f.rolledBack, database.MigrationID(version, step))
+	return nil
+}
+
+func (f *fakeMigrations) UpdateVersion(version string) error {
+	f.version = version
+	return nil
+}
+
+func (f *fakeMigrations) SupportsTransactions() bool {
+	return f.transactions
+}
+
+type fakeVersionManager struct {
+	steps []migration.Step
+}
+
+func (vm fakeVersionManager) Steps() []migration.Step {
+	return vm.steps
+}
+
+// stepLog records the calls of the steps
+type stepLog struct {
+	calls []string
+}
+
+func (l *stepLog) step(name string, failUp error) migration.Step {
+	return migration.Step{
+		Name: name,
+		Up: func(ctx context.Context) error {
+			l.calls = append(l.calls, "up "+name)
+			return failUp
+		},
+		Down: func(ctx context.Context) error {
+			l.calls = append(l.calls, "down "+name)
+			return nil
+		},
+		DryRun: func(ctx context.Context) ([]migration.Impact, error) {
+			l.calls = append(l.calls, "dry-run "+name)
+			return []migration.Impact{{Database: "litmus", Collection: name, Operation: "update", Documents: 1}}, nil
+		},
+	}
+}
+
+// testUpgradePath upgrades from 1.0.0 to 1.3.0, 1.1.0 has the steps a and b, 1.2.0 has none and 1.3.0 has c
+func testUpgradePath(a, b, c migration.Step) map[string]UpgradeExecutor {
+	return map[string]UpgradeExecutor{
+		"1.0.0": {NextVersion: "1.1.0", VersionManager: fakeVersionManager{steps: []migration.Step{a, b}}},
+		"1.1.0": {NextVersion: "1.2.0", VersionManager: nil},
+		"1.2.0": {NextVersion: "1.3.0", VersionManager: fakeVersionManager{steps: []migration.Step{c}}},
+		"1.3.0": {NextVersion: "", VersionManager: nil},
+	}
+}
+
+func newTestManager(from, to string, migrations *fakeMigrations, path map[string]UpgradeExecutor) *UpgradeManager {
+	return &UpgradeManager{
+		Logger:          zap.NewNop(),
+		Migrations:      migrations,
+		PreviousVersion: from,
+		TargetVersion:   to,
+		path:            path,
+	}
+}
+
+func TestVerifyPath(t *testing.T) {
+	log := &stepLog{}
+	path := testUpgradePath(log.step("a", nil), log.step("b", nil), log.step("c", nil))
+	m := newTestManager("", "", nil, path)
+
+	testcases := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr bool
+	}{
+		{name: "whole path", from: "1.0.0", to: "1.3.0"},
+		{name: "part of the path", from: "1.1.0", to: "1.2.0"},
+		{name: "downgrade", from: "1.3.0", to: "1.0.0", wantErr: true},
+		{name: "same version", from: "1.1.0", to: "1.1.0", wantErr: true},
+		{name: "unknown target version", from: "1.0.0", to: "2.0.0", wantErr: true},
+		{name: "unknown previous version", from: "0.9.0", to: "1.3.0", wantErr: true},
+		{name: "unknown versions", from: "0.9.0", to: "2.0.0", wantErr: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := m.verifyPath(path, tc.from, tc.to)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("verifyPath(%s, %s) error = %v, wantErr %v", tc.from, tc.to, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestPathSteps(t *testing.T) {
+	log := &stepLog{}
+	path := testUpgradePath(log.step("a", nil), log.step("b", nil), log.step("c", nil))
+	m := newTestManager("", "", nil, path)
+
+	testcases := []struct {
+		name string
+		from string
+		to   string
+		want []string
+	}{
+		{name: "whole path", from: "1.0.0", to: "1.3.0", want: []string{"1.1.0/a", "1.1.0/b", "1.3.0/c"}},
+		{name: "from a version without steps", from: "1.1.0", to: "1.3.0", want: []string{"1.3.0/c"}},
+		{name: "to a version without steps", from: "1.0.0", to: "1.2.0", want: []string{"1.1.0/a", "1.1.0/b"}},
+		{name: "versions without steps", from: "1.1.0", to: "1.2.0", want: nil},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, vs := range m.pathSteps(path, tc.from, tc.to) {
+				got = append(got, database.MigrationID(vs.Version, vs.Step.Name))
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("pathSteps(%s, %s) = %v, want %v", tc.from, tc.to, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRunResumesInterruptedUpgrade(t *testing.T) {
+	log := &stepLog{}
+	migrations := &fakeMigrations{applied: map[string]bool{"1.1.0/a": true}, version: "1.0.0"}
+	m := newTestManager("1.0.0", "1.3.0", migrations, testUpgradePath(log.step("a", nil), log.step("b", nil), log.step("c", nil)))
+
+	if err := m.Run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	// the step applied by the interrupted run is skipped
+	if want := []string{"up b", "up c"}; !reflect.DeepEqual(log.calls, want) {
+		t.Errorf("Run() called %v, want %v", log.calls, want)
+	}
+	if want := []string{"1.1.0/b", "1.3.0/c"}; !reflect.DeepEqual(migrations.recorded, want) {
+		t.Errorf("Run() recorded %v, want %v", migrations.recorded, want)
+	}
+	if migrations.version != "1.3.0" {
+		t.Errorf("Run() set the version %s, want 1.3.0", migrations.version)
+	}
+}
+
+func TestRunStopsAtFailedStep(t *testing.T) {
+	log := &stepLog{}
+	migrations := &fakeMigrations{applied: map[string]bool{}, version: "1.0.0"}
+	m := newTestManager("1.0.0", "1.3.0", migrations, testUpgradePath(log.step("a", nil), log.step("b", errors.New("timeout")), log.step("c", nil)))
+
+	if err := m.Run(); err == nil {
+		t.Fatal("Run() didn't return the error of the failed step")
+	}
+	// the failed step isn't recorded, so that the next run retries it
+	if want := []string{"1.1.0/a"}; !reflect.DeepEqual(migrations.recorded, want) {
+		t.Errorf("Run() recorded %v, want %v", migrations.recorded, want)
+	}
+	if migrations.version != "1.0.0" {
+		t.Errorf("Run() set the version %s after a failed step", migrations.version)
+	}
+
+	// the next run resumes at the failed step
+	log.calls = nil
+	m.path = testUpgradePath(log.step("a", nil), log.step("b", nil), log.step("c", nil))
+	if err := m.Run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if want := []string{"up b", "up c"}; !reflect.DeepEqual(log.calls, want) {
+		t.Errorf("Run() called %v, want %v", log.calls, want)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	log := &stepLog{}
+	migrations := &fakeMigrations{applied: map[string]bool{"1.1.0/a": true}, version: "1.0.0"}
+	m := newTestManager("1.0.0", "1.3.0", migrations, testUpgradePath(log.step("a", nil), log.step("b", nil), log.step("c", nil)))
+
+	reports, err := m.DryRun()
+	if err != nil {
+		t.Fatalf("DryRun() error = %v", err)
+	}
+	var got []string
+	for _, report := range reports {
+		if len(report.Impacts) != 1 || report.Impacts[0].Collection != report.Step {
+			t.Errorf("DryRun() reported the impacts %v for step %s", report.Impacts, report.Step)
+		}
+		got = append(got, database.MigrationID(report.Version, report.Step))
+	}
+	if want := []string{"1.1.0/b", "1.3.0/c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DryRun() reported %v, want %v", got, want)
+	}
+	// nothing is applied
+	if want := []string{"dry-run b", "dry-run c"}; !reflect.DeepEqual(log.calls, want) {
+		t.Errorf("DryRun() called %v, want %v", log.calls, want)
+	}
+	if len(migrations.recorded) != 0 || migrations.version != "1.0.0" {
+		t.Errorf("DryRun() recorded %v and set the version %s", migrations.recorded, migrations.version)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	log := &stepLog{}
+	// b wasn't applied by the interrupted upgrade
+	migrations := &fakeMigrations{applied: map[string]bool{"1.1.0/a": true, "1.3.0/c": true}, version: "1.3.0"}
+	m := newTestManager("1.3.0", "1.0.0", migrations, testUpgradePath(log.step("a", nil), log.step("b", nil), log.step("c", nil)))
+
+	if err := m.Rollback(); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if want := []string{"down c", "down a"}; !reflect.DeepEqual(log.calls, want) {
+		t.Errorf("Rollback() called %v, want %v", log.calls, want)
+	}
+	if want := []string{"1.3.0/c", "1.1.0/a"}; !reflect.DeepEqual(migrations.rolledBack, want) {
+		t.Errorf("Rollback() recorded %v, want %v", migrations.rolledBack, want)
+	}
+	if migrations.version != "1.0.0" {
+		t.Errorf("Rollback() set the version %s, want 1.0.0", migrations.version)
+	}
+}
+
+func TestRollbackOfIrreversibleStep(t *testing.T) {
+	log := &stepLog{}
+	a := log.step("a", nil)
+	a.Down = nil
+	migrations := &fakeMigrations{applied: map[string]bool{"1.1.0/a": true, "1.3.0/c": true}, version: "1.3.0"}
+	m := newTestManager("1.3.0", "1.0.0", migrations, testUpgradePath(a, log.step("b", nil), log.step("c", nil)))
+
+	if err := m.Rollback(); err == nil {
+		t.Fatal("Rollback() of a step without Down didn't fail")
+	}
+	// no step is rolled back, so that the DB isn't left half rolled back
+	if len(log.calls) != 0 || len(migrations.rolledBack) != 0 || migrations.version != "1.3.0" {
+		t.Errorf("Rollback() called %v and rolled back %v", log.calls, migrations.rolledBack)
+	}
+}
+
+func TestRollbackOutsideTransaction(t *testing.T) {
+	log := &stepLog{}
+	a := log.step("a", nil)
+	a.TransactionalUp = true
+	migrations := &fakeMigrations{applied: map[string]bool{"1.1.0/a": true}, version: "1.1.0", transactions: true}
+	// the manager has no DB client, a transaction would fail
+	m := newTestManager("1.1.0", "1.0.0", migrations, testUpgradePath(a, log.step("b", nil), log.step("c", nil)))
+
+	if err := m.Rollback(); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if want := []string{"down a"}; !reflect.DeepEqual(log.calls, want) {
+		t.Errorf("Rollback() called %v, want %v", log.calls, want)
+	}
+}
+
+// TestRollbackDropsCollection upgrades and rolls back the copy of the projects to the auth DB, which drops
+// the copied collection. It runs against the DB of TEST_DB_SERVER, a replica set to test the transactions,
+// and is skipped if it isn't set
+func TestRollbackDropsCollection(t *testing.T) {
+	uri := os.Getenv("TEST_DB_SERVER")
+	if uri == "" {
+		t.Skip("TEST_DB_SERVER isn't set")
+	}
+	ctx := context.Background()
+	dbClient, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dbClient.Disconnect(ctx)
+
+	projects := dbClient.Database("litmus").Collection("project")
+	authProjects := dbClient.Database("auth").Collection("project")
+	for _, collection := range []*mongo.Collection{projects, authProjects, dbClient.Database(database.DbName).Collection(database.MigrationsCollection)} {
+		if err := collection.Drop(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := projects.InsertOne(ctx, bson.M{"_id": "project"}); err != nil {
+		t.Fatal(err)
+	}
+
+	copyProjects := v2_4_0.NewVersionManger(zap.NewNop(), dbClient).Steps()[0]
+	path := map[string]UpgradeExecutor{
+		"2.3.0": {NextVersion: "2.4.0", VersionManager: fakeVersionManager{steps: []migration.Step{copyProjects}}},
+		"2.4.0": {NextVersion: "", VersionManager: nil},
+	}
+	m := &UpgradeManager{
+		Logger:          zap.NewNop(),
+		DBClient:        dbClient,
+		Migrations:      database.NewMigrationOperator(dbClient),
+		PreviousVersion: "2.3.0",
+		TargetVersion:   "2.4.0",
+		path:            path,
+	}
+	if err := m.Run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if count, err := authProjects.CountDocuments(ctx, bson.M{}); err != nil || count != 1 {
+		t.Fatalf("Run() copied %d projects, error = %v", count, err)
+	}
+
+	m.PreviousVersion, m.TargetVersion = "2.4.0", "2.3.0"
+	if err := m.Rollback(); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	names, err := authProjects.Database().ListCollectionNames(ctx, bson.M{"name": authProjects.Name()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 0 {
+		t.Error("Rollback() didn't drop the copied projects")
+	}
+}
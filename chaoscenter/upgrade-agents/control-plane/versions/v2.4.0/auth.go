@@ -2,61 +2,140 @@ package v2_4_0
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/litmuschaos/litmus/litmus-portal/upgrader-agents/control-plane/pkg/migration"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.uber.org/zap"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// upgradeAuthDb: migrates project collection from litmus-db to auth-db, renames usercredentials collection to users"
-func upgradeAuthDb(logger *zap.Logger, dbClient *mongo.Client) error {
+// authDbSteps: migrates project collection from litmus-db to auth-db, renames usercredentials collection to users
+func authDbSteps(dbClient *mongo.Client) []migration.Step {
+	var (
+		projectLitmusCollection   = dbClient.Database("litmus").Collection("project")       // project collection from litmus DB
+		userLitmusCollection      = dbClient.Database("litmus").Collection("user")          // user collection from litmus DB
+		usersAuthCollection       = dbClient.Database("auth").Collection("users")           // users collection from auth DB
+		userCredentialsCollection = dbClient.Database("auth").Collection("usercredentials") // usercredentials collection from auth DB
+		authDBProjectCollection   = dbClient.Database("auth").Collection("project")         // project collected in auth DB
+	)
 
-	// migration of project collection to auth DB
-	projectLitmusCollection := dbClient.Database("litmus").Collection("project") //project collection from litmus DB
-	userLitmusCollection := dbClient.Database("litmus").Collection("user")       // user collection from litmus DB
-	usersAuthCollection := dbClient.Database("auth").Collection("users")         // users collection from auth DB
-	authDBProjectCollection := dbClient.Database("auth").Collection("project")   // project collected in auth DB
+	return []migration.Step{
+		{
+			// migration of project collection to auth DB, the rollback drops the collection so it can't be
+			// transactional
+			Name:            "copy-projects-to-auth-db",
+			TransactionalUp: true,
+			Up: func(ctx context.Context) error {
+				return copyCollection(ctx, projectLitmusCollection, authDBProjectCollection)
+			},
+			Down: func(ctx context.Context) error {
+				return dropCollection(ctx, authDBProjectCollection)
+			},
+			DryRun: func(ctx context.Context) ([]migration.Impact, error) {
+				impact, err := migration.CountImpact(ctx, projectLitmusCollection, "copy to auth.project")
+				return []migration.Impact{impact}, err
+			},
+		},
+		{
+			// deleting project collection in litmus DB, the rollback restores it from the auth DB
+			Name: "drop-litmus-project",
+			Up: func(ctx context.Context) error {
+				return dropCollection(ctx, projectLitmusCollection)
+			},
+			Down: func(ctx context.Context) error {
+				return copyCollection(ctx, authDBProjectCollection, projectLitmusCollection)
+			},
+			DryRun: func(ctx context.Context) ([]migration.Impact, error) {
+				impact, err := migration.CountImpact(ctx, projectLitmusCollection, "drop")
+				return []migration.Impact{impact}, err
+			},
+		},
+		{
+			// deleting user collection in litmus DB, the users are kept in the usercredentials collection
+			Name: "drop-litmus-user",
+			Up: func(ctx context.Context) error {
+				return dropCollection(ctx, userLitmusCollection)
+			},
+			DryRun: func(ctx context.Context) ([]migration.Impact, error) {
+				impact, err := migration.CountImpact(ctx, userLitmusCollection, "drop")
+				return []migration.Impact{impact}, err
+			},
+		},
+		{
+			// deleting users collection in auth DB
+			Name: "drop-auth-users",
+			Up: func(ctx context.Context) error {
+				// the users collection is the renamed usercredentials collection if the rename step was
+				// interrupted before being recorded
+				exists, err := collectionExists(ctx, userCredentialsCollection)
+				if err != nil || !exists {
+					return err
+				}
+				return dropCollection(ctx, usersAuthCollection)
+			},
+			DryRun: func(ctx context.Context) ([]migration.Impact, error) {
+				impact, err := migration.CountImpact(ctx, usersAuthCollection, "drop")
+				return []migration.Impact{impact}, err
+			},
+		},
+		{
+			// renaming usercredentials collection to users
+			Name: "rename-usercredentials-to-users",
+			Up: func(ctx context.Context) error {
+				return renameCollection(ctx, dbClient, "auth.usercredentials", "auth.users")
+			},
+			Down: func(ctx context.Context) error {
+				return renameCollection(ctx, dbClient, "auth.users", "auth.usercredentials")
+			},
+			DryRun: func(ctx context.Context) ([]migration.Impact, error) {
+				impact, err := migration.CountImpact(ctx, userCredentialsCollection, "rename to auth.users")
+				return []migration.Impact{impact}, err
+			},
+		},
+	}
+}
 
-	// fetching the projects from project collection in litmus DB
-	cursor, err := projectLitmusCollection.Find(context.Background(), bson.M{})
+// copyCollection upserts all the documents of the source collection in the destination collection
+func copyCollection(ctx context.Context, source *mongo.Collection, destination *mongo.Collection) error {
+	cursor, err := source.Find(ctx, bson.M{})
 	if err != nil {
-		fmt.Errorf("Error in getting project collection: %w", err)
+		return err
 	}
 
-	var result []interface{}
-	if err := cursor.All(context.Background(), &result); err != nil {
-		fmt.Errorf("Error: %w", err)
+	var documents []bson.M
+	if err := cursor.All(ctx, &documents); err != nil {
+		return err
 	}
 
-	// inserting the project documents in project collection(auth DB)
-	_, err = authDBProjectCollection.InsertMany(context.TODO(), result)
-	if err != nil {
-		fmt.Errorf("Error: %w", err)
+	for _, document := range documents {
+		_, err := destination.ReplaceOne(ctx, bson.M{"_id": document["_id"]}, document, options.Replace().SetUpsert(true))
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	// deleting project collection in litmus DB
-	err = projectLitmusCollection.Drop(context.Background())
-	if err != nil {
-		fmt.Errorf("Error: %w", err)
+func dropCollection(ctx context.Context, collection *mongo.Collection) error {
+	if err := collection.Drop(ctx); err != nil && !migration.IsNamespaceNotFound(err) {
+		return err
 	}
+	return nil
+}
 
-	// deleting user collection in litmus DB
-	err = userLitmusCollection.Drop(context.Background())
-	if err != nil {
-		fmt.Errorf("Error: %w", err)
+// renameCollection renames a collection, it is a no-op if the collection was already renamed
+func renameCollection(ctx context.Context, dbClient *mongo.Client, from string, to string) error {
+	res := dbClient.Database("admin").RunCommand(ctx, bson.D{{"renameCollection", from}, {"to", to}})
+	if err := res.Err(); err != nil && !migration.IsNamespaceNotFound(err) {
+		return err
 	}
+	return nil
+}
 
-	// deleting users collection in auth DB
-	err = usersAuthCollection.Drop(context.Background())
+func collectionExists(ctx context.Context, collection *mongo.Collection) (bool, error) {
+	names, err := collection.Database().ListCollectionNames(ctx, bson.D{{"name", collection.Name()}})
 	if err != nil {
-		fmt.Errorf("Error: %w", err)
-	}
-
-	// renaming usercredentials collection to users
-	res := dbClient.Database("admin").RunCommand(context.Background(), bson.D{{"renameCollection", "auth.usercredentials"}, {"to", "auth.users"}})
-	if res.Err() != nil {
-		return res.Err()
+		return false, err
 	}
-	return err
+	return len(names) > 0, nil
 }
@@ -1,6 +1,7 @@
 package v2_4_0
 
 import (
+	"github.com/litmuschaos/litmus/litmus-portal/upgrader-agents/control-plane/pkg/migration"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
@@ -16,12 +17,9 @@ func NewVersionManger(logger *zap.Logger, dbClient *mongo.Client) *VersionManage
 	return &VersionManager{Logger: logger, DBClient: dbClient}
 }
 
-// Run executes all the steps required for the Version Manger
+// Steps returns all the steps required for the Version Manger
 // to upgrade from the previous version to `this` version
-func (vm VersionManager) Run() error {
-	if err := upgradeAuthDb(vm.Logger, vm.DBClient); err != nil {
-		return err
-	}
-	// other upgrade step .....
-	return nil
+func (vm VersionManager) Steps() []migration.Step {
+	// other upgrade steps are appended here .....
+	return authDbSteps(vm.DBClient)
 }
@@ -1,31 +1,24 @@
 package v2_6_0
 
 import (
-	"context"
-	"fmt"
-
-	"go.mongodb.org/mongo-driver/bson"
+	"github.com/litmuschaos/litmus/litmus-portal/upgrader-agents/control-plane/pkg/migration"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
 
-// upgradeWorkflowCollection updated the index related changes in workflow-collection
-func upgradeWorkflowCollection(logger *zap.Logger, dbClient *mongo.Client) error {
-	workflowCollection := dbClient.Database("litmus").Collection("workflow-collection")
-
-	//delete the existing workflow_name index
-	_, err := workflowCollection.Indexes().DropOne(context.Background(), "workflow_name_1")
-	if err != nil {
-		fmt.Errorf("error: %w", err)
-	}
+// VersionManager implements IVersionManger
+type VersionManager struct {
+	Logger   *zap.Logger
+	DBClient *mongo.Client
+}
 
-	//create a new workflow index with partial filter expression
-	_, err = workflowCollection.Indexes().CreateOne(context.Background(),
-		mongo.IndexModel{Keys: bson.M{"workflow_name": 1},
-			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.D{{
-				"isRemoved", false,
-			}})})
+// NewVersionManger provides a new instance of a new VersionManager
+func NewVersionManger(logger *zap.Logger, dbClient *mongo.Client) *VersionManager {
+	return &VersionManager{Logger: logger, DBClient: dbClient}
+}
 
-	return err
+// Steps returns all the steps required for the Version Manger
+// to upgrade from the previous version to `this` version
+func (vm VersionManager) Steps() []migration.Step {
+	return workflowCollectionSteps(vm.DBClient)
 }
@@ -1,26 +1,64 @@
 package v2_6_0
 
 import (
+	"context"
+
+	"github.com/litmuschaos/litmus/litmus-portal/upgrader-agents/control-plane/pkg/migration"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.uber.org/zap"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// VersionManager implements IVersionManger
-type VersionManager struct {
-	Logger   *zap.Logger
-	DBClient *mongo.Client
-}
+const workflowNameIndex = "workflow_name_1"
 
-// NewVersionManger provides a new instance of a new VersionManager
-func NewVersionManger(logger *zap.Logger, dbClient *mongo.Client) *VersionManager {
-	return &VersionManager{Logger: logger, DBClient: dbClient}
+// workflowCollectionSteps updates the index related changes in workflow-collection
+func workflowCollectionSteps(dbClient *mongo.Client) []migration.Step {
+	workflowCollection := dbClient.Database("litmus").Collection("workflow-collection")
+
+	return []migration.Step{
+		{
+			//delete the existing workflow_name index
+			Name: "drop-workflow-name-index",
+			Up: func(ctx context.Context) error {
+				return dropIndex(ctx, workflowCollection, workflowNameIndex)
+			},
+			Down: func(ctx context.Context) error {
+				_, err := workflowCollection.Indexes().CreateOne(ctx,
+					mongo.IndexModel{Keys: bson.M{"workflow_name": 1},
+						Options: options.Index().SetUnique(true)})
+				return err
+			},
+			DryRun: func(ctx context.Context) ([]migration.Impact, error) {
+				impact, err := migration.CountImpact(ctx, workflowCollection, "drop index "+workflowNameIndex)
+				return []migration.Impact{impact}, err
+			},
+		},
+		{
+			//create a new workflow index with partial filter expression
+			Name: "create-partial-workflow-name-index",
+			Up: func(ctx context.Context) error {
+				_, err := workflowCollection.Indexes().CreateOne(ctx,
+					mongo.IndexModel{Keys: bson.M{"workflow_name": 1},
+						Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.D{{
+							"isRemoved", false,
+						}})})
+				return err
+			},
+			Down: func(ctx context.Context) error {
+				return dropIndex(ctx, workflowCollection, workflowNameIndex)
+			},
+			DryRun: func(ctx context.Context) ([]migration.Impact, error) {
+				impact, err := migration.CountImpact(ctx, workflowCollection, "create unique partial index "+workflowNameIndex)
+				return []migration.Impact{impact}, err
+			},
+		},
+	}
 }
 
-// Run executes all the steps required for the Version Manger
-// to upgrade from the previous version to `this` version
-func (vm VersionManager) Run() error {
-	if err := upgradeWorkflowCollection(vm.Logger, vm.DBClient); err != nil {
-		return nil
+func dropIndex(ctx context.Context, collection *mongo.Collection, name string) error {
+	_, err := collection.Indexes().DropOne(ctx, name)
+	if err != nil && !migration.IsIndexNotFound(err) && !migration.IsNamespaceNotFound(err) {
+		return err
 	}
 	return nil
 }
@@ -1,11 +1,15 @@
 package versions
 
+import "github.com/litmuschaos/litmus/litmus-portal/upgrader-agents/control-plane/pkg/migration"
+
 // IVersionManager is the interface for the Version Manager,
 // a version manager is responsible to upgrade all the control plane components
 // from the previous version to the current version,
 // this includes any version related metadata stored in DB or configmaps
 type IVersionManager interface {
-	// Run executes all the steps required for the Version Manger
-	// to upgrade from the previous version to `this` version
-	Run() error
+	// Steps returns the ordered steps required for the Version Manger
+	// to upgrade from the previous version to `this` version. The steps
+	// are recorded once applied, so they are run only once even if the
+	// upgrade is interrupted and resumed
+	Steps() []migration.Step
 }